@@ -5,6 +5,7 @@ import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/config"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/logger"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
 	"github.com/skinnykaen/robbo_student_personal_account.git/server"
 	"log"
 
@@ -18,6 +19,7 @@ func InvokeWith(options ...fx.Option) *fx.App {
 	var di = []fx.Option{
 		fx.Provide(logger.NewLogger),
 		fx.Provide(db_client.NewPostgresClient),
+		fx.Provide(storage.NewStorage),
 		fx.Provide(modules.SetupGateway),
 		fx.Provide(modules.SetupUseCase),
 		fx.Provide(modules.SetupDelegate),
@@ -34,5 +36,6 @@ func RunApp() {
 	InvokeWith(
 		//fx.Invoke(server.NewHttpServer),
 		fx.Invoke(server.NewServer),
+		fx.Invoke(server.NewWorkers),
 	).Run()
 }
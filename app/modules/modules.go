@@ -1,6 +1,25 @@
 package modules
 
 import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	activitydelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/activity/delegate"
+	activitygateway "github.com/skinnykaen/robbo_student_personal_account.git/package/activity/gateway"
+	activityhttp "github.com/skinnykaen/robbo_student_personal_account.git/package/activity/http"
+	activityusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/activity/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	assetsdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/assets/delegate"
+	assetsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/assets/gateway"
+	assetshttp "github.com/skinnykaen/robbo_student_personal_account.git/package/assets/http"
+	assetsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/assets/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	assignmentsdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/assignments/delegate"
+	assignmentsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/assignments/gateway"
+	assignmentsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/assignments/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/attendance"
+	attendancedelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/attendance/delegate"
+	attendancegateway "github.com/skinnykaen/robbo_student_personal_account.git/package/attendance/gateway"
+	attendanceusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/attendance/usecase"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
 	authdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/auth/delegate"
 	authgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/auth/gateway"
@@ -11,6 +30,10 @@ import (
 	chrtgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/cohorts/gateway"
 	chrthttp "github.com/skinnykaen/robbo_student_personal_account.git/package/cohorts/http"
 	chrtusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/cohorts/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	commentsdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/comments/delegate"
+	commentsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/comments/gateway"
+	commentsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/comments/usecase"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
 	coursePacketdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket/delegate"
 	coursePacketgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket/gateway"
@@ -21,9 +44,21 @@ import (
 	crsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/courses/gateway"
 	crshttp "github.com/skinnykaen/robbo_student_personal_account.git/package/courses/http"
 	crsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/courses/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard"
+	dashboarddelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard/delegate"
+	dashboardgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard/gateway"
+	dashboardusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard/usecase"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/edx"
 	edxusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/edx/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/gallery"
+	gallerydelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/gallery/delegate"
+	gallerygateway "github.com/skinnykaen/robbo_student_personal_account.git/package/gallery/gateway"
+	galleryusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/gallery/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	notificationsdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/notifications/delegate"
+	notificationsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/notifications/gateway"
+	notificationsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/notifications/usecase"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
 	ppagedelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage/delegate"
 	ppagegateway "github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage/gateway"
@@ -34,6 +69,10 @@ import (
 	prjgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/projects/gateway"
 	prjhttp "github.com/skinnykaen/robbo_student_personal_account.git/package/projects/http"
 	prjusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/projects/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	regionsdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/regions/delegate"
+	regionsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/regions/gateway"
+	regionsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/regions/usecase"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/resolvers"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
 	robboGroupdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup/delegate"
@@ -45,6 +84,15 @@ import (
 	robboUnitsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits/gateway"
 	robboUnitshttp "github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits/http"
 	robboUnitsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	scheduledelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/schedule/delegate"
+	schedulegateway "github.com/skinnykaen/robbo_student_personal_account.git/package/schedule/gateway"
+	scheduleusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/schedule/usecase"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	termsdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/terms/delegate"
+	termsgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/terms/gateway"
+	termsusecase "github.com/skinnykaen/robbo_student_personal_account.git/package/terms/usecase"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
 	usersdelegate "github.com/skinnykaen/robbo_student_personal_account.git/package/users/delegate"
 	usersgateway "github.com/skinnykaen/robbo_student_personal_account.git/package/users/gateway"
@@ -53,82 +101,153 @@ import (
 )
 
 type GatewayModule struct {
-	AuthGateway         auth.Gateway
-	CohortsGateway      cohorts.Gateway
-	CoursePacketGateway coursePacket.Gateway
-	CoursesGateway      courses.Gateway
-	ProjectPageGateway  projectPage.Gateway
-	ProjectsGateway     projects.Gateway
-	RobboGroupGateway   robboGroup.Gateway
-	RobboUnitsGateway   robboUnits.Gateway
-	UsersGateway        users.Gateway
+	ActivityGateway      activity.Gateway
+	AuthGateway          auth.Gateway
+	CohortsGateway       cohorts.Gateway
+	CoursePacketGateway  coursePacket.Gateway
+	CoursesGateway       courses.Gateway
+	ProjectPageGateway   projectPage.Gateway
+	ProjectsGateway      projects.Gateway
+	RobboGroupGateway    robboGroup.Gateway
+	RobboUnitsGateway    robboUnits.Gateway
+	ScheduleGateway      schedule.Gateway
+	NotificationsGateway notifications.Gateway
+	AttendanceGateway    attendance.Gateway
+	DashboardGateway     dashboard.Gateway
+	TermsGateway         terms.Gateway
+	RegionsGateway       regions.Gateway
+	AssetsGateway        assets.Gateway
+	GalleryGateway       gallery.Gateway
+	CommentsGateway      comments.Gateway
+	AssignmentsGateway   assignments.Gateway
+	UsersGateway         users.Gateway
 }
 
-func SetupGateway(postgresClient db_client.PostgresClient) GatewayModule {
+func SetupGateway(postgresClient db_client.PostgresClient, blobStorage storage.Storage) GatewayModule {
 	return GatewayModule{
-		AuthGateway:         authgateway.SetupAuthGateway(postgresClient),
-		CohortsGateway:      chrtgateway.SetupCohortsGateway(postgresClient),
-		CoursePacketGateway: coursePacketgateway.SetupCoursePacketGateway(postgresClient),
-		CoursesGateway:      crsgateway.SetupCoursesGateway(postgresClient),
-		ProjectPageGateway:  ppagegateway.SetupProjectPageGateway(postgresClient),
-		ProjectsGateway:     prjgateway.SetupProjectsGateway(postgresClient),
-		RobboGroupGateway:   robboGroupgateway.SetupRobboGroupGateway(postgresClient),
-		RobboUnitsGateway:   robboUnitsgateway.SetupRobboUnitsGateway(postgresClient),
-		UsersGateway:        usersgateway.SetupUsersGateway(postgresClient),
+		ActivityGateway:      activitygateway.SetupActivityGateway(postgresClient),
+		AuthGateway:          authgateway.SetupAuthGateway(postgresClient),
+		CohortsGateway:       chrtgateway.SetupCohortsGateway(postgresClient),
+		CoursePacketGateway:  coursePacketgateway.SetupCoursePacketGateway(postgresClient),
+		CoursesGateway:       crsgateway.SetupCoursesGateway(postgresClient),
+		ProjectPageGateway:   ppagegateway.SetupProjectPageGateway(postgresClient, blobStorage),
+		ProjectsGateway:      prjgateway.SetupProjectsGateway(postgresClient, blobStorage),
+		RobboGroupGateway:    robboGroupgateway.SetupRobboGroupGateway(postgresClient),
+		RobboUnitsGateway:    robboUnitsgateway.SetupRobboUnitsGateway(postgresClient),
+		ScheduleGateway:      schedulegateway.SetupScheduleGateway(postgresClient),
+		NotificationsGateway: notificationsgateway.SetupNotificationsGateway(postgresClient),
+		AttendanceGateway:    attendancegateway.SetupAttendanceGateway(postgresClient),
+		DashboardGateway:     dashboardgateway.SetupDashboardGateway(postgresClient),
+		TermsGateway:         termsgateway.SetupTermsGateway(postgresClient),
+		RegionsGateway:       regionsgateway.SetupRegionsGateway(postgresClient),
+		AssetsGateway:        assetsgateway.SetupAssetsGateway(postgresClient, blobStorage),
+		GalleryGateway:       gallerygateway.SetupGalleryGateway(postgresClient),
+		CommentsGateway:      commentsgateway.SetupCommentsGateway(postgresClient),
+		AssignmentsGateway:   assignmentsgateway.SetupAssignmentsGateway(postgresClient, blobStorage),
+		UsersGateway:         usersgateway.SetupUsersGateway(postgresClient),
 	}
 }
 
 type UseCaseModule struct {
-	AuthUseCase         auth.UseCase
-	CohortsUseCase      cohorts.UseCase
-	CoursePacketUseCase coursePacket.UseCase
-	CoursesUseCase      courses.UseCase
-	EdxUseCase          edx.UseCase
-	ProjectPageUseCase  projectPage.UseCase
-	ProjectsUseCase     projects.UseCase
-	RobboGroupUseCase   robboGroup.UseCase
-	RobboUnitsUseCase   robboUnits.UseCase
-	UsersUseCase        users.UseCase
+	ActivityUseCase      activity.UseCase
+	AuthUseCase          auth.UseCase
+	CohortsUseCase       cohorts.UseCase
+	CoursePacketUseCase  coursePacket.UseCase
+	CoursesUseCase       courses.UseCase
+	EdxUseCase           edx.UseCase
+	ProjectPageUseCase   projectPage.UseCase
+	ProjectsUseCase      projects.UseCase
+	RobboGroupUseCase    robboGroup.UseCase
+	RobboUnitsUseCase    robboUnits.UseCase
+	ScheduleUseCase      schedule.UseCase
+	NotificationsUseCase notifications.UseCase
+	AttendanceUseCase    attendance.UseCase
+	DashboardUseCase     dashboard.UseCase
+	TermsUseCase         terms.UseCase
+	RegionsUseCase       regions.UseCase
+	AssetsUseCase        assets.UseCase
+	GalleryUseCase       gallery.UseCase
+	CommentsUseCase      comments.UseCase
+	AssignmentsUseCase   assignments.UseCase
+	UsersUseCase         users.UseCase
+	AccessScope          access.Scope
 }
 
 func SetupUseCase(gateway GatewayModule) UseCaseModule {
+	accessScope := access.SetupScope(gateway.UsersGateway, gateway.RobboUnitsGateway, gateway.RobboGroupGateway, gateway.RegionsGateway)
 	return UseCaseModule{
-		AuthUseCase:         authusecase.SetupAuthUseCase(gateway.UsersGateway),
-		CohortsUseCase:      chrtusecase.SetupCohortUseCase(gateway.CohortsGateway),
-		CoursePacketUseCase: coursePacketusecase.SetupCoursePacketUseCase(gateway.CoursePacketGateway),
-		CoursesUseCase:      crsusecase.SetupCourseUseCase(gateway.CoursesGateway),
-		EdxUseCase:          edxusecase.SetupEdxApiUseCase(),
-		ProjectPageUseCase:  ppageusecase.SetupProjectPageUseCase(gateway.ProjectPageGateway, gateway.ProjectsGateway),
-		ProjectsUseCase:     prjusecase.SetupProjectUseCase(gateway.ProjectsGateway),
-		RobboGroupUseCase:   robboGroupusecase.SetupRobboGroupUseCase(gateway.RobboGroupGateway, gateway.UsersGateway),
-		RobboUnitsUseCase:   robboUnitsusecase.SetupRobboUnitsUseCase(gateway.RobboUnitsGateway, gateway.UsersGateway),
-		UsersUseCase:        usersusecase.SetupUsersUseCase(gateway.UsersGateway),
+		ActivityUseCase:      activityusecase.SetupActivityUseCase(gateway.ActivityGateway),
+		AuthUseCase:          authusecase.SetupAuthUseCase(gateway.UsersGateway),
+		CohortsUseCase:       chrtusecase.SetupCohortUseCase(gateway.CohortsGateway),
+		CoursePacketUseCase:  coursePacketusecase.SetupCoursePacketUseCase(gateway.CoursePacketGateway),
+		CoursesUseCase:       crsusecase.SetupCourseUseCase(gateway.CoursesGateway),
+		EdxUseCase:           edxusecase.SetupEdxApiUseCase(),
+		ProjectPageUseCase:   ppageusecase.SetupProjectPageUseCase(gateway.ProjectPageGateway, gateway.ProjectsGateway, gateway.AssetsGateway),
+		ProjectsUseCase:      prjusecase.SetupProjectUseCase(gateway.ProjectsGateway, gateway.UsersGateway, accessScope),
+		RobboGroupUseCase:    robboGroupusecase.SetupRobboGroupUseCase(gateway.RobboGroupGateway, gateway.UsersGateway),
+		RobboUnitsUseCase:    robboUnitsusecase.SetupRobboUnitsUseCase(gateway.RobboUnitsGateway, gateway.UsersGateway, gateway.RegionsGateway),
+		ScheduleUseCase:      scheduleusecase.SetupScheduleUseCase(gateway.ScheduleGateway, gateway.UsersGateway, gateway.RobboGroupGateway, gateway.TermsGateway),
+		NotificationsUseCase: notificationsusecase.SetupNotificationsUseCase(gateway.NotificationsGateway),
+		AttendanceUseCase:    attendanceusecase.SetupAttendanceUseCase(gateway.AttendanceGateway, gateway.ScheduleGateway, gateway.UsersGateway, gateway.RobboGroupGateway, gateway.NotificationsGateway),
+		DashboardUseCase:     dashboardusecase.SetupDashboardUseCase(gateway.DashboardGateway),
+		TermsUseCase:         termsusecase.SetupTermsUseCase(gateway.TermsGateway),
+		RegionsUseCase:       regionsusecase.SetupRegionsUseCase(gateway.RegionsGateway),
+		AssetsUseCase:        assetsusecase.SetupAssetsUseCase(gateway.AssetsGateway),
+		GalleryUseCase:       galleryusecase.SetupGalleryUseCase(gateway.GalleryGateway),
+		CommentsUseCase:      commentsusecase.SetupCommentsUseCase(gateway.CommentsGateway, gateway.UsersGateway, gateway.NotificationsGateway),
+		AssignmentsUseCase:   assignmentsusecase.SetupAssignmentsUseCase(gateway.AssignmentsGateway, gateway.ProjectsGateway, gateway.ProjectPageGateway, gateway.UsersGateway, gateway.NotificationsGateway, accessScope),
+		UsersUseCase:         usersusecase.SetupUsersUseCase(gateway.UsersGateway, gateway.NotificationsGateway),
+		AccessScope:          accessScope,
 	}
 }
 
 type DelegateModule struct {
-	AuthDelegate         auth.Delegate
-	CohortsDelegate      cohorts.Delegate
-	CoursePacketDelegate coursePacket.Delegate
-	CoursesDelegate      courses.Delegate
-	ProjectPageDelegate  projectPage.Delegate
-	ProjectsDelegate     projects.Delegate
-	RobboGroupDelegate   robboGroup.Delegate
-	RobboUnitsDelegate   robboUnits.Delegate
-	UsersDelegate        users.Delegate
+	ActivityDelegate      activity.Delegate
+	AuthDelegate          auth.Delegate
+	CohortsDelegate       cohorts.Delegate
+	CoursePacketDelegate  coursePacket.Delegate
+	CoursesDelegate       courses.Delegate
+	ProjectPageDelegate   projectPage.Delegate
+	ProjectsDelegate      projects.Delegate
+	RobboGroupDelegate    robboGroup.Delegate
+	RobboUnitsDelegate    robboUnits.Delegate
+	ScheduleDelegate      schedule.Delegate
+	NotificationsDelegate notifications.Delegate
+	AttendanceDelegate    attendance.Delegate
+	DashboardDelegate     dashboard.Delegate
+	TermsDelegate         terms.Delegate
+	RegionsDelegate       regions.Delegate
+	AssetsDelegate        assets.Delegate
+	GalleryDelegate       gallery.Delegate
+	CommentsDelegate      comments.Delegate
+	AssignmentsDelegate   assignments.Delegate
+	UsersDelegate         users.Delegate
+	AccessScope           access.Scope
 }
 
 func SetupDelegate(usecase UseCaseModule) DelegateModule {
 	return DelegateModule{
-		AuthDelegate:         authdelegate.SetupAuthDelegate(usecase.AuthUseCase),
-		CohortsDelegate:      chrtdelegate.SetupCohortDelegate(usecase.CohortsUseCase, usecase.EdxUseCase),
-		CoursePacketDelegate: coursePacketdelegate.SetupCoursePacketDelegate(usecase.CoursePacketUseCase),
-		CoursesDelegate:      crsdelegate.SetupCourseDelegate(usecase.CoursesUseCase, usecase.EdxUseCase),
-		ProjectPageDelegate:  ppagedelegate.SetupProjectPageDelegate(usecase.ProjectPageUseCase),
-		ProjectsDelegate:     prjdelegate.SetupProjectDelegate(usecase.ProjectsUseCase),
-		RobboGroupDelegate:   robboGroupdelegate.SetupRobboGroupDelegate(usecase.RobboGroupUseCase),
-		RobboUnitsDelegate:   robboUnitsdelegate.SetupRobboUnitsDelegate(usecase.RobboUnitsUseCase),
-		UsersDelegate:        usersdelegate.SetupUsersDelegate(usecase.UsersUseCase),
+		ActivityDelegate:      activitydelegate.SetupActivityDelegate(usecase.ActivityUseCase),
+		AuthDelegate:          authdelegate.SetupAuthDelegate(usecase.AuthUseCase),
+		CohortsDelegate:       chrtdelegate.SetupCohortDelegate(usecase.CohortsUseCase, usecase.EdxUseCase),
+		CoursePacketDelegate:  coursePacketdelegate.SetupCoursePacketDelegate(usecase.CoursePacketUseCase, usecase.EdxUseCase),
+		CoursesDelegate:       crsdelegate.SetupCourseDelegate(usecase.CoursesUseCase, usecase.EdxUseCase),
+		ProjectPageDelegate:   ppagedelegate.SetupProjectPageDelegate(usecase.ProjectPageUseCase, usecase.ProjectsUseCase),
+		ProjectsDelegate:      prjdelegate.SetupProjectDelegate(usecase.ProjectsUseCase),
+		RobboGroupDelegate:    robboGroupdelegate.SetupRobboGroupDelegate(usecase.RobboGroupUseCase),
+		RobboUnitsDelegate:    robboUnitsdelegate.SetupRobboUnitsDelegate(usecase.RobboUnitsUseCase),
+		ScheduleDelegate:      scheduledelegate.SetupScheduleDelegate(usecase.ScheduleUseCase),
+		NotificationsDelegate: notificationsdelegate.SetupNotificationsDelegate(usecase.NotificationsUseCase),
+		AttendanceDelegate:    attendancedelegate.SetupAttendanceDelegate(usecase.AttendanceUseCase),
+		DashboardDelegate:     dashboarddelegate.SetupDashboardDelegate(usecase.DashboardUseCase),
+		TermsDelegate:         termsdelegate.SetupTermsDelegate(usecase.TermsUseCase),
+		RegionsDelegate:       regionsdelegate.SetupRegionsDelegate(usecase.RegionsUseCase),
+		AssetsDelegate:        assetsdelegate.SetupAssetsDelegate(usecase.AssetsUseCase),
+		GalleryDelegate:       gallerydelegate.SetupGalleryDelegate(usecase.GalleryUseCase),
+		CommentsDelegate:      commentsdelegate.SetupCommentsDelegate(usecase.CommentsUseCase),
+		AssignmentsDelegate:   assignmentsdelegate.SetupAssignmentsDelegate(usecase.AssignmentsUseCase),
+		UsersDelegate:         usersdelegate.SetupUsersDelegate(usecase.UsersUseCase),
+		AccessScope:           usecase.AccessScope,
 	}
 }
 
@@ -142,19 +261,23 @@ type HandlerModule struct {
 	RobboUnitsHandler   robboUnitshttp.Handler
 	RobboGroupHandler   robboGrouphttp.Handler
 	CoursePacketHandler coursePackethttp.Handler
+	ActivityHandler     activityhttp.Handler
+	AssetsHandler       assetshttp.Handler
 }
 
 func SetupHandler(delegate DelegateModule) HandlerModule {
 	return HandlerModule{
 		ProjectsHandler:     prjhttp.NewProjectsHandler(delegate.AuthDelegate, delegate.ProjectsDelegate),
-		ProjectPageHandler:  ppagehttp.NewProjectPageHandler(delegate.AuthDelegate, delegate.ProjectsDelegate, delegate.ProjectPageDelegate),
-		AuthHandler:         authhttp.NewAuthHandler(delegate.AuthDelegate),
+		ProjectPageHandler:  ppagehttp.NewProjectPageHandler(delegate.AuthDelegate, delegate.ProjectsDelegate, delegate.ProjectPageDelegate, delegate.AccessScope),
+		AuthHandler:         authhttp.NewAuthHandler(delegate.AuthDelegate, delegate.ActivityDelegate),
 		CoursesHandler:      crshttp.NewCoursesHandler(delegate.AuthDelegate, delegate.CoursesDelegate),
 		CohortsHandler:      chrthttp.NewCohortsHandler(delegate.AuthDelegate, delegate.CohortsDelegate),
-		UsersHandler:        usershtpp.NewUsersHandler(delegate.AuthDelegate, delegate.UsersDelegate),
+		UsersHandler:        usershtpp.NewUsersHandler(delegate.AuthDelegate, delegate.UsersDelegate, delegate.AccessScope),
 		RobboUnitsHandler:   robboUnitshttp.NewRobboUnitsHandler(delegate.AuthDelegate, delegate.RobboUnitsDelegate),
 		RobboGroupHandler:   robboGrouphttp.NewRobboGroupHandler(delegate.AuthDelegate, delegate.RobboGroupDelegate),
 		CoursePacketHandler: coursePackethttp.NewCoursePacketHandler(delegate.AuthDelegate, delegate.CoursePacketDelegate),
+		ActivityHandler:     activityhttp.NewActivityHandler(delegate.AuthDelegate, delegate.ActivityDelegate),
+		AssetsHandler:       assetshttp.NewAssetsHandler(delegate.AuthDelegate, delegate.AssetsDelegate),
 	}
 }
 
@@ -171,6 +294,19 @@ func SetupGraphQLModule(delegate DelegateModule) GraphQLModule {
 			delegate.RobboUnitsDelegate,
 			delegate.CoursesDelegate,
 			delegate.ProjectPageDelegate,
+			delegate.ActivityDelegate,
+			delegate.ScheduleDelegate,
+			delegate.NotificationsDelegate,
+			delegate.AttendanceDelegate,
+			delegate.CoursePacketDelegate,
+			delegate.DashboardDelegate,
+			delegate.TermsDelegate,
+			delegate.RegionsDelegate,
+			delegate.ProjectsDelegate,
+			delegate.GalleryDelegate,
+			delegate.CommentsDelegate,
+			delegate.AssignmentsDelegate,
+			delegate.AccessScope,
 		),
 	}
 }
@@ -0,0 +1,318 @@
+package access
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+)
+
+// Scope tells which units, groups and users the staff may manage. Super admins manage everything,
+// region admins the units of their region, unit admins the units they are bound to and teachers
+// the groups they teach. Every check takes the id and role of the user asking first.
+type Scope interface {
+	CheckRegion(userId string, userRole models.Role, regionId string) error
+	CheckRobboUnit(userId string, userRole models.Role, robboUnitId string) error
+	// CheckRobboGroup lets the teachers of the group in too, CheckRobboGroupAdmin only the admins of its unit
+	CheckRobboGroup(userId string, userRole models.Role, robboGroupId string) error
+	CheckRobboGroupAdmin(userId string, userRole models.Role, robboGroupId string) error
+	// CheckStudent lets the student, their parents, their teachers and the admins of their units in
+	CheckStudent(userId string, userRole models.Role, studentId string) error
+	CheckTeacher(userId string, userRole models.Role, teacherId string) error
+	CheckParent(userId string, userRole models.Role, parentId string) error
+	// CheckUser picks the check by the role of the other user, users of other roles are left to themselves and super admins
+	CheckUser(userId string, userRole models.Role, otherId string, otherRole models.Role) error
+	// RobboUnitIds lists the units the admin manages, all is set for super admins instead
+	RobboUnitIds(userId string, userRole models.Role) (robboUnitIds []string, all bool, err error)
+}
+
+// IsStaff reports whether the role belongs to the teaching or administrative staff.
+func IsStaff(role models.Role) bool {
+	return role == models.Teacher || IsAdmin(role)
+}
+
+// IsAdmin reports whether the role administers units. Roles are compared one by one:
+// RegionAdmin was appended after Anonymous, so their order says nothing about rights.
+func IsAdmin(role models.Role) bool {
+	return role == models.UnitAdmin || role == models.RegionAdmin || role == models.SuperAdmin
+}
+
+// SameUser compares ids together with roles, every role keeps its users in a table of its own
+// and their ids overlap.
+func SameUser(userId string, userRole models.Role, otherId string, otherRole models.Role) bool {
+	return userId != "" && userId == otherId && userRole == otherRole
+}
+
+type ScopeImpl struct {
+	usersGateway      users.Gateway
+	robboUnitsGateway robboUnits.Gateway
+	robboGroupGateway robboGroup.Gateway
+	regionsGateway    regions.Gateway
+}
+
+func SetupScope(
+	usersGateway users.Gateway,
+	robboUnitsGateway robboUnits.Gateway,
+	robboGroupGateway robboGroup.Gateway,
+	regionsGateway regions.Gateway,
+) Scope {
+	return &ScopeImpl{
+		usersGateway:      usersGateway,
+		robboUnitsGateway: robboUnitsGateway,
+		robboGroupGateway: robboGroupGateway,
+		regionsGateway:    regionsGateway,
+	}
+}
+
+func (s *ScopeImpl) CheckRegion(userId string, userRole models.Role, regionId string) error {
+	switch userRole {
+	case models.SuperAdmin:
+		return nil
+	case models.RegionAdmin:
+		regionAdmin, err := s.usersGateway.GetRegionAdminById(userId)
+		if err != nil {
+			return err
+		}
+		if regionId == "" || regionAdmin.RegionId != regionId {
+			return ErrNoAccess
+		}
+		return nil
+	default:
+		return ErrNoAccess
+	}
+}
+
+func (s *ScopeImpl) CheckRobboUnit(userId string, userRole models.Role, robboUnitId string) error {
+	switch userRole {
+	case models.SuperAdmin:
+		return nil
+	case models.RegionAdmin:
+		robboUnit, err := s.robboUnitsGateway.GetRobboUnitById(robboUnitId)
+		if err != nil {
+			return err
+		}
+		return s.CheckRegion(userId, userRole, robboUnit.RegionId)
+	case models.UnitAdmin:
+		relations, err := s.usersGateway.GetRelationByUnitAdminId(userId)
+		if err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			if relation.RobboUnitId == robboUnitId {
+				return nil
+			}
+		}
+		return ErrNoAccess
+	default:
+		return ErrNoAccess
+	}
+}
+
+func (s *ScopeImpl) CheckRobboGroup(userId string, userRole models.Role, robboGroupId string) error {
+	if userRole != models.Teacher {
+		return s.CheckRobboGroupAdmin(userId, userRole, robboGroupId)
+	}
+	teaches, err := s.teaches(userId, robboGroupId)
+	if err != nil {
+		return err
+	}
+	if !teaches {
+		return ErrNoAccess
+	}
+	return nil
+}
+
+func (s *ScopeImpl) CheckRobboGroupAdmin(userId string, userRole models.Role, robboGroupId string) error {
+	if !IsAdmin(userRole) {
+		return ErrNoAccess
+	}
+	if userRole == models.SuperAdmin {
+		return nil
+	}
+	robboGroup, err := s.robboGroupGateway.GetRobboGroupById(robboGroupId)
+	if err != nil {
+		return err
+	}
+	return s.CheckRobboUnit(userId, userRole, robboGroup.RobboUnitId)
+}
+
+func (s *ScopeImpl) CheckStudent(userId string, userRole models.Role, studentId string) error {
+	switch userRole {
+	case models.SuperAdmin:
+		return nil
+	case models.Student:
+		if userId != studentId {
+			return ErrNoAccess
+		}
+		return nil
+	case models.Parent:
+		relations, err := s.usersGateway.GetRelationByParentId(userId)
+		if err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			if relation.ChildId == studentId {
+				return nil
+			}
+		}
+		return ErrNoAccess
+	case models.Teacher, models.UnitAdmin, models.RegionAdmin:
+		robboGroupIds, robboUnitIds, err := s.placesOf(studentId)
+		if err != nil {
+			return err
+		}
+		for _, robboGroupId := range robboGroupIds {
+			if s.CheckRobboGroup(userId, userRole, robboGroupId) == nil {
+				return nil
+			}
+		}
+		if userRole != models.Teacher {
+			// a student may be bound to a unit without studying in any of its groups
+			for _, robboUnitId := range robboUnitIds {
+				if s.CheckRobboUnit(userId, userRole, robboUnitId) == nil {
+					return nil
+				}
+			}
+		}
+		return ErrNoAccess
+	default:
+		return ErrNoAccess
+	}
+}
+
+func (s *ScopeImpl) CheckTeacher(userId string, userRole models.Role, teacherId string) error {
+	switch userRole {
+	case models.SuperAdmin:
+		return nil
+	case models.Teacher:
+		if userId != teacherId {
+			return ErrNoAccess
+		}
+		return nil
+	case models.UnitAdmin, models.RegionAdmin:
+		relations, err := s.robboGroupGateway.GetRelationByTeacherId(teacherId)
+		if err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			if s.CheckRobboGroupAdmin(userId, userRole, relation.RobboGroupId) == nil {
+				return nil
+			}
+		}
+		return ErrNoAccess
+	default:
+		return ErrNoAccess
+	}
+}
+
+func (s *ScopeImpl) CheckParent(userId string, userRole models.Role, parentId string) error {
+	switch userRole {
+	case models.SuperAdmin:
+		return nil
+	case models.Parent:
+		if userId != parentId {
+			return ErrNoAccess
+		}
+		return nil
+	case models.Teacher, models.UnitAdmin, models.RegionAdmin:
+		// the staff reach a parent through one of their children
+		relations, err := s.usersGateway.GetRelationByParentId(parentId)
+		if err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			if s.CheckStudent(userId, userRole, relation.ChildId) == nil {
+				return nil
+			}
+		}
+		return ErrNoAccess
+	default:
+		return ErrNoAccess
+	}
+}
+
+func (s *ScopeImpl) CheckUser(userId string, userRole models.Role, otherId string, otherRole models.Role) error {
+	if SameUser(userId, userRole, otherId, otherRole) {
+		return nil
+	}
+	switch otherRole {
+	case models.Student:
+		return s.CheckStudent(userId, userRole, otherId)
+	case models.Teacher:
+		return s.CheckTeacher(userId, userRole, otherId)
+	case models.Parent:
+		return s.CheckParent(userId, userRole, otherId)
+	}
+	if userRole != models.SuperAdmin {
+		return ErrNoAccess
+	}
+	return nil
+}
+
+func (s *ScopeImpl) RobboUnitIds(userId string, userRole models.Role) (robboUnitIds []string, all bool, err error) {
+	switch userRole {
+	case models.SuperAdmin:
+		return nil, true, nil
+	case models.RegionAdmin:
+		regionAdmin, getErr := s.usersGateway.GetRegionAdminById(userId)
+		if getErr != nil {
+			return nil, false, getErr
+		}
+		robboUnits, getErr := s.regionsGateway.GetRobboUnitsByRegionId(regionAdmin.RegionId)
+		if getErr != nil {
+			return nil, false, getErr
+		}
+		for _, robboUnit := range robboUnits {
+			robboUnitIds = append(robboUnitIds, robboUnit.Id)
+		}
+		return
+	case models.UnitAdmin:
+		relations, getErr := s.usersGateway.GetRelationByUnitAdminId(userId)
+		if getErr != nil {
+			return nil, false, getErr
+		}
+		for _, relation := range relations {
+			robboUnitIds = append(robboUnitIds, relation.RobboUnitId)
+		}
+		return
+	default:
+		return nil, false, ErrNoAccess
+	}
+}
+
+func (s *ScopeImpl) teaches(teacherId, robboGroupId string) (bool, error) {
+	relations, err := s.robboGroupGateway.GetRelationByRobboGroupId(robboGroupId)
+	if err != nil {
+		return false, err
+	}
+	for _, relation := range relations {
+		if relation.TeacherId == teacherId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// placesOf lists the groups the student studies in now and the units they are bound to.
+func (s *ScopeImpl) placesOf(studentId string) (robboGroupIds, robboUnitIds []string, err error) {
+	student, err := s.usersGateway.GetStudentById(studentId)
+	if err != nil {
+		return
+	}
+	memberships, err := s.usersGateway.GetGroupMembershipsByStudentId(studentId, true)
+	if err != nil {
+		return
+	}
+	if student.RobboGroupId != "" && student.RobboGroupId != "0" {
+		robboGroupIds = append(robboGroupIds, student.RobboGroupId)
+	}
+	if student.RobboUnitId != "" && student.RobboUnitId != "0" {
+		robboUnitIds = append(robboUnitIds, student.RobboUnitId)
+	}
+	for _, membership := range memberships {
+		robboGroupIds = append(robboGroupIds, membership.RobboGroupId)
+		robboUnitIds = append(robboUnitIds, membership.RobboUnitId)
+	}
+	return
+}
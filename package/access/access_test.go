@@ -0,0 +1,176 @@
+package access
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Two regions with a unit each and a unit outside of any region. Every unit has a group,
+// "north" is taught by teacher 1 and studied in by student 1, whose parent is parent 1.
+type fakeUsers struct {
+	users.Gateway
+}
+
+func (fakeUsers) GetRegionAdminById(regionAdminId string) (*models.RegionAdminCore, error) {
+	return &models.RegionAdminCore{RegionId: map[string]string{"1": "north", "2": "south"}[regionAdminId]}, nil
+}
+
+func (fakeUsers) GetRelationByUnitAdminId(unitAdminId string) ([]*models.UnitAdminsRobboUnitsCore, error) {
+	if unitAdminId == "1" {
+		return []*models.UnitAdminsRobboUnitsCore{{UnitAdminId: "1", RobboUnitId: "north"}}, nil
+	}
+	return nil, nil
+}
+
+func (fakeUsers) GetRelationByParentId(parentId string) ([]*models.ChildrenOfParentCore, error) {
+	if parentId == "1" {
+		return []*models.ChildrenOfParentCore{{ParentId: "1", ChildId: "1"}}, nil
+	}
+	return nil, nil
+}
+
+func (fakeUsers) GetStudentById(studentId string) (*models.StudentCore, error) {
+	if studentId == "1" {
+		return &models.StudentCore{RobboGroupId: "north", RobboUnitId: "north"}, nil
+	}
+	return &models.StudentCore{RobboUnitId: "0", RobboGroupId: "0"}, nil
+}
+
+func (fakeUsers) GetGroupMembershipsByStudentId(string, bool) ([]*models.GroupMembershipCore, error) {
+	return nil, nil
+}
+
+type fakeRobboUnits struct {
+	robboUnits.Gateway
+}
+
+func (fakeRobboUnits) GetRobboUnitById(robboUnitId string) (*models.RobboUnitCore, error) {
+	// units are named after their regions
+	if robboUnitId == "nowhere" {
+		return &models.RobboUnitCore{Id: robboUnitId}, nil
+	}
+	return &models.RobboUnitCore{Id: robboUnitId, RegionId: robboUnitId}, nil
+}
+
+type fakeRobboGroups struct {
+	robboGroup.Gateway
+}
+
+func (fakeRobboGroups) GetRobboGroupById(robboGroupId string) (*models.RobboGroupCore, error) {
+	// groups are named after their units
+	return &models.RobboGroupCore{Id: robboGroupId, RobboUnitId: robboGroupId}, nil
+}
+
+func (fakeRobboGroups) GetRelationByRobboGroupId(robboGroupId string) ([]*models.TeachersRobboGroupsCore, error) {
+	if robboGroupId == "north" {
+		return []*models.TeachersRobboGroupsCore{{TeacherId: "1", RobboGroupId: "north"}}, nil
+	}
+	return nil, nil
+}
+
+func (fakeRobboGroups) GetRelationByTeacherId(teacherId string) ([]*models.TeachersRobboGroupsCore, error) {
+	if teacherId == "1" {
+		return []*models.TeachersRobboGroupsCore{{TeacherId: "1", RobboGroupId: "north"}}, nil
+	}
+	return nil, nil
+}
+
+type fakeRegions struct {
+	regions.Gateway
+}
+
+func (fakeRegions) GetRobboUnitsByRegionId(regionId string) ([]*models.RobboUnitCore, error) {
+	return []*models.RobboUnitCore{{Id: regionId, RegionId: regionId}}, nil
+}
+
+func testScope() Scope {
+	return SetupScope(fakeUsers{}, fakeRobboUnits{}, fakeRobboGroups{}, fakeRegions{})
+}
+
+func TestIsAdmin(t *testing.T) {
+	assert.True(t, IsAdmin(models.RegionAdmin))
+	assert.True(t, IsAdmin(models.UnitAdmin))
+	assert.False(t, IsAdmin(models.Anonymous), "Anonymous is numbered below RegionAdmin")
+	assert.False(t, IsStaff(models.Anonymous))
+	assert.True(t, IsStaff(models.Teacher))
+}
+
+func TestSameUser(t *testing.T) {
+	assert.True(t, SameUser("1", models.Student, "1", models.Student))
+	assert.False(t, SameUser("1", models.Teacher, "1", models.Student), "a teacher and a student may share an id")
+	assert.False(t, SameUser("", models.Student, "", models.Student))
+}
+
+func TestCheckRobboUnit(t *testing.T) {
+	scope := testScope()
+	assert.NoError(t, scope.CheckRobboUnit("1", models.RegionAdmin, "north"))
+	assert.ErrorIs(t, scope.CheckRobboUnit("1", models.RegionAdmin, "south"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckRobboUnit("1", models.RegionAdmin, "nowhere"), ErrNoAccess)
+	assert.NoError(t, scope.CheckRobboUnit("1", models.UnitAdmin, "north"))
+	assert.ErrorIs(t, scope.CheckRobboUnit("1", models.UnitAdmin, "south"), ErrNoAccess)
+	assert.NoError(t, scope.CheckRobboUnit("1", models.SuperAdmin, "nowhere"))
+	assert.ErrorIs(t, scope.CheckRobboUnit("1", models.Teacher, "north"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckRobboUnit("1", models.Anonymous, "north"), ErrNoAccess)
+}
+
+func TestCheckRobboGroup(t *testing.T) {
+	scope := testScope()
+	assert.NoError(t, scope.CheckRobboGroup("1", models.Teacher, "north"))
+	assert.ErrorIs(t, scope.CheckRobboGroup("1", models.Teacher, "south"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckRobboGroupAdmin("1", models.Teacher, "north"), ErrNoAccess, "teachers do not administer groups")
+	assert.NoError(t, scope.CheckRobboGroupAdmin("2", models.RegionAdmin, "south"))
+	assert.ErrorIs(t, scope.CheckRobboGroupAdmin("2", models.RegionAdmin, "north"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckRobboGroupAdmin("1", models.Student, "north"), ErrNoAccess)
+}
+
+func TestCheckStudent(t *testing.T) {
+	scope := testScope()
+	assert.NoError(t, scope.CheckStudent("1", models.Student, "1"))
+	assert.ErrorIs(t, scope.CheckStudent("2", models.Student, "1"), ErrNoAccess)
+	assert.NoError(t, scope.CheckStudent("1", models.Parent, "1"))
+	assert.ErrorIs(t, scope.CheckStudent("2", models.Parent, "1"), ErrNoAccess)
+	assert.NoError(t, scope.CheckStudent("1", models.Teacher, "1"))
+	assert.ErrorIs(t, scope.CheckStudent("1", models.Teacher, "2"), ErrNoAccess)
+	assert.NoError(t, scope.CheckStudent("1", models.RegionAdmin, "1"))
+	assert.ErrorIs(t, scope.CheckStudent("2", models.RegionAdmin, "1"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckStudent("1", models.FreeListener, "1"), ErrNoAccess)
+}
+
+func TestCheckTeacherAndParent(t *testing.T) {
+	scope := testScope()
+	assert.NoError(t, scope.CheckTeacher("1", models.UnitAdmin, "1"))
+	assert.ErrorIs(t, scope.CheckTeacher("2", models.RegionAdmin, "1"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckTeacher("2", models.Teacher, "1"), ErrNoAccess)
+	assert.NoError(t, scope.CheckParent("1", models.Teacher, "1"), "the teacher of the child")
+	assert.ErrorIs(t, scope.CheckParent("2", models.RegionAdmin, "1"), ErrNoAccess)
+	assert.ErrorIs(t, scope.CheckParent("1", models.Student, "1"), ErrNoAccess)
+}
+
+func TestCheckUser(t *testing.T) {
+	scope := testScope()
+	assert.NoError(t, scope.CheckUser("1", models.Parent, "1", models.Student), "the parent of the student")
+	assert.ErrorIs(t, scope.CheckUser("2", models.Parent, "1", models.Student), ErrNoAccess)
+	assert.NoError(t, scope.CheckUser("1", models.FreeListener, "1", models.FreeListener))
+	assert.ErrorIs(t, scope.CheckUser("1", models.Teacher, "1", models.FreeListener), ErrNoAccess)
+	assert.NoError(t, scope.CheckUser("1", models.SuperAdmin, "2", models.FreeListener))
+}
+
+func TestRobboUnitIds(t *testing.T) {
+	scope := testScope()
+	robboUnitIds, all, err := scope.RobboUnitIds("2", models.RegionAdmin)
+	assert.NoError(t, err)
+	assert.False(t, all)
+	assert.Equal(t, []string{"south"}, robboUnitIds)
+
+	_, all, err = scope.RobboUnitIds("1", models.SuperAdmin)
+	assert.NoError(t, err)
+	assert.True(t, all)
+
+	_, _, err = scope.RobboUnitIds("1", models.Teacher)
+	assert.ErrorIs(t, err, ErrNoAccess)
+}
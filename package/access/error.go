@@ -0,0 +1,8 @@
+package access
+
+import "errors"
+
+var (
+	// ErrNoAccess reads like the errors the resolvers return for the same case
+	ErrNoAccess = errors.New("status unauthorized")
+)
@@ -0,0 +1,11 @@
+package activity
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	RecordLoginEvent(loginEvent *models.LoginEventCore) (err error)
+	TouchUser(userId string, role models.Role) (err error)
+	GetLoginEventsByUserId(userId string, role models.Role) (loginEvents []*models.LoginEventHTTP, err error)
+	GetInactiveStudentsByRobboUnitId(robboUnitId string, periodDays int) (students []*models.StudentHTTP, err error)
+	GetInactiveParentsByRobboUnitId(robboUnitId string, periodDays int) (parents []*models.ParentHTTP, err error)
+}
@@ -0,0 +1,75 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+)
+
+type ActivityDelegateImpl struct {
+	UseCase activity.UseCase
+}
+
+type ActivityDelegateModule struct {
+	fx.Out
+	activity.Delegate
+}
+
+func SetupActivityDelegate(usecase activity.UseCase) ActivityDelegateModule {
+	return ActivityDelegateModule{
+		Delegate: &ActivityDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *ActivityDelegateImpl) RecordLoginEvent(loginEvent *models.LoginEventCore) (err error) {
+	return p.UseCase.RecordLoginEvent(loginEvent)
+}
+
+func (p *ActivityDelegateImpl) TouchUser(userId string, role models.Role) (err error) {
+	return p.UseCase.TouchUser(userId, role)
+}
+
+func (p *ActivityDelegateImpl) GetLoginEventsByUserId(userId string, role models.Role) (loginEvents []*models.LoginEventHTTP, err error) {
+	loginEventsCore, err := p.UseCase.GetLoginEventsByUserId(userId, role)
+	if err != nil {
+		return
+	}
+	for _, loginEventCore := range loginEventsCore {
+		var loginEventTemp models.LoginEventHTTP
+		loginEventTemp.FromCore(loginEventCore)
+		loginEvents = append(loginEvents, &loginEventTemp)
+	}
+	return
+}
+
+func (p *ActivityDelegateImpl) GetInactiveStudentsByRobboUnitId(robboUnitId string, periodDays int) (students []*models.StudentHTTP, err error) {
+	studentsCore, err := p.UseCase.GetInactiveStudentsByRobboUnitId(robboUnitId, periodDays)
+	if err != nil {
+		return
+	}
+	for _, studentCore := range studentsCore {
+		studentTemp := models.StudentHTTP{
+			UserHTTP: &models.UserHTTP{},
+		}
+		studentTemp.FromCore(studentCore)
+		students = append(students, &studentTemp)
+	}
+	return
+}
+
+func (p *ActivityDelegateImpl) GetInactiveParentsByRobboUnitId(robboUnitId string, periodDays int) (parents []*models.ParentHTTP, err error) {
+	parentsCore, err := p.UseCase.GetInactiveParentsByRobboUnitId(robboUnitId, periodDays)
+	if err != nil {
+		return
+	}
+	for _, parentCore := range parentsCore {
+		parentTemp := models.ParentHTTP{
+			UserHTTP: &models.UserHTTP{},
+		}
+		parentTemp.FromCore(*parentCore)
+		parents = append(parents, &parentTemp)
+	}
+	return
+}
@@ -0,0 +1,7 @@
+package activity
+
+import "errors"
+
+var (
+	ErrUnknownRole = errors.New("unknown user role")
+)
@@ -0,0 +1,14 @@
+package activity
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type Gateway interface {
+	CreateLoginEvent(loginEvent *models.LoginEventCore) (err error)
+	GetLoginEventsByUserId(userId string, role models.Role) (loginEvents []*models.LoginEventCore, err error)
+	UpdateLastSeenAt(userId string, role models.Role, lastSeenAt time.Time) (err error)
+	GetInactiveStudentsByRobboUnitId(robboUnitId string, since time.Time) (students []*models.StudentCore, err error)
+	GetInactiveParentsByRobboUnitId(robboUnitId string, since time.Time) (parents []*models.ParentCore, err error)
+}
@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ActivityGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type ActivityGatewayModule struct {
+	fx.Out
+	activity.Gateway
+}
+
+func SetupActivityGateway(postgresClient db_client.PostgresClient) ActivityGatewayModule {
+	return ActivityGatewayModule{
+		Gateway: &ActivityGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+func (r *ActivityGatewayImpl) CreateLoginEvent(loginEvent *models.LoginEventCore) (err error) {
+	loginEventDb := models.LoginEventDB{}
+	loginEventDb.FromCore(loginEvent)
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Create(&loginEventDb).Error
+		return
+	})
+	return
+}
+
+func (r *ActivityGatewayImpl) GetLoginEventsByUserId(userId string, role models.Role) (loginEvents []*models.LoginEventCore, err error) {
+	var loginEventsDb []*models.LoginEventDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("user_id = ? AND role = ?", userId, role).
+			Order("created_at desc").
+			Find(&loginEventsDb).Error
+		return
+	})
+
+	for _, loginEventDb := range loginEventsDb {
+		loginEvents = append(loginEvents, loginEventDb.ToCore())
+	}
+	return
+}
+
+func (r *ActivityGatewayImpl) UpdateLastSeenAt(userId string, role models.Role, lastSeenAt time.Time) (err error) {
+	var model interface{}
+	switch role {
+	case models.Student:
+		model = &models.StudentDB{}
+	case models.Teacher:
+		model = &models.TeacherDB{}
+	case models.Parent:
+		model = &models.ParentDB{}
+	case models.FreeListener:
+		model = &models.FreeListenerDB{}
+	case models.UnitAdmin:
+		model = &models.UnitAdminDB{}
+	case models.RegionAdmin:
+		model = &models.RegionAdminDB{}
+	case models.SuperAdmin:
+		model = &models.SuperAdminDB{}
+	default:
+		return activity.ErrUnknownRole
+	}
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		// UpdateColumn keeps updated_at untouched, so profile edits stay distinguishable from visits
+		err = tx.Model(model).Where("id = ?", userId).UpdateColumn("last_seen_at", lastSeenAt).Error
+		return
+	})
+	return
+}
+
+func (r *ActivityGatewayImpl) GetInactiveStudentsByRobboUnitId(robboUnitId string, since time.Time) (students []*models.StudentCore, err error) {
+	var studentsDb []*models.StudentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("robbo_unit_id = ?", robboUnitId).
+			Where("(last_seen_at IS NULL OR last_seen_at < ?)", since).
+			Order("last_seen_at asc nulls first").
+			Find(&studentsDb).Error
+		return
+	})
+
+	for _, studentDb := range studentsDb {
+		students = append(students, studentDb.ToCore())
+	}
+	return
+}
+
+func (r *ActivityGatewayImpl) GetInactiveParentsByRobboUnitId(robboUnitId string, since time.Time) (parents []*models.ParentCore, err error) {
+	var parentsDb []*models.ParentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Distinct("parent_dbs.*").
+			Joins("JOIN children_of_parent_dbs ON children_of_parent_dbs.parent_id = CAST(parent_dbs.id AS TEXT) "+
+				"AND children_of_parent_dbs.deleted_at IS NULL").
+			Joins("JOIN student_dbs ON CAST(student_dbs.id AS TEXT) = children_of_parent_dbs.child_id "+
+				"AND student_dbs.deleted_at IS NULL").
+			Where("student_dbs.robbo_unit_id = ?", robboUnitId).
+			Where("(parent_dbs.last_seen_at IS NULL OR parent_dbs.last_seen_at < ?)", since).
+			Find(&parentsDb).Error
+		return
+	})
+
+	for _, parentDb := range parentsDb {
+		parents = append(parents, parentDb.ToCore())
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"log"
+)
+
+type Handler struct {
+	authDelegate     auth.Delegate
+	activityDelegate activity.Delegate
+}
+
+func NewActivityHandler(
+	authDelegate auth.Delegate,
+	activityDelegate activity.Delegate,
+) Handler {
+	return Handler{
+		authDelegate:     authDelegate,
+		activityDelegate: activityDelegate,
+	}
+}
+
+// LastSeenMiddleware refreshes lastSeenAt of the caller on every authorized request.
+// Anonymous requests pass through untouched, writes are throttled in the usecase.
+func (h *Handler) LastSeenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
+		if userIdentityErr == nil {
+			if err := h.activityDelegate.TouchUser(userId, role); err != nil {
+				log.Println(err)
+			}
+		}
+		c.Next()
+	}
+}
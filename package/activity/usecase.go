@@ -0,0 +1,11 @@
+package activity
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type UseCase interface {
+	RecordLoginEvent(loginEvent *models.LoginEventCore) (err error)
+	TouchUser(userId string, role models.Role) (err error)
+	GetLoginEventsByUserId(userId string, role models.Role) (loginEvents []*models.LoginEventCore, err error)
+	GetInactiveStudentsByRobboUnitId(robboUnitId string, periodDays int) (students []*models.StudentCore, err error)
+	GetInactiveParentsByRobboUnitId(robboUnitId string, periodDays int) (parents []*models.ParentCore, err error)
+}
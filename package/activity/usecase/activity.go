@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type ActivityUseCaseImpl struct {
+	activityGateway        activity.Gateway
+	lastSeenUpdateInterval time.Duration
+	inactivityPeriodDays   int
+	// lastSeenWrites remembers when last_seen_at was written for "role:id",
+	// so the middleware hits the database at most once per interval per user
+	lastSeenWrites sync.Map
+	// lastSeenSweep is when lastSeenWrites was last cleared of the writes older than the interval
+	lastSeenSweep      time.Time
+	lastSeenSweepMutex sync.Mutex
+}
+
+type ActivityUseCaseModule struct {
+	fx.Out
+	activity.UseCase
+}
+
+func SetupActivityUseCase(activityGateway activity.Gateway) ActivityUseCaseModule {
+	return ActivityUseCaseModule{
+		UseCase: &ActivityUseCaseImpl{
+			activityGateway:        activityGateway,
+			lastSeenUpdateInterval: time.Duration(viper.GetInt("activity.last_seen_update_interval")) * time.Second,
+			inactivityPeriodDays:   viper.GetInt("activity.inactivity_period_days"),
+		},
+	}
+}
+
+func (p *ActivityUseCaseImpl) RecordLoginEvent(loginEvent *models.LoginEventCore) (err error) {
+	err = p.activityGateway.CreateLoginEvent(loginEvent)
+	if err != nil {
+		return
+	}
+	if loginEvent.Success {
+		return p.TouchUser(loginEvent.UserId, loginEvent.Role)
+	}
+	return
+}
+
+func (p *ActivityUseCaseImpl) TouchUser(userId string, role models.Role) (err error) {
+	now := time.Now()
+	key := strconv.Itoa(int(role)) + ":" + userId
+	if lastWrite, ok := p.lastSeenWrites.Load(key); ok && now.Sub(lastWrite.(time.Time)) < p.lastSeenUpdateInterval {
+		return
+	}
+	p.evictLastSeenWrites(now)
+	if err = p.activityGateway.UpdateLastSeenAt(userId, role, now); err != nil {
+		// a failed write does not hold the next one back
+		return
+	}
+	p.lastSeenWrites.Store(key, now)
+	return
+}
+
+// evictLastSeenWrites forgets the writes older than the interval at most once per interval,
+// they would not hold the next write back anyway and would pile up for every user ever seen.
+func (p *ActivityUseCaseImpl) evictLastSeenWrites(now time.Time) {
+	p.lastSeenSweepMutex.Lock()
+	if now.Sub(p.lastSeenSweep) < p.lastSeenUpdateInterval {
+		p.lastSeenSweepMutex.Unlock()
+		return
+	}
+	p.lastSeenSweep = now
+	p.lastSeenSweepMutex.Unlock()
+
+	p.lastSeenWrites.Range(func(key, lastWrite interface{}) bool {
+		if now.Sub(lastWrite.(time.Time)) >= p.lastSeenUpdateInterval {
+			p.lastSeenWrites.Delete(key)
+		}
+		return true
+	})
+}
+
+func (p *ActivityUseCaseImpl) GetLoginEventsByUserId(userId string, role models.Role) (loginEvents []*models.LoginEventCore, err error) {
+	return p.activityGateway.GetLoginEventsByUserId(userId, role)
+}
+
+func (p *ActivityUseCaseImpl) GetInactiveStudentsByRobboUnitId(robboUnitId string, periodDays int) (students []*models.StudentCore, err error) {
+	return p.activityGateway.GetInactiveStudentsByRobboUnitId(robboUnitId, p.inactiveSince(periodDays))
+}
+
+func (p *ActivityUseCaseImpl) GetInactiveParentsByRobboUnitId(robboUnitId string, periodDays int) (parents []*models.ParentCore, err error) {
+	return p.activityGateway.GetInactiveParentsByRobboUnitId(robboUnitId, p.inactiveSince(periodDays))
+}
+
+func (p *ActivityUseCaseImpl) inactiveSince(periodDays int) time.Time {
+	if periodDays <= 0 {
+		periodDays = p.inactivityPeriodDays
+	}
+	return time.Now().AddDate(0, 0, -periodDays)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeActivity struct {
+	activity.Gateway
+	writes []string
+	since  time.Time
+	err    error
+}
+
+func (f *fakeActivity) UpdateLastSeenAt(userId string, role models.Role, lastSeenAt time.Time) error {
+	f.writes = append(f.writes, userId)
+	return f.err
+}
+
+func (f *fakeActivity) GetInactiveStudentsByRobboUnitId(robboUnitId string, since time.Time) ([]*models.StudentCore, error) {
+	f.since = since
+	return nil, nil
+}
+
+func countLastSeenWrites(p *ActivityUseCaseImpl) (count int) {
+	p.lastSeenWrites.Range(func(interface{}, interface{}) bool {
+		count++
+		return true
+	})
+	return
+}
+
+func TestTouchUser(t *testing.T) {
+	gateway := &fakeActivity{}
+	p := &ActivityUseCaseImpl{activityGateway: gateway, lastSeenUpdateInterval: time.Hour}
+
+	assert.NoError(t, p.TouchUser("1", models.Student))
+	assert.NoError(t, p.TouchUser("1", models.Student))
+	assert.NoError(t, p.TouchUser("1", models.Teacher))
+	assert.Equal(t, []string{"1", "1"}, gateway.writes, "the student is written once, the teacher with the same id apart")
+}
+
+func TestTouchUserRetriesFailedWrites(t *testing.T) {
+	gateway := &fakeActivity{err: errors.New("connection lost")}
+	p := &ActivityUseCaseImpl{activityGateway: gateway, lastSeenUpdateInterval: time.Hour}
+
+	assert.Error(t, p.TouchUser("1", models.Student))
+	gateway.err = nil
+	assert.NoError(t, p.TouchUser("1", models.Student))
+	assert.Equal(t, []string{"1", "1"}, gateway.writes, "a failed write does not throttle the next one")
+}
+
+func TestTouchUserEvictsOldWrites(t *testing.T) {
+	gateway := &fakeActivity{}
+	p := &ActivityUseCaseImpl{activityGateway: gateway, lastSeenUpdateInterval: time.Hour}
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"0:1", "0:2", "1:3"} {
+		p.lastSeenWrites.Store(key, old)
+	}
+	p.lastSeenWrites.Store("0:4", time.Now())
+
+	assert.NoError(t, p.TouchUser("5", models.Student))
+	assert.Equal(t, 2, countLastSeenWrites(p), "only the recent write and the new one are kept")
+
+	// the next sweep waits for the interval to pass
+	p.lastSeenWrites.Store("0:1", old)
+	assert.NoError(t, p.TouchUser("6", models.Student))
+	assert.Equal(t, 4, countLastSeenWrites(p))
+}
+
+func TestInactiveSince(t *testing.T) {
+	gateway := &fakeActivity{}
+	p := &ActivityUseCaseImpl{activityGateway: gateway, inactivityPeriodDays: 30}
+
+	_, err := p.GetInactiveStudentsByRobboUnitId("1", 0)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), gateway.since, time.Minute)
+
+	_, err = p.GetInactiveStudentsByRobboUnitId("1", 7)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), gateway.since, time.Minute)
+}
@@ -0,0 +1,9 @@
+package assets
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	GetAsset(md5ext string) (asset *models.AssetCore, content []byte, err error)
+	GetAssetMetadata(md5ext string) (asset *models.AssetCore, err error)
+	SaveAsset(md5ext string, content []byte) (asset *models.AssetCore, err error)
+}
@@ -0,0 +1,36 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+)
+
+type AssetsDelegateImpl struct {
+	UseCase assets.UseCase
+}
+
+type AssetsDelegateModule struct {
+	fx.Out
+	assets.Delegate
+}
+
+func SetupAssetsDelegate(usecase assets.UseCase) AssetsDelegateModule {
+	return AssetsDelegateModule{
+		Delegate: &AssetsDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *AssetsDelegateImpl) GetAsset(md5ext string) (asset *models.AssetCore, content []byte, err error) {
+	return p.UseCase.GetAsset(md5ext)
+}
+
+func (p *AssetsDelegateImpl) GetAssetMetadata(md5ext string) (asset *models.AssetCore, err error) {
+	return p.UseCase.GetAssetMetadata(md5ext)
+}
+
+func (p *AssetsDelegateImpl) SaveAsset(md5ext string, content []byte) (asset *models.AssetCore, err error) {
+	return p.UseCase.SaveAsset(md5ext, content)
+}
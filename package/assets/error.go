@@ -0,0 +1,11 @@
+package assets
+
+import "errors"
+
+var (
+	ErrAssetNotFound    = errors.New("asset not found")
+	ErrBadMd5ext        = errors.New("asset name must be an md5 hash followed by a supported extension")
+	ErrChecksumMismatch = errors.New("asset content does not match its md5")
+	ErrAssetTooLarge    = errors.New("asset is too large")
+	ErrEmptyAsset       = errors.New("asset is empty")
+)
@@ -0,0 +1,9 @@
+package assets
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Gateway interface {
+	GetAssetByMd5ext(md5ext string) (asset *models.AssetCore, err error)
+	GetAssetContent(md5ext string) (content []byte, err error)
+	CreateAsset(asset *models.AssetCore, content []byte) (err error)
+}
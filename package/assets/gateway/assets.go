@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AssetsGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+	Storage        storage.Storage
+}
+
+type AssetsGatewayModule struct {
+	fx.Out
+	assets.Gateway
+}
+
+func SetupAssetsGateway(postgresClient db_client.PostgresClient, blobStorage storage.Storage) AssetsGatewayModule {
+	return AssetsGatewayModule{
+		Gateway: &AssetsGatewayImpl{PostgresClient: &postgresClient, Storage: blobStorage},
+	}
+}
+
+func assetKey(md5ext string) string {
+	return "assets/" + md5ext
+}
+
+func (r *AssetsGatewayImpl) GetAssetByMd5ext(md5ext string) (asset *models.AssetCore, err error) {
+	var assetDb models.AssetDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("md5ext = ?", md5ext).First(&assetDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return assets.ErrAssetNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	asset = assetDb.ToCore()
+	return
+}
+
+func (r *AssetsGatewayImpl) GetAssetContent(md5ext string) (content []byte, err error) {
+	content, err = r.Storage.Get(assetKey(md5ext))
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, assets.ErrAssetNotFound
+	}
+	return
+}
+
+// CreateAsset stores the content before the record, so a recorded asset can always be served.
+// Concurrent uploads of the same asset write the same bytes and keep the first record.
+func (r *AssetsGatewayImpl) CreateAsset(asset *models.AssetCore, content []byte) (err error) {
+	if err = r.Storage.Put(assetKey(asset.Md5ext), content); err != nil {
+		return
+	}
+	assetDb := models.AssetDB{}
+	assetDb.FromCore(asset)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&assetDb).Error
+	})
+	return
+}
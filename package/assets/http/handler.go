@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+type Handler struct {
+	authDelegate   auth.Delegate
+	assetsDelegate assets.Delegate
+}
+
+func NewAssetsHandler(
+	authDelegate auth.Delegate,
+	assetsDelegate assets.Delegate,
+) Handler {
+	return Handler{
+		authDelegate:   authDelegate,
+		assetsDelegate: assetsDelegate,
+	}
+}
+
+// InitAssetsRoutes serves the storage protocol of scratch-gui. Assets are read without a token
+// so that the editor can load them as images, only signed in users upload them.
+func (h *Handler) InitAssetsRoutes(router *gin.Engine) {
+	asset := router.Group("/assets/internalapi/asset")
+	{
+		asset.GET("/:md5ext", h.GetAsset)
+		// scratch-www style address of the same asset
+		asset.GET("/:md5ext/get/", h.GetAsset)
+		asset.POST("/:md5ext", h.SaveAsset)
+	}
+}
+
+type saveAssetResponse struct {
+	Status      string `json:"status"`
+	ContentName string `json:"content-name"`
+}
+
+func (h *Handler) GetAsset(c *gin.Context) {
+	md5ext := c.Param("md5ext")
+	etag := `"` + md5ext + `"`
+	if c.GetHeader("If-None-Match") == etag {
+		// the name is checked to be stored, a client must not learn that it is cached
+		asset, err := h.assetsDelegate.GetAssetMetadata(md5ext)
+		if err != nil {
+			c.AbortWithStatus(statusOf(err))
+			return
+		}
+		setAssetHeaders(c, asset, etag)
+		c.Status(http.StatusNotModified)
+		return
+	}
+	asset, content, err := h.assetsDelegate.GetAsset(md5ext)
+	if err != nil {
+		c.AbortWithStatus(statusOf(err))
+		return
+	}
+	setAssetHeaders(c, asset, etag)
+	c.Data(http.StatusOK, asset.ContentType, content)
+}
+
+// setAssetHeaders keeps uploaded content from running as a page of the site: an SVG opened
+// directly could carry scripts, so it is sandboxed and downloaded rather than shown.
+func setAssetHeaders(c *gin.Context, asset *models.AssetCore, etag string) {
+	// content addressed, the bytes behind a name never change
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", etag)
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if asset.DataFormat == "svg" {
+		c.Header("Content-Disposition", `attachment; filename="`+asset.Md5ext+`"`)
+	}
+}
+
+func (h *Handler) SaveAsset(c *gin.Context) {
+	if _, _, userIdentityErr := h.authDelegate.UserIdentity(c); userIdentityErr != nil {
+		log.Println(userIdentityErr)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"status": "error", "error": userIdentityErr.Error()})
+		return
+	}
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, viper.GetInt64("assets.max_size"))
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+		return
+	}
+	asset, err := h.assetsDelegate.SaveAsset(c.Param("md5ext"), content)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatusJSON(statusOf(err), gin.H{"status": "error", "error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, saveAssetResponse{
+		Status:      "ok",
+		ContentName: asset.Md5ext,
+	})
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, assets.ErrAssetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, assets.ErrBadMd5ext), errors.Is(err, assets.ErrChecksumMismatch), errors.Is(err, assets.ErrEmptyAsset):
+		return http.StatusBadRequest
+	case errors.Is(err, assets.ErrAssetTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
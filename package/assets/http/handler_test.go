@@ -0,0 +1,114 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	storedSvg = "cd21514d0531fdffb22204e0ec5ed84a.svg"
+	storedPng = "0fb9be3e8397c983338cb71dc84d0b25.png"
+	missing   = "83a9787d4cb6f3b7632b4ddfebf74367.wav"
+)
+
+// identity is who every request of a test is sent by.
+type identity struct {
+	auth.Delegate
+	role models.Role
+}
+
+func (i identity) UserIdentity(*gin.Context) (string, models.Role, error) {
+	if i.role == models.Anonymous {
+		return "", models.Anonymous, auth.ErrTokenNotFound
+	}
+	return "1", i.role, nil
+}
+
+// assetsDelegate stores the svg and the png, the content of an asset is its name.
+type assetsDelegate struct {
+	assets.Delegate
+	saved []string
+}
+
+func (d *assetsDelegate) GetAssetMetadata(md5ext string) (*models.AssetCore, error) {
+	if md5ext != storedSvg && md5ext != storedPng {
+		return nil, assets.ErrAssetNotFound
+	}
+	asset, _ := models.ParseMd5ext(md5ext)
+	return asset, nil
+}
+
+func (d *assetsDelegate) GetAsset(md5ext string) (*models.AssetCore, []byte, error) {
+	asset, err := d.GetAssetMetadata(md5ext)
+	if err != nil {
+		return nil, nil, err
+	}
+	return asset, []byte(md5ext), nil
+}
+
+func (d *assetsDelegate) SaveAsset(md5ext string, content []byte) (*models.AssetCore, error) {
+	d.saved = append(d.saved, md5ext)
+	asset, _ := models.ParseMd5ext(md5ext)
+	return asset, nil
+}
+
+func serve(who identity, delegate *assetsDelegate, method, path string, header http.Header) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewAssetsHandler(who, delegate)
+	handler.InitAssetsRoutes(router)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader("content"))
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetAssetHeaders(t *testing.T) {
+	response := serve(identity{role: models.Anonymous}, &assetsDelegate{}, "GET", "/assets/internalapi/asset/"+storedSvg, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "image/svg+xml", response.Header().Get("Content-Type"))
+	assert.Equal(t, "default-src 'none'; style-src 'unsafe-inline'; sandbox", response.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "nosniff", response.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, `attachment; filename="`+storedSvg+`"`, response.Header().Get("Content-Disposition"))
+
+	response = serve(identity{role: models.Anonymous}, &assetsDelegate{}, "GET", "/assets/internalapi/asset/"+storedPng+"/get/", nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "nosniff", response.Header().Get("X-Content-Type-Options"))
+	assert.Empty(t, response.Header().Get("Content-Disposition"), "images other than svg run no scripts")
+}
+
+func TestGetAssetNotModified(t *testing.T) {
+	response := serve(identity{role: models.Anonymous}, &assetsDelegate{}, "GET", "/assets/internalapi/asset/"+storedSvg,
+		http.Header{"If-None-Match": {`"` + storedSvg + `"`}})
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Equal(t, "nosniff", response.Header().Get("X-Content-Type-Options"))
+
+	response = serve(identity{role: models.Anonymous}, &assetsDelegate{}, "GET", "/assets/internalapi/asset/"+missing,
+		http.Header{"If-None-Match": {`"` + missing + `"`}})
+	assert.Equal(t, http.StatusNotFound, response.Code, "an asset that isn't stored is never reported cached")
+}
+
+func TestSaveAssetNeedsSignIn(t *testing.T) {
+	viper.Set("assets.max_size", 1024)
+	defer viper.Set("assets.max_size", nil)
+	delegate := &assetsDelegate{}
+	response := serve(identity{role: models.Anonymous}, delegate, "POST", "/assets/internalapi/asset/"+storedPng, nil)
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Empty(t, delegate.saved)
+
+	response = serve(identity{role: models.Student}, delegate, "POST", "/assets/internalapi/asset/"+storedPng, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `"content-name":"`+storedPng+`"`)
+	assert.Equal(t, []string{storedPng}, delegate.saved)
+}
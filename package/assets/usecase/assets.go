@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+)
+
+type AssetsUseCaseImpl struct {
+	assetsGateway assets.Gateway
+	maxSize       int
+}
+
+type AssetsUseCaseModule struct {
+	fx.Out
+	assets.UseCase
+}
+
+func SetupAssetsUseCase(assetsGateway assets.Gateway) AssetsUseCaseModule {
+	return AssetsUseCaseModule{
+		UseCase: &AssetsUseCaseImpl{
+			assetsGateway: assetsGateway,
+			maxSize:       viper.GetInt("assets.max_size"),
+		},
+	}
+}
+
+func (p *AssetsUseCaseImpl) GetAsset(md5ext string) (asset *models.AssetCore, content []byte, err error) {
+	if _, err = parseMd5ext(md5ext); err != nil {
+		return
+	}
+	if asset, err = p.assetsGateway.GetAssetByMd5ext(md5ext); err != nil {
+		return
+	}
+	content, err = p.assetsGateway.GetAssetContent(md5ext)
+	return
+}
+
+// GetAssetMetadata tells what is stored under md5ext without reading the content.
+func (p *AssetsUseCaseImpl) GetAssetMetadata(md5ext string) (asset *models.AssetCore, err error) {
+	if _, err = parseMd5ext(md5ext); err != nil {
+		return
+	}
+	return p.assetsGateway.GetAssetByMd5ext(md5ext)
+}
+
+// SaveAsset accepts the content only if it hashes to the md5 in its name, so an asset
+// can never be replaced with different bytes. Uploading an existing asset is a no-op.
+func (p *AssetsUseCaseImpl) SaveAsset(md5ext string, content []byte) (asset *models.AssetCore, err error) {
+	if asset, err = parseMd5ext(md5ext); err != nil {
+		return
+	}
+	if err = verifyContent(asset, content, p.maxSize); err != nil {
+		return
+	}
+	existing, err := p.assetsGateway.GetAssetByMd5ext(md5ext)
+	switch {
+	case err == nil:
+		if _, contentErr := p.assetsGateway.GetAssetContent(md5ext); contentErr == nil {
+			return existing, nil
+		}
+		// the blob was lost from the storage, the upload brings it back
+	case !errors.Is(err, assets.ErrAssetNotFound):
+		return
+	}
+	if err = p.assetsGateway.CreateAsset(asset, content); err != nil {
+		return
+	}
+	return p.assetsGateway.GetAssetByMd5ext(md5ext)
+}
+
+func parseMd5ext(md5ext string) (asset *models.AssetCore, err error) {
+	asset, ok := models.ParseMd5ext(md5ext)
+	if !ok {
+		return nil, assets.ErrBadMd5ext
+	}
+	return
+}
+
+func verifyContent(asset *models.AssetCore, content []byte, maxSize int) error {
+	if len(content) == 0 {
+		return assets.ErrEmptyAsset
+	}
+	if maxSize > 0 && len(content) > maxSize {
+		return assets.ErrAssetTooLarge
+	}
+	if !asset.HasContent(content) {
+		return assets.ErrChecksumMismatch
+	}
+	asset.Size = len(content)
+	return nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseMd5ext(t *testing.T) {
+	asset, err := parseMd5ext("cd21514d0531fdffb22204e0ec5ed84a.svg")
+	assert.NoError(t, err)
+	assert.Equal(t, "cd21514d0531fdffb22204e0ec5ed84a", asset.Md5)
+	assert.Equal(t, "svg", asset.DataFormat)
+	assert.Equal(t, "image/svg+xml", asset.ContentType)
+
+	for _, md5ext := range []string{
+		"cd21514d0531fdffb22204e0ec5ed84a",
+		"cd21514d0531fdffb22204e0ec5ed84a.exe",
+		"CD21514D0531FDFFB22204E0EC5ED84A.svg",
+		"../cd21514d0531fdffb22204e0ec5ed84a.svg",
+		"cd21514d0531fdffb22204e0ec5ed84.svg",
+	} {
+		_, err = parseMd5ext(md5ext)
+		assert.ErrorIs(t, err, assets.ErrBadMd5ext, md5ext)
+	}
+}
+
+func TestVerifyContent(t *testing.T) {
+	content := []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>")
+	sum := md5.Sum(content)
+	asset, err := parseMd5ext(hex.EncodeToString(sum[:]) + ".svg")
+	assert.NoError(t, err)
+
+	assert.NoError(t, verifyContent(asset, content, 1024))
+	assert.Equal(t, len(content), asset.Size)
+
+	assert.ErrorIs(t, verifyContent(asset, append(content, ' '), 1024), assets.ErrChecksumMismatch)
+	assert.ErrorIs(t, verifyContent(asset, content, 10), assets.ErrAssetTooLarge)
+	assert.ErrorIs(t, verifyContent(asset, nil, 1024), assets.ErrEmptyAsset)
+}
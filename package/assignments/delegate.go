@@ -0,0 +1,27 @@
+package assignments
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	CreateAssignment(input *models.NewAssignment, teacherId string, teacherRole models.Role) (assignment *models.AssignmentHTTP, err error)
+	UpdateAssignment(input *models.UpdateAssignment, userId string, userRole models.Role) (assignment *models.AssignmentHTTP, err error)
+	DeleteAssignment(assignmentId, userId string, userRole models.Role) (err error)
+	GetAssignmentsByRobboGroupId(robboGroupId, userId string, userRole models.Role) (assignments []*models.AssignmentHTTP, err error)
+	GetAssignmentProgress(assignmentId, userId string, userRole models.Role) (progress *models.AssignmentProgressHTTP, err error)
+	GetSubmittedProject(assignmentId, studentId, userId string, userRole models.Role) (projectJson string, err error)
+
+	GetStudentAssignments(studentId string) (studentAssignments []*models.StudentAssignmentHTTP, err error)
+	StartAssignment(assignmentId, studentId string) (work *models.AssignmentWorkHTTP, err error)
+	SubmitAssignment(assignmentId, studentId string) (work *models.AssignmentWorkHTTP, err error)
+	CheckAssignment(assignmentId, studentId string) (results []*models.AssignmentCheckResultHTTP, err error)
+
+	ReviewAssignment(input *models.AssignmentReviewInput, reviewerId string, reviewerRole models.Role) (review *models.AssignmentReviewHTTP, err error)
+	GetAssignmentReviews(assignmentId, studentId, userId string, userRole models.Role) (reviews []*models.AssignmentReviewHTTP, err error)
+	GetReviewedProject(reviewId, userId string, userRole models.Role) (projectJson string, err error)
+	GetGradebook(robboGroupId, userId string, userRole models.Role) (gradebook *models.GradebookHTTP, err error)
+	GetStudentAssignmentsByParentId(parentId, userId string, userRole models.Role) (studentAssignments []*models.StudentAssignmentHTTP, err error)
+
+	GetAssignmentSimilarity(assignmentId, userId string, userRole models.Role) (report *models.SimilarityReportHTTP, err error)
+	GetGroupSimilarity(robboGroupId, userId string, userRole models.Role) (report *models.SimilarityReportHTTP, err error)
+	FingerprintSubmissions() (err error)
+}
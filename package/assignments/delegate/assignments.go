@@ -0,0 +1,176 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"time"
+)
+
+type AssignmentsDelegateImpl struct {
+	assignments.UseCase
+}
+
+type AssignmentsDelegateModule struct {
+	fx.Out
+	assignments.Delegate
+}
+
+func SetupAssignmentsDelegate(usecase assignments.UseCase) AssignmentsDelegateModule {
+	return AssignmentsDelegateModule{
+		Delegate: &AssignmentsDelegateImpl{
+			UseCase: usecase,
+		},
+	}
+}
+
+func (p *AssignmentsDelegateImpl) CreateAssignment(input *models.NewAssignment, teacherId string, teacherRole models.Role) (assignment *models.AssignmentHTTP, err error) {
+	deadline, err := parseTimestamp(input.Deadline)
+	if err != nil {
+		return
+	}
+	return toHttp(p.UseCase.CreateAssignment(&models.AssignmentCore{
+		RobboGroupId:      input.RobboGroupID,
+		TeacherId:         teacherId,
+		Title:             input.Title,
+		Instruction:       input.Instruction,
+		TemplateProjectId: input.TemplateProjectID,
+		Deadline:          deadline,
+		Rubric:            rubricOf(input.Rubric),
+		Checks:            checksOf(input.Checks),
+	}, teacherRole))
+}
+
+func (p *AssignmentsDelegateImpl) UpdateAssignment(input *models.UpdateAssignment, userId string, userRole models.Role) (assignment *models.AssignmentHTTP, err error) {
+	deadline, err := parseTimestamp(input.Deadline)
+	if err != nil {
+		return
+	}
+	return toHttp(p.UseCase.UpdateAssignment(&models.AssignmentCore{
+		Id:          input.ID,
+		Title:       input.Title,
+		Instruction: input.Instruction,
+		Deadline:    deadline,
+		Checks:      checksOf(input.Checks),
+	}, userId, userRole))
+}
+
+func (p *AssignmentsDelegateImpl) GetAssignmentsByRobboGroupId(robboGroupId, userId string, userRole models.Role) (assignmentsHttp []*models.AssignmentHTTP, err error) {
+	assignmentsCore, err := p.UseCase.GetAssignmentsByRobboGroupId(robboGroupId, userId, userRole)
+	if err != nil {
+		return
+	}
+	assignmentsHttp = make([]*models.AssignmentHTTP, 0, len(assignmentsCore))
+	for _, assignmentCore := range assignmentsCore {
+		assignmentHttp := &models.AssignmentHTTP{}
+		assignmentHttp.FromCore(assignmentCore)
+		assignmentsHttp = append(assignmentsHttp, assignmentHttp)
+	}
+	return
+}
+
+func (p *AssignmentsDelegateImpl) GetAssignmentProgress(assignmentId, userId string, userRole models.Role) (progress *models.AssignmentProgressHTTP, err error) {
+	progressCore, err := p.UseCase.GetAssignmentProgress(assignmentId, userId, userRole)
+	if err != nil {
+		return
+	}
+	progress = &models.AssignmentProgressHTTP{}
+	progress.FromCore(progressCore)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) GetStudentAssignments(studentId string) (studentAssignments []*models.StudentAssignmentHTTP, err error) {
+	studentAssignmentsCore, err := p.UseCase.GetStudentAssignments(studentId)
+	if err != nil {
+		return
+	}
+	return toStudentAssignmentsHttp(studentAssignmentsCore), nil
+}
+
+func (p *AssignmentsDelegateImpl) StartAssignment(assignmentId, studentId string) (work *models.AssignmentWorkHTTP, err error) {
+	started, err := p.UseCase.StartAssignment(assignmentId, studentId)
+	if err != nil {
+		return
+	}
+	work = &models.AssignmentWorkHTTP{}
+	work.FromCore(started.Work, started.Assignment.Deadline)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) SubmitAssignment(assignmentId, studentId string) (work *models.AssignmentWorkHTTP, err error) {
+	submitted, err := p.UseCase.SubmitAssignment(assignmentId, studentId)
+	if err != nil {
+		return
+	}
+	work = &models.AssignmentWorkHTTP{}
+	work.FromCore(submitted.Work, submitted.Assignment.Deadline)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) CheckAssignment(assignmentId, studentId string) (results []*models.AssignmentCheckResultHTTP, err error) {
+	resultsCore, err := p.UseCase.CheckAssignment(assignmentId, studentId)
+	if err != nil {
+		return
+	}
+	results = make([]*models.AssignmentCheckResultHTTP, 0, len(resultsCore))
+	for _, resultCore := range resultsCore {
+		result := &models.AssignmentCheckResultHTTP{}
+		result.FromCore(resultCore)
+		results = append(results, result)
+	}
+	return
+}
+
+func toStudentAssignmentsHttp(studentAssignmentsCore []*models.StudentAssignmentCore) (studentAssignments []*models.StudentAssignmentHTTP) {
+	studentAssignments = make([]*models.StudentAssignmentHTTP, 0, len(studentAssignmentsCore))
+	for _, studentAssignmentCore := range studentAssignmentsCore {
+		studentAssignment := &models.StudentAssignmentHTTP{}
+		studentAssignment.FromCore(studentAssignmentCore)
+		studentAssignments = append(studentAssignments, studentAssignment)
+	}
+	return
+}
+
+// rubricOf keeps a missing rubric nil, so that the default one is used.
+func rubricOf(input []*models.RubricCriterionInput) (rubric []models.RubricCriterionCore) {
+	if input == nil {
+		return nil
+	}
+	rubric = make([]models.RubricCriterionCore, 0, len(input))
+	for _, criterion := range input {
+		rubric = append(rubric, criterion.ToCore())
+	}
+	return
+}
+
+// checksOf keeps missing checks nil, so that an update leaves the checks as they are.
+func checksOf(input []*models.AssignmentCheckInput) (checks []models.AssignmentCheckCore) {
+	if input == nil {
+		return nil
+	}
+	checks = make([]models.AssignmentCheckCore, 0, len(input))
+	for _, check := range input {
+		checks = append(checks, check.ToCore())
+	}
+	return
+}
+
+func toHttp(assignmentCore *models.AssignmentCore, err error) (assignment *models.AssignmentHTTP, _ error) {
+	if err != nil {
+		return nil, err
+	}
+	assignment = &models.AssignmentHTTP{}
+	assignment.FromCore(assignmentCore)
+	return assignment, nil
+}
+
+func parseTimestamp(timestamp *string) (parsed *time.Time, err error) {
+	if timestamp == nil {
+		return
+	}
+	parsedTime, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return nil, assignments.ErrBadTimestampFormat
+	}
+	return &parsedTime, nil
+}
@@ -0,0 +1,58 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+func (p *AssignmentsDelegateImpl) ReviewAssignment(input *models.AssignmentReviewInput, reviewerId string, reviewerRole models.Role) (review *models.AssignmentReviewHTTP, err error) {
+	scores := make([]models.CriterionScoreCore, 0, len(input.Scores))
+	for _, score := range input.Scores {
+		scores = append(scores, models.CriterionScoreCore{Criterion: score.Criterion, Score: score.Score})
+	}
+	reviewCore, err := p.UseCase.ReviewAssignment(&models.AssignmentReviewCore{
+		AssignmentId: input.AssignmentID,
+		StudentId:    input.StudentID,
+		ReviewerId:   reviewerId,
+		Decision:     models.ReviewDecision(input.Decision),
+		Scores:       scores,
+		Feedback:     input.Feedback,
+	}, reviewerRole)
+	if err != nil {
+		return
+	}
+	review = &models.AssignmentReviewHTTP{}
+	review.FromCore(reviewCore)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) GetAssignmentReviews(assignmentId, studentId, userId string, userRole models.Role) (reviews []*models.AssignmentReviewHTTP, err error) {
+	reviewsCore, err := p.UseCase.GetAssignmentReviews(assignmentId, studentId, userId, userRole)
+	if err != nil {
+		return
+	}
+	reviews = make([]*models.AssignmentReviewHTTP, 0, len(reviewsCore))
+	for _, reviewCore := range reviewsCore {
+		review := &models.AssignmentReviewHTTP{}
+		review.FromCore(reviewCore)
+		reviews = append(reviews, review)
+	}
+	return
+}
+
+func (p *AssignmentsDelegateImpl) GetGradebook(robboGroupId, userId string, userRole models.Role) (gradebook *models.GradebookHTTP, err error) {
+	gradebookCore, err := p.UseCase.GetGradebook(robboGroupId, userId, userRole)
+	if err != nil {
+		return
+	}
+	gradebook = &models.GradebookHTTP{}
+	gradebook.FromCore(gradebookCore)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) GetStudentAssignmentsByParentId(parentId, userId string, userRole models.Role) (studentAssignments []*models.StudentAssignmentHTTP, err error) {
+	studentAssignmentsCore, err := p.UseCase.GetStudentAssignmentsByParentId(parentId, userId, userRole)
+	if err != nil {
+		return
+	}
+	return toStudentAssignmentsHttp(studentAssignmentsCore), nil
+}
@@ -0,0 +1,29 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+func (p *AssignmentsDelegateImpl) GetAssignmentSimilarity(assignmentId, userId string, userRole models.Role) (report *models.SimilarityReportHTTP, err error) {
+	reportCore, err := p.UseCase.GetAssignmentSimilarity(assignmentId, userId, userRole)
+	if err != nil {
+		return
+	}
+	report = &models.SimilarityReportHTTP{}
+	report.FromCore(reportCore)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) GetGroupSimilarity(robboGroupId, userId string, userRole models.Role) (report *models.SimilarityReportHTTP, err error) {
+	reportCore, err := p.UseCase.GetGroupSimilarity(robboGroupId, userId, userRole)
+	if err != nil {
+		return
+	}
+	report = &models.SimilarityReportHTTP{}
+	report.FromCore(reportCore)
+	return
+}
+
+func (p *AssignmentsDelegateImpl) FingerprintSubmissions() (err error) {
+	return p.UseCase.FingerprintSubmissions()
+}
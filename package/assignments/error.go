@@ -0,0 +1,26 @@
+package assignments
+
+import "errors"
+
+var (
+	ErrAssignmentNotFound = errors.New("assignment not found")
+	ErrWorkNotFound       = errors.New("student has no work on the assignment")
+	ErrNotStarted         = errors.New("assignment is not started yet")
+	ErrNotSubmitted       = errors.New("assignment is not submitted yet")
+	ErrNoAccess           = errors.New("no access to the assignment")
+	ErrNotInGroup         = errors.New("student is not a member of the assignment's group")
+	ErrEmptyTitle         = errors.New("assignment title is empty")
+	ErrTitleTooLong       = errors.New("assignment title is too long")
+	ErrInstructionTooLong = errors.New("assignment instruction is too long")
+	ErrBadTimestampFormat = errors.New("timestamp must be in RFC3339 format")
+	ErrTemplateNotFound   = errors.New("template project not found")
+	ErrBadRubric          = errors.New("rubric criteria must have distinct names and from 1 to 100 points")
+	ErrReviewNotFound     = errors.New("review not found")
+	ErrBadDecision        = errors.New("review decision must be one of graded, returned")
+	ErrBadScores          = errors.New("graded work must have a score within the points of every rubric criterion")
+	ErrEmptyFeedback      = errors.New("returned work must have feedback")
+	ErrFeedbackTooLong    = errors.New("feedback is too long")
+	ErrAlreadyGraded      = errors.New("graded work cannot be submitted again")
+	ErrSubmissionChanged  = errors.New("work has been submitted again since it was opened for review")
+	ErrBadChecks          = errors.New("checks must have a known kind, a value and a minimum from 1 to 1000")
+)
@@ -0,0 +1,27 @@
+package assignments
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Gateway interface {
+	CreateAssignment(assignment *models.AssignmentCore, studentIds []string, project *models.ProjectCore, projectPage *models.ProjectPageCore) (id string, err error)
+	GetAssignmentById(assignmentId string) (assignment *models.AssignmentCore, err error)
+	GetAssignmentsByRobboGroupIds(robboGroupIds []string) (assignments []*models.AssignmentCore, err error)
+	UpdateAssignment(assignment *models.AssignmentCore) (err error)
+	DeleteAssignment(assignmentId string) (err error)
+
+	CreateWork(work *models.AssignmentWorkCore, project *models.ProjectCore, projectPage *models.ProjectPageCore) (created *models.AssignmentWorkCore, err error)
+	GetWork(assignmentId, studentId string) (work *models.AssignmentWorkCore, err error)
+	GetWorksByAssignmentId(assignmentId string) (works []*models.AssignmentWorkCore, err error)
+	GetWorksByAssignmentIds(assignmentIds []string) (works []*models.AssignmentWorkCore, err error)
+	GetWorksByStudentId(studentId string) (works []*models.AssignmentWorkCore, err error)
+	SubmitWork(work *models.AssignmentWorkCore) (err error)
+	GetFingerprintsByAssignmentIds(assignmentIds []string) (works []*models.AssignmentWorkCore, err error)
+	GetUnfingerprintedWorks(offset, limit int) (works []*models.AssignmentWorkCore, err error)
+	SetWorkFingerprint(work *models.AssignmentWorkCore) (err error)
+
+	CreateReview(review *models.AssignmentReviewCore, status models.AssignmentWorkStatus) (id string, err error)
+	GetReviewById(reviewId string) (review *models.AssignmentReviewCore, err error)
+	GetReviews(assignmentId, studentId string) (reviews []*models.AssignmentReviewCore, err error)
+	GetLatestReviewsByAssignmentIds(assignmentIds []string) (reviews []*models.AssignmentReviewCore, err error)
+	GetLatestReviewsByStudentId(studentId string) (reviews []*models.AssignmentReviewCore, err error)
+}
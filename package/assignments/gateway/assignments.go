@@ -0,0 +1,340 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type AssignmentsGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+	Storage        storage.Storage
+}
+
+type AssignmentsGatewayModule struct {
+	fx.Out
+	assignments.Gateway
+}
+
+func SetupAssignmentsGateway(postgresClient db_client.PostgresClient, blobStorage storage.Storage) AssignmentsGatewayModule {
+	return AssignmentsGatewayModule{
+		Gateway: &AssignmentsGatewayImpl{PostgresClient: &postgresClient, Storage: blobStorage},
+	}
+}
+
+// CreateAssignment stores the assignment together with a copy of the template for every student
+// in one transaction, a copy that fails leaves no assignment behind. The copies are made of project
+// and projectPage, every copy is a project of its student.
+func (r *AssignmentsGatewayImpl) CreateAssignment(
+	assignment *models.AssignmentCore,
+	studentIds []string,
+	project *models.ProjectCore,
+	projectPage *models.ProjectPageCore,
+) (id string, err error) {
+	assignmentDb := models.AssignmentDB{}
+	assignmentDb.FromCore(assignment)
+	projectDb, err := r.projectOf(project)
+	if err != nil {
+		return
+	}
+	// the copies are made of the template, the assignment keeps it in their blob
+	assignmentDb.TemplateHash = projectDb.BodyHash
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Create(&assignmentDb).Error; err != nil {
+			return
+		}
+		id = strconv.FormatUint(uint64(assignmentDb.ID), 10)
+		for _, studentId := range studentIds {
+			copyDb, copyPage := projectDb, *projectPage
+			copyDb.AuthorId = studentId
+			work := &models.AssignmentWorkCore{AssignmentId: id, StudentId: studentId, Status: models.AssignmentAssigned}
+			if _, err = createWork(tx, work, &copyDb, &copyPage); err != nil {
+				return
+			}
+		}
+		return
+	})
+	if err != nil {
+		return "", err
+	}
+	return
+}
+
+func (r *AssignmentsGatewayImpl) GetAssignmentById(assignmentId string) (assignment *models.AssignmentCore, err error) {
+	var assignmentDb models.AssignmentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", assignmentId).First(&assignmentDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return assignments.ErrAssignmentNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	assignment = assignmentDb.ToCore()
+	assignment.TemplateJson, err = r.frozen(assignmentDb.TemplateHash, assignmentDb.LegacyTemplateJson)
+	return
+}
+
+// GetAssignmentsByRobboGroupIds returns the assignments newest first without their templates.
+func (r *AssignmentsGatewayImpl) GetAssignmentsByRobboGroupIds(robboGroupIds []string) (assignmentsCore []*models.AssignmentCore, err error) {
+	assignmentsCore = []*models.AssignmentCore{}
+	if len(robboGroupIds) == 0 {
+		return
+	}
+	var assignmentsDb []*models.AssignmentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Omit("template_json").Where("robbo_group_id IN ?", robboGroupIds).Order("created_at desc, id desc").Find(&assignmentsDb).Error
+	})
+	if err != nil {
+		return
+	}
+	for _, assignmentDb := range assignmentsDb {
+		assignmentsCore = append(assignmentsCore, assignmentDb.ToCore())
+	}
+	return
+}
+
+// UpdateAssignment writes the title, the instruction and the deadline. The group and the template never change.
+func (r *AssignmentsGatewayImpl) UpdateAssignment(assignment *models.AssignmentCore) (err error) {
+	assignmentDb := models.AssignmentDB{}
+	assignmentDb.FromCore(assignment)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&assignmentDb).Select("title", "instruction", "deadline", "checks").Updates(&assignmentDb).Error
+	})
+	return
+}
+
+// DeleteAssignment keeps the works, the copies are projects of the students now.
+func (r *AssignmentsGatewayImpl) DeleteAssignment(assignmentId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("id = ?", assignmentId).Delete(&models.AssignmentDB{}).Error
+	})
+	return
+}
+
+// CreateWork stores the copy of the template with its page and the work on it at once. When the
+// student already has a work on the assignment nothing is stored and the existing work is returned.
+func (r *AssignmentsGatewayImpl) CreateWork(
+	work *models.AssignmentWorkCore,
+	project *models.ProjectCore,
+	projectPage *models.ProjectPageCore,
+) (created *models.AssignmentWorkCore, err error) {
+	projectDb, err := r.projectOf(project)
+	if err != nil {
+		return
+	}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		created, err = createWork(tx, work, &projectDb, projectPage)
+		return
+	})
+	if err != nil {
+		// a concurrent request has made the copy first, its transaction has won the unique index
+		if existing, getErr := r.GetWork(work.AssignmentId, work.StudentId); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return
+}
+
+// projectOf stores the body of the copy, every copy of the template shares its blob.
+func (r *AssignmentsGatewayImpl) projectOf(project *models.ProjectCore) (projectDb models.ProjectDB, err error) {
+	projectDb.FromCore(project)
+	hash, size, err := storage.PutCompressed(r.Storage, models.ProjectBodyPrefix, strings.NewReader(project.Json))
+	if err != nil {
+		return
+	}
+	projectDb.BodyHash, projectDb.BodySize = hash, int(size)
+	return
+}
+
+// putSubmission freezes a submitted project in the blob storage. Submissions are kept with the bodies
+// of projects, a submission of an unchanged copy shares the blob of the copy.
+func (r *AssignmentsGatewayImpl) putSubmission(projectJson string) (hash string, err error) {
+	hash, _, err = storage.PutCompressed(r.Storage, models.ProjectBodyPrefix, strings.NewReader(projectJson))
+	return
+}
+
+// frozen reads a submission or a template, rows saved before the blob storage keep it themselves.
+// The blob is decompressed straight into the string.
+func (r *AssignmentsGatewayImpl) frozen(hash, legacyJson string) (projectJson string, err error) {
+	if hash == "" {
+		return legacyJson, nil
+	}
+	content, err := storage.GetCompressed(r.Storage, models.ProjectBodyPrefix, hash)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+	var body strings.Builder
+	if _, err = io.Copy(&body, content); err != nil {
+		return
+	}
+	return body.String(), nil
+}
+
+// createWork completes the link of the page with the id of the copy. A work the student already
+// has on the assignment is returned as it is.
+func createWork(
+	tx *gorm.DB,
+	work *models.AssignmentWorkCore,
+	projectDb *models.ProjectDB,
+	projectPage *models.ProjectPageCore,
+) (created *models.AssignmentWorkCore, err error) {
+	workDb := models.AssignmentWorkDB{}
+	err = tx.Where("assignment_id = ? AND student_id = ?", work.AssignmentId, work.StudentId).First(&workDb).Error
+	if err == nil {
+		return workDb.ToCore(), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if err = tx.Create(projectDb).Error; err != nil {
+		return
+	}
+	projectId := strconv.FormatUint(uint64(projectDb.ID), 10)
+	projectPage.ProjectId = projectId
+	projectPage.LinkScratch += projectId
+	projectPageDb := models.ProjectPageDB{}
+	projectPageDb.FromCore(projectPage)
+	if err = tx.Create(&projectPageDb).Error; err != nil {
+		return
+	}
+	work.ProjectId = projectId
+	workDb.FromCore(work)
+	if err = tx.Create(&workDb).Error; err != nil {
+		return
+	}
+	return workDb.ToCore(), nil
+}
+
+func (r *AssignmentsGatewayImpl) GetWork(assignmentId, studentId string) (work *models.AssignmentWorkCore, err error) {
+	var workDb models.AssignmentWorkDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("assignment_id = ? AND student_id = ?", assignmentId, studentId).First(&workDb).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return assignments.ErrWorkNotFound
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	work = workDb.ToCore()
+	work.SubmittedJson, err = r.frozen(workDb.SubmittedHash, workDb.LegacySubmittedJson)
+	return
+}
+
+// GetWorksByAssignmentId lists the works without the submitted projects and their fingerprints.
+func (r *AssignmentsGatewayImpl) GetWorksByAssignmentId(assignmentId string) (works []*models.AssignmentWorkCore, err error) {
+	return r.getWorks("assignment_id = ?", assignmentId)
+}
+
+// GetWorksByAssignmentIds lists the works without the submitted projects and their fingerprints.
+func (r *AssignmentsGatewayImpl) GetWorksByAssignmentIds(assignmentIds []string) (works []*models.AssignmentWorkCore, err error) {
+	if len(assignmentIds) == 0 {
+		return []*models.AssignmentWorkCore{}, nil
+	}
+	return r.getWorks("assignment_id IN ?", assignmentIds)
+}
+
+// GetWorksByStudentId lists the works without the submitted projects and their fingerprints.
+func (r *AssignmentsGatewayImpl) GetWorksByStudentId(studentId string) (works []*models.AssignmentWorkCore, err error) {
+	return r.getWorks("student_id = ?", studentId)
+}
+
+func (r *AssignmentsGatewayImpl) getWorks(condition string, value interface{}) (works []*models.AssignmentWorkCore, err error) {
+	var worksDb []*models.AssignmentWorkDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Omit("submitted_json", "fingerprint").Where(condition, value).Order("id").Find(&worksDb).Error
+	})
+	if err != nil {
+		return
+	}
+	works = make([]*models.AssignmentWorkCore, 0, len(worksDb))
+	for _, workDb := range worksDb {
+		works = append(works, workDb.ToCore())
+	}
+	return
+}
+
+// SubmitWork freezes work.SubmittedJson, the work then points to its blob.
+func (r *AssignmentsGatewayImpl) SubmitWork(work *models.AssignmentWorkCore) (err error) {
+	if work.SubmittedHash, err = r.putSubmission(work.SubmittedJson); err != nil {
+		return
+	}
+	workDb := models.AssignmentWorkDB{}
+	workDb.FromCore(work)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.AssignmentWorkDB{}).
+			Where("assignment_id = ? AND student_id = ?", work.AssignmentId, work.StudentId).
+			Updates(map[string]interface{}{
+				"status":         workDb.Status,
+				"submitted_at":   workDb.SubmittedAt,
+				"submitted_hash": workDb.SubmittedHash,
+				"submitted_json": "",
+				"check_results":  workDb.CheckResults,
+				"fingerprint":    workDb.Fingerprint,
+			}).Error
+	})
+	return
+}
+
+// GetFingerprintsByAssignmentIds lists the works with their fingerprints but without the submitted projects.
+func (r *AssignmentsGatewayImpl) GetFingerprintsByAssignmentIds(assignmentIds []string) (works []*models.AssignmentWorkCore, err error) {
+	works = []*models.AssignmentWorkCore{}
+	if len(assignmentIds) == 0 {
+		return
+	}
+	var worksDb []*models.AssignmentWorkDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Omit("submitted_json").Where("assignment_id IN ?", assignmentIds).Order("id").Find(&worksDb).Error
+	})
+	if err != nil {
+		return
+	}
+	for _, workDb := range worksDb {
+		works = append(works, workDb.ToCore())
+	}
+	return
+}
+
+// GetUnfingerprintedWorks lists submitted works that have no fingerprint yet, without the submitted projects.
+// The first offset of them are skipped.
+func (r *AssignmentsGatewayImpl) GetUnfingerprintedWorks(offset, limit int) (works []*models.AssignmentWorkCore, err error) {
+	var worksDb []*models.AssignmentWorkDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Omit("submitted_json").Where("submitted_at IS NOT NULL AND fingerprint = ''").
+			Order("id").Offset(offset).Limit(limit).Find(&worksDb).Error
+	})
+	if err != nil {
+		return
+	}
+	works = make([]*models.AssignmentWorkCore, 0, len(worksDb))
+	for _, workDb := range worksDb {
+		works = append(works, workDb.ToCore())
+	}
+	return
+}
+
+// SetWorkFingerprint writes the fingerprint unless the work has been submitted again since it was read.
+func (r *AssignmentsGatewayImpl) SetWorkFingerprint(work *models.AssignmentWorkCore) (err error) {
+	workDb := models.AssignmentWorkDB{}
+	workDb.FromCore(work)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.AssignmentWorkDB{}).
+			Where("assignment_id = ? AND student_id = ? AND submitted_at = ?", work.AssignmentId, work.StudentId, work.SubmittedAt).
+			Update("fingerprint", workDb.Fingerprint).Error
+	})
+	return
+}
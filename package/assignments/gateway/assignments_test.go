@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateAssignmentCopiesTemplate(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &AssignmentsGatewayImpl{PostgresClient: postgresClient, Storage: storage.NewPostgresStorage(*postgresClient)}
+
+	_, err = gateway.CreateAssignment(
+		&models.AssignmentCore{RobboGroupId: "3", TeacherId: "1", Title: "Maze", TemplateJson: "{}"},
+		[]string{"10", "11"},
+		&models.ProjectCore{AuthorRole: models.Student, Json: "{}", Name: "Maze"},
+		&models.ProjectPageCore{Title: "Maze", LinkScratch: "scratch/?#"},
+	)
+	assert.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("{}"))
+	assignmentInserts := recorder.Find(`INSERT INTO "assignment_dbs"`, `"template_hash"`)
+	if assert.Len(t, assignmentInserts, 1) {
+		assert.Contains(t, assignmentInserts[0].Args, hex.EncodeToString(sum[:]), "the assignment keeps the template in the blob of the copies")
+	}
+	assert.Len(t, recorder.Find(`INSERT INTO "storage_blob_dbs"`), 1, "the copies share the blob of the template")
+	projectInserts := recorder.Find(`INSERT INTO "project_dbs"`)
+	if assert.Len(t, projectInserts, 2) {
+		assert.Contains(t, projectInserts[0].Args, "10")
+		assert.Contains(t, projectInserts[1].Args, "11")
+	}
+	assert.Len(t, recorder.Find(`INSERT INTO "project_page_dbs"`), 2)
+	assert.Len(t, recorder.Find(`INSERT INTO "assignment_work_dbs"`), 2)
+}
+
+func TestSubmitWorkPointsToBlob(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &AssignmentsGatewayImpl{PostgresClient: postgresClient, Storage: storage.NewPostgresStorage(*postgresClient)}
+
+	work := &models.AssignmentWorkCore{AssignmentId: "5", StudentId: "10", Status: models.AssignmentSubmitted, SubmittedJson: "{}"}
+	assert.NoError(t, gateway.SubmitWork(work))
+	assert.Len(t, work.SubmittedHash, 64)
+
+	assert.Len(t, recorder.Find(`INSERT INTO "storage_blob_dbs"`), 1)
+	updates := recorder.Find(`UPDATE "assignment_work_dbs"`, `"submitted_hash"=`, `"submitted_json"=`)
+	if assert.Len(t, updates, 1) {
+		assert.Contains(t, updates[0].Args, work.SubmittedHash)
+		assert.NotContains(t, updates[0].Args, "{}", "the row keeps no copy of the project")
+	}
+}
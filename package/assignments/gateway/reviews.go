@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+// reviewColumns leaves the reviewed project out of the lists of reviews.
+const reviewColumns = "id, created_at, updated_at, assignment_id, student_id, reviewer_id, submitted_at, decision, scores, score, max_score, feedback"
+
+// CreateReview stores the review and moves the work to the status it has decided. The work must
+// still be the submission the review is about, a student may have submitted again in between.
+// The review points to the blob of the submission, a work submitted before the blob storage has it stored now.
+func (r *AssignmentsGatewayImpl) CreateReview(review *models.AssignmentReviewCore, status models.AssignmentWorkStatus) (id string, err error) {
+	if review.ProjectHash == "" {
+		if review.ProjectHash, err = r.putSubmission(review.ProjectJson); err != nil {
+			return
+		}
+	}
+	reviewDb := models.AssignmentReviewDB{}
+	reviewDb.FromCore(review)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Model(&models.AssignmentWorkDB{}).
+			Where("assignment_id = ? AND student_id = ? AND submitted_at = ?", review.AssignmentId, review.StudentId, review.SubmittedAt).
+			Update("status", string(status))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return assignments.ErrSubmissionChanged
+		}
+		return tx.Create(&reviewDb).Error
+	})
+	if err != nil {
+		return
+	}
+	return strconv.FormatUint(uint64(reviewDb.ID), 10), nil
+}
+
+func (r *AssignmentsGatewayImpl) GetReviewById(reviewId string) (review *models.AssignmentReviewCore, err error) {
+	var reviewDb models.AssignmentReviewDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("id = ?", reviewId).First(&reviewDb).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return assignments.ErrReviewNotFound
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	review = reviewDb.ToCore()
+	review.ProjectJson, err = r.frozen(reviewDb.ProjectHash, reviewDb.LegacyProjectJson)
+	return
+}
+
+// GetReviews lists the reviews of the work newest first.
+func (r *AssignmentsGatewayImpl) GetReviews(assignmentId, studentId string) (reviews []*models.AssignmentReviewCore, err error) {
+	var reviewsDb []*models.AssignmentReviewDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Select(reviewColumns).Where("assignment_id = ? AND student_id = ?", assignmentId, studentId).
+			Order("id desc").Find(&reviewsDb).Error
+	})
+	return toReviewsCore(reviewsDb), err
+}
+
+// GetLatestReviewsByAssignmentIds returns the latest review of every reviewed work on the assignments.
+func (r *AssignmentsGatewayImpl) GetLatestReviewsByAssignmentIds(assignmentIds []string) (reviews []*models.AssignmentReviewCore, err error) {
+	if len(assignmentIds) == 0 {
+		return []*models.AssignmentReviewCore{}, nil
+	}
+	return r.getLatestReviews("assignment_id IN ?", assignmentIds)
+}
+
+// GetLatestReviewsByStudentId returns the latest review of every reviewed work of the student.
+func (r *AssignmentsGatewayImpl) GetLatestReviewsByStudentId(studentId string) (reviews []*models.AssignmentReviewCore, err error) {
+	return r.getLatestReviews("student_id = ?", studentId)
+}
+
+func (r *AssignmentsGatewayImpl) getLatestReviews(condition string, value interface{}) (reviews []*models.AssignmentReviewCore, err error) {
+	var reviewsDb []*models.AssignmentReviewDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Select("DISTINCT ON (assignment_id, student_id) "+reviewColumns).
+			Where(condition, value).
+			Order("assignment_id, student_id, id desc").
+			Find(&reviewsDb).Error
+	})
+	return toReviewsCore(reviewsDb), err
+}
+
+func toReviewsCore(reviewsDb []*models.AssignmentReviewDB) (reviews []*models.AssignmentReviewCore) {
+	reviews = make([]*models.AssignmentReviewCore, 0, len(reviewsDb))
+	for _, reviewDb := range reviewsDb {
+		reviews = append(reviews, reviewDb.ToCore())
+	}
+	return
+}
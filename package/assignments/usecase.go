@@ -0,0 +1,27 @@
+package assignments
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type UseCase interface {
+	CreateAssignment(assignment *models.AssignmentCore, teacherRole models.Role) (created *models.AssignmentCore, err error)
+	UpdateAssignment(assignment *models.AssignmentCore, userId string, userRole models.Role) (updated *models.AssignmentCore, err error)
+	DeleteAssignment(assignmentId, userId string, userRole models.Role) (err error)
+	GetAssignmentsByRobboGroupId(robboGroupId, userId string, userRole models.Role) (assignments []*models.AssignmentCore, err error)
+	GetAssignmentProgress(assignmentId, userId string, userRole models.Role) (progress *models.AssignmentProgressCore, err error)
+	GetSubmittedProject(assignmentId, studentId, userId string, userRole models.Role) (projectJson string, err error)
+
+	GetStudentAssignments(studentId string) (studentAssignments []*models.StudentAssignmentCore, err error)
+	StartAssignment(assignmentId, studentId string) (started *models.StudentAssignmentCore, err error)
+	SubmitAssignment(assignmentId, studentId string) (submitted *models.StudentAssignmentCore, err error)
+	CheckAssignment(assignmentId, studentId string) (results []models.AssignmentCheckResultCore, err error)
+
+	ReviewAssignment(review *models.AssignmentReviewCore, reviewerRole models.Role) (created *models.AssignmentReviewCore, err error)
+	GetAssignmentReviews(assignmentId, studentId, userId string, userRole models.Role) (reviews []*models.AssignmentReviewCore, err error)
+	GetReviewedProject(reviewId, userId string, userRole models.Role) (projectJson string, err error)
+	GetGradebook(robboGroupId, userId string, userRole models.Role) (gradebook *models.GradebookCore, err error)
+	GetStudentAssignmentsByParentId(parentId, userId string, userRole models.Role) (studentAssignments []*models.StudentAssignmentCore, err error)
+
+	GetAssignmentSimilarity(assignmentId, userId string, userRole models.Role) (report *models.SimilarityReportCore, err error)
+	GetGroupSimilarity(robboGroupId, userId string, userRole models.Role) (report *models.SimilarityReportCore, err error)
+	FingerprintSubmissions() (err error)
+}
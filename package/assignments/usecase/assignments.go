@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxTitleLength       = 256
+	maxInstructionLength = 4096
+	// maxPageInstructionLength is the size of ProjectPageDB.Instruction
+	maxPageInstructionLength = 256
+)
+
+type AssignmentsUseCaseImpl struct {
+	assignmentsGateway   assignments.Gateway
+	projectGateway       projects.Gateway
+	projectPageGateway   projectPage.Gateway
+	usersGateway         users.Gateway
+	notificationsGateway notifications.Gateway
+	accessScope          access.Scope
+	defaultRubric        []models.RubricCriterionCore
+	similarityLimits     similarityLimits
+	// fingerprintFailures counts the works the current pass of FingerprintSubmissions could not fingerprint,
+	// they stay in front of the unfingerprinted ones and are skipped until the pass gets to the end
+	fingerprintFailures int
+}
+
+type AssignmentsUseCaseModule struct {
+	fx.Out
+	assignments.UseCase
+}
+
+func SetupAssignmentsUseCase(
+	assignmentsGateway assignments.Gateway,
+	projectGateway projects.Gateway,
+	projectPageGateway projectPage.Gateway,
+	usersGateway users.Gateway,
+	notificationsGateway notifications.Gateway,
+	accessScope access.Scope,
+) AssignmentsUseCaseModule {
+	var defaultRubric []models.RubricCriterionCore
+	if err := viper.UnmarshalKey("assignments.rubric", &defaultRubric); err != nil {
+		log.Println(err)
+	}
+	return AssignmentsUseCaseModule{
+		UseCase: &AssignmentsUseCaseImpl{
+			assignmentsGateway:   assignmentsGateway,
+			projectGateway:       projectGateway,
+			projectPageGateway:   projectPageGateway,
+			usersGateway:         usersGateway,
+			notificationsGateway: notificationsGateway,
+			accessScope:          accessScope,
+			defaultRubric:        defaultRubric,
+			similarityLimits: similarityLimits{
+				threshold:       viper.GetFloat64("assignments.similarity_threshold"),
+				minScriptBlocks: viper.GetInt("assignments.similarity_min_script_blocks"),
+				batchSize:       viper.GetInt("assignments.fingerprint_batch_size"),
+			},
+		},
+	}
+}
+
+// CreateAssignment freezes the template and gives every current member of the group a copy of it,
+// all at once. Students who join the group later start their copy themselves.
+func (p *AssignmentsUseCaseImpl) CreateAssignment(assignment *models.AssignmentCore, teacherRole models.Role) (created *models.AssignmentCore, err error) {
+	if err = p.checkGroupAccess(assignment.RobboGroupId, assignment.TeacherId, teacherRole); err != nil {
+		return
+	}
+	if err = normalizeAssignment(assignment); err != nil {
+		return
+	}
+	if assignment.Rubric == nil {
+		assignment.Rubric = p.defaultRubric
+	}
+	if err = checkRubric(assignment.Rubric); err != nil {
+		return
+	}
+	if assignment.Checks == nil {
+		assignment.Checks = []models.AssignmentCheckCore{}
+	}
+	if err = normalizeChecks(assignment.Checks); err != nil {
+		return
+	}
+	template, err := p.templateOf(assignment.TemplateProjectId, assignment.TeacherId, teacherRole)
+	if err != nil {
+		return
+	}
+	assignment.TemplateJson = template.Json
+	students, err := p.usersGateway.GetStudentsByRobboGroupId(assignment.RobboGroupId)
+	if err != nil {
+		return
+	}
+	studentIds := make([]string, 0, len(students))
+	for _, student := range students {
+		studentIds = append(studentIds, student.Id)
+	}
+	project, page := copyOf(assignment)
+	if assignment.Id, err = p.assignmentsGateway.CreateAssignment(assignment, studentIds, project, page); err != nil {
+		return
+	}
+	return p.assignmentsGateway.GetAssignmentById(assignment.Id)
+}
+
+// templateOf lets the teacher give their own projects and the projects shared in the gallery.
+// A project the teacher may not see is reported the way a missing one is.
+func (p *AssignmentsUseCaseImpl) templateOf(projectId, teacherId string, teacherRole models.Role) (template *models.ProjectCore, err error) {
+	template, err = p.projectGateway.GetProjectById(projectId)
+	if err != nil {
+		return nil, assignments.ErrTemplateNotFound
+	}
+	if access.SameUser(teacherId, teacherRole, template.AuthorId, template.AuthorRole) {
+		return
+	}
+	page, err := p.projectPageGateway.GetProjectPageByProjectId(projectId)
+	if err != nil || !page.IsShared {
+		return nil, assignments.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (p *AssignmentsUseCaseImpl) UpdateAssignment(assignment *models.AssignmentCore, userId string, userRole models.Role) (updated *models.AssignmentCore, err error) {
+	current, err := p.assignmentsGateway.GetAssignmentById(assignment.Id)
+	if err != nil {
+		return
+	}
+	if err = p.checkGroupAccess(current.RobboGroupId, userId, userRole); err != nil {
+		return
+	}
+	if err = normalizeAssignment(assignment); err != nil {
+		return
+	}
+	// checks that are left out stay as they are, submitted works keep the results they were submitted with
+	if assignment.Checks == nil {
+		assignment.Checks = current.Checks
+	}
+	if err = normalizeChecks(assignment.Checks); err != nil {
+		return
+	}
+	if err = p.assignmentsGateway.UpdateAssignment(assignment); err != nil {
+		return
+	}
+	return p.assignmentsGateway.GetAssignmentById(assignment.Id)
+}
+
+func (p *AssignmentsUseCaseImpl) DeleteAssignment(assignmentId, userId string, userRole models.Role) (err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkGroupAccess(assignment.RobboGroupId, userId, userRole); err != nil {
+		return
+	}
+	return p.assignmentsGateway.DeleteAssignment(assignmentId)
+}
+
+func (p *AssignmentsUseCaseImpl) GetAssignmentsByRobboGroupId(robboGroupId, userId string, userRole models.Role) (assignmentsCore []*models.AssignmentCore, err error) {
+	if err = p.checkGroupAccess(robboGroupId, userId, userRole); err != nil {
+		return
+	}
+	return p.assignmentsGateway.GetAssignmentsByRobboGroupIds([]string{robboGroupId})
+}
+
+// GetAssignmentProgress lists the works of the current members of the group. Members who have not
+// started their copy yet are listed with an assigned work without a project.
+func (p *AssignmentsUseCaseImpl) GetAssignmentProgress(assignmentId, userId string, userRole models.Role) (progress *models.AssignmentProgressCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkGroupAccess(assignment.RobboGroupId, userId, userRole); err != nil {
+		return
+	}
+	students, err := p.usersGateway.GetStudentsByRobboGroupId(assignment.RobboGroupId)
+	if err != nil {
+		return
+	}
+	works, err := p.assignmentsGateway.GetWorksByAssignmentId(assignmentId)
+	if err != nil {
+		return
+	}
+	byStudent := make(map[string]*models.AssignmentWorkCore, len(works))
+	for _, work := range works {
+		byStudent[work.StudentId] = work
+	}
+	progress = &models.AssignmentProgressCore{
+		Assignment: assignment,
+		Works:      make([]*models.AssignmentWorkCore, 0, len(students)),
+	}
+	for _, student := range students {
+		work, ok := byStudent[student.Id]
+		if !ok {
+			work = notStarted(assignment, student.Id)
+		}
+		progress.Works = append(progress.Works, work)
+	}
+	return
+}
+
+func (p *AssignmentsUseCaseImpl) GetSubmittedProject(assignmentId, studentId, userId string, userRole models.Role) (projectJson string, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkGroupAccess(assignment.RobboGroupId, userId, userRole); err != nil {
+		return
+	}
+	work, err := p.assignmentsGateway.GetWork(assignmentId, studentId)
+	if err != nil {
+		return
+	}
+	if work.Status != models.AssignmentSubmitted {
+		return "", assignments.ErrNotSubmitted
+	}
+	return work.SubmittedJson, nil
+}
+
+// GetStudentAssignments lists the assignments of the groups the student studies in and those
+// the student has worked on in the groups they have left. It only reads, the assignments
+// the student has not started come with an assigned work without a project.
+func (p *AssignmentsUseCaseImpl) GetStudentAssignments(studentId string) (studentAssignments []*models.StudentAssignmentCore, err error) {
+	robboGroupIds, err := p.robboGroupIdsOf(studentId)
+	if err != nil {
+		return
+	}
+	current, err := p.assignmentsGateway.GetAssignmentsByRobboGroupIds(robboGroupIds)
+	if err != nil {
+		return
+	}
+	works, err := p.assignmentsGateway.GetWorksByStudentId(studentId)
+	if err != nil {
+		return
+	}
+	reviews, err := p.assignmentsGateway.GetLatestReviewsByStudentId(studentId)
+	if err != nil {
+		return
+	}
+	latestReviews := make(map[string]*models.AssignmentReviewCore, len(reviews))
+	for _, review := range reviews {
+		latestReviews[review.AssignmentId] = review
+	}
+	byAssignment := make(map[string]*models.AssignmentWorkCore, len(works))
+	for _, work := range works {
+		byAssignment[work.AssignmentId] = work
+	}
+	studentAssignments = make([]*models.StudentAssignmentCore, 0, len(current))
+	for _, assignment := range current {
+		work, ok := byAssignment[assignment.Id]
+		if !ok {
+			work = notStarted(assignment, studentId)
+		}
+		delete(byAssignment, assignment.Id)
+		studentAssignments = append(studentAssignments, &models.StudentAssignmentCore{
+			Assignment: assignment,
+			Work:       work,
+			Review:     latestReviews[assignment.Id],
+		})
+	}
+	for _, work := range works {
+		if _, left := byAssignment[work.AssignmentId]; !left {
+			continue
+		}
+		assignment, getErr := p.assignmentsGateway.GetAssignmentById(work.AssignmentId)
+		if getErr == assignments.ErrAssignmentNotFound {
+			continue
+		}
+		if getErr != nil {
+			return nil, getErr
+		}
+		studentAssignments = append(studentAssignments, &models.StudentAssignmentCore{
+			Assignment: assignment,
+			Work:       work,
+			Review:     latestReviews[assignment.Id],
+		})
+	}
+	return
+}
+
+// StartAssignment gives the student their copy of the template, a student who has started already
+// gets the copy they have.
+func (p *AssignmentsUseCaseImpl) StartAssignment(assignmentId, studentId string) (started *models.StudentAssignmentCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	robboGroupIds, err := p.robboGroupIdsOf(studentId)
+	if err != nil {
+		return
+	}
+	if !contains(robboGroupIds, assignment.RobboGroupId) {
+		return nil, assignments.ErrNotInGroup
+	}
+	work, err := p.ensureWork(assignment, studentId)
+	if err != nil {
+		return
+	}
+	return &models.StudentAssignmentCore{Assignment: assignment, Work: work}, nil
+}
+
+// SubmitAssignment freezes the copy as it is now. Submitting again replaces the frozen project,
+// the student keeps working on the same copy until it is graded.
+func (p *AssignmentsUseCaseImpl) SubmitAssignment(assignmentId, studentId string) (submitted *models.StudentAssignmentCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	work, err := p.assignmentsGateway.GetWork(assignmentId, studentId)
+	if err == assignments.ErrWorkNotFound {
+		return nil, assignments.ErrNotStarted
+	}
+	if err != nil {
+		return
+	}
+	if work.Status == models.AssignmentGraded {
+		return nil, assignments.ErrAlreadyGraded
+	}
+	robboGroupIds, err := p.robboGroupIdsOf(studentId)
+	if err != nil {
+		return
+	}
+	if !contains(robboGroupIds, assignment.RobboGroupId) {
+		return nil, assignments.ErrNotInGroup
+	}
+	project, err := p.projectGateway.GetProjectById(work.ProjectId)
+	if err != nil {
+		return nil, projects.ErrProjectNotFound
+	}
+	submittedAt := time.Now()
+	work.Status = models.AssignmentSubmitted
+	work.SubmittedAt = &submittedAt
+	work.SubmittedJson = project.Json
+	work.CheckResults = runChecks(project.Json, assignment.Checks)
+	work.Fingerprint = fingerprintOf(project.Json)
+	if err = p.assignmentsGateway.SubmitWork(work); err != nil {
+		return nil, err
+	}
+	return &models.StudentAssignmentCore{Assignment: assignment, Work: work}, nil
+}
+
+// ensureWork gives the student their copy of the template unless they have one already.
+func (p *AssignmentsUseCaseImpl) ensureWork(assignment *models.AssignmentCore, studentId string) (work *models.AssignmentWorkCore, err error) {
+	project, page := copyOf(assignment)
+	project.AuthorId = studentId
+	return p.assignmentsGateway.CreateWork(notStarted(assignment, studentId), project, page)
+}
+
+// copyOf is the copy of the template a student works on, the gateway makes it the project of the student.
+func copyOf(assignment *models.AssignmentCore) (*models.ProjectCore, *models.ProjectPageCore) {
+	return &models.ProjectCore{
+			AuthorRole: models.Student,
+			Json:       assignment.TemplateJson,
+			Name:       assignment.Title,
+		},
+		&models.ProjectPageCore{
+			Title:       assignment.Title,
+			Instruction: truncate(assignment.Instruction, maxPageInstructionLength),
+			LinkScratch: viper.GetString("projectPage.scratchLink") + "?#",
+		}
+}
+
+func notStarted(assignment *models.AssignmentCore, studentId string) *models.AssignmentWorkCore {
+	return &models.AssignmentWorkCore{
+		AssignmentId: assignment.Id,
+		StudentId:    studentId,
+		Status:       models.AssignmentAssigned,
+	}
+}
+
+// checkGroupAccess lets teachers in on the groups they teach and admins on the groups of their units.
+func (p *AssignmentsUseCaseImpl) checkGroupAccess(robboGroupId, userId string, userRole models.Role) error {
+	err := p.accessScope.CheckRobboGroup(userId, userRole, robboGroupId)
+	if errors.Is(err, access.ErrNoAccess) {
+		return assignments.ErrNoAccess
+	}
+	return err
+}
+
+// robboGroupIdsOf lists every group the student currently studies in, the primary one included.
+func (p *AssignmentsUseCaseImpl) robboGroupIdsOf(studentId string) (robboGroupIds []string, err error) {
+	student, err := p.usersGateway.GetStudentById(studentId)
+	if err != nil {
+		return
+	}
+	memberships, err := p.usersGateway.GetGroupMembershipsByStudentId(studentId, true)
+	if err != nil {
+		return
+	}
+	if student.RobboGroupId != "" && student.RobboGroupId != "0" {
+		robboGroupIds = append(robboGroupIds, student.RobboGroupId)
+	}
+	for _, membership := range memberships {
+		if !contains(robboGroupIds, membership.RobboGroupId) {
+			robboGroupIds = append(robboGroupIds, membership.RobboGroupId)
+		}
+	}
+	return
+}
+
+func normalizeAssignment(assignment *models.AssignmentCore) error {
+	assignment.Title = strings.TrimSpace(assignment.Title)
+	assignment.Instruction = strings.TrimSpace(assignment.Instruction)
+	switch {
+	case assignment.Title == "":
+		return assignments.ErrEmptyTitle
+	case utf8.RuneCountInString(assignment.Title) > maxTitleLength:
+		return assignments.ErrTitleTooLong
+	case utf8.RuneCountInString(assignment.Instruction) > maxInstructionLength:
+		return assignments.ErrInstructionTooLong
+	}
+	return nil
+}
+
+func truncate(text string, length int) string {
+	if runes := []rune(text); len(runes) > length {
+		return string(runes[:length])
+	}
+	return text
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeAssignment(t *testing.T) {
+	assignment := &models.AssignmentCore{Title: "  Maze game ", Instruction: "\nMake the cat find the exit\n"}
+	assert.NoError(t, normalizeAssignment(assignment))
+	assert.Equal(t, "Maze game", assignment.Title)
+	assert.Equal(t, "Make the cat find the exit", assignment.Instruction)
+
+	assert.ErrorIs(t, normalizeAssignment(&models.AssignmentCore{Title: " \t"}), assignments.ErrEmptyTitle)
+	// the limits are in characters, not bytes
+	assert.NoError(t, normalizeAssignment(&models.AssignmentCore{Title: strings.Repeat("я", maxTitleLength)}))
+	assert.ErrorIs(t, normalizeAssignment(&models.AssignmentCore{Title: strings.Repeat("я", maxTitleLength+1)}), assignments.ErrTitleTooLong)
+	assert.ErrorIs(t, normalizeAssignment(&models.AssignmentCore{
+		Title:       "Maze game",
+		Instruction: strings.Repeat("a", maxInstructionLength+1),
+	}), assignments.ErrInstructionTooLong)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "ёжик", truncate("ёжик", 4))
+	assert.Equal(t, "ёж", truncate("ёжик", 2))
+}
+
+func TestWorkLate(t *testing.T) {
+	deadline := time.Date(2022, 10, 1, 18, 0, 0, 0, time.UTC)
+	before, after := deadline.Add(-time.Minute), deadline.Add(time.Minute)
+
+	assert.False(t, (&models.AssignmentWorkCore{SubmittedAt: &before}).Late(&deadline))
+	assert.True(t, (&models.AssignmentWorkCore{SubmittedAt: &after}).Late(&deadline))
+	assert.False(t, (&models.AssignmentWorkCore{SubmittedAt: &after}).Late(nil))
+	assert.False(t, (&models.AssignmentWorkCore{}).Late(&deadline))
+}
+
+// Group 3 is taught by teacher 1 and studied in by students 10 and 11. Project 7 is a project of
+// teacher 1, project 8 of teacher 2 is shared and project 9 of teacher 2 is not.
+type groupScope struct {
+	access.Scope
+}
+
+func (groupScope) CheckRobboGroup(userId string, userRole models.Role, robboGroupId string) error {
+	if robboGroupId == "3" && (userId == "1" && userRole == models.Teacher || userRole == models.SuperAdmin) {
+		return nil
+	}
+	return access.ErrNoAccess
+}
+
+type groupMembers struct {
+	users.Gateway
+}
+
+func (groupMembers) GetStudentsByRobboGroupId(robboGroupId string) ([]*models.StudentCore, error) {
+	return []*models.StudentCore{{UserCore: models.UserCore{Id: "10"}}, {UserCore: models.UserCore{Id: "11"}}}, nil
+}
+
+func (groupMembers) GetStudentById(studentId string) (*models.StudentCore, error) {
+	if studentId == "10" || studentId == "11" {
+		return &models.StudentCore{RobboGroupId: "3"}, nil
+	}
+	return &models.StudentCore{}, nil
+}
+
+func (groupMembers) GetGroupMembershipsByStudentId(string, bool) ([]*models.GroupMembershipCore, error) {
+	return nil, nil
+}
+
+type templates struct {
+	projects.Gateway
+}
+
+func (templates) GetProjectById(projectId string) (*models.ProjectCore, error) {
+	authors := map[string]string{"7": "1", "8": "2", "9": "2"}
+	if authors[projectId] == "" {
+		return nil, projects.ErrProjectNotFound
+	}
+	return &models.ProjectCore{ID: projectId, AuthorId: authors[projectId], AuthorRole: models.Teacher, Json: "{}"}, nil
+}
+
+type templatePages struct {
+	projectPage.Gateway
+}
+
+func (templatePages) GetProjectPageByProjectId(projectId string) (*models.ProjectPageCore, error) {
+	return &models.ProjectPageCore{ProjectId: projectId, IsShared: projectId == "8"}, nil
+}
+
+// worksGateway has one assignment in group 3, student 10 has started it.
+type worksGateway struct {
+	assignments.Gateway
+	created   []string
+	copiedFor []string
+}
+
+func (g *worksGateway) CreateAssignment(assignment *models.AssignmentCore, studentIds []string, project *models.ProjectCore, _ *models.ProjectPageCore) (string, error) {
+	g.copiedFor = append(g.copiedFor, studentIds...)
+	return "5", nil
+}
+
+func (g *worksGateway) GetAssignmentById(assignmentId string) (*models.AssignmentCore, error) {
+	return &models.AssignmentCore{Id: assignmentId, RobboGroupId: "3", Title: "Maze"}, nil
+}
+
+func (g *worksGateway) GetAssignmentsByRobboGroupIds([]string) ([]*models.AssignmentCore, error) {
+	return []*models.AssignmentCore{{Id: "5", RobboGroupId: "3", Title: "Maze"}}, nil
+}
+
+func (g *worksGateway) GetWorksByAssignmentId(string) ([]*models.AssignmentWorkCore, error) {
+	return []*models.AssignmentWorkCore{{AssignmentId: "5", StudentId: "10", ProjectId: "20"}}, nil
+}
+
+func (g *worksGateway) GetWorksByStudentId(studentId string) ([]*models.AssignmentWorkCore, error) {
+	if studentId == "10" {
+		return g.GetWorksByAssignmentId("5")
+	}
+	return nil, nil
+}
+
+func (g *worksGateway) GetLatestReviewsByStudentId(string) ([]*models.AssignmentReviewCore, error) {
+	return nil, nil
+}
+
+func (g *worksGateway) CreateWork(work *models.AssignmentWorkCore, project *models.ProjectCore, _ *models.ProjectPageCore) (*models.AssignmentWorkCore, error) {
+	g.created = append(g.created, project.AuthorId)
+	work.ProjectId = "21"
+	return work, nil
+}
+
+func testUseCase(gateway *worksGateway) *AssignmentsUseCaseImpl {
+	return &AssignmentsUseCaseImpl{
+		assignmentsGateway: gateway,
+		projectGateway:     templates{},
+		projectPageGateway: templatePages{},
+		usersGateway:       groupMembers{},
+		accessScope:        groupScope{},
+	}
+}
+
+func TestCreateAssignment(t *testing.T) {
+	gateway := &worksGateway{}
+	usecase := testUseCase(gateway)
+	newAssignment := func(templateProjectId string) *models.AssignmentCore {
+		return &models.AssignmentCore{RobboGroupId: "3", TeacherId: "1", Title: "Maze", TemplateProjectId: templateProjectId}
+	}
+
+	_, err := usecase.CreateAssignment(newAssignment("7"), models.Teacher)
+	assert.NoError(t, err, "a project of the teacher")
+	assert.Equal(t, []string{"10", "11"}, gateway.copiedFor, "the copies are made with the assignment")
+	_, err = usecase.CreateAssignment(newAssignment("8"), models.Teacher)
+	assert.NoError(t, err, "a shared project")
+	_, err = usecase.CreateAssignment(newAssignment("9"), models.Teacher)
+	assert.ErrorIs(t, err, assignments.ErrTemplateNotFound, "an unshared project of another teacher")
+	_, err = usecase.CreateAssignment(newAssignment("7"), models.UnitAdmin)
+	assert.ErrorIs(t, err, assignments.ErrNoAccess, "unit admin 1 of another unit")
+	assert.Empty(t, gateway.created)
+}
+
+func TestReadsMakeNoCopies(t *testing.T) {
+	gateway := &worksGateway{}
+	usecase := testUseCase(gateway)
+
+	progress, err := usecase.GetAssignmentProgress("5", "1", models.Teacher)
+	assert.NoError(t, err)
+	if assert.Len(t, progress.Works, 2) {
+		assert.Equal(t, "20", progress.Works[0].ProjectId)
+		assert.Equal(t, "", progress.Works[1].ProjectId, "student 11 has not started")
+		assert.Equal(t, models.AssignmentAssigned, progress.Works[1].Status)
+	}
+	studentAssignments, err := usecase.GetStudentAssignments("11")
+	assert.NoError(t, err)
+	if assert.Len(t, studentAssignments, 1) {
+		assert.Equal(t, "", studentAssignments[0].Work.ProjectId)
+	}
+	assert.Empty(t, gateway.created)
+
+	_, err = usecase.GetAssignmentProgress("5", "2", models.Teacher)
+	assert.ErrorIs(t, err, assignments.ErrNoAccess)
+	_, err = usecase.GetAssignmentProgress("5", "2", models.RegionAdmin)
+	assert.ErrorIs(t, err, assignments.ErrNoAccess, "admins are scoped too")
+}
+
+func TestStartAssignment(t *testing.T) {
+	gateway := &worksGateway{}
+	usecase := testUseCase(gateway)
+
+	started, err := usecase.StartAssignment("5", "11")
+	assert.NoError(t, err)
+	assert.Equal(t, "21", started.Work.ProjectId)
+	assert.Equal(t, []string{"11"}, gateway.created)
+
+	_, err = usecase.StartAssignment("5", "12")
+	assert.ErrorIs(t, err, assignments.ErrNotInGroup)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/scratch"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxChecks         = 50
+	maxCheckTitleSize = 256
+	maxCheckValueSize = 128
+	maxCheckMin       = 1000
+)
+
+// checkMetrics are what a metric check can count, all of them are metrics of the analysis of the project.
+var checkMetrics = map[string]func(analysis *models.ProjectAnalysisCore) int{
+	"loops":        func(analysis *models.ProjectAnalysisCore) int { return analysis.Loops },
+	"conditionals": func(analysis *models.ProjectAnalysisCore) int { return analysis.Conditionals },
+	"broadcasts":   func(analysis *models.ProjectAnalysisCore) int { return analysis.Broadcasts },
+	"customBlocks": func(analysis *models.ProjectAnalysisCore) int { return analysis.CustomBlocks },
+	"clones":       func(analysis *models.ProjectAnalysisCore) int { return analysis.Clones },
+	"blocks":       func(analysis *models.ProjectAnalysisCore) int { return analysis.Blocks },
+	"scripts":      func(analysis *models.ProjectAnalysisCore) int { return analysis.Scripts },
+	"sprites":      func(analysis *models.ProjectAnalysisCore) int { return analysis.Sprites },
+	"variables":    func(analysis *models.ProjectAnalysisCore) int { return analysis.Variables },
+	"lists":        func(analysis *models.ProjectAnalysisCore) int { return analysis.Lists },
+}
+
+// countFor is how many times the project has what the check looks for.
+func countFor(inventory *scratch.Inventory, check models.AssignmentCheckCore) (found int) {
+	switch check.Kind {
+	case models.CheckOpcode:
+		return inventory.Opcodes[check.Value]
+	case models.CheckCategory:
+		return inventory.Categories[check.Value]
+	case models.CheckExtension:
+		if inventory.Categories[check.Value] > 0 {
+			return 1
+		}
+		for _, extension := range inventory.Analysis.Extensions {
+			if extension == check.Value {
+				return 1
+			}
+		}
+	case models.CheckSprite:
+		for _, sprite := range inventory.Sprites {
+			if strings.EqualFold(sprite, check.Value) {
+				found++
+			}
+		}
+	case models.CheckMetric:
+		if metric, ok := checkMetrics[check.Value]; ok {
+			return metric(inventory.Analysis)
+		}
+	}
+	return
+}
+
+func runChecks(projectJson string, checks []models.AssignmentCheckCore) []models.AssignmentCheckResultCore {
+	inventory, err := scratch.Inspect(projectJson)
+	results := make([]models.AssignmentCheckResultCore, 0, len(checks))
+	for _, check := range checks {
+		actual := 0
+		// a project that cannot be read has nothing in it, so it fails every check
+		if err == nil {
+			actual = countFor(inventory, check)
+		}
+		results = append(results, models.AssignmentCheckResultCore{
+			AssignmentCheckCore: check,
+			Actual:              actual,
+			Passed:              actual >= check.Min,
+		})
+	}
+	return results
+}
+
+// normalizeChecks validates the checks a teacher has written, fills in the minimum of 1
+// and a title for the checks that have none.
+func normalizeChecks(checks []models.AssignmentCheckCore) error {
+	if len(checks) > maxChecks {
+		return assignments.ErrBadChecks
+	}
+	for i := range checks {
+		check := &checks[i]
+		check.Title = strings.TrimSpace(check.Title)
+		check.Value = strings.TrimSpace(check.Value)
+		if check.Min == 0 {
+			check.Min = 1
+		}
+		switch {
+		case check.Value == "" || utf8.RuneCountInString(check.Value) > maxCheckValueSize:
+			return assignments.ErrBadChecks
+		case check.Min < 1 || check.Min > maxCheckMin:
+			return assignments.ErrBadChecks
+		case utf8.RuneCountInString(check.Title) > maxCheckTitleSize:
+			return assignments.ErrBadChecks
+		}
+		switch check.Kind {
+		case models.CheckOpcode, models.CheckCategory:
+		case models.CheckExtension, models.CheckSprite:
+			check.Min = 1
+		case models.CheckMetric:
+			if _, ok := checkMetrics[check.Value]; !ok {
+				return assignments.ErrBadChecks
+			}
+		default:
+			return assignments.ErrBadChecks
+		}
+		if check.Title == "" {
+			check.Title = describeCheck(*check)
+		}
+	}
+	return nil
+}
+
+func describeCheck(check models.AssignmentCheckCore) string {
+	switch check.Kind {
+	case models.CheckOpcode:
+		return fmt.Sprintf("Uses the %s block at least %d time(s)", check.Value, check.Min)
+	case models.CheckCategory:
+		return fmt.Sprintf("Uses at least %d %s block(s)", check.Min, check.Value)
+	case models.CheckExtension:
+		return fmt.Sprintf("Uses the %s extension", check.Value)
+	case models.CheckSprite:
+		return fmt.Sprintf("Has a sprite named %s", check.Value)
+	default:
+		return fmt.Sprintf("Has at least %d %s", check.Min, check.Value)
+	}
+}
+
+// CheckAssignment runs the checks on the copy the student is working on, so that they
+// see what is missing before they submit.
+func (p *AssignmentsUseCaseImpl) CheckAssignment(assignmentId, studentId string) (results []models.AssignmentCheckResultCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	work, err := p.assignmentsGateway.GetWork(assignmentId, studentId)
+	if err == assignments.ErrWorkNotFound {
+		return nil, assignments.ErrNotStarted
+	}
+	if err != nil {
+		return
+	}
+	project, err := p.projectGateway.GetProjectById(work.ProjectId)
+	if err != nil {
+		return nil, projects.ErrProjectNotFound
+	}
+	return runChecks(project.Json, assignment.Checks), nil
+}
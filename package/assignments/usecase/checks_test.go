@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const checkedProject = `{"targets":[
+	{"isStage":true,"name":"Stage","variables":{"v1":["score",0]},"blocks":{}},
+	{"isStage":false,"name":"Robot","blocks":{
+		"a":{"opcode":"event_whenflagclicked","next":"b","parent":null,"topLevel":true},
+		"b":{"opcode":"control_repeat","next":null,"parent":"a","inputs":{"SUBSTACK":[2,"c"]},"topLevel":false},
+		"c":{"opcode":"robboRobot_moveForward","next":null,"parent":"b","inputs":{"STEPS":[1,"d"]},"topLevel":false},
+		"d":{"opcode":"math_number","next":null,"parent":"c","shadow":true,"topLevel":false},
+		"r":[12,"score","v1",10,10]
+	}}
+],"extensions":["robboRobot"]}`
+
+func TestRunChecks(t *testing.T) {
+	checks := []models.AssignmentCheckCore{
+		{Kind: models.CheckOpcode, Value: "event_whenflagclicked", Min: 1},
+		{Kind: models.CheckMetric, Value: "loops", Min: 1},
+		{Kind: models.CheckMetric, Value: "conditionals", Min: 1},
+		{Kind: models.CheckExtension, Value: "robboRobot", Min: 1},
+		{Kind: models.CheckSprite, Value: "robot", Min: 1},
+		{Kind: models.CheckCategory, Value: "control", Min: 2},
+		{Kind: models.CheckMetric, Value: "blocks", Min: 4},
+	}
+
+	results := runChecks(checkedProject, checks)
+
+	passed := make([]bool, 0, len(results))
+	for _, result := range results {
+		passed = append(passed, result.Passed)
+	}
+	assert.Equal(t, []bool{true, true, false, true, true, false, true}, passed)
+	assert.Equal(t, 1, results[5].Actual)
+	assert.Equal(t, 4, results[6].Actual, "the shadow number is not a block")
+
+	for _, result := range runChecks("not a project", checks) {
+		assert.False(t, result.Passed)
+	}
+}
+
+func TestNormalizeChecks(t *testing.T) {
+	checks := []models.AssignmentCheckCore{
+		{Kind: models.CheckOpcode, Value: " event_whenflagclicked "},
+		{Kind: models.CheckSprite, Value: "Robot", Min: 5, Title: "Robot is there"},
+	}
+	assert.NoError(t, normalizeChecks(checks))
+	assert.Equal(t, models.AssignmentCheckCore{
+		Title: "Uses the event_whenflagclicked block at least 1 time(s)",
+		Kind:  models.CheckOpcode,
+		Value: "event_whenflagclicked",
+		Min:   1,
+	}, checks[0])
+	assert.Equal(t, 1, checks[1].Min, "a sprite is either there or not")
+	assert.Equal(t, "Robot is there", checks[1].Title)
+
+	bad := [][]models.AssignmentCheckCore{
+		{{Kind: "color", Value: "red"}},
+		{{Kind: models.CheckMetric, Value: "loopz"}},
+		{{Kind: models.CheckOpcode, Value: ""}},
+		{{Kind: models.CheckOpcode, Value: "control_if", Min: -1}},
+	}
+	for _, checks := range bad {
+		assert.ErrorIs(t, normalizeChecks(checks), assignments.ErrBadChecks)
+	}
+}
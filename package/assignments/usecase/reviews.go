@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"log"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxRubricCriteria    = 20
+	maxCriterionScore    = 100
+	maxFeedbackLength    = 4096
+	maxCriterionNameSize = 128
+)
+
+// ReviewAssignment reviews the latest submission of the work. A graded work is scored on every
+// criterion of the rubric, a returned one goes back to the student with the feedback only.
+func (p *AssignmentsUseCaseImpl) ReviewAssignment(review *models.AssignmentReviewCore, reviewerRole models.Role) (created *models.AssignmentReviewCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(review.AssignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkGroupAccess(assignment.RobboGroupId, review.ReviewerId, reviewerRole); err != nil {
+		return
+	}
+	work, err := p.assignmentsGateway.GetWork(review.AssignmentId, review.StudentId)
+	if err != nil {
+		return
+	}
+	if work.SubmittedAt == nil || work.Status == models.AssignmentReturned {
+		return nil, assignments.ErrNotSubmitted
+	}
+	if !review.Decision.IsValid() {
+		return nil, assignments.ErrBadDecision
+	}
+	review.Feedback = strings.TrimSpace(review.Feedback)
+	if utf8.RuneCountInString(review.Feedback) > maxFeedbackLength {
+		return nil, assignments.ErrFeedbackTooLong
+	}
+	status := models.AssignmentGraded
+	if review.Decision == models.ReviewReturned {
+		if review.Feedback == "" {
+			return nil, assignments.ErrEmptyFeedback
+		}
+		review.Scores = []models.CriterionScoreCore{}
+		status = models.AssignmentReturned
+	} else if review.Scores, err = scoreOnRubric(assignment.Rubric, review.Scores); err != nil {
+		return
+	}
+	review.Score = 0
+	for _, score := range review.Scores {
+		review.Score += score.Score
+	}
+	review.MaxScore = assignment.MaxScore()
+	review.SubmittedAt = *work.SubmittedAt
+	review.ProjectHash, review.ProjectJson = work.SubmittedHash, work.SubmittedJson
+	if review.Id, err = p.assignmentsGateway.CreateReview(review, status); err != nil {
+		return
+	}
+	// a failed notification must not fail a review that is already saved
+	if notifyErr := p.notifyAboutReview(assignment, review); notifyErr != nil {
+		log.Println(notifyErr)
+	}
+	return p.assignmentsGateway.GetReviewById(review.Id)
+}
+
+func (p *AssignmentsUseCaseImpl) GetAssignmentReviews(assignmentId, studentId, userId string, userRole models.Role) (reviews []*models.AssignmentReviewCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkWorkAccess(assignment, studentId, userId, userRole); err != nil {
+		return
+	}
+	return p.assignmentsGateway.GetReviews(assignmentId, studentId)
+}
+
+func (p *AssignmentsUseCaseImpl) GetReviewedProject(reviewId, userId string, userRole models.Role) (projectJson string, err error) {
+	review, err := p.assignmentsGateway.GetReviewById(reviewId)
+	if err != nil {
+		return
+	}
+	assignment, err := p.assignmentsGateway.GetAssignmentById(review.AssignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkWorkAccess(assignment, review.StudentId, userId, userRole); err != nil {
+		return
+	}
+	return review.ProjectJson, nil
+}
+
+// GetGradebook puts the latest grades of the current members of the group on the assignments
+// of the group, oldest assignment first.
+func (p *AssignmentsUseCaseImpl) GetGradebook(robboGroupId, userId string, userRole models.Role) (gradebook *models.GradebookCore, err error) {
+	if err = p.checkGroupAccess(robboGroupId, userId, userRole); err != nil {
+		return
+	}
+	assignmentsCore, err := p.assignmentsGateway.GetAssignmentsByRobboGroupIds([]string{robboGroupId})
+	if err != nil {
+		return
+	}
+	for i, j := 0, len(assignmentsCore)-1; i < j; i, j = i+1, j-1 {
+		assignmentsCore[i], assignmentsCore[j] = assignmentsCore[j], assignmentsCore[i]
+	}
+	assignmentIds := make([]string, 0, len(assignmentsCore))
+	for _, assignment := range assignmentsCore {
+		assignmentIds = append(assignmentIds, assignment.Id)
+	}
+	students, err := p.usersGateway.GetStudentsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	works, err := p.assignmentsGateway.GetWorksByAssignmentIds(assignmentIds)
+	if err != nil {
+		return
+	}
+	reviews, err := p.assignmentsGateway.GetLatestReviewsByAssignmentIds(assignmentIds)
+	if err != nil {
+		return
+	}
+	return buildGradebook(robboGroupId, assignmentsCore, students, works, reviews), nil
+}
+
+// GetStudentAssignmentsByParentId gathers the assignments of all the children of the parent with the latest feedback
+// on them. The parent sees all of them, the staff only the assignments of the groups they have access to.
+func (p *AssignmentsUseCaseImpl) GetStudentAssignmentsByParentId(parentId, userId string, userRole models.Role) (studentAssignments []*models.StudentAssignmentCore, err error) {
+	err = p.accessScope.CheckParent(userId, userRole, parentId)
+	if errors.Is(err, access.ErrNoAccess) {
+		return nil, assignments.ErrNoAccess
+	}
+	if err != nil {
+		return
+	}
+	relations, err := p.usersGateway.GetRelationByParentId(parentId)
+	if err != nil {
+		return
+	}
+	seesAll := userRole == models.SuperAdmin || access.SameUser(userId, userRole, parentId, models.Parent)
+	groupAccess := make(map[string]bool)
+	studentAssignments = []*models.StudentAssignmentCore{}
+	for _, relation := range relations {
+		childAssignments, getErr := p.GetStudentAssignments(relation.ChildId)
+		if getErr != nil {
+			return nil, getErr
+		}
+		for _, studentAssignment := range childAssignments {
+			robboGroupId := studentAssignment.Assignment.RobboGroupId
+			allowed, checked := groupAccess[robboGroupId]
+			if !seesAll && !checked {
+				checkErr := p.checkGroupAccess(robboGroupId, userId, userRole)
+				if checkErr != nil && checkErr != assignments.ErrNoAccess {
+					return nil, checkErr
+				}
+				allowed = checkErr == nil
+				groupAccess[robboGroupId] = allowed
+			}
+			if seesAll || allowed {
+				studentAssignments = append(studentAssignments, studentAssignment)
+			}
+		}
+	}
+	return
+}
+
+// checkWorkAccess lets the student, their parents and the staff of the group see the reviews of the work.
+func (p *AssignmentsUseCaseImpl) checkWorkAccess(assignment *models.AssignmentCore, studentId, userId string, userRole models.Role) error {
+	switch userRole {
+	case models.Student:
+		if userId == studentId {
+			return nil
+		}
+	case models.Parent:
+		relations, err := p.usersGateway.GetRelationByChildrenId(studentId)
+		if err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			if relation.ParentId == userId {
+				return nil
+			}
+		}
+	case models.Teacher, models.UnitAdmin, models.RegionAdmin, models.SuperAdmin:
+		return p.checkGroupAccess(assignment.RobboGroupId, userId, userRole)
+	}
+	return assignments.ErrNoAccess
+}
+
+func (p *AssignmentsUseCaseImpl) notifyAboutReview(assignment *models.AssignmentCore, review *models.AssignmentReviewCore) error {
+	text := fmt.Sprintf("Your work on %s is graded: %d of %d", assignment.Title, review.Score, review.MaxScore)
+	if review.Decision == models.ReviewReturned {
+		text = fmt.Sprintf("Your work on %s is returned for rework", assignment.Title)
+	}
+	return p.notificationsGateway.CreateNotifications([]*models.NotificationCore{{
+		RecipientId:   review.StudentId,
+		RecipientRole: models.Student,
+		Kind:          models.ReviewNotification,
+		Text:          text,
+	}})
+}
+
+func checkRubric(rubric []models.RubricCriterionCore) error {
+	if len(rubric) > maxRubricCriteria {
+		return assignments.ErrBadRubric
+	}
+	seen := make(map[string]bool, len(rubric))
+	for i := range rubric {
+		criterion := &rubric[i]
+		criterion.Name = strings.TrimSpace(criterion.Name)
+		if criterion.Name == "" || utf8.RuneCountInString(criterion.Name) > maxCriterionNameSize || seen[criterion.Name] {
+			return assignments.ErrBadRubric
+		}
+		if criterion.MaxScore < 1 || criterion.MaxScore > maxCriterionScore {
+			return assignments.ErrBadRubric
+		}
+		seen[criterion.Name] = true
+	}
+	return nil
+}
+
+// scoreOnRubric checks there is exactly one score for every criterion and puts the scores in the order of the rubric.
+func scoreOnRubric(rubric []models.RubricCriterionCore, scores []models.CriterionScoreCore) (scored []models.CriterionScoreCore, err error) {
+	byCriterion := make(map[string]int, len(scores))
+	for _, score := range scores {
+		if _, duplicate := byCriterion[score.Criterion]; duplicate {
+			return nil, assignments.ErrBadScores
+		}
+		byCriterion[score.Criterion] = score.Score
+	}
+	if len(byCriterion) != len(rubric) {
+		return nil, assignments.ErrBadScores
+	}
+	scored = make([]models.CriterionScoreCore, 0, len(rubric))
+	for _, criterion := range rubric {
+		score, ok := byCriterion[criterion.Name]
+		if !ok || score < 0 || score > criterion.MaxScore {
+			return nil, assignments.ErrBadScores
+		}
+		scored = append(scored, models.CriterionScoreCore{
+			Criterion: criterion.Name,
+			Score:     score,
+			MaxScore:  criterion.MaxScore,
+		})
+	}
+	return
+}
+
+func buildGradebook(
+	robboGroupId string,
+	assignmentsCore []*models.AssignmentCore,
+	students []*models.StudentCore,
+	works []*models.AssignmentWorkCore,
+	reviews []*models.AssignmentReviewCore,
+) *models.GradebookCore {
+	workOf := make(map[[2]string]*models.AssignmentWorkCore, len(works))
+	for _, work := range works {
+		workOf[[2]string{work.AssignmentId, work.StudentId}] = work
+	}
+	reviewOf := make(map[[2]string]*models.AssignmentReviewCore, len(reviews))
+	for _, review := range reviews {
+		reviewOf[[2]string{review.AssignmentId, review.StudentId}] = review
+	}
+	gradebook := &models.GradebookCore{
+		RobboGroupId: robboGroupId,
+		Assignments:  assignmentsCore,
+		Rows:         make([]*models.GradebookRowCore, 0, len(students)),
+	}
+	for _, student := range students {
+		row := &models.GradebookRowCore{
+			Student: student,
+			Grades:  make([]*models.GradebookCellCore, 0, len(assignmentsCore)),
+		}
+		for _, assignment := range assignmentsCore {
+			key := [2]string{assignment.Id, student.Id}
+			cell := &models.GradebookCellCore{
+				AssignmentId: assignment.Id,
+				Status:       models.AssignmentAssigned,
+				MaxScore:     assignment.MaxScore(),
+			}
+			if work, ok := workOf[key]; ok {
+				cell.Status = work.Status
+				cell.Late = work.Late(assignment.Deadline)
+			}
+			if review, ok := reviewOf[key]; ok && cell.Status == models.AssignmentGraded && review.Decision == models.ReviewGraded {
+				score := review.Score
+				cell.Score = &score
+				cell.MaxScore = review.MaxScore
+			}
+			row.Grades = append(row.Grades, cell)
+		}
+		gradebook.Rows = append(gradebook.Rows, row)
+	}
+	return gradebook
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"bytes"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testRubric = []models.RubricCriterionCore{
+	{Name: "Task completed", MaxScore: 5},
+	{Name: "Creativity", MaxScore: 2},
+}
+
+func TestCheckRubric(t *testing.T) {
+	rubric := []models.RubricCriterionCore{{Name: " Task completed ", MaxScore: 5}}
+	assert.NoError(t, checkRubric(rubric))
+	assert.Equal(t, "Task completed", rubric[0].Name)
+	assert.NoError(t, checkRubric([]models.RubricCriterionCore{}))
+
+	for _, rubric := range [][]models.RubricCriterionCore{
+		{{Name: "", MaxScore: 5}},
+		{{Name: "Creativity", MaxScore: 0}},
+		{{Name: "Creativity", MaxScore: 101}},
+		{{Name: "Creativity", MaxScore: 2}, {Name: " Creativity", MaxScore: 3}},
+	} {
+		assert.ErrorIs(t, checkRubric(rubric), assignments.ErrBadRubric)
+	}
+}
+
+func TestScoreOnRubric(t *testing.T) {
+	scored, err := scoreOnRubric(testRubric, []models.CriterionScoreCore{
+		{Criterion: "Creativity", Score: 2},
+		{Criterion: "Task completed", Score: 0},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CriterionScoreCore{
+		{Criterion: "Task completed", Score: 0, MaxScore: 5},
+		{Criterion: "Creativity", Score: 2, MaxScore: 2},
+	}, scored)
+
+	for _, scores := range [][]models.CriterionScoreCore{
+		{{Criterion: "Task completed", Score: 5}},
+		{{Criterion: "Task completed", Score: 6}, {Criterion: "Creativity", Score: 2}},
+		{{Criterion: "Task completed", Score: -1}, {Criterion: "Creativity", Score: 2}},
+		{{Criterion: "Task completed", Score: 5}, {Criterion: "Task completed", Score: 5}},
+		{{Criterion: "Task completed", Score: 5}, {Criterion: "Music", Score: 2}},
+	} {
+		_, err = scoreOnRubric(testRubric, scores)
+		assert.ErrorIs(t, err, assignments.ErrBadScores)
+	}
+}
+
+func TestBuildGradebook(t *testing.T) {
+	deadline := time.Date(2022, 10, 1, 18, 0, 0, 0, time.UTC)
+	late := deadline.Add(time.Hour)
+	assignmentsCore := []*models.AssignmentCore{
+		{Id: "1", Rubric: testRubric},
+		{Id: "2", Rubric: testRubric, Deadline: &deadline},
+	}
+	students := []*models.StudentCore{{UserCore: models.UserCore{Id: "10"}}, {UserCore: models.UserCore{Id: "11"}}}
+	works := []*models.AssignmentWorkCore{
+		{AssignmentId: "1", StudentId: "10", Status: models.AssignmentGraded},
+		{AssignmentId: "2", StudentId: "10", Status: models.AssignmentSubmitted, SubmittedAt: &late},
+		{AssignmentId: "1", StudentId: "11", Status: models.AssignmentSubmitted},
+	}
+	reviews := []*models.AssignmentReviewCore{
+		{AssignmentId: "1", StudentId: "10", Decision: models.ReviewGraded, Score: 6, MaxScore: 7},
+		// resubmitted after it was returned, there is no grade yet
+		{AssignmentId: "1", StudentId: "11", Decision: models.ReviewReturned, MaxScore: 7},
+	}
+
+	gradebook := buildGradebook("5", assignmentsCore, students, works, reviews)
+	assert.Len(t, gradebook.Rows, 2)
+
+	first := gradebook.Rows[0].Grades
+	assert.Equal(t, models.AssignmentGraded, first[0].Status)
+	assert.Equal(t, 6, *first[0].Score)
+	assert.Equal(t, 7, first[0].MaxScore)
+	assert.Equal(t, models.AssignmentSubmitted, first[1].Status)
+	assert.True(t, first[1].Late)
+	assert.Nil(t, first[1].Score)
+
+	second := gradebook.Rows[1].Grades
+	assert.Equal(t, models.AssignmentSubmitted, second[0].Status)
+	assert.Nil(t, second[0].Score)
+	assert.Equal(t, models.AssignmentAssigned, second[1].Status)
+	assert.Equal(t, 7, second[1].MaxScore)
+}
+
+func TestDefaultRubricConfig(t *testing.T) {
+	config := viper.New()
+	config.SetConfigType("yaml")
+	assert.NoError(t, config.ReadConfig(bytes.NewBufferString(`
+assignments:
+  rubric:
+    - name: Task completed
+      max_score: 5
+`)))
+	var rubric []models.RubricCriterionCore
+	assert.NoError(t, config.UnmarshalKey("assignments.rubric", &rubric))
+	assert.Equal(t, []models.RubricCriterionCore{{Name: "Task completed", MaxScore: 5}}, rubric)
+}
+
+// Parent 30 is the parent of students 10 and 11. Teacher 2 reaches the parent through a child
+// in another group than group 3.
+type familyScope struct {
+	groupScope
+}
+
+func (familyScope) CheckParent(userId string, userRole models.Role, parentId string) error {
+	if parentId == "30" && (access.SameUser(userId, userRole, parentId, models.Parent) || userRole == models.Teacher) {
+		return nil
+	}
+	return access.ErrNoAccess
+}
+
+type families struct {
+	groupMembers
+}
+
+func (families) GetRelationByParentId(parentId string) ([]*models.ChildrenOfParentCore, error) {
+	return []*models.ChildrenOfParentCore{{ParentId: parentId, ChildId: "10"}, {ParentId: parentId, ChildId: "11"}}, nil
+}
+
+func TestFeedbackByParentScoped(t *testing.T) {
+	usecase := testUseCase(&worksGateway{})
+	usecase.accessScope = familyScope{}
+	usecase.usersGateway = families{}
+
+	studentAssignments, err := usecase.GetStudentAssignmentsByParentId("30", "30", models.Parent)
+	assert.NoError(t, err)
+	assert.Len(t, studentAssignments, 2)
+	studentAssignments, err = usecase.GetStudentAssignmentsByParentId("30", "1", models.Teacher)
+	assert.NoError(t, err)
+	assert.Len(t, studentAssignments, 2)
+	studentAssignments, err = usecase.GetStudentAssignmentsByParentId("30", "2", models.Teacher)
+	assert.NoError(t, err)
+	assert.Empty(t, studentAssignments, "group 3 is not a group of teacher 2")
+
+	_, err = usecase.GetStudentAssignmentsByParentId("30", "31", models.Parent)
+	assert.ErrorIs(t, err, assignments.ErrNoAccess)
+	_, err = usecase.GetStudentAssignmentsByParentId("30", "10", models.Student)
+	assert.ErrorIs(t, err, assignments.ErrNoAccess)
+}
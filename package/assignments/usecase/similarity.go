@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/scratch"
+	"hash/fnv"
+	"log"
+	"sort"
+	"strings"
+)
+
+const (
+	// shingleSize is how many tokens of a script a shingle covers, a block and its inputs take a few
+	shingleSize = 4
+	// similarScriptThreshold is how much of two scripts has to match for them to be shown side by side
+	similarScriptThreshold = 0.8
+)
+
+type similarityLimits struct {
+	// threshold is the similarity from which a pair of works is reported
+	threshold float64
+	// minScriptBlocks leaves out scripts too small to tell anything, like a lone flag hat
+	minScriptBlocks int
+	batchSize       int
+}
+
+// fingerprintOf normalizes every script of the project into a sequence of tokens: the opcodes
+// of its blocks and the names of the inputs other blocks are put into. Values typed into the
+// blocks, variable names, block positions and sprite names never get into the tokens.
+// A project that cannot be read has no scripts.
+func fingerprintOf(projectJson string) *models.ProjectFingerprintCore {
+	fingerprint := &models.ProjectFingerprintCore{Scripts: []models.ScriptFingerprintCore{}}
+	targets, err := scratch.ParseTargets(projectJson)
+	if err != nil {
+		return fingerprint
+	}
+	for _, target := range targets {
+		blocks, err := scratch.ParseBlocks(target.Blocks)
+		if err != nil {
+			continue
+		}
+		var tops []string
+		for id, block := range blocks {
+			// a variable or list reporter dropped on the workspace has no structure
+			if block.TopLevel && !block.IsShadow() && block.Opcode != scratch.PrimitiveOpcode {
+				tops = append(tops, id)
+			}
+		}
+		sort.Strings(tops)
+		for _, id := range tops {
+			walker := &scriptWalker{blocks: blocks, visited: make(map[string]bool)}
+			walker.walk(id)
+			fingerprint.Scripts = append(fingerprint.Scripts, models.ScriptFingerprintCore{
+				Sprite:   target.Name,
+				BlockId:  id,
+				Blocks:   walker.count,
+				Hash:     hashTokens(walker.tokens),
+				Shingles: shinglesOf(walker.tokens),
+			})
+		}
+	}
+	return fingerprint
+}
+
+type scriptWalker struct {
+	blocks  map[string]*scratch.Block
+	visited map[string]bool
+	tokens  []string
+	count   int
+}
+
+// walk writes the stack that starts at the block, each block followed by the blocks in its inputs.
+func (w *scriptWalker) walk(id string) {
+	for id != "" && !w.visited[id] {
+		block, ok := w.blocks[id]
+		if !ok || block.IsShadow() || block.Opcode == scratch.PrimitiveOpcode {
+			return
+		}
+		w.visited[id] = true
+		w.count++
+		w.tokens = append(w.tokens, block.Opcode)
+		inputs := block.InputBlocks()
+		names := make([]string, 0, len(inputs))
+		for name := range inputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child, ok := w.blocks[inputs[name]]
+			if !ok || child.IsShadow() || child.Opcode == scratch.PrimitiveOpcode {
+				continue
+			}
+			w.tokens = append(w.tokens, "("+name)
+			w.walk(inputs[name])
+			w.tokens = append(w.tokens, ")")
+		}
+		id = block.Next()
+	}
+}
+
+func hashTokens(tokens []string) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(strings.Join(tokens, "\x00")))
+	return hash.Sum64()
+}
+
+// shinglesOf hashes every run of shingleSize tokens, so scripts that are changed a little still share most of them.
+func shinglesOf(tokens []string) []uint64 {
+	if len(tokens) <= shingleSize {
+		return []uint64{hashTokens(tokens)}
+	}
+	seen := make(map[uint64]bool)
+	shingles := make([]uint64, 0, len(tokens)-shingleSize+1)
+	for i := 0; i+shingleSize <= len(tokens); i++ {
+		shingle := hashTokens(tokens[i : i+shingleSize])
+		if !seen[shingle] {
+			seen[shingle] = true
+			shingles = append(shingles, shingle)
+		}
+	}
+	sort.Slice(shingles, func(i, j int) bool { return shingles[i] < shingles[j] })
+	return shingles
+}
+
+// templateOf is what every copy of the assignment starts with, sharing it is not a sign of copying.
+type templateOf struct {
+	shingles map[uint64]bool
+	hashes   map[uint64]bool
+}
+
+func newTemplate(templateJson string) templateOf {
+	template := templateOf{shingles: make(map[uint64]bool), hashes: make(map[uint64]bool)}
+	for _, script := range fingerprintOf(templateJson).Scripts {
+		template.hashes[script.Hash] = true
+		for _, shingle := range script.Shingles {
+			template.shingles[shingle] = true
+		}
+	}
+	return template
+}
+
+func (t templateOf) shinglesOf(scripts ...models.ScriptFingerprintCore) map[uint64]bool {
+	shingles := make(map[uint64]bool)
+	for _, script := range scripts {
+		for _, shingle := range script.Shingles {
+			if !t.shingles[shingle] {
+				shingles[shingle] = true
+			}
+		}
+	}
+	return shingles
+}
+
+func jaccard(a, b map[uint64]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for shingle := range a {
+		if b[shingle] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func (l similarityLimits) comparable(fingerprint *models.ProjectFingerprintCore) (scripts []models.ScriptFingerprintCore) {
+	for _, script := range fingerprint.Scripts {
+		if script.Blocks >= l.minScriptBlocks {
+			scripts = append(scripts, script)
+		}
+	}
+	return
+}
+
+// similarScripts pairs every script of the first work that is not in the template with the script
+// of the second work most like it.
+func (l similarityLimits) similarScripts(template templateOf, first, second *models.ProjectFingerprintCore) (similar []models.SimilarScriptsCore) {
+	similar = []models.SimilarScriptsCore{}
+	seconds := l.comparable(second)
+	for _, a := range l.comparable(first) {
+		if template.hashes[a.Hash] {
+			continue
+		}
+		aShingles := template.shinglesOf(a)
+		var best *models.ScriptFingerprintCore
+		bestScore := 0.0
+		for i := range seconds {
+			b := &seconds[i]
+			if a.Hash == b.Hash {
+				best, bestScore = b, 1
+				break
+			}
+			if score := jaccard(aShingles, template.shinglesOf(*b)); score > bestScore {
+				best, bestScore = b, score
+			}
+		}
+		if best == nil || bestScore < similarScriptThreshold {
+			continue
+		}
+		similar = append(similar, models.SimilarScriptsCore{
+			First:     models.ScriptRefCore{Sprite: a.Sprite, BlockId: a.BlockId, Blocks: a.Blocks},
+			Second:    models.ScriptRefCore{Sprite: best.Sprite, BlockId: best.BlockId, Blocks: best.Blocks},
+			Identical: a.Hash == best.Hash,
+		})
+	}
+	return
+}
+
+// similarityReport compares every two submissions of each assignment, the works of one assignment
+// are never compared with those of another.
+func (l similarityLimits) similarityReport(assignmentsCore []*models.AssignmentCore, works []*models.AssignmentWorkCore) *models.SimilarityReportCore {
+	report := &models.SimilarityReportCore{Pairs: []*models.SimilarPairCore{}, Threshold: l.threshold}
+	byAssignment := make(map[string][]*models.AssignmentWorkCore)
+	for _, work := range works {
+		if work.SubmittedAt == nil {
+			continue
+		}
+		if work.Fingerprint == nil {
+			report.Pending++
+			continue
+		}
+		report.Compared++
+		byAssignment[work.AssignmentId] = append(byAssignment[work.AssignmentId], work)
+	}
+	for _, assignment := range assignmentsCore {
+		submitted := byAssignment[assignment.Id]
+		if len(submitted) < 2 {
+			continue
+		}
+		template := newTemplate(assignment.TemplateJson)
+		shingles := make([]map[uint64]bool, len(submitted))
+		for i, work := range submitted {
+			shingles[i] = template.shinglesOf(l.comparable(work.Fingerprint)...)
+		}
+		for i := range submitted {
+			for j := i + 1; j < len(submitted); j++ {
+				similarity := jaccard(shingles[i], shingles[j])
+				if similarity == 0 || similarity < l.threshold {
+					continue
+				}
+				report.Pairs = append(report.Pairs, &models.SimilarPairCore{
+					AssignmentId:    assignment.Id,
+					FirstStudentId:  submitted[i].StudentId,
+					SecondStudentId: submitted[j].StudentId,
+					Similarity:      similarity,
+					Scripts:         l.similarScripts(template, submitted[i].Fingerprint, submitted[j].Fingerprint),
+				})
+			}
+		}
+	}
+	sort.SliceStable(report.Pairs, func(i, j int) bool {
+		return report.Pairs[i].Similarity > report.Pairs[j].Similarity
+	})
+	return report
+}
+
+// GetAssignmentSimilarity lists the submissions of the assignment that look copied from each other.
+func (p *AssignmentsUseCaseImpl) GetAssignmentSimilarity(assignmentId, userId string, userRole models.Role) (report *models.SimilarityReportCore, err error) {
+	assignment, err := p.assignmentsGateway.GetAssignmentById(assignmentId)
+	if err != nil {
+		return
+	}
+	if err = p.checkGroupAccess(assignment.RobboGroupId, userId, userRole); err != nil {
+		return
+	}
+	works, err := p.assignmentsGateway.GetFingerprintsByAssignmentIds([]string{assignmentId})
+	if err != nil {
+		return
+	}
+	return p.similarityLimits.similarityReport([]*models.AssignmentCore{assignment}, works), nil
+}
+
+// GetGroupSimilarity does the same for every assignment of the group at once.
+func (p *AssignmentsUseCaseImpl) GetGroupSimilarity(robboGroupId, userId string, userRole models.Role) (report *models.SimilarityReportCore, err error) {
+	if err = p.checkGroupAccess(robboGroupId, userId, userRole); err != nil {
+		return
+	}
+	assignmentsCore, err := p.assignmentsGateway.GetAssignmentsByRobboGroupIds([]string{robboGroupId})
+	if err != nil {
+		return
+	}
+	assignmentIds := make([]string, 0, len(assignmentsCore))
+	for _, assignment := range assignmentsCore {
+		assignmentIds = append(assignmentIds, assignment.Id)
+	}
+	works, err := p.assignmentsGateway.GetFingerprintsByAssignmentIds(assignmentIds)
+	if err != nil {
+		return
+	}
+	if assignmentsCore, err = p.withTemplates(assignmentsCore, works); err != nil {
+		return
+	}
+	return p.similarityLimits.similarityReport(assignmentsCore, works), nil
+}
+
+// withTemplates reads the templates the assignments are listed without, only of those assignments
+// that have two submissions to compare.
+func (p *AssignmentsUseCaseImpl) withTemplates(
+	assignmentsCore []*models.AssignmentCore,
+	works []*models.AssignmentWorkCore,
+) (withTemplates []*models.AssignmentCore, err error) {
+	submitted := make(map[string]int)
+	for _, work := range works {
+		if work.SubmittedAt != nil && work.Fingerprint != nil {
+			submitted[work.AssignmentId]++
+		}
+	}
+	for _, assignment := range assignmentsCore {
+		if submitted[assignment.Id] >= 2 {
+			if assignment, err = p.assignmentsGateway.GetAssignmentById(assignment.Id); err != nil {
+				return
+			}
+		}
+		withTemplates = append(withTemplates, assignment)
+	}
+	return
+}
+
+// FingerprintSubmissions builds the fingerprints of a batch of works submitted before fingerprints
+// were built on submission. The workers run it periodically until none are left. A work that fails
+// is logged and skipped, the next pass tries it again.
+func (p *AssignmentsUseCaseImpl) FingerprintSubmissions() (err error) {
+	works, err := p.assignmentsGateway.GetUnfingerprintedWorks(p.fingerprintFailures, p.similarityLimits.batchSize)
+	if err != nil {
+		return
+	}
+	if len(works) == 0 {
+		p.fingerprintFailures = 0
+		return
+	}
+	for _, work := range works {
+		if fingerprintErr := p.fingerprintWork(work.AssignmentId, work.StudentId); fingerprintErr != nil {
+			log.Println(work.AssignmentId, work.StudentId, fingerprintErr)
+			p.fingerprintFailures++
+		}
+	}
+	return
+}
+
+func (p *AssignmentsUseCaseImpl) fingerprintWork(assignmentId, studentId string) (err error) {
+	work, err := p.assignmentsGateway.GetWork(assignmentId, studentId)
+	if err != nil {
+		return
+	}
+	work.Fingerprint = fingerprintOf(work.SubmittedJson)
+	return p.assignmentsGateway.SetWorkFingerprint(work)
+}
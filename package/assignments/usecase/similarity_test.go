@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const templateScript = `"t1":{"opcode":"event_whenflagclicked","next":"t2","parent":null,"topLevel":true,"x":0,"y":0},
+	"t2":{"opcode":"motion_gotoxy","next":"t3","parent":"t1","topLevel":false},
+	"t3":{"opcode":"looks_show","next":null,"parent":"t2","topLevel":false}`
+
+const copiedScript = `"{p}1":{"opcode":"event_whenkeypressed","next":"{p}2","parent":null,"topLevel":true,"x":{x},"y":{y}},
+	"{p}2":{"opcode":"control_forever","next":null,"parent":"{p}1","inputs":{"SUBSTACK":[2,"{p}3"]},"topLevel":false},
+	"{p}3":{"opcode":"control_if","next":"{p}6","parent":"{p}2","inputs":{"CONDITION":[2,"{p}4"],"SUBSTACK":[2,"{p}5"]},"topLevel":false},
+	"{p}4":{"opcode":"sensing_touchingobject","next":null,"parent":"{p}3","inputs":{"TOUCHINGOBJECTMENU":[1,"{p}7"]},"topLevel":false},
+	"{p}7":{"opcode":"sensing_touchingobjectmenu","next":null,"parent":"{p}4","shadow":true,"topLevel":false},
+	"{p}5":{"opcode":"looks_say","next":null,"parent":"{p}3","inputs":{"MESSAGE":[1,[10,"{message}"]]},"topLevel":false},
+	"{p}6":{"opcode":"motion_movesteps","next":null,"parent":"{p}3","inputs":{"STEPS":[1,[4,"{steps}"]]},"topLevel":false}`
+
+func project(sprite, script string) string {
+	return `{"targets":[{"isStage":true,"name":"Stage","blocks":{}},{"isStage":false,"name":"` + sprite + `","blocks":{` +
+		templateScript + `,` + script + `}}]}`
+}
+
+// copied is the same script with other ids, another position and other values typed in.
+func copied(prefix string, x, y int, message string, steps int) string {
+	return strings.NewReplacer(
+		"{p}", prefix, "{x}", strconv.Itoa(x), "{y}", strconv.Itoa(y), "{message}", message, "{steps}", strconv.Itoa(steps),
+	).Replace(copiedScript)
+}
+
+func TestFingerprintOf(t *testing.T) {
+	first := fingerprintOf(project("Cat", copied("a", 10, 20, "Hello", 10)))
+	second := fingerprintOf(project("Robot", copied("b", 300, -40, "Bye", 25)))
+
+	assert.Len(t, first.Scripts, 2)
+	assert.Equal(t, 6, first.Scripts[0].Blocks, "the shadow menu is not a block")
+	assert.Equal(t, "Cat", first.Scripts[0].Sprite)
+	assert.Equal(t, "a1", first.Scripts[0].BlockId)
+	assert.Equal(t, first.Scripts[0].Hash, second.Scripts[0].Hash, "names, positions and values are left out")
+	assert.Equal(t, first.Scripts[0].Shingles, second.Scripts[0].Shingles)
+
+	withReporter := fingerprintOf(project("Cat", copied("a", 10, 20, "Hello", 10)+`,"v":[12,"score","id",5,5]`))
+	assert.Len(t, withReporter.Scripts, 2, "a variable reporter on the workspace is not a script")
+	assert.Empty(t, fingerprintOf("not a project").Scripts)
+}
+
+func TestSimilarityReport(t *testing.T) {
+	submittedAt := time.Now()
+	assignment := &models.AssignmentCore{Id: "1", TemplateJson: project("Sprite1", `"x":{"opcode":"looks_hide","topLevel":true}`)}
+	own := `"o1":{"opcode":"event_whenthisspriteclicked","next":"o2","parent":null,"topLevel":true},
+		"o2":{"opcode":"sound_play","next":"o3","parent":"o1","topLevel":false},
+		"o3":{"opcode":"looks_nextcostume","next":null,"parent":"o2","topLevel":false}`
+	works := []*models.AssignmentWorkCore{
+		{AssignmentId: "1", StudentId: "ann", SubmittedAt: &submittedAt, Fingerprint: fingerprintOf(project("Cat", copied("a", 1, 2, "Hi", 1)))},
+		{AssignmentId: "1", StudentId: "bob", SubmittedAt: &submittedAt, Fingerprint: fingerprintOf(project("Dog", copied("b", 5, 6, "Yo", 7)))},
+		// only the template, which everyone shares
+		{AssignmentId: "1", StudentId: "cid", SubmittedAt: &submittedAt, Fingerprint: fingerprintOf(project("Cat", `"z":{"opcode":"looks_hide","topLevel":true}`))},
+		{AssignmentId: "1", StudentId: "dan", SubmittedAt: &submittedAt, Fingerprint: fingerprintOf(project("Cat", own))},
+		{AssignmentId: "1", StudentId: "eve", SubmittedAt: &submittedAt},
+		{AssignmentId: "1", StudentId: "fay"},
+	}
+	limits := similarityLimits{threshold: 0.7, minScriptBlocks: 3}
+
+	report := limits.similarityReport([]*models.AssignmentCore{assignment}, works)
+
+	assert.Equal(t, 4, report.Compared)
+	assert.Equal(t, 1, report.Pending)
+	assert.Len(t, report.Pairs, 1)
+	pair := report.Pairs[0]
+	assert.Equal(t, []string{"ann", "bob"}, []string{pair.FirstStudentId, pair.SecondStudentId})
+	assert.Equal(t, 1.0, pair.Similarity)
+	assert.Equal(t, []models.SimilarScriptsCore{{
+		First:     models.ScriptRefCore{Sprite: "Cat", BlockId: "a1", Blocks: 6},
+		Second:    models.ScriptRefCore{Sprite: "Dog", BlockId: "b1", Blocks: 6},
+		Identical: true,
+	}}, pair.Scripts, "the script of the template is not shown")
+}
+
+// unfingerprinted has the works of students 6 and 7 submitted before fingerprints, the submission of 6 is lost.
+type unfingerprinted struct {
+	assignments.Gateway
+	fingerprinted []*models.AssignmentWorkCore
+	reads         map[string]int
+}
+
+func (g *unfingerprinted) GetUnfingerprintedWorks(offset, limit int) (works []*models.AssignmentWorkCore, err error) {
+	works = []*models.AssignmentWorkCore{{AssignmentId: "1", StudentId: "6"}}
+	if len(g.fingerprinted) == 0 {
+		works = append(works, &models.AssignmentWorkCore{AssignmentId: "1", StudentId: "7"})
+	}
+	if offset >= len(works) {
+		return nil, nil
+	}
+	return works[offset:], nil
+}
+
+func (g *unfingerprinted) GetWork(assignmentId, studentId string) (*models.AssignmentWorkCore, error) {
+	g.reads[studentId]++
+	if studentId == "6" {
+		return nil, errors.New("no blob")
+	}
+	return &models.AssignmentWorkCore{AssignmentId: assignmentId, StudentId: studentId, SubmittedJson: `{"targets":[]}`}, nil
+}
+
+func (g *unfingerprinted) SetWorkFingerprint(work *models.AssignmentWorkCore) error {
+	g.fingerprinted = append(g.fingerprinted, work)
+	return nil
+}
+
+func TestFingerprintSubmissions(t *testing.T) {
+	gateway := &unfingerprinted{reads: map[string]int{}}
+	useCase := &AssignmentsUseCaseImpl{assignmentsGateway: gateway}
+
+	assert.NoError(t, useCase.FingerprintSubmissions(), "a failing work does not fail the batch")
+	if assert.Len(t, gateway.fingerprinted, 1) {
+		assert.Equal(t, "7", gateway.fingerprinted[0].StudentId)
+		assert.NotNil(t, gateway.fingerprinted[0].Fingerprint)
+	}
+
+	assert.NoError(t, useCase.FingerprintSubmissions())
+	assert.Equal(t, 1, gateway.reads["6"], "the work of 6 is skipped for the rest of the pass")
+	assert.NoError(t, useCase.FingerprintSubmissions())
+	assert.Equal(t, 2, gateway.reads["6"], "the next pass tries the work of 6 again")
+}
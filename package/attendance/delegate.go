@@ -0,0 +1,10 @@
+package attendance
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	MarkAttendance(lessonId, markedBy string, marks []*models.AttendanceMark, notifyParents *bool) (attendance []*models.AttendanceHTTP, err error)
+	GetAttendanceByLessonId(lessonId string) (attendance []*models.AttendanceHTTP, err error)
+	GetAttendanceStatsByStudentId(studentId string, from, to *string) (stats *models.AttendanceStatsHTTP, err error)
+	GetAttendanceStatsByRobboGroupId(robboGroupId string, from, to *string) (stats []*models.AttendanceStatsHTTP, err error)
+}
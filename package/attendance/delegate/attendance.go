@@ -0,0 +1,104 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/attendance"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"time"
+)
+
+type AttendanceDelegateImpl struct {
+	UseCase attendance.UseCase
+}
+
+type AttendanceDelegateModule struct {
+	fx.Out
+	attendance.Delegate
+}
+
+func SetupAttendanceDelegate(usecase attendance.UseCase) AttendanceDelegateModule {
+	return AttendanceDelegateModule{
+		Delegate: &AttendanceDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *AttendanceDelegateImpl) MarkAttendance(lessonId, markedBy string, marks []*models.AttendanceMark, notifyParents *bool) (attendanceHttp []*models.AttendanceHTTP, err error) {
+	var marksCore []*models.AttendanceCore
+	for _, mark := range marks {
+		marksCore = append(marksCore, mark.ToCore())
+	}
+	attendanceCore, err := p.UseCase.MarkAttendance(lessonId, markedBy, marksCore, notifyParents)
+	if err != nil {
+		return
+	}
+	return attendanceToHTTP(attendanceCore), nil
+}
+
+func (p *AttendanceDelegateImpl) GetAttendanceByLessonId(lessonId string) (attendanceHttp []*models.AttendanceHTTP, err error) {
+	attendanceCore, err := p.UseCase.GetAttendanceByLessonId(lessonId)
+	if err != nil {
+		return
+	}
+	return attendanceToHTTP(attendanceCore), nil
+}
+
+func (p *AttendanceDelegateImpl) GetAttendanceStatsByStudentId(studentId string, from, to *string) (statsHttp *models.AttendanceStatsHTTP, err error) {
+	fromTime, toTime, err := parsePeriod(from, to)
+	if err != nil {
+		return
+	}
+	statsCore, err := p.UseCase.GetAttendanceStatsByStudentId(studentId, fromTime, toTime)
+	if err != nil {
+		return
+	}
+	statsHttp = &models.AttendanceStatsHTTP{}
+	statsHttp.FromCore(statsCore)
+	return
+}
+
+func (p *AttendanceDelegateImpl) GetAttendanceStatsByRobboGroupId(robboGroupId string, from, to *string) (statsHttp []*models.AttendanceStatsHTTP, err error) {
+	fromTime, toTime, err := parsePeriod(from, to)
+	if err != nil {
+		return
+	}
+	statsCore, err := p.UseCase.GetAttendanceStatsByRobboGroupId(robboGroupId, fromTime, toTime)
+	if err != nil {
+		return
+	}
+	statsHttp = make([]*models.AttendanceStatsHTTP, 0, len(statsCore))
+	for _, studentStats := range statsCore {
+		var statsTemp models.AttendanceStatsHTTP
+		statsTemp.FromCore(studentStats)
+		statsHttp = append(statsHttp, &statsTemp)
+	}
+	return
+}
+
+// parsePeriod treats a missing bound as an open end of the period.
+func parsePeriod(from, to *string) (fromTime, toTime time.Time, err error) {
+	toTime = time.Now().AddDate(100, 0, 0)
+	if from != nil {
+		if fromTime, err = time.Parse(time.RFC3339, *from); err != nil {
+			err = attendance.ErrBadTimestampFormat
+			return
+		}
+	}
+	if to != nil {
+		if toTime, err = time.Parse(time.RFC3339, *to); err != nil {
+			err = attendance.ErrBadTimestampFormat
+		}
+	}
+	return
+}
+
+func attendanceToHTTP(attendanceCore []*models.AttendanceCore) (attendanceHttp []*models.AttendanceHTTP) {
+	attendanceHttp = make([]*models.AttendanceHTTP, 0, len(attendanceCore))
+	for _, recordCore := range attendanceCore {
+		var recordTemp models.AttendanceHTTP
+		recordTemp.FromCore(recordCore)
+		attendanceHttp = append(attendanceHttp, &recordTemp)
+	}
+	return
+}
@@ -0,0 +1,12 @@
+package attendance
+
+import "errors"
+
+var (
+	ErrBadAttendanceStatus    = errors.New("attendance status must be one of present, absent, late, excused")
+	ErrStudentNotInGroup      = errors.New("student is not a member of the lesson's group")
+	ErrLessonNotStarted       = errors.New("attendance cannot be marked before the lesson starts")
+	ErrCorrectionWindowClosed = errors.New("attendance correction window is closed")
+	ErrBadTimestampFormat     = errors.New("timestamp must be in RFC3339 format")
+	ErrRobboGroupArchived     = errors.New("robbo group belongs to an archived term")
+)
@@ -0,0 +1,13 @@
+package attendance
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type Gateway interface {
+	SaveAttendance(attendance []*models.AttendanceCore) (err error)
+	GetAttendanceByLessonId(lessonId string) (attendance []*models.AttendanceCore, err error)
+	GetAttendanceByStudentId(studentId string, from, to time.Time) (attendance []*models.AttendanceCore, err error)
+	GetAttendanceByRobboGroupId(robboGroupId string, from, to time.Time) (attendance []*models.AttendanceCore, err error)
+}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/attendance"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type AttendanceGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type AttendanceGatewayModule struct {
+	fx.Out
+	attendance.Gateway
+}
+
+func SetupAttendanceGateway(postgresClient db_client.PostgresClient) AttendanceGatewayModule {
+	return AttendanceGatewayModule{
+		Gateway: &AttendanceGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+// SaveAttendance inserts new marks and overwrites the existing mark of a student for the same lesson.
+func (r *AttendanceGatewayImpl) SaveAttendance(attendanceCore []*models.AttendanceCore) (err error) {
+	if len(attendanceCore) == 0 {
+		return
+	}
+	var attendanceDb []*models.AttendanceDB
+	for _, recordCore := range attendanceCore {
+		recordDb := models.AttendanceDB{}
+		recordDb.FromCore(recordCore)
+		attendanceDb = append(attendanceDb, &recordDb)
+	}
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "lesson_id"}, {Name: "student_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "comment", "marked_by", "marked_at", "updated_at"}),
+		}).Create(&attendanceDb).Error
+		return
+	})
+	return
+}
+
+func (r *AttendanceGatewayImpl) GetAttendanceByLessonId(lessonId string) (attendanceCore []*models.AttendanceCore, err error) {
+	var attendanceDb []*models.AttendanceDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("lesson_id = ?", lessonId).Order("student_id").Find(&attendanceDb).Error
+		return
+	})
+
+	for _, recordDb := range attendanceDb {
+		attendanceCore = append(attendanceCore, recordDb.ToCore())
+	}
+	return
+}
+
+func (r *AttendanceGatewayImpl) GetAttendanceByStudentId(studentId string, from, to time.Time) (attendanceCore []*models.AttendanceCore, err error) {
+	return r.getAttendanceInPeriod("attendance_dbs.student_id = ?", studentId, from, to)
+}
+
+func (r *AttendanceGatewayImpl) GetAttendanceByRobboGroupId(robboGroupId string, from, to time.Time) (attendanceCore []*models.AttendanceCore, err error) {
+	return r.getAttendanceInPeriod("attendance_dbs.robbo_group_id = ?", robboGroupId, from, to)
+}
+
+// attendanceRow is a mark with the start of its lesson as the lesson has it now.
+type attendanceRow struct {
+	models.AttendanceDB
+	LessonStartAt time.Time
+}
+
+// getAttendanceInPeriod lists the marks of the lessons that start within the period,
+// the lessons are joined so that a rescheduled lesson is counted where it has moved to.
+func (r *AttendanceGatewayImpl) getAttendanceInPeriod(owner, ownerId string, from, to time.Time) (attendanceCore []*models.AttendanceCore, err error) {
+	var rows []*attendanceRow
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Model(&models.AttendanceDB{}).
+			Select("attendance_dbs.*, lesson_dbs.start_at AS lesson_start_at").
+			Joins("JOIN lesson_dbs ON CAST(lesson_dbs.id AS TEXT) = attendance_dbs.lesson_id AND lesson_dbs.deleted_at IS NULL").
+			Where(owner, ownerId).
+			Where("lesson_dbs.start_at >= ? AND lesson_dbs.start_at < ?", from, to).
+			Order("lesson_dbs.start_at").
+			Scan(&rows).Error
+		return
+	})
+
+	for _, row := range rows {
+		recordCore := row.AttendanceDB.ToCore()
+		recordCore.LessonStartAt = row.LessonStartAt
+		attendanceCore = append(attendanceCore, recordCore)
+	}
+	return
+}
@@ -0,0 +1,32 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetAttendanceByStudentIdReadsTheLessonStart(t *testing.T) {
+	rescheduledTo := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+	postgresClient, recorder, err := dbtest.Open(func(query string, args []interface{}) ([]string, [][]interface{}) {
+		return []string{"id", "lesson_id", "robbo_group_id", "student_id", "status", "lesson_start_at"},
+			[][]interface{}{{int64(1), "7", "3", "5", "absent", rescheduledTo}}
+	})
+	assert.NoError(t, err)
+	gateway := &AttendanceGatewayImpl{PostgresClient: postgresClient}
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	records, err := gateway.GetAttendanceByStudentId("5", from, from.AddDate(0, 1, 0))
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, rescheduledTo, records[0].LessonStartAt)
+		assert.Equal(t, models.AttendanceAbsent, records[0].Status)
+	}
+
+	queries := recorder.Find("JOIN lesson_dbs", "lesson_dbs.start_at >= ")
+	if assert.Len(t, queries, 1) {
+		assert.Equal(t, []interface{}{"5", from, from.AddDate(0, 1, 0)}, queries[0].Args)
+	}
+}
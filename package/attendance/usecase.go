@@ -0,0 +1,13 @@
+package attendance
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type UseCase interface {
+	MarkAttendance(lessonId, markedBy string, marks []*models.AttendanceCore, notifyParents *bool) (attendance []*models.AttendanceCore, err error)
+	GetAttendanceByLessonId(lessonId string) (attendance []*models.AttendanceCore, err error)
+	GetAttendanceStatsByStudentId(studentId string, from, to time.Time) (stats *models.AttendanceStatsCore, err error)
+	GetAttendanceStatsByRobboGroupId(robboGroupId string, from, to time.Time) (stats []*models.AttendanceStatsCore, err error)
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/attendance"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"log"
+	"time"
+)
+
+type AttendanceUseCaseImpl struct {
+	attendanceGateway    attendance.Gateway
+	scheduleGateway      schedule.Gateway
+	usersGateway         users.Gateway
+	robboGroupGateway    robboGroup.Gateway
+	notificationsGateway notifications.Gateway
+	correctionWindow     time.Duration
+	notifyParents        bool
+}
+
+type AttendanceUseCaseModule struct {
+	fx.Out
+	attendance.UseCase
+}
+
+func SetupAttendanceUseCase(
+	attendanceGateway attendance.Gateway,
+	scheduleGateway schedule.Gateway,
+	usersGateway users.Gateway,
+	robboGroupGateway robboGroup.Gateway,
+	notificationsGateway notifications.Gateway,
+) AttendanceUseCaseModule {
+	return AttendanceUseCaseModule{
+		UseCase: &AttendanceUseCaseImpl{
+			attendanceGateway:    attendanceGateway,
+			scheduleGateway:      scheduleGateway,
+			usersGateway:         usersGateway,
+			robboGroupGateway:    robboGroupGateway,
+			notificationsGateway: notificationsGateway,
+			correctionWindow:     time.Duration(viper.GetInt("attendance.correction_window_hours")) * time.Hour,
+			notifyParents:        viper.GetBool("attendance.notify_parents"),
+		},
+	}
+}
+
+func (p *AttendanceUseCaseImpl) MarkAttendance(lessonId, markedBy string, marks []*models.AttendanceCore, notifyParents *bool) (attendanceCore []*models.AttendanceCore, err error) {
+	lesson, err := p.scheduleGateway.GetLessonById(lessonId)
+	if err != nil {
+		return
+	}
+	if lesson.Status == models.LessonCancelled {
+		return nil, schedule.ErrLessonCancelled
+	}
+	now := time.Now()
+	if now.Before(lesson.StartAt) {
+		return nil, attendance.ErrLessonNotStarted
+	}
+	if now.After(lesson.EndAt.Add(p.correctionWindow)) {
+		return nil, attendance.ErrCorrectionWindowClosed
+	}
+	// the attendance of archived terms is kept as it was
+	robboGroupCore, err := p.robboGroupGateway.GetRobboGroupById(lesson.RobboGroupId)
+	if err != nil {
+		return
+	}
+	if robboGroupCore.Archived {
+		return nil, attendance.ErrRobboGroupArchived
+	}
+
+	students, err := p.usersGateway.GetStudentsByRobboGroupId(lesson.RobboGroupId)
+	if err != nil {
+		return
+	}
+	studentsById := make(map[string]*models.StudentCore)
+	for _, student := range students {
+		studentsById[student.Id] = student
+	}
+
+	previous, err := p.attendanceGateway.GetAttendanceByLessonId(lessonId)
+	if err != nil {
+		return
+	}
+	previousStatus := make(map[string]models.AttendanceStatus)
+	for _, record := range previous {
+		previousStatus[record.StudentId] = record.Status
+	}
+
+	var absentStudents []*models.StudentCore
+	for _, mark := range marks {
+		if !mark.Status.IsValid() {
+			return nil, attendance.ErrBadAttendanceStatus
+		}
+		student, ok := studentsById[mark.StudentId]
+		if !ok {
+			return nil, attendance.ErrStudentNotInGroup
+		}
+		mark.LessonId = lessonId
+		mark.RobboGroupId = lesson.RobboGroupId
+		mark.MarkedBy = markedBy
+		mark.MarkedAt = now
+		if mark.Status == models.AttendanceAbsent && previousStatus[mark.StudentId] != models.AttendanceAbsent {
+			absentStudents = append(absentStudents, student)
+		}
+	}
+
+	if err = p.attendanceGateway.SaveAttendance(marks); err != nil {
+		return
+	}
+
+	notify := p.notifyParents
+	if notifyParents != nil {
+		notify = *notifyParents
+	}
+	if notify {
+		// a failed notification must not roll back attendance that is already saved
+		if notifyErr := p.notifyParentsAboutAbsence(lesson, absentStudents); notifyErr != nil {
+			log.Println(notifyErr)
+		}
+	}
+	return p.attendanceGateway.GetAttendanceByLessonId(lessonId)
+}
+
+func (p *AttendanceUseCaseImpl) notifyParentsAboutAbsence(lesson *models.LessonCore, absentStudents []*models.StudentCore) (err error) {
+	var notificationsCore []*models.NotificationCore
+	for _, student := range absentStudents {
+		relations, getRelationsErr := p.usersGateway.GetRelationByChildrenId(student.Id)
+		if getRelationsErr != nil {
+			return getRelationsErr
+		}
+		for _, relation := range relations {
+			notificationsCore = append(notificationsCore, &models.NotificationCore{
+				RecipientId:   relation.ParentId,
+				RecipientRole: models.Parent,
+				Kind:          models.AbsenceNotification,
+				Text: fmt.Sprintf("%s %s was marked absent from the lesson on %s",
+					student.Firstname, student.Lastname, lesson.StartAt.Format("02.01.2006 15:04")),
+			})
+		}
+	}
+	return p.notificationsGateway.CreateNotifications(notificationsCore)
+}
+
+func (p *AttendanceUseCaseImpl) GetAttendanceByLessonId(lessonId string) (attendanceCore []*models.AttendanceCore, err error) {
+	return p.attendanceGateway.GetAttendanceByLessonId(lessonId)
+}
+
+func (p *AttendanceUseCaseImpl) GetAttendanceStatsByStudentId(studentId string, from, to time.Time) (stats *models.AttendanceStatsCore, err error) {
+	records, err := p.attendanceGateway.GetAttendanceByStudentId(studentId, from, to)
+	if err != nil {
+		return
+	}
+	stats, ok := countAttendance(records)[studentId]
+	if !ok {
+		stats = &models.AttendanceStatsCore{StudentId: studentId}
+	}
+	return
+}
+
+func (p *AttendanceUseCaseImpl) GetAttendanceStatsByRobboGroupId(robboGroupId string, from, to time.Time) (stats []*models.AttendanceStatsCore, err error) {
+	students, err := p.usersGateway.GetStudentsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	records, err := p.attendanceGateway.GetAttendanceByRobboGroupId(robboGroupId, from, to)
+	if err != nil {
+		return
+	}
+	return groupAttendanceStats(robboGroupId, students, records), nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/attendance"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeLessons struct {
+	schedule.Gateway
+	lesson *models.LessonCore
+}
+
+func (f *fakeLessons) GetLessonById(lessonId string) (*models.LessonCore, error) {
+	return f.lesson, nil
+}
+
+type fakeRobboGroups struct {
+	robboGroup.Gateway
+	archived bool
+}
+
+func (f *fakeRobboGroups) GetRobboGroupById(robboGroupId string) (*models.RobboGroupCore, error) {
+	return &models.RobboGroupCore{Id: robboGroupId, Archived: f.archived}, nil
+}
+
+type fakeStudents struct {
+	users.Gateway
+}
+
+func (fakeStudents) GetStudentsByRobboGroupId(robboGroupId string) ([]*models.StudentCore, error) {
+	return []*models.StudentCore{{UserCore: models.UserCore{Id: "5"}}}, nil
+}
+
+type fakeAttendance struct {
+	attendance.Gateway
+	saved []*models.AttendanceCore
+}
+
+func (f *fakeAttendance) GetAttendanceByLessonId(lessonId string) ([]*models.AttendanceCore, error) {
+	return nil, nil
+}
+
+func (f *fakeAttendance) SaveAttendance(marks []*models.AttendanceCore) error {
+	f.saved = append(f.saved, marks...)
+	return nil
+}
+
+func TestMarkAttendanceOfArchivedGroup(t *testing.T) {
+	startAt := time.Now().Add(-time.Hour)
+	records := &fakeAttendance{}
+	groups := &fakeRobboGroups{archived: true}
+	p := &AttendanceUseCaseImpl{
+		attendanceGateway: records,
+		scheduleGateway:   &fakeLessons{lesson: &models.LessonCore{Id: "1", RobboGroupId: "7", StartAt: startAt, EndAt: startAt.Add(30 * time.Minute)}},
+		usersGateway:      fakeStudents{},
+		robboGroupGateway: groups,
+		correctionWindow:  24 * time.Hour,
+	}
+	noNotifications := false
+
+	_, err := p.MarkAttendance("1", "3", []*models.AttendanceCore{{StudentId: "5", Status: models.AttendancePresent}}, &noNotifications)
+	assert.Equal(t, attendance.ErrRobboGroupArchived, err)
+	assert.Empty(t, records.saved)
+
+	groups.archived = false
+	_, err = p.MarkAttendance("1", "3", []*models.AttendanceCore{{StudentId: "5", Status: models.AttendancePresent}}, &noNotifications)
+	assert.NoError(t, err)
+	assert.Len(t, records.saved, 1)
+}
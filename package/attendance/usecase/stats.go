@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"sort"
+)
+
+// countAttendance folds attendance records into per-student counters. The group of
+// a student is taken from their latest record, records are expected in lesson order.
+func countAttendance(records []*models.AttendanceCore) map[string]*models.AttendanceStatsCore {
+	stats := make(map[string]*models.AttendanceStatsCore)
+	for _, record := range records {
+		studentStats, ok := stats[record.StudentId]
+		if !ok {
+			studentStats = &models.AttendanceStatsCore{StudentId: record.StudentId}
+			stats[record.StudentId] = studentStats
+		}
+		studentStats.RobboGroupId = record.RobboGroupId
+		studentStats.Total++
+		switch record.Status {
+		case models.AttendancePresent:
+			studentStats.Present++
+		case models.AttendanceAbsent:
+			studentStats.Absent++
+		case models.AttendanceLate:
+			studentStats.Late++
+		case models.AttendanceExcused:
+			studentStats.Excused++
+		}
+	}
+	return stats
+}
+
+// groupAttendanceStats reports every current member of the group, including the ones
+// without any marks yet, plus former members that still have records in the period.
+func groupAttendanceStats(robboGroupId string, students []*models.StudentCore, records []*models.AttendanceCore) (stats []*models.AttendanceStatsCore) {
+	counted := countAttendance(records)
+	for _, student := range students {
+		if _, ok := counted[student.Id]; !ok {
+			counted[student.Id] = &models.AttendanceStatsCore{StudentId: student.Id}
+		}
+	}
+	for _, studentStats := range counted {
+		studentStats.RobboGroupId = robboGroupId
+		stats = append(stats, studentStats)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].StudentId < stats[j].StudentId
+	})
+	return
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCountAttendance(t *testing.T) {
+	records := []*models.AttendanceCore{
+		{StudentId: "1", RobboGroupId: "5", Status: models.AttendancePresent},
+		{StudentId: "1", RobboGroupId: "5", Status: models.AttendanceLate},
+		{StudentId: "1", RobboGroupId: "6", Status: models.AttendanceAbsent},
+		{StudentId: "2", RobboGroupId: "6", Status: models.AttendanceExcused},
+	}
+
+	stats := countAttendance(records)
+
+	assert.Len(t, stats, 2)
+	assert.Equal(t, &models.AttendanceStatsCore{StudentId: "1", RobboGroupId: "6", Total: 3, Present: 1, Absent: 1, Late: 1}, stats["1"])
+	assert.InDelta(t, 2.0/3.0, stats["1"].Rate(), 1e-9)
+	assert.Equal(t, 1, stats["2"].Excused)
+	assert.Zero(t, stats["2"].Rate())
+}
+
+func TestGroupAttendanceStats(t *testing.T) {
+	students := []*models.StudentCore{
+		{UserCore: models.UserCore{Id: "1"}},
+		{UserCore: models.UserCore{Id: "3"}},
+	}
+	records := []*models.AttendanceCore{
+		{StudentId: "1", RobboGroupId: "6", Status: models.AttendancePresent},
+		{StudentId: "2", RobboGroupId: "6", Status: models.AttendanceAbsent},
+	}
+
+	stats := groupAttendanceStats("6", students, records)
+
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "1", stats[0].StudentId)
+	assert.Equal(t, 1, stats[0].Present)
+	assert.Equal(t, "2", stats[1].StudentId)
+	assert.Equal(t, 1, stats[1].Absent)
+	assert.Equal(t, "3", stats[2].StudentId)
+	assert.Zero(t, stats[2].Total)
+	assert.Equal(t, "6", stats[2].RobboGroupId)
+}
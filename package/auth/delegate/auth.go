@@ -57,7 +57,11 @@ func (s *AuthDelegateImpl) UserIdentity(c *gin.Context) (id string, role models.
 		return
 	}
 
-	claims, err := s.UseCase.ParseToken(headerParts[1], []byte(viper.GetString("auth.access_signing_key")))
+	return s.TokenIdentity(headerParts[1])
+}
+
+func (s *AuthDelegateImpl) TokenIdentity(accessToken string) (id string, role models.Role, err error) {
+	claims, err := s.UseCase.ParseToken(accessToken, []byte(viper.GetString("auth.access_signing_key")))
 	if err != nil {
 		return "", models.Anonymous, auth.ErrInvalidAccessToken
 	}
@@ -3,6 +3,7 @@ package http
 import (
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"log"
@@ -10,14 +11,17 @@ import (
 )
 
 type Handler struct {
-	delegate auth.Delegate
+	delegate         auth.Delegate
+	activityDelegate activity.Delegate
 }
 
 func NewAuthHandler(
 	authDelegate auth.Delegate,
+	activityDelegate activity.Delegate,
 ) Handler {
 	return Handler{
-		delegate: authDelegate,
+		delegate:         authDelegate,
+		activityDelegate: activityDelegate,
 	}
 }
 
@@ -52,6 +56,7 @@ func (h *Handler) SignIn(c *gin.Context) {
 	}
 
 	accessToken, refreshToken, err := h.delegate.SignIn(signInInput.Email, signInInput.Password, signInInput.Role)
+	h.recordLoginEvent(c, models.SignInEvent, signInInput.Email, models.Role(signInInput.Role), accessToken, err)
 	if err != nil {
 		fmt.Println(err)
 		ErrorHandling(err, c)
@@ -101,6 +106,7 @@ func (h *Handler) Refresh(c *gin.Context) {
 	}
 
 	newAccessToken, err := h.delegate.RefreshToken(refreshToken)
+	h.recordLoginEvent(c, models.RefreshEvent, "", models.Anonymous, newAccessToken, err)
 	if err != nil {
 		fmt.Println(err)
 		ErrorHandling(err, c)
@@ -136,6 +142,31 @@ func (h *Handler) CheckAuth(c *gin.Context) {
 	})
 }
 
+// recordLoginEvent stores the outcome of a sign in or token refresh.
+// On success the user is taken from the issued access token, on failure only the attempted email and role are known.
+func (h *Handler) recordLoginEvent(c *gin.Context, eventType models.LoginEventType, email string, role models.Role, accessToken string, authErr error) {
+	loginEvent := &models.LoginEventCore{
+		Email:     email,
+		Role:      role,
+		Type:      eventType,
+		Ip:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Success:   authErr == nil,
+	}
+	if authErr == nil {
+		userId, userRole, tokenIdentityErr := h.delegate.TokenIdentity(accessToken)
+		if tokenIdentityErr != nil {
+			log.Println(tokenIdentityErr)
+			return
+		}
+		loginEvent.UserId = userId
+		loginEvent.Role = userRole
+	}
+	if err := h.activityDelegate.RecordLoginEvent(loginEvent); err != nil {
+		log.Println(err)
+	}
+}
+
 func ErrorHandling(err error, c *gin.Context) {
 	switch err {
 	case auth.ErrUserAlreadyExist:
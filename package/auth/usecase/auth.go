@@ -88,6 +88,13 @@ func (a *AuthUseCaseImpl) SignIn(email, password string, role uint) (accessToken
 		}
 		user.Id = unitAdmin.Id
 		user.Role = models.UnitAdmin
+	case models.RegionAdmin:
+		regionAdmin, getRegionAdminErr := a.Gateway.GetRegionAdmin(email, passwordHash)
+		if getRegionAdminErr != nil {
+			return "", "", getRegionAdminErr
+		}
+		user.Id = regionAdmin.Id
+		user.Role = models.RegionAdmin
 	case models.SuperAdmin:
 		superAdmin, getSuperAdminErr := a.Gateway.GetSuperAdmin(email, passwordHash)
 		if getSuperAdminErr != nil {
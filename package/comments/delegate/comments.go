@@ -0,0 +1,77 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+)
+
+type CommentsDelegateImpl struct {
+	comments.UseCase
+}
+
+type CommentsDelegateModule struct {
+	fx.Out
+	comments.Delegate
+}
+
+func SetupCommentsDelegate(usecase comments.UseCase) CommentsDelegateModule {
+	return CommentsDelegateModule{
+		Delegate: &CommentsDelegateImpl{
+			UseCase: usecase,
+		},
+	}
+}
+
+func (p *CommentsDelegateImpl) GetProjectComments(projectId, viewerId string, viewerRole models.Role) (commentsHttp []*models.ProjectCommentHTTP, err error) {
+	commentsCore, err := p.UseCase.GetProjectComments(projectId, viewerId, viewerRole)
+	if err != nil {
+		return
+	}
+	commentsHttp = make([]*models.ProjectCommentHTTP, 0, len(commentsCore))
+	for _, commentCore := range commentsCore {
+		commentHttp := &models.ProjectCommentHTTP{}
+		commentHttp.FromCore(commentCore)
+		commentsHttp = append(commentsHttp, commentHttp)
+	}
+	return
+}
+
+func (p *CommentsDelegateImpl) GetReportedComments(moderatorId string, moderatorRole models.Role) (reported []*models.ReportedCommentHTTP, err error) {
+	reportedCore, err := p.UseCase.GetReportedComments(moderatorId, moderatorRole)
+	if err != nil {
+		return
+	}
+	reported = make([]*models.ReportedCommentHTTP, 0, len(reportedCore))
+	for _, item := range reportedCore {
+		reportedHttp := &models.ReportedCommentHTTP{}
+		reportedHttp.FromCore(item)
+		reported = append(reported, reportedHttp)
+	}
+	return
+}
+
+func (p *CommentsDelegateImpl) PostComment(projectId string, parentId *string, text, authorId string, authorRole models.Role) (comment *models.ProjectCommentHTTP, err error) {
+	parent := ""
+	if parentId != nil {
+		parent = *parentId
+	}
+	return toHttp(p.UseCase.PostComment(projectId, parent, text, authorId, authorRole))
+}
+
+func (p *CommentsDelegateImpl) EditComment(commentId, text, authorId string, authorRole models.Role) (comment *models.ProjectCommentHTTP, err error) {
+	return toHttp(p.UseCase.EditComment(commentId, text, authorId, authorRole))
+}
+
+func (p *CommentsDelegateImpl) ModerateComment(commentId string, hidden bool, moderatorId string, moderatorRole models.Role) (comment *models.ProjectCommentHTTP, err error) {
+	return toHttp(p.UseCase.ModerateComment(commentId, hidden, moderatorId, moderatorRole))
+}
+
+func toHttp(commentCore *models.ProjectCommentCore, err error) (comment *models.ProjectCommentHTTP, _ error) {
+	if err != nil {
+		return nil, err
+	}
+	comment = &models.ProjectCommentHTTP{}
+	comment.FromCore(commentCore)
+	return comment, nil
+}
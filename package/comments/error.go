@@ -0,0 +1,17 @@
+package comments
+
+import "errors"
+
+var (
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrProjectNotShared = errors.New("project is not shared")
+	ErrCommentsDisabled = errors.New("comments are turned off for this project")
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrBadReply         = errors.New("reply must answer a comment on the same project")
+	ErrEmptyComment     = errors.New("comment is empty")
+	ErrCommentTooLong   = errors.New("comment is too long")
+	ErrBannedWords      = errors.New("comment contains words that are not allowed")
+	ErrLinksNotAllowed  = errors.New("comment contains links that are not allowed")
+	ErrNoAccess         = errors.New("no access to the comment")
+	ErrOwnCommentReport = errors.New("cannot report your own comment")
+)
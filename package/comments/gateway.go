@@ -0,0 +1,18 @@
+package comments
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Gateway interface {
+	GetCommentedProject(projectId string) (project *models.CommentedProjectCore, err error)
+	SetCommentsDisabled(projectId string, disabled bool) (err error)
+	IsTeacherOfStudent(teacherId, studentId string) (isTeacher bool, err error)
+
+	CreateComment(comment *models.ProjectCommentCore) (created *models.ProjectCommentCore, err error)
+	GetCommentById(commentId string) (comment *models.ProjectCommentCore, err error)
+	GetCommentsByProjectId(projectId string) (comments []*models.ProjectCommentCore, err error)
+	UpdateComment(comment *models.ProjectCommentCore) (err error)
+
+	CreateReport(report *models.CommentReportCore) (openReports int, err error)
+	ResolveReports(commentId string) (err error)
+	GetReportedComments(teacherId string) (reported []*models.ReportedCommentCore, err error)
+}
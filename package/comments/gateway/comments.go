@@ -0,0 +1,199 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentsGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type CommentsGatewayModule struct {
+	fx.Out
+	comments.Gateway
+}
+
+func SetupCommentsGateway(postgresClient db_client.PostgresClient) CommentsGatewayModule {
+	return CommentsGatewayModule{
+		Gateway: &CommentsGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+func (r *CommentsGatewayImpl) GetCommentedProject(projectId string) (project *models.CommentedProjectCore, err error) {
+	var projects []*models.CommentedProjectCore
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(`
+SELECT pp.project_id, pp.title, p.author_id, p.author_role, pp.is_shared, pp.comments_disabled
+FROM project_page_dbs pp
+JOIN project_dbs p ON CAST(p.id AS text) = pp.project_id AND p.deleted_at IS NULL
+WHERE pp.project_id = ? AND pp.deleted_at IS NULL`, projectId).Scan(&projects).Error
+	})
+	if err != nil {
+		return
+	}
+	if len(projects) == 0 {
+		return nil, comments.ErrProjectNotFound
+	}
+	return projects[0], nil
+}
+
+func (r *CommentsGatewayImpl) SetCommentsDisabled(projectId string, disabled bool) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.ProjectPageDB{}).Where("project_id = ?", projectId).
+			Update("comments_disabled", disabled).Error
+	})
+	return
+}
+
+// IsTeacherOfStudent looks through the current groups of the student, legacy primary group included.
+func (r *CommentsGatewayImpl) IsTeacherOfStudent(teacherId, studentId string) (isTeacher bool, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(`
+SELECT EXISTS (
+	SELECT 1 FROM teachers_robbo_groups_dbs t
+	WHERE t.teacher_id = @teacher AND t.deleted_at IS NULL AND t.robbo_group_id IN (
+		SELECT robbo_group_id FROM group_membership_dbs
+		WHERE student_id = @student AND left_at IS NULL AND deleted_at IS NULL
+		UNION
+		SELECT CAST(robbo_group_id AS text) FROM student_dbs WHERE CAST(id AS text) = @student AND deleted_at IS NULL
+	)
+)`, map[string]interface{}{"teacher": teacherId, "student": studentId}).Scan(&isTeacher).Error
+	})
+	return
+}
+
+func (r *CommentsGatewayImpl) CreateComment(comment *models.ProjectCommentCore) (created *models.ProjectCommentCore, err error) {
+	commentDb := models.ProjectCommentDB{}
+	commentDb.FromCore(comment)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Create(&commentDb).Error
+	})
+	if err != nil {
+		return
+	}
+	return commentDb.ToCore(), nil
+}
+
+func (r *CommentsGatewayImpl) GetCommentById(commentId string) (comment *models.ProjectCommentCore, err error) {
+	var commentDb models.ProjectCommentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", commentId).First(&commentDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return comments.ErrCommentNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	return commentDb.ToCore(), nil
+}
+
+// GetCommentsByProjectId returns the comments oldest first, a reply always comes after the comment it answers.
+func (r *CommentsGatewayImpl) GetCommentsByProjectId(projectId string) (commentsCore []*models.ProjectCommentCore, err error) {
+	var commentsDb []*models.ProjectCommentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("project_id = ?", projectId).Order("created_at, id").Find(&commentsDb).Error
+	})
+	if err != nil {
+		return
+	}
+	commentsCore = make([]*models.ProjectCommentCore, 0, len(commentsDb))
+	for _, commentDb := range commentsDb {
+		commentsCore = append(commentsCore, commentDb.ToCore())
+	}
+	return
+}
+
+// UpdateComment writes the text and the status, the rest of a comment never changes.
+func (r *CommentsGatewayImpl) UpdateComment(comment *models.ProjectCommentCore) (err error) {
+	commentDb := models.ProjectCommentDB{}
+	commentDb.FromCore(comment)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&commentDb).Select("text", "status", "edited_at").Updates(&commentDb).Error
+	})
+	return
+}
+
+// CreateReport keeps the first report of a user and returns how many open reports the comment has.
+func (r *CommentsGatewayImpl) CreateReport(report *models.CommentReportCore) (openReports int, err error) {
+	reportDb := models.CommentReportDB{}
+	reportDb.FromCore(report)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reportDb).Error; err != nil {
+			return
+		}
+		var count int64
+		err = tx.Model(&models.CommentReportDB{}).Where("comment_id = ? AND NOT resolved", report.CommentId).
+			Count(&count).Error
+		openReports = int(count)
+		return
+	})
+	return
+}
+
+func (r *CommentsGatewayImpl) ResolveReports(commentId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.CommentReportDB{}).Where("comment_id = ? AND NOT resolved", commentId).
+			Update("resolved", true).Error
+	})
+	return
+}
+
+// GetReportedComments lists the comments with open reports, for a teacher only those on
+// projects of the students in their groups.
+func (r *CommentsGatewayImpl) GetReportedComments(teacherId string) (reported []*models.ReportedCommentCore, err error) {
+	var commentsDb []*models.ProjectCommentDB
+	var reportsDb []*models.CommentReportDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		query := tx.Where("CAST(id AS text) IN (?)",
+			tx.Model(&models.CommentReportDB{}).Select("comment_id").Where("NOT resolved"))
+		if teacherId != "" {
+			query = query.Where(`project_id IN (
+	SELECT CAST(p.id AS text) FROM project_dbs p WHERE p.deleted_at IS NULL AND p.author_role = @student AND p.author_id IN (
+		SELECT m.student_id FROM group_membership_dbs m
+		JOIN teachers_robbo_groups_dbs t ON t.robbo_group_id = m.robbo_group_id AND t.deleted_at IS NULL
+		WHERE t.teacher_id = @teacher AND m.left_at IS NULL AND m.deleted_at IS NULL
+		UNION
+		SELECT CAST(s.id AS text) FROM student_dbs s
+		JOIN teachers_robbo_groups_dbs t ON t.robbo_group_id = CAST(s.robbo_group_id AS text) AND t.deleted_at IS NULL
+		WHERE t.teacher_id = @teacher AND s.deleted_at IS NULL
+	)
+)`, map[string]interface{}{"teacher": teacherId, "student": models.Student})
+		}
+		if err = query.Order("created_at, id").Find(&commentsDb).Error; err != nil {
+			return
+		}
+		if len(commentsDb) == 0 {
+			return
+		}
+		ids := make([]string, 0, len(commentsDb))
+		for _, commentDb := range commentsDb {
+			ids = append(ids, commentDb.ToCore().Id)
+		}
+		return tx.Where("comment_id IN ? AND NOT resolved", ids).Order("created_at").Find(&reportsDb).Error
+	})
+	if err != nil {
+		return
+	}
+	byComment := make(map[string]*models.ReportedCommentCore, len(commentsDb))
+	reported = make([]*models.ReportedCommentCore, 0, len(commentsDb))
+	for _, commentDb := range commentsDb {
+		item := &models.ReportedCommentCore{Comment: commentDb.ToCore()}
+		byComment[item.Comment.Id] = item
+		reported = append(reported, item)
+	}
+	for _, reportDb := range reportsDb {
+		if item, ok := byComment[reportDb.CommentId]; ok {
+			item.Reports = append(item.Reports, reportDb.ToCore())
+		}
+	}
+	return
+}
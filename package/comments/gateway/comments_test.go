@@ -0,0 +1,21 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetReportedCommentsOfStudentProjects(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &CommentsGatewayImpl{PostgresClient: postgresClient}
+
+	_, err = gateway.GetReportedComments("12")
+	assert.NoError(t, err)
+	found := recorder.Find(`FROM "project_comment_dbs"`, "p.author_role =")
+	if assert.Len(t, found, 1, "authors of other roles share the ids of students") {
+		assert.Contains(t, found[0].Args, models.Student)
+	}
+}
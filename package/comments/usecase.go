@@ -0,0 +1,15 @@
+package comments
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type UseCase interface {
+	GetProjectComments(projectId, viewerId string, viewerRole models.Role) (comments []*models.ProjectCommentCore, err error)
+	GetReportedComments(moderatorId string, moderatorRole models.Role) (reported []*models.ReportedCommentCore, err error)
+
+	PostComment(projectId, parentId, text, authorId string, authorRole models.Role) (comment *models.ProjectCommentCore, err error)
+	EditComment(commentId, text, authorId string, authorRole models.Role) (comment *models.ProjectCommentCore, err error)
+	DeleteComment(commentId, authorId string, authorRole models.Role) (err error)
+	ReportComment(commentId, reason, reporterId string, reporterRole models.Role) (err error)
+	ModerateComment(commentId string, hidden bool, moderatorId string, moderatorRole models.Role) (comment *models.ProjectCommentCore, err error)
+	SetCommentsDisabled(projectId string, disabled bool, userId string, userRole models.Role) (err error)
+}
@@ -0,0 +1,365 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxReasonLength is the size of CommentReportDB.Reason
+const maxReasonLength = 512
+
+type CommentsUseCaseImpl struct {
+	commentsGateway      comments.Gateway
+	usersGateway         users.Gateway
+	notificationsGateway notifications.Gateway
+	filter               *contentFilter
+	maxLength            int
+	autoHideReports      int
+}
+
+type CommentsUseCaseModule struct {
+	fx.Out
+	comments.UseCase
+}
+
+func SetupCommentsUseCase(
+	commentsGateway comments.Gateway,
+	usersGateway users.Gateway,
+	notificationsGateway notifications.Gateway,
+) CommentsUseCaseModule {
+	return CommentsUseCaseModule{
+		UseCase: &CommentsUseCaseImpl{
+			commentsGateway:      commentsGateway,
+			usersGateway:         usersGateway,
+			notificationsGateway: notificationsGateway,
+			filter: newContentFilter(
+				viper.GetStringSlice("comments.banned_words"),
+				viper.GetBool("comments.allow_links"),
+				viper.GetStringSlice("comments.allowed_link_hosts"),
+			),
+			maxLength:       viper.GetInt("comments.max_length"),
+			autoHideReports: viper.GetInt("comments.auto_hide_reports"),
+		},
+	}
+}
+
+// GetProjectComments returns the whole thread. Deleted comments keep their place without text,
+// hidden ones show their text to their author and to the moderators only.
+func (p *CommentsUseCaseImpl) GetProjectComments(projectId, viewerId string, viewerRole models.Role) (commentsCore []*models.ProjectCommentCore, err error) {
+	project, err := p.commentsGateway.GetCommentedProject(projectId)
+	if err != nil {
+		return
+	}
+	if project.CommentsDisabled {
+		return []*models.ProjectCommentCore{}, nil
+	}
+	isModerator := false
+	if !project.IsShared {
+		if isModerator, err = p.canModerate(project, viewerId, viewerRole); err != nil {
+			return
+		}
+	}
+	if !project.IsShared && !isModerator && !ownsProject(project, viewerId, viewerRole) {
+		return nil, comments.ErrProjectNotShared
+	}
+	if commentsCore, err = p.commentsGateway.GetCommentsByProjectId(projectId); err != nil {
+		return
+	}
+	if !isModerator && hasHidden(commentsCore) {
+		if isModerator, err = p.canModerate(project, viewerId, viewerRole); err != nil {
+			return
+		}
+	}
+	for _, comment := range commentsCore {
+		switch comment.Status {
+		case models.CommentDeleted:
+			comment.Text = ""
+		case models.CommentHidden:
+			if !isModerator && !isAuthor(comment, viewerId, viewerRole) {
+				comment.Text = ""
+			}
+		}
+	}
+	return
+}
+
+func (p *CommentsUseCaseImpl) GetReportedComments(moderatorId string, moderatorRole models.Role) (reported []*models.ReportedCommentCore, err error) {
+	switch moderatorRole {
+	case models.SuperAdmin:
+		return p.commentsGateway.GetReportedComments("")
+	case models.Teacher:
+		return p.commentsGateway.GetReportedComments(moderatorId)
+	default:
+		return nil, comments.ErrNoAccess
+	}
+}
+
+func (p *CommentsUseCaseImpl) PostComment(projectId, parentId, text, authorId string, authorRole models.Role) (comment *models.ProjectCommentCore, err error) {
+	project, err := p.commentsGateway.GetCommentedProject(projectId)
+	if err != nil {
+		return
+	}
+	if !project.IsShared {
+		return nil, comments.ErrProjectNotShared
+	}
+	if project.CommentsDisabled {
+		return nil, comments.ErrCommentsDisabled
+	}
+	if text, err = p.checkText(text); err != nil {
+		return
+	}
+	var parent *models.ProjectCommentCore
+	if parentId != "" {
+		if parent, err = p.commentsGateway.GetCommentById(parentId); err != nil {
+			return
+		}
+		if parent.ProjectId != projectId || parent.Status == models.CommentDeleted {
+			return nil, comments.ErrBadReply
+		}
+	}
+	comment, err = p.commentsGateway.CreateComment(&models.ProjectCommentCore{
+		ProjectId:  projectId,
+		ParentId:   parentId,
+		AuthorId:   authorId,
+		AuthorRole: authorRole,
+		Text:       text,
+		Status:     models.CommentVisible,
+	})
+	if err != nil {
+		return
+	}
+	// a failed notification must not fail a comment that is already saved
+	if notifyErr := p.notifyAboutComment(project, parent, comment); notifyErr != nil {
+		log.Println(notifyErr)
+	}
+	return
+}
+
+func (p *CommentsUseCaseImpl) EditComment(commentId, text, authorId string, authorRole models.Role) (comment *models.ProjectCommentCore, err error) {
+	if comment, err = p.ownComment(commentId, authorId, authorRole); err != nil {
+		return
+	}
+	project, err := p.commentsGateway.GetCommentedProject(comment.ProjectId)
+	if err != nil {
+		return
+	}
+	if project.CommentsDisabled {
+		return nil, comments.ErrCommentsDisabled
+	}
+	if comment.Text, err = p.checkText(text); err != nil {
+		return nil, err
+	}
+	editedAt := time.Now()
+	comment.EditedAt = &editedAt
+	if err = p.commentsGateway.UpdateComment(comment); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DeleteComment clears the text and keeps the comment in the thread for the replies to it.
+func (p *CommentsUseCaseImpl) DeleteComment(commentId, authorId string, authorRole models.Role) (err error) {
+	comment, err := p.ownComment(commentId, authorId, authorRole)
+	if err != nil {
+		return
+	}
+	comment.Text = ""
+	comment.Status = models.CommentDeleted
+	if err = p.commentsGateway.UpdateComment(comment); err != nil {
+		return
+	}
+	return p.commentsGateway.ResolveReports(commentId)
+}
+
+// ReportComment hides the comment until a moderator looks at it once it has collected enough open reports.
+func (p *CommentsUseCaseImpl) ReportComment(commentId, reason, reporterId string, reporterRole models.Role) (err error) {
+	comment, err := p.commentsGateway.GetCommentById(commentId)
+	if err != nil {
+		return
+	}
+	if comment.Status == models.CommentDeleted {
+		return comments.ErrCommentNotFound
+	}
+	if isAuthor(comment, reporterId, reporterRole) {
+		return comments.ErrOwnCommentReport
+	}
+	reason = strings.TrimSpace(reason)
+	if runes := []rune(reason); len(runes) > maxReasonLength {
+		reason = string(runes[:maxReasonLength])
+	}
+	openReports, err := p.commentsGateway.CreateReport(&models.CommentReportCore{
+		CommentId:    commentId,
+		ReporterId:   reporterId,
+		ReporterRole: reporterRole,
+		Reason:       reason,
+	})
+	if err != nil {
+		return
+	}
+	if p.autoHideReports > 0 && openReports >= p.autoHideReports && comment.Status == models.CommentVisible {
+		comment.Status = models.CommentHidden
+		return p.commentsGateway.UpdateComment(comment)
+	}
+	return
+}
+
+// ModerateComment hides or restores the comment and closes its reports either way.
+func (p *CommentsUseCaseImpl) ModerateComment(commentId string, hidden bool, moderatorId string, moderatorRole models.Role) (comment *models.ProjectCommentCore, err error) {
+	if comment, err = p.commentsGateway.GetCommentById(commentId); err != nil {
+		return
+	}
+	if comment.Status == models.CommentDeleted {
+		return nil, comments.ErrCommentNotFound
+	}
+	project, err := p.commentsGateway.GetCommentedProject(comment.ProjectId)
+	if err != nil {
+		return
+	}
+	isModerator, err := p.canModerate(project, moderatorId, moderatorRole)
+	if err != nil {
+		return
+	}
+	if !isModerator {
+		return nil, comments.ErrNoAccess
+	}
+	comment.Status = models.CommentVisible
+	if hidden {
+		comment.Status = models.CommentHidden
+	}
+	if err = p.commentsGateway.UpdateComment(comment); err != nil {
+		return nil, err
+	}
+	if err = p.commentsGateway.ResolveReports(commentId); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SetCommentsDisabled is the switch of the project owner and of their parents.
+func (p *CommentsUseCaseImpl) SetCommentsDisabled(projectId string, disabled bool, userId string, userRole models.Role) (err error) {
+	project, err := p.commentsGateway.GetCommentedProject(projectId)
+	if err != nil {
+		return
+	}
+	allowed := ownsProject(project, userId, userRole)
+	if userRole == models.Parent && project.AuthorRole == models.Student {
+		if allowed, err = p.isParentOf(userId, project.AuthorId); err != nil {
+			return
+		}
+	}
+	if !allowed {
+		return comments.ErrNoAccess
+	}
+	return p.commentsGateway.SetCommentsDisabled(projectId, disabled)
+}
+
+func (p *CommentsUseCaseImpl) checkText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", comments.ErrEmptyComment
+	}
+	if p.maxLength > 0 && utf8.RuneCountInString(text) > p.maxLength {
+		return "", comments.ErrCommentTooLong
+	}
+	if err := p.filter.check(text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+func (p *CommentsUseCaseImpl) ownComment(commentId, authorId string, authorRole models.Role) (comment *models.ProjectCommentCore, err error) {
+	if comment, err = p.commentsGateway.GetCommentById(commentId); err != nil {
+		return
+	}
+	if comment.Status == models.CommentDeleted {
+		return nil, comments.ErrCommentNotFound
+	}
+	if !isAuthor(comment, authorId, authorRole) {
+		return nil, comments.ErrNoAccess
+	}
+	return
+}
+
+// canModerate lets super admins moderate everywhere and teachers on the projects of their students.
+func (p *CommentsUseCaseImpl) canModerate(project *models.CommentedProjectCore, userId string, userRole models.Role) (bool, error) {
+	switch userRole {
+	case models.SuperAdmin:
+		return true, nil
+	case models.Teacher:
+		if project.AuthorRole != models.Student {
+			return false, nil
+		}
+		return p.commentsGateway.IsTeacherOfStudent(userId, project.AuthorId)
+	default:
+		return false, nil
+	}
+}
+
+func (p *CommentsUseCaseImpl) isParentOf(parentId, childId string) (bool, error) {
+	relations, err := p.usersGateway.GetRelationByChildrenId(childId)
+	if err != nil {
+		return false, err
+	}
+	for _, relation := range relations {
+		if relation.ParentId == parentId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// notifyAboutComment tells the author of the answered comment about a reply and the student
+// who made the project about any other comment. Nobody is told about their own comments.
+func (p *CommentsUseCaseImpl) notifyAboutComment(project *models.CommentedProjectCore, parent, comment *models.ProjectCommentCore) error {
+	var notificationsCore []*models.NotificationCore
+	if parent != nil && !isAuthor(parent, comment.AuthorId, comment.AuthorRole) {
+		notificationsCore = append(notificationsCore, &models.NotificationCore{
+			RecipientId:   parent.AuthorId,
+			RecipientRole: parent.AuthorRole,
+			Kind:          models.CommentNotification,
+			Text:          fmt.Sprintf("Someone replied to your comment on the project %s", project.Title),
+		})
+	}
+	projectAuthorIsRecipient := parent != nil && isAuthor(parent, project.AuthorId, project.AuthorRole)
+	projectAuthorCommented := isAuthor(comment, project.AuthorId, project.AuthorRole)
+	// only students are told about comments on their projects
+	if project.AuthorRole == models.Student && !projectAuthorIsRecipient && !projectAuthorCommented {
+		notificationsCore = append(notificationsCore, &models.NotificationCore{
+			RecipientId:   project.AuthorId,
+			RecipientRole: models.Student,
+			Kind:          models.CommentNotification,
+			Text:          fmt.Sprintf("There is a new comment on your project %s", project.Title),
+		})
+	}
+	if len(notificationsCore) == 0 {
+		return nil
+	}
+	return p.notificationsGateway.CreateNotifications(notificationsCore)
+}
+
+func isAuthor(comment *models.ProjectCommentCore, userId string, userRole models.Role) bool {
+	return comment.AuthorId == userId && comment.AuthorRole == userRole
+}
+
+// ownsProject compares the role too, the ids of users of different roles overlap.
+func ownsProject(project *models.CommentedProjectCore, userId string, userRole models.Role) bool {
+	return project.AuthorId == userId && project.AuthorRole == userRole
+}
+
+func hasHidden(commentsCore []*models.ProjectCommentCore) bool {
+	for _, comment := range commentsCore {
+		if comment.Status == models.CommentHidden {
+			return true
+		}
+	}
+	return false
+}
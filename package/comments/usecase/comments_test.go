@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestContentFilter(t *testing.T) {
+	filter := newContentFilter([]string{"Dumb", "idiot*", " ", "*"}, false, []string{"scratch.mit.edu"})
+
+	for _, text := range []string{
+		"Nice sprites!",
+		"dumbbell is not banned",
+		"see scratch.mit.edu/projects/1 for the original",
+		"https://www.scratch.mit.edu/studios/2",
+		"the cat says meow.x",
+		"игры.рус and 1.5 sprites",
+	} {
+		assert.NoError(t, filter.check(text), text)
+	}
+	for _, text := range []string{"so DUMB", "dumb!", "idiots everywhere"} {
+		assert.ErrorIs(t, filter.check(text), comments.ErrBannedWords, text)
+	}
+	for _, text := range []string{
+		"go to http://example.com",
+		"www.example.org",
+		"play at games.ru/free",
+		"scratch.mit.edu.evil.com",
+		"сайт.рф",
+		"лучшие игры.ru",
+		"заходи на вконтакте.com/x",
+		"a.ru,b.ru",
+	} {
+		assert.ErrorIs(t, filter.check(text), comments.ErrLinksNotAllowed, text)
+	}
+
+	assert.Equal(t, []string{"сайт.рф", "игры.ru", "вконтакте.com/x"}, findLinks("сайт.рф, игры.ru и вконтакте.com/x"))
+	assert.Equal(t, []string{"a.ru", "b.ru"}, findLinks("a.ru b.ru"), "a link does not take the boundary of the next one")
+
+	filter = newContentFilter(nil, true, nil)
+	assert.NoError(t, filter.check("go to http://example.com"))
+}
+
+func TestCheckText(t *testing.T) {
+	usecase := &CommentsUseCaseImpl{filter: newContentFilter([]string{"dumb"}, false, nil), maxLength: 5}
+
+	text, err := usecase.checkText("  ура! \n")
+	assert.NoError(t, err)
+	assert.Equal(t, "ура!", text)
+
+	// the limit is in characters, not bytes
+	_, err = usecase.checkText(strings.Repeat("я", 5))
+	assert.NoError(t, err)
+	_, err = usecase.checkText(strings.Repeat("я", 6))
+	assert.ErrorIs(t, err, comments.ErrCommentTooLong)
+
+	_, err = usecase.checkText(" \t")
+	assert.ErrorIs(t, err, comments.ErrEmptyComment)
+	_, err = usecase.checkText("dumb")
+	assert.ErrorIs(t, err, comments.ErrBannedWords)
+}
+
+// projectGateway has project 7 made by user 1 of the given role, taught by teacher 1 when that is a student.
+type projectGateway struct {
+	comments.Gateway
+	authorRole models.Role
+	disabled   bool
+}
+
+func (g *projectGateway) GetCommentedProject(projectId string) (*models.CommentedProjectCore, error) {
+	return &models.CommentedProjectCore{ProjectId: projectId, AuthorId: "1", AuthorRole: g.authorRole}, nil
+}
+
+func (g *projectGateway) SetCommentsDisabled(projectId string, disabled bool) error {
+	g.disabled = disabled
+	return nil
+}
+
+func (g *projectGateway) IsTeacherOfStudent(teacherId, studentId string) (bool, error) {
+	return teacherId == "1" && studentId == "1", nil
+}
+
+// childOf makes parent 1 the parent of student 1.
+type childOf struct {
+	users.Gateway
+}
+
+func (childOf) GetRelationByChildrenId(childId string) ([]*models.ChildrenOfParentCore, error) {
+	return []*models.ChildrenOfParentCore{{ParentId: "1", ChildId: childId}}, nil
+}
+
+func TestSetCommentsDisabledComparesRoles(t *testing.T) {
+	cases := []struct {
+		authorRole models.Role
+		userRole   models.Role
+		allowed    bool
+	}{
+		{models.Student, models.Student, true},
+		{models.Student, models.Parent, true},
+		{models.Student, models.Teacher, false},
+		{models.Teacher, models.Teacher, true},
+		{models.Teacher, models.Student, false},
+		{models.Teacher, models.Parent, false},
+	}
+	for _, c := range cases {
+		gateway := &projectGateway{authorRole: c.authorRole}
+		usecase := &CommentsUseCaseImpl{commentsGateway: gateway, usersGateway: childOf{}}
+		err := usecase.SetCommentsDisabled("7", true, "1", c.userRole)
+		if c.allowed {
+			assert.NoError(t, err, "%v on the project of %v", c.userRole, c.authorRole)
+		} else {
+			assert.ErrorIs(t, err, comments.ErrNoAccess, "%v on the project of %v", c.userRole, c.authorRole)
+		}
+		assert.Equal(t, c.allowed, gateway.disabled)
+	}
+}
+
+func TestCanModerateProjectsOfStudentsOnly(t *testing.T) {
+	usecase := &CommentsUseCaseImpl{commentsGateway: &projectGateway{}}
+
+	moderates, err := usecase.canModerate(&models.CommentedProjectCore{AuthorId: "1", AuthorRole: models.Student}, "1", models.Teacher)
+	assert.NoError(t, err)
+	assert.True(t, moderates)
+	moderates, err = usecase.canModerate(&models.CommentedProjectCore{AuthorId: "1", AuthorRole: models.Parent}, "1", models.Teacher)
+	assert.NoError(t, err)
+	assert.False(t, moderates, "parent 1 is not student 1")
+}
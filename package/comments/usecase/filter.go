@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// linkPattern catches addresses with a scheme, www. addresses and dotted words. The boundaries are
+// spelled out because \b only knows ASCII letters and would miss "игры.ru" and "сайт.рф".
+var linkPattern = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}])((?:https?://|www\.)[^\s]+|(?:[\p{L}\p{N}-]+\.)+[\p{L}\p{N}-]+(?:/[^\s]*)?)`)
+
+// linkZones are the zones children run into most, a dotted word in one of them is taken for a link,
+// so "example.ru" is a link while "sprite.x" is not.
+var linkZones = map[string]bool{
+	"ru": true, "рф": true, "su": true, "com": true, "net": true, "org": true, "info": true, "io": true,
+	"me": true, "xyz": true, "ly": true, "gg": true, "be": true, "cc": true, "tk": true,
+}
+
+// findLinks lists the links of the text, a dotted word only counts when it ends in one of linkZones.
+func findLinks(text string) (links []string) {
+	for _, match := range linkPattern.FindAllStringSubmatch(text, -1) {
+		link := match[1]
+		lower := strings.ToLower(link)
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "www.") {
+			host := linkHost(link)
+			if !linkZones[host[strings.LastIndex(host, ".")+1:]] {
+				continue
+			}
+		}
+		links = append(links, link)
+	}
+	return
+}
+
+// contentFilter rejects comments with banned words and, unless links are allowed, with links
+// to hosts that are not on the allowed list. A banned word ending with * bans every word
+// starting with it.
+type contentFilter struct {
+	bannedWords    map[string]bool
+	bannedPrefixes []string
+	allowLinks     bool
+	allowedHosts   []string
+}
+
+func newContentFilter(bannedWords []string, allowLinks bool, allowedHosts []string) *contentFilter {
+	filter := &contentFilter{
+		bannedWords: make(map[string]bool, len(bannedWords)),
+		allowLinks:  allowLinks,
+	}
+	for _, word := range bannedWords {
+		word = strings.ToLower(strings.TrimSpace(word))
+		switch {
+		case word == "" || word == "*":
+		case strings.HasSuffix(word, "*"):
+			filter.bannedPrefixes = append(filter.bannedPrefixes, strings.TrimSuffix(word, "*"))
+		default:
+			filter.bannedWords[word] = true
+		}
+	}
+	for _, host := range allowedHosts {
+		filter.allowedHosts = append(filter.allowedHosts, strings.ToLower(strings.TrimSpace(host)))
+	}
+	return filter
+}
+
+func (f *contentFilter) check(text string) error {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if f.bannedWords[word] {
+			return comments.ErrBannedWords
+		}
+		for _, prefix := range f.bannedPrefixes {
+			if strings.HasPrefix(word, prefix) {
+				return comments.ErrBannedWords
+			}
+		}
+	}
+	if f.allowLinks {
+		return nil
+	}
+	for _, link := range findLinks(text) {
+		if !f.isAllowedHost(linkHost(link)) {
+			return comments.ErrLinksNotAllowed
+		}
+	}
+	return nil
+}
+
+// isAllowedHost accepts the allowed hosts and their subdomains.
+func (f *contentFilter) isAllowedHost(host string) bool {
+	for _, allowed := range f.allowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func linkHost(link string) string {
+	host := strings.ToLower(link)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.IndexAny(host, "/?#:"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.TrimPrefix(host, "www.")
+}
@@ -10,4 +10,17 @@ type Delegate interface {
 	UpdateCoursePacket(coursePacket *models.CoursePacketHTTP) (err error)
 	GetCoursePacketById(coursePacketId string) (coursePacket models.CoursePacketHTTP, err error)
 	GetAllCoursePackets() (coursePackets []*models.CoursePacketHTTP, err error)
+
+	AddCourseToCoursePacket(coursePacketId, courseId string) (courseIds []string, err error)
+	RemoveCourseFromCoursePacket(coursePacketId, courseId string) (courseIds []string, err error)
+	GetCourseIdsByCoursePacketId(coursePacketId string) (courseIds []string, err error)
+	AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId string) (relation *models.RobboGroupCoursePacketHTTP, err error)
+	DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId string) (err error)
+	GetCoursePacketsByRobboGroupId(robboGroupId string) (relations []*models.RobboGroupCoursePacketHTTP, err error)
+
+	ProcessEdxEnrollments() (err error)
+	RetryEdxEnrollment(enrollmentId string) (enrollment *models.EdxEnrollmentHTTP, err error)
+	GetEdxEnrollmentById(enrollmentId string) (enrollment *models.EdxEnrollmentHTTP, err error)
+	GetEdxEnrollmentsByStudentId(studentId string) (enrollments []*models.EdxEnrollmentHTTP, err error)
+	GetEdxEnrollmentsByRobboGroupId(robboGroupId string) (enrollments []*models.EdxEnrollmentHTTP, err error)
 }
@@ -2,12 +2,14 @@ package delegate
 
 import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/edx"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"go.uber.org/fx"
 )
 
 type CoursePacketDelegateImpl struct {
 	coursePacket.UseCase
+	EdxUseCase edx.UseCase
 }
 
 type CoursePacketDelegateModule struct {
@@ -15,10 +17,11 @@ type CoursePacketDelegateModule struct {
 	coursePacket.Delegate
 }
 
-func SetupCoursePacketDelegate(usecase coursePacket.UseCase) CoursePacketDelegateModule {
+func SetupCoursePacketDelegate(usecase coursePacket.UseCase, edxUsecase edx.UseCase) CoursePacketDelegateModule {
 	return CoursePacketDelegateModule{
 		Delegate: &CoursePacketDelegateImpl{
 			usecase,
+			edxUsecase,
 		},
 	}
 }
@@ -0,0 +1,137 @@
+package delegate
+
+import (
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"log"
+)
+
+func (p *CoursePacketDelegateImpl) AddCourseToCoursePacket(coursePacketId, courseId string) (courseIds []string, err error) {
+	return p.UseCase.AddCourseToCoursePacket(coursePacketId, courseId)
+}
+
+func (p *CoursePacketDelegateImpl) RemoveCourseFromCoursePacket(coursePacketId, courseId string) (courseIds []string, err error) {
+	return p.UseCase.RemoveCourseFromCoursePacket(coursePacketId, courseId)
+}
+
+func (p *CoursePacketDelegateImpl) GetCourseIdsByCoursePacketId(coursePacketId string) (courseIds []string, err error) {
+	return p.UseCase.GetCourseIdsByCoursePacketId(coursePacketId)
+}
+
+func (p *CoursePacketDelegateImpl) AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId string) (relation *models.RobboGroupCoursePacketHTTP, err error) {
+	relationCore, err := p.UseCase.AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId)
+	if err != nil {
+		return
+	}
+	relation = &models.RobboGroupCoursePacketHTTP{}
+	relation.FromCore(relationCore)
+	return
+}
+
+func (p *CoursePacketDelegateImpl) DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId string) (err error) {
+	return p.UseCase.DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId)
+}
+
+func (p *CoursePacketDelegateImpl) GetCoursePacketsByRobboGroupId(robboGroupId string) (relations []*models.RobboGroupCoursePacketHTTP, err error) {
+	relationsCore, err := p.UseCase.GetCoursePacketsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	relations = make([]*models.RobboGroupCoursePacketHTTP, 0, len(relationsCore))
+	for _, relationCore := range relationsCore {
+		var relationTemp models.RobboGroupCoursePacketHTTP
+		relationTemp.FromCore(relationCore)
+		relations = append(relations, &relationTemp)
+	}
+	return
+}
+
+// ProcessEdxEnrollments runs one pass of the enrollment worker: it queues jobs for new group
+// members and pushes every due job to edX, recording the outcome of each call. A job whose outcome
+// can't be recorded stays running and is claimed again once stale, the pass goes on with the rest.
+func (p *CoursePacketDelegateImpl) ProcessEdxEnrollments() (err error) {
+	if err = p.UseCase.SyncEdxEnrollments(); err != nil {
+		// the jobs queued on earlier passes are still due
+		log.Println(err)
+	}
+	enrollments, err := p.UseCase.ClaimDueEdxEnrollments()
+	if err != nil {
+		return
+	}
+	failed := 0
+	for _, enrollment := range enrollments {
+		edxErr := p.postEnrollment(enrollment)
+		if edxErr != nil {
+			log.Println(edxErr)
+		}
+		if completeErr := p.UseCase.CompleteEdxEnrollment(enrollment, edxErr); completeErr != nil {
+			log.Println(completeErr)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d edx enrollments were not recorded", failed, len(enrollments))
+	}
+	return
+}
+
+func (p *CoursePacketDelegateImpl) postEnrollment(enrollment *models.EdxEnrollmentCore) (err error) {
+	if enrollment.Username == "" {
+		return coursePacket.ErrNoEdxUsername
+	}
+	_, err = p.EdxUseCase.PostEnrollment(map[string]interface{}{
+		"user":      enrollment.Username,
+		"is_active": enrollment.Action == models.EdxEnroll,
+		"course_details": map[string]string{
+			"course_id": enrollment.CourseId,
+		},
+	})
+	return
+}
+
+func (p *CoursePacketDelegateImpl) RetryEdxEnrollment(enrollmentId string) (enrollment *models.EdxEnrollmentHTTP, err error) {
+	enrollmentCore, err := p.UseCase.RetryEdxEnrollment(enrollmentId)
+	if err != nil {
+		return
+	}
+	enrollment = &models.EdxEnrollmentHTTP{}
+	enrollment.FromCore(enrollmentCore)
+	return
+}
+
+func (p *CoursePacketDelegateImpl) GetEdxEnrollmentById(enrollmentId string) (enrollment *models.EdxEnrollmentHTTP, err error) {
+	enrollmentCore, err := p.UseCase.GetEdxEnrollmentById(enrollmentId)
+	if err != nil {
+		return
+	}
+	enrollment = &models.EdxEnrollmentHTTP{}
+	enrollment.FromCore(enrollmentCore)
+	return
+}
+
+func (p *CoursePacketDelegateImpl) GetEdxEnrollmentsByStudentId(studentId string) (enrollments []*models.EdxEnrollmentHTTP, err error) {
+	enrollmentsCore, err := p.UseCase.GetEdxEnrollmentsByStudentId(studentId)
+	if err != nil {
+		return
+	}
+	return enrollmentsToHTTP(enrollmentsCore), nil
+}
+
+func (p *CoursePacketDelegateImpl) GetEdxEnrollmentsByRobboGroupId(robboGroupId string) (enrollments []*models.EdxEnrollmentHTTP, err error) {
+	enrollmentsCore, err := p.UseCase.GetEdxEnrollmentsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	return enrollmentsToHTTP(enrollmentsCore), nil
+}
+
+func enrollmentsToHTTP(enrollmentsCore []*models.EdxEnrollmentCore) (enrollments []*models.EdxEnrollmentHTTP) {
+	enrollments = make([]*models.EdxEnrollmentHTTP, 0, len(enrollmentsCore))
+	for _, enrollmentCore := range enrollmentsCore {
+		var enrollmentTemp models.EdxEnrollmentHTTP
+		enrollmentTemp.FromCore(enrollmentCore)
+		enrollments = append(enrollments, &enrollmentTemp)
+	}
+	return
+}
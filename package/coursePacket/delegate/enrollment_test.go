@@ -0,0 +1,71 @@
+package delegate
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/edx"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeEnrollments struct {
+	coursePacket.UseCase
+	due       []*models.EdxEnrollmentCore
+	syncErr   error
+	completed map[string]error
+}
+
+func (f *fakeEnrollments) SyncEdxEnrollments() error {
+	return f.syncErr
+}
+
+func (f *fakeEnrollments) ClaimDueEdxEnrollments() ([]*models.EdxEnrollmentCore, error) {
+	return f.due, nil
+}
+
+func (f *fakeEnrollments) CompleteEdxEnrollment(enrollment *models.EdxEnrollmentCore, edxErr error) error {
+	f.completed[enrollment.Id] = edxErr
+	if enrollment.Id == "1" {
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+type fakeEdx struct {
+	edx.UseCase
+	posted []string
+}
+
+func (f *fakeEdx) PostEnrollment(message map[string]interface{}) ([]byte, error) {
+	user := message["user"].(string)
+	f.posted = append(f.posted, user)
+	if user == "down" {
+		return nil, errors.New("edx is down")
+	}
+	return nil, nil
+}
+
+func TestProcessEdxEnrollmentsGoesOnAfterFailures(t *testing.T) {
+	usecase := &fakeEnrollments{
+		due: []*models.EdxEnrollmentCore{
+			{Id: "1", Username: "alice", CourseId: "c1", Action: models.EdxEnroll},
+			{Id: "2", Username: "down", CourseId: "c1", Action: models.EdxEnroll},
+			{Id: "3", CourseId: "c1", Action: models.EdxEnroll},
+			{Id: "4", Username: "bob", CourseId: "c1", Action: models.EdxUnenroll},
+		},
+		syncErr:   errors.New("sync failed"),
+		completed: map[string]error{},
+	}
+	edxUseCase := &fakeEdx{}
+	p := &CoursePacketDelegateImpl{usecase, edxUseCase}
+
+	err := p.ProcessEdxEnrollments()
+	assert.Error(t, err, "the job whose outcome was lost is reported")
+	assert.Equal(t, []string{"alice", "down", "bob"}, edxUseCase.posted, "the due jobs run although the sync and a record failed")
+	assert.Len(t, usecase.completed, 4)
+	assert.NoError(t, usecase.completed["1"])
+	assert.EqualError(t, usecase.completed["2"], "edx is down")
+	assert.Equal(t, coursePacket.ErrNoEdxUsername, usecase.completed["3"])
+	assert.NoError(t, usecase.completed["4"])
+}
@@ -0,0 +1,9 @@
+package coursePacket
+
+import "errors"
+
+var (
+	ErrEnrollmentNotFound  = errors.New("edx enrollment not found")
+	ErrEnrollmentNotFailed = errors.New("only failed edx enrollments can be retried")
+	ErrNoEdxUsername       = errors.New("student has no edx username")
+)
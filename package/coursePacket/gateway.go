@@ -1,6 +1,9 @@
 package coursePacket
 
-import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
 
 type Gateway interface {
 	CreateCoursePacket(coursePacket *models.CoursePacketCore) (id string, err error)
@@ -8,4 +11,19 @@ type Gateway interface {
 	UpdateCoursePacket(coursePacket *models.CoursePacketCore) (err error)
 	GetAllCoursePackets() (coursePackets []*models.CoursePacketCore, err error)
 	GetCoursePacketById(coursePacketId string) (coursePacket *models.CoursePacketCore, err error)
+
+	AddCourseToCoursePacket(coursePacketId, courseId string) (err error)
+	RemoveCourseFromCoursePacket(coursePacketId, courseId string) (err error)
+	GetCourseIdsByCoursePacketId(coursePacketId string) (courseIds []string, err error)
+	AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId string) (relation *models.RobboGroupCoursePacketCore, err error)
+	DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId string) (err error)
+	GetCoursePacketsByRobboGroupId(robboGroupId string) (relations []*models.RobboGroupCoursePacketCore, err error)
+
+	EnqueueMissingEdxEnrollments() (err error)
+	EnqueueUnwantedEdxUnenrollments() (err error)
+	ClaimDueEdxEnrollments(limit int, staleBefore time.Time) (enrollments []*models.EdxEnrollmentCore, err error)
+	UpdateEdxEnrollment(enrollment *models.EdxEnrollmentCore) (err error)
+	GetEdxEnrollmentById(enrollmentId string) (enrollment *models.EdxEnrollmentCore, err error)
+	GetEdxEnrollmentsByStudentId(studentId string) (enrollments []*models.EdxEnrollmentCore, err error)
+	GetEdxEnrollmentsByRobboGroupId(robboGroupId string) (enrollments []*models.EdxEnrollmentCore, err error)
 }
@@ -0,0 +1,284 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"time"
+)
+
+// desiredEnrollments selects every (student_id, course_id) pair that should be enrolled on edX:
+// active group members and students whose primary group is the group, times the courses of
+// every packet attached to the group.
+const desiredEnrollments = `
+SELECT m.student_id AS student_id, pc.course_id AS course_id
+FROM group_membership_dbs m
+JOIN robbo_group_course_packet_dbs gp ON gp.robbo_group_id = m.robbo_group_id AND gp.deleted_at IS NULL
+JOIN course_packet_course_dbs pc ON pc.course_packet_id = gp.course_packet_id AND pc.deleted_at IS NULL
+WHERE m.left_at IS NULL AND m.deleted_at IS NULL
+UNION
+SELECT CAST(s.id AS text) AS student_id, pc.course_id AS course_id
+FROM student_dbs s
+JOIN robbo_group_course_packet_dbs gp ON gp.robbo_group_id = CAST(s.robbo_group_id AS text) AND gp.deleted_at IS NULL
+JOIN course_packet_course_dbs pc ON pc.course_packet_id = gp.course_packet_id AND pc.deleted_at IS NULL
+WHERE s.deleted_at IS NULL AND COALESCE(s.merged_into_id, 0) = 0`
+
+func (r *CoursePacketGatewayImpl) AddCourseToCoursePacket(coursePacketId, courseId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		relation := models.CoursePacketCourseDB{CoursePacketId: coursePacketId, CourseId: courseId}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&relation).Error
+	})
+	return
+}
+
+func (r *CoursePacketGatewayImpl) RemoveCourseFromCoursePacket(coursePacketId, courseId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Unscoped().Where("course_packet_id = ? AND course_id = ?", coursePacketId, courseId).
+			Delete(&models.CoursePacketCourseDB{}).Error; err != nil {
+			return
+		}
+		return markUnwantedEnrollments(tx, []string{courseId})
+	})
+	return
+}
+
+func (r *CoursePacketGatewayImpl) GetCourseIdsByCoursePacketId(coursePacketId string) (courseIds []string, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		courseIds, err = courseIdsOf(tx, coursePacketId)
+		return
+	})
+	return
+}
+
+func (r *CoursePacketGatewayImpl) AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId string) (relation *models.RobboGroupCoursePacketCore, err error) {
+	relationDb := models.RobboGroupCoursePacketDB{RobboGroupId: robboGroupId, CoursePacketId: coursePacketId}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", coursePacketId).First(&models.CoursePacketDB{}).Error; err != nil {
+			return
+		}
+		if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&relationDb).Error; err != nil {
+			return
+		}
+		return tx.Where("robbo_group_id = ? AND course_packet_id = ?", robboGroupId, coursePacketId).
+			First(&relationDb).Error
+	})
+	if err != nil {
+		return
+	}
+	relation = relationDb.ToCore()
+	return
+}
+
+// DetachCoursePacketFromRobboGroup removes the link and queues unenrollment for the packet courses
+// that group members no longer get through any other packet.
+func (r *CoursePacketGatewayImpl) DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Unscoped().Where("robbo_group_id = ? AND course_packet_id = ?", robboGroupId, coursePacketId).
+			Delete(&models.RobboGroupCoursePacketDB{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		courseIds, err := courseIdsOf(tx, coursePacketId)
+		if err != nil {
+			return
+		}
+		return markUnwantedEnrollments(tx, courseIds)
+	})
+	return
+}
+
+func (r *CoursePacketGatewayImpl) GetCoursePacketsByRobboGroupId(robboGroupId string) (relations []*models.RobboGroupCoursePacketCore, err error) {
+	var relationsDb []*models.RobboGroupCoursePacketDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("robbo_group_id = ?", robboGroupId).Order("created_at").Find(&relationsDb).Error
+	})
+	for _, relationDb := range relationsDb {
+		relations = append(relations, relationDb.ToCore())
+	}
+	return
+}
+
+// EnqueueMissingEdxEnrollments creates pending enroll jobs for desired pairs that have none yet
+// and turns queued or finished unenrollments of such pairs back into enrollments.
+func (r *CoursePacketGatewayImpl) EnqueueMissingEdxEnrollments() (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Exec(`
+INSERT INTO edx_enrollment_dbs (created_at, updated_at, student_id, course_id, action, status, attempts, next_attempt_at, last_error)
+SELECT now(), now(), d.student_id, d.course_id, @enroll, @pending, 0, now(), ''
+FROM (`+desiredEnrollments+`) d
+ON CONFLICT (student_id, course_id) DO UPDATE
+SET action = @enroll, status = @pending, attempts = 0, next_attempt_at = now(), last_error = '', updated_at = now(), deleted_at = NULL
+WHERE edx_enrollment_dbs.action = @unenroll OR edx_enrollment_dbs.deleted_at IS NOT NULL`,
+			map[string]interface{}{
+				"enroll":   string(models.EdxEnroll),
+				"unenroll": string(models.EdxUnenroll),
+				"pending":  string(models.EdxEnrollmentPending),
+			}).Error
+	})
+	return
+}
+
+// EnqueueUnwantedEdxUnenrollments queues unenrollment for the enroll jobs of students who no
+// longer get the course through any group, such as students removed from or transferred out of
+// a group with a packet.
+func (r *CoursePacketGatewayImpl) EnqueueUnwantedEdxUnenrollments() (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return markUnwantedEnrollments(tx, nil)
+	})
+	return
+}
+
+// ClaimDueEdxEnrollments marks up to limit due jobs as running and returns them. Jobs left running
+// since before staleBefore belong to a worker that died and are claimed again.
+// Concurrent workers never get the same job.
+func (r *CoursePacketGatewayImpl) ClaimDueEdxEnrollments(limit int, staleBefore time.Time) (enrollments []*models.EdxEnrollmentCore, err error) {
+	var enrollmentsDb []*models.EdxEnrollmentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Exec(`
+UPDATE edx_enrollment_dbs SET status = ?, updated_at = now()
+WHERE status = ? AND updated_at < ? AND deleted_at IS NULL`,
+			string(models.EdxEnrollmentPending), string(models.EdxEnrollmentRunning), staleBefore).Error; err != nil {
+			return
+		}
+		if err = tx.Raw(`
+UPDATE edx_enrollment_dbs SET status = ?, updated_at = now()
+WHERE id IN (
+	SELECT id FROM edx_enrollment_dbs
+	WHERE status = ? AND next_attempt_at <= now() AND deleted_at IS NULL
+	ORDER BY next_attempt_at
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`,
+			string(models.EdxEnrollmentRunning), string(models.EdxEnrollmentPending), limit).Scan(&enrollmentsDb).Error; err != nil {
+			return
+		}
+
+		studentIds := make([]string, 0, len(enrollmentsDb))
+		for _, enrollmentDb := range enrollmentsDb {
+			studentIds = append(studentIds, enrollmentDb.StudentId)
+		}
+		var studentsDb []*models.StudentDB
+		if len(studentIds) > 0 {
+			if err = tx.Unscoped().Where("id IN ?", studentIds).Find(&studentsDb).Error; err != nil {
+				return
+			}
+		}
+		usernames := make(map[string]string, len(studentsDb))
+		for _, studentDb := range studentsDb {
+			usernames[strconv.FormatUint(uint64(studentDb.ID), 10)] = studentDb.Nickname
+		}
+		for _, enrollmentDb := range enrollmentsDb {
+			enrollment := enrollmentDb.ToCore()
+			enrollment.Username = usernames[enrollment.StudentId]
+			enrollments = append(enrollments, enrollment)
+		}
+		return
+	})
+	return
+}
+
+func (r *CoursePacketGatewayImpl) UpdateEdxEnrollment(enrollment *models.EdxEnrollmentCore) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.EdxEnrollmentDB{}).Where("id = ?", enrollment.Id).Updates(map[string]interface{}{
+			"status":          string(enrollment.Status),
+			"attempts":        enrollment.Attempts,
+			"next_attempt_at": enrollment.NextAttemptAt,
+			"last_error":      enrollment.LastError,
+		}).Error
+	})
+	return
+}
+
+func (r *CoursePacketGatewayImpl) GetEdxEnrollmentById(enrollmentId string) (enrollment *models.EdxEnrollmentCore, err error) {
+	var enrollmentDb models.EdxEnrollmentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", enrollmentId).First(&enrollmentDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return coursePacket.ErrEnrollmentNotFound
+			}
+			return
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	enrollment = enrollmentDb.ToCore()
+	return
+}
+
+func (r *CoursePacketGatewayImpl) GetEdxEnrollmentsByStudentId(studentId string) (enrollments []*models.EdxEnrollmentCore, err error) {
+	var enrollmentsDb []*models.EdxEnrollmentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("student_id = ?", studentId).Order("course_id").Find(&enrollmentsDb).Error
+	})
+	for _, enrollmentDb := range enrollmentsDb {
+		enrollments = append(enrollments, enrollmentDb.ToCore())
+	}
+	return
+}
+
+func (r *CoursePacketGatewayImpl) GetEdxEnrollmentsByRobboGroupId(robboGroupId string) (enrollments []*models.EdxEnrollmentCore, err error) {
+	var enrollmentsDb []*models.EdxEnrollmentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		activeMembers := tx.Model(&models.GroupMembershipDB{}).
+			Select("student_id").
+			Where("robbo_group_id = ? AND left_at IS NULL", robboGroupId)
+		primaryMembers := tx.Model(&models.StudentDB{}).
+			Select("CAST(id AS text)").
+			Where("robbo_group_id = ?", robboGroupId)
+		courses := tx.Model(&models.CoursePacketCourseDB{}).
+			Select("course_packet_course_dbs.course_id").
+			Joins("JOIN robbo_group_course_packet_dbs gp ON gp.course_packet_id = course_packet_course_dbs.course_packet_id AND gp.deleted_at IS NULL").
+			Where("gp.robbo_group_id = ?", robboGroupId)
+		return tx.Where("(student_id IN (?) OR student_id IN (?)) AND course_id IN (?)", activeMembers, primaryMembers, courses).
+			Order("student_id, course_id").
+			Find(&enrollmentsDb).Error
+	})
+	for _, enrollmentDb := range enrollmentsDb {
+		enrollments = append(enrollments, enrollmentDb.ToCore())
+	}
+	return
+}
+
+func courseIdsOf(tx *gorm.DB, coursePacketId string) (courseIds []string, err error) {
+	err = tx.Model(&models.CoursePacketCourseDB{}).
+		Where("course_packet_id = ?", coursePacketId).
+		Order("course_id").
+		Pluck("course_id", &courseIds).Error
+	return
+}
+
+// markUnwantedEnrollments turns enroll jobs for the given courses, or for every course when
+// courseIds is nil, into pending unenrollments unless the student still gets the course through
+// some group packet.
+func markUnwantedEnrollments(tx *gorm.DB, courseIds []string) (err error) {
+	if courseIds != nil && len(courseIds) == 0 {
+		return
+	}
+	courses := ""
+	if courseIds != nil {
+		courses = " AND e.course_id IN @courseIds"
+	}
+	return tx.Exec(`
+UPDATE edx_enrollment_dbs e
+SET action = @unenroll, status = @pending, attempts = 0, next_attempt_at = now(), last_error = '', updated_at = now()
+WHERE e.action = @enroll AND e.deleted_at IS NULL`+courses+`
+AND NOT EXISTS (
+	SELECT 1 FROM (`+desiredEnrollments+`) d
+	WHERE d.student_id = e.student_id AND d.course_id = e.course_id
+)`,
+		map[string]interface{}{
+			"enroll":    string(models.EdxEnroll),
+			"unenroll":  string(models.EdxUnenroll),
+			"pending":   string(models.EdxEnrollmentPending),
+			"courseIds": courseIds,
+		}).Error
+}
@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEnqueueUnwantedEdxUnenrollments(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &CoursePacketGatewayImpl{PostgresClient: postgresClient}
+
+	assert.NoError(t, gateway.EnqueueUnwantedEdxUnenrollments())
+	updates := recorder.Find("UPDATE edx_enrollment_dbs e", "NOT EXISTS", "group_membership_dbs m")
+	if assert.Len(t, updates, 1) {
+		assert.NotContains(t, updates[0].SQL, "e.course_id IN", "every course is swept")
+		assert.Contains(t, updates[0].Args, string(models.EdxUnenroll))
+	}
+}
+
+func TestRemoveCourseFromCoursePacketUnenrollsTheCourse(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &CoursePacketGatewayImpl{PostgresClient: postgresClient}
+
+	assert.NoError(t, gateway.RemoveCourseFromCoursePacket("1", "course-v1:robbo+1"))
+	updates := recorder.Find("UPDATE edx_enrollment_dbs e", "e.course_id IN")
+	if assert.Len(t, updates, 1) {
+		assert.Contains(t, updates[0].Args, "course-v1:robbo+1")
+	}
+}
@@ -8,4 +8,19 @@ type UseCase interface {
 	UpdateCoursePacket(coursePacket *models.CoursePacketCore) (err error)
 	GetAllCoursePackets() (coursePackets []*models.CoursePacketCore, err error)
 	GetCoursePacketById(coursePacketId string) (coursePacket *models.CoursePacketCore, err error)
+
+	AddCourseToCoursePacket(coursePacketId, courseId string) (courseIds []string, err error)
+	RemoveCourseFromCoursePacket(coursePacketId, courseId string) (courseIds []string, err error)
+	GetCourseIdsByCoursePacketId(coursePacketId string) (courseIds []string, err error)
+	AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId string) (relation *models.RobboGroupCoursePacketCore, err error)
+	DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId string) (err error)
+	GetCoursePacketsByRobboGroupId(robboGroupId string) (relations []*models.RobboGroupCoursePacketCore, err error)
+
+	SyncEdxEnrollments() (err error)
+	ClaimDueEdxEnrollments() (enrollments []*models.EdxEnrollmentCore, err error)
+	CompleteEdxEnrollment(enrollment *models.EdxEnrollmentCore, edxErr error) (err error)
+	RetryEdxEnrollment(enrollmentId string) (enrollment *models.EdxEnrollmentCore, err error)
+	GetEdxEnrollmentById(enrollmentId string) (enrollment *models.EdxEnrollmentCore, err error)
+	GetEdxEnrollmentsByStudentId(studentId string) (enrollments []*models.EdxEnrollmentCore, err error)
+	GetEdxEnrollmentsByRobboGroupId(robboGroupId string) (enrollments []*models.EdxEnrollmentCore, err error)
 }
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type enrollmentPolicy struct {
+	batchSize   int
+	maxAttempts int
+	retryDelay  time.Duration
+	staleAfter  time.Duration
+}
+
+// finish records the outcome of one edX call. A failed call is retried with exponential
+// backoff until maxAttempts is reached, after which the job stays failed until retried by hand.
+func (e enrollmentPolicy) finish(enrollment *models.EdxEnrollmentCore, edxErr error, now time.Time) {
+	enrollment.Attempts++
+	if edxErr == nil {
+		enrollment.Status = models.EdxEnrollmentDone
+		enrollment.LastError = ""
+		return
+	}
+	enrollment.LastError = edxErr.Error()
+	if len(enrollment.LastError) > 1024 {
+		enrollment.LastError = enrollment.LastError[:1024]
+	}
+	if enrollment.Attempts >= e.maxAttempts {
+		enrollment.Status = models.EdxEnrollmentFailed
+		return
+	}
+	enrollment.Status = models.EdxEnrollmentPending
+	enrollment.NextAttemptAt = now.Add(e.retryDelay << (enrollment.Attempts - 1))
+}
+
+func (p *CoursePacketUseCaseImpl) AddCourseToCoursePacket(coursePacketId, courseId string) (courseIds []string, err error) {
+	if _, err = p.Gateway.GetCoursePacketById(coursePacketId); err != nil {
+		return
+	}
+	if err = p.Gateway.AddCourseToCoursePacket(coursePacketId, courseId); err != nil {
+		return
+	}
+	if err = p.Gateway.EnqueueMissingEdxEnrollments(); err != nil {
+		return
+	}
+	return p.Gateway.GetCourseIdsByCoursePacketId(coursePacketId)
+}
+
+func (p *CoursePacketUseCaseImpl) RemoveCourseFromCoursePacket(coursePacketId, courseId string) (courseIds []string, err error) {
+	if err = p.Gateway.RemoveCourseFromCoursePacket(coursePacketId, courseId); err != nil {
+		return
+	}
+	return p.Gateway.GetCourseIdsByCoursePacketId(coursePacketId)
+}
+
+func (p *CoursePacketUseCaseImpl) GetCourseIdsByCoursePacketId(coursePacketId string) (courseIds []string, err error) {
+	return p.Gateway.GetCourseIdsByCoursePacketId(coursePacketId)
+}
+
+func (p *CoursePacketUseCaseImpl) AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId string) (relation *models.RobboGroupCoursePacketCore, err error) {
+	relation, err = p.Gateway.AttachCoursePacketToRobboGroup(robboGroupId, coursePacketId)
+	if err != nil {
+		return
+	}
+	err = p.Gateway.EnqueueMissingEdxEnrollments()
+	return
+}
+
+func (p *CoursePacketUseCaseImpl) DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId string) (err error) {
+	return p.Gateway.DetachCoursePacketFromRobboGroup(robboGroupId, coursePacketId)
+}
+
+func (p *CoursePacketUseCaseImpl) GetCoursePacketsByRobboGroupId(robboGroupId string) (relations []*models.RobboGroupCoursePacketCore, err error) {
+	return p.Gateway.GetCoursePacketsByRobboGroupId(robboGroupId)
+}
+
+// SyncEdxEnrollments picks up students who joined groups with attached packets since the last run
+// and the students who left them.
+func (p *CoursePacketUseCaseImpl) SyncEdxEnrollments() (err error) {
+	if err = p.Gateway.EnqueueMissingEdxEnrollments(); err != nil {
+		return
+	}
+	return p.Gateway.EnqueueUnwantedEdxUnenrollments()
+}
+
+func (p *CoursePacketUseCaseImpl) ClaimDueEdxEnrollments() (enrollments []*models.EdxEnrollmentCore, err error) {
+	return p.Gateway.ClaimDueEdxEnrollments(p.enrollmentPolicy.batchSize, time.Now().Add(-p.enrollmentPolicy.staleAfter))
+}
+
+func (p *CoursePacketUseCaseImpl) CompleteEdxEnrollment(enrollment *models.EdxEnrollmentCore, edxErr error) (err error) {
+	p.enrollmentPolicy.finish(enrollment, edxErr, time.Now())
+	return p.Gateway.UpdateEdxEnrollment(enrollment)
+}
+
+func (p *CoursePacketUseCaseImpl) RetryEdxEnrollment(enrollmentId string) (enrollment *models.EdxEnrollmentCore, err error) {
+	enrollment, err = p.Gateway.GetEdxEnrollmentById(enrollmentId)
+	if err != nil {
+		return
+	}
+	if enrollment.Status != models.EdxEnrollmentFailed {
+		return nil, coursePacket.ErrEnrollmentNotFailed
+	}
+	enrollment.Status = models.EdxEnrollmentPending
+	enrollment.Attempts = 0
+	enrollment.NextAttemptAt = time.Now()
+	if err = p.Gateway.UpdateEdxEnrollment(enrollment); err != nil {
+		return
+	}
+	return
+}
+
+func (p *CoursePacketUseCaseImpl) GetEdxEnrollmentById(enrollmentId string) (enrollment *models.EdxEnrollmentCore, err error) {
+	return p.Gateway.GetEdxEnrollmentById(enrollmentId)
+}
+
+func (p *CoursePacketUseCaseImpl) GetEdxEnrollmentsByStudentId(studentId string) (enrollments []*models.EdxEnrollmentCore, err error) {
+	return p.Gateway.GetEdxEnrollmentsByStudentId(studentId)
+}
+
+func (p *CoursePacketUseCaseImpl) GetEdxEnrollmentsByRobboGroupId(robboGroupId string) (enrollments []*models.EdxEnrollmentCore, err error) {
+	return p.Gateway.GetEdxEnrollmentsByRobboGroupId(robboGroupId)
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEnrollmentPolicyFinish(t *testing.T) {
+	policy := enrollmentPolicy{maxAttempts: 3, retryDelay: time.Minute}
+	now := time.Date(2022, 10, 3, 10, 0, 0, 0, time.UTC)
+	enrollment := &models.EdxEnrollmentCore{Status: models.EdxEnrollmentRunning}
+
+	policy.finish(enrollment, errors.New("edx is down"), now)
+	assert.Equal(t, models.EdxEnrollmentPending, enrollment.Status)
+	assert.Equal(t, now.Add(time.Minute), enrollment.NextAttemptAt)
+	assert.Equal(t, "edx is down", enrollment.LastError)
+
+	policy.finish(enrollment, errors.New("edx is down"), now)
+	assert.Equal(t, now.Add(2*time.Minute), enrollment.NextAttemptAt)
+
+	policy.finish(enrollment, errors.New("edx is down"), now)
+	assert.Equal(t, models.EdxEnrollmentFailed, enrollment.Status)
+	assert.Equal(t, 3, enrollment.Attempts)
+
+	succeeded := &models.EdxEnrollmentCore{Status: models.EdxEnrollmentRunning, Attempts: 1, LastError: "timeout"}
+	policy.finish(succeeded, nil, now)
+	assert.Equal(t, models.EdxEnrollmentDone, succeeded.Status)
+	assert.Equal(t, 2, succeeded.Attempts)
+	assert.Empty(t, succeeded.LastError)
+}
+
+type fakeEnrollments struct {
+	coursePacket.Gateway
+	enrollment *models.EdxEnrollmentCore
+	updated    []*models.EdxEnrollmentCore
+	synced     []string
+}
+
+func (f *fakeEnrollments) GetEdxEnrollmentById(enrollmentId string) (*models.EdxEnrollmentCore, error) {
+	copied := *f.enrollment
+	return &copied, nil
+}
+
+func (f *fakeEnrollments) UpdateEdxEnrollment(enrollment *models.EdxEnrollmentCore) error {
+	f.updated = append(f.updated, enrollment)
+	return nil
+}
+
+func (f *fakeEnrollments) EnqueueMissingEdxEnrollments() error {
+	f.synced = append(f.synced, "enroll")
+	return nil
+}
+
+func (f *fakeEnrollments) EnqueueUnwantedEdxUnenrollments() error {
+	f.synced = append(f.synced, "unenroll")
+	return nil
+}
+
+func TestRetryEdxEnrollment(t *testing.T) {
+	gateway := &fakeEnrollments{enrollment: &models.EdxEnrollmentCore{
+		Id: "1", Status: models.EdxEnrollmentFailed, Attempts: 3, LastError: "edx is down",
+	}}
+	p := &CoursePacketUseCaseImpl{Gateway: gateway}
+
+	enrollment, err := p.RetryEdxEnrollment("1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.EdxEnrollmentPending, enrollment.Status)
+	assert.Zero(t, enrollment.Attempts)
+	assert.WithinDuration(t, time.Now(), enrollment.NextAttemptAt, time.Minute)
+	assert.Len(t, gateway.updated, 1)
+
+	gateway.enrollment.Status = models.EdxEnrollmentRunning
+	_, err = p.RetryEdxEnrollment("1")
+	assert.Equal(t, coursePacket.ErrEnrollmentNotFailed, err)
+	assert.Len(t, gateway.updated, 1, "a job that didn't fail is left alone")
+}
+
+func TestSyncEdxEnrollmentsQueuesUnenrollments(t *testing.T) {
+	gateway := &fakeEnrollments{}
+	p := &CoursePacketUseCaseImpl{Gateway: gateway}
+
+	assert.NoError(t, p.SyncEdxEnrollments())
+	assert.Equal(t, []string{"enroll", "unenroll"}, gateway.synced)
+}
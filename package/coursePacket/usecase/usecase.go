@@ -5,11 +5,14 @@ import (
 
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
 	"go.uber.org/fx"
+	"time"
 )
 
 type CoursePacketUseCaseImpl struct {
 	coursePacket.Gateway
+	enrollmentPolicy enrollmentPolicy
 }
 
 type CoursePacketUseCaseModule struct {
@@ -21,6 +24,12 @@ func SetupCoursePacketUseCase(gateway coursePacket.Gateway) CoursePacketUseCaseM
 	return CoursePacketUseCaseModule{
 		UseCase: &CoursePacketUseCaseImpl{
 			Gateway: gateway,
+			enrollmentPolicy: enrollmentPolicy{
+				batchSize:   viper.GetInt("coursePacket.enrollment_batch_size"),
+				maxAttempts: viper.GetInt("coursePacket.enrollment_max_attempts"),
+				retryDelay:  time.Duration(viper.GetInt("coursePacket.enrollment_retry_delay")) * time.Second,
+				staleAfter:  time.Duration(viper.GetInt("coursePacket.enrollment_stale_after")) * time.Second,
+			},
 		},
 	}
 }
@@ -0,0 +1,8 @@
+package dashboard
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	GetRobboUnitDashboard(robboUnitId string, from, to, granularity *string) (dashboard *models.RobboUnitDashboardHTTP, err error)
+	GetRegionReport(regionId string, from, to *string) (report *models.RegionReportHTTP, err error)
+}
@@ -0,0 +1,76 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"time"
+)
+
+type DashboardDelegateImpl struct {
+	UseCase dashboard.UseCase
+}
+
+type DashboardDelegateModule struct {
+	fx.Out
+	dashboard.Delegate
+}
+
+func SetupDashboardDelegate(usecase dashboard.UseCase) DashboardDelegateModule {
+	return DashboardDelegateModule{
+		Delegate: &DashboardDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *DashboardDelegateImpl) GetRobboUnitDashboard(robboUnitId string, from, to, granularity *string) (unitDashboard *models.RobboUnitDashboardHTTP, err error) {
+	fromTime, err := parseTimestamp(from)
+	if err != nil {
+		return
+	}
+	toTime, err := parseTimestamp(to)
+	if err != nil {
+		return
+	}
+	var dashboardGranularity models.DashboardGranularity
+	if granularity != nil {
+		dashboardGranularity = models.DashboardGranularity(*granularity)
+	}
+	dashboardCore, err := p.UseCase.GetRobboUnitDashboard(robboUnitId, fromTime, toTime, dashboardGranularity)
+	if err != nil {
+		return
+	}
+	unitDashboard = &models.RobboUnitDashboardHTTP{}
+	unitDashboard.FromCore(dashboardCore)
+	return
+}
+
+func (p *DashboardDelegateImpl) GetRegionReport(regionId string, from, to *string) (report *models.RegionReportHTTP, err error) {
+	fromTime, err := parseTimestamp(from)
+	if err != nil {
+		return
+	}
+	toTime, err := parseTimestamp(to)
+	if err != nil {
+		return
+	}
+	reportCore, err := p.UseCase.GetRegionReport(regionId, fromTime, toTime)
+	if err != nil {
+		return
+	}
+	report = &models.RegionReportHTTP{}
+	report.FromCore(reportCore)
+	return
+}
+
+func parseTimestamp(timestamp *string) (parsed *time.Time, err error) {
+	if timestamp == nil {
+		return
+	}
+	parsedTime, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return nil, dashboard.ErrBadTimestampFormat
+	}
+	return &parsedTime, nil
+}
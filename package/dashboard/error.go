@@ -0,0 +1,10 @@
+package dashboard
+
+import "errors"
+
+var (
+	ErrBadTimestampFormat = errors.New("timestamp must be in RFC3339 format")
+	ErrBadTimeRange       = errors.New("end must be after start")
+	ErrBadGranularity     = errors.New("granularity must be one of day, week, month")
+	ErrTooManyBuckets     = errors.New("period is too long for the chosen granularity")
+)
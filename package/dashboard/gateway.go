@@ -0,0 +1,14 @@
+package dashboard
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type Gateway interface {
+	GetRobboUnitCounters(robboUnitId string, from, to time.Time) (counters *models.DashboardCountersCore, err error)
+	GetRobboGroupStats(robboUnitId string, from, to time.Time) (groups []*models.RobboGroupStatsCore, err error)
+	GetRegionCounters(regionId string, from, to time.Time) (counters *models.DashboardCountersCore, err error)
+	GetRobboUnitStats(regionId string, from, to time.Time) (units []*models.RobboUnitStatsCore, err error)
+	GetRobboUnitTrend(robboUnitId string, from, to time.Time, granularity models.DashboardGranularity) (trend []*models.DashboardTrendPointCore, err error)
+}
@@ -0,0 +1,302 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"time"
+)
+
+type DashboardGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type DashboardGatewayModule struct {
+	fx.Out
+	dashboard.Gateway
+}
+
+func SetupDashboardGateway(postgresClient db_client.PostgresClient) DashboardGatewayModule {
+	return DashboardGatewayModule{
+		Gateway: &DashboardGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+// Dashboard queries start with a scope: ug holds the live groups of the unit or of the region,
+// those of archived terms left out, gs pairs each of them with its current students, legacy
+// primary group students included,
+// am holds the attendance marks of the lessons of the period dated by the lessons as they are now,
+// act keeps the pairs of gs whose student signed in, attended a lesson or worked on a project
+// during the period.
+const (
+	byUnit   = `robbo_unit_id = @unit`
+	byRegion = `robbo_unit_id IN (SELECT CAST(id AS text) FROM robbo_unit_dbs WHERE region_id = @region AND deleted_at IS NULL)`
+)
+
+func scope(unitFilter string) string {
+	return `
+WITH ug AS (
+	SELECT id, CAST(id AS text) AS group_id, name, robbo_unit_id FROM robbo_group_dbs
+	WHERE ` + unitFilter + ` AND deleted_at IS NULL AND archived_at IS NULL
+), gs AS (
+	SELECT m.robbo_group_id AS group_id, m.student_id AS student_id, ug.robbo_unit_id AS unit_id
+	FROM group_membership_dbs m
+	JOIN ug ON ug.group_id = m.robbo_group_id
+	WHERE m.left_at IS NULL AND m.deleted_at IS NULL
+	UNION
+	SELECT ug.group_id, CAST(s.id AS text), ug.robbo_unit_id
+	FROM student_dbs s
+	JOIN ug ON ug.id = s.robbo_group_id
+	WHERE s.deleted_at IS NULL AND COALESCE(s.merged_into_id, 0) = 0
+), am AS (
+	SELECT a.robbo_group_id, a.student_id, a.status, l.start_at AS lesson_start_at
+	FROM attendance_dbs a
+	JOIN lesson_dbs l ON CAST(l.id AS text) = a.lesson_id AND l.deleted_at IS NULL
+	WHERE a.deleted_at IS NULL AND l.start_at >= @from AND l.start_at < @to
+), act AS (
+	SELECT gs.group_id, gs.student_id, gs.unit_id FROM gs
+	WHERE EXISTS (
+		SELECT 1 FROM login_event_dbs le
+		WHERE le.user_id = gs.student_id AND le.role = @student AND le.success AND le.deleted_at IS NULL
+		AND le.created_at >= @from AND le.created_at < @to
+	) OR EXISTS (
+		SELECT 1 FROM am WHERE am.student_id = gs.student_id AND am.status IN @attended
+	) OR EXISTS (
+		SELECT 1 FROM project_dbs p
+		WHERE p.author_id = gs.student_id AND p.author_role = @student AND p.deleted_at IS NULL
+		AND p.updated_at >= @from AND p.updated_at < @to
+	)
+)`
+}
+
+// countersQuery totals the scope, a student or a teacher working in several groups is counted once.
+// Projects are counted for the students of the scope only, staff of the same id are not students.
+// Pages shared before sharing was dated have no sharing date, so shared counts the projects created in the period that are shared now.
+const countersQuery = `
+SELECT st.students, ac.active_students, gr."groups", t.teachers, a.attendance_marks, a.attended,
+	p.projects_created, p.projects_shared, e.edx_enrolled, e.edx_pending, e.edx_failed
+FROM (SELECT COUNT(DISTINCT student_id) AS students FROM gs) st
+CROSS JOIN (SELECT COUNT(DISTINCT student_id) AS active_students FROM act) ac
+CROSS JOIN (SELECT COUNT(*) AS "groups" FROM ug) gr
+CROSS JOIN (
+	SELECT COUNT(DISTINCT t.teacher_id) AS teachers
+	FROM teachers_robbo_groups_dbs t JOIN ug ON ug.group_id = t.robbo_group_id
+	WHERE t.deleted_at IS NULL
+) t
+CROSS JOIN (
+	SELECT COUNT(*) AS attendance_marks, COUNT(*) FILTER (WHERE a.status IN @attended) AS attended
+	FROM am a JOIN ug ON ug.group_id = a.robbo_group_id
+) a
+CROSS JOIN (
+	SELECT COUNT(*) AS projects_created, COUNT(*) FILTER (WHERE EXISTS (
+		SELECT 1 FROM project_page_dbs pp
+		WHERE pp.project_id = CAST(p.id AS text) AND pp.is_shared AND pp.deleted_at IS NULL
+	)) AS projects_shared
+	FROM project_dbs p
+	WHERE p.deleted_at IS NULL AND p.created_at >= @from AND p.created_at < @to
+	AND p.author_role = @student AND p.author_id IN (SELECT student_id FROM gs)
+) p
+CROSS JOIN (
+	SELECT COUNT(*) FILTER (WHERE e.action = @enroll AND e.status = @done) AS edx_enrolled,
+		COUNT(*) FILTER (WHERE e.status IN @inProgress) AS edx_pending,
+		COUNT(*) FILTER (WHERE e.status = @failed) AS edx_failed
+	FROM edx_enrollment_dbs e
+	WHERE e.deleted_at IS NULL AND e.student_id IN (SELECT student_id FROM gs)
+) e`
+
+func (r *DashboardGatewayImpl) GetRobboUnitCounters(robboUnitId string, from, to time.Time) (counters *models.DashboardCountersCore, err error) {
+	args := queryArgs(from, to)
+	args["unit"] = robboUnitId
+	counters = &models.DashboardCountersCore{}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(scope(byUnit)+countersQuery, args).Scan(counters).Error
+	})
+	return
+}
+
+func (r *DashboardGatewayImpl) GetRegionCounters(regionId string, from, to time.Time) (counters *models.DashboardCountersCore, err error) {
+	args := queryArgs(from, to)
+	args["region"] = regionId
+	counters = &models.DashboardCountersCore{}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(scope(byRegion)+countersQuery, args).Scan(counters).Error
+	})
+	return
+}
+
+// GetRobboUnitStats breaks the region down by unit, units without groups included.
+func (r *DashboardGatewayImpl) GetRobboUnitStats(regionId string, from, to time.Time) (units []*models.RobboUnitStatsCore, err error) {
+	args := queryArgs(from, to)
+	args["region"] = regionId
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(scope(byRegion)+`
+SELECT CAST(u.id AS text) AS robbo_unit_id, u.name,
+	COALESCE(gr."groups", 0) AS "groups",
+	COALESCE(st.students, 0) AS students,
+	COALESCE(ac.active_students, 0) AS active_students,
+	COALESCE(t.teachers, 0) AS teachers,
+	COALESCE(a.attendance_marks, 0) AS attendance_marks,
+	COALESCE(a.attended, 0) AS attended,
+	COALESCE(p.projects_created, 0) AS projects_created,
+	COALESCE(p.projects_shared, 0) AS projects_shared,
+	COALESCE(e.edx_enrolled, 0) AS edx_enrolled,
+	COALESCE(e.edx_pending, 0) AS edx_pending,
+	COALESCE(e.edx_failed, 0) AS edx_failed
+FROM robbo_unit_dbs u
+LEFT JOIN (
+	SELECT robbo_unit_id, COUNT(*) AS "groups" FROM ug GROUP BY robbo_unit_id
+) gr ON gr.robbo_unit_id = CAST(u.id AS text)
+LEFT JOIN (
+	SELECT unit_id, COUNT(DISTINCT student_id) AS students FROM gs GROUP BY unit_id
+) st ON st.unit_id = CAST(u.id AS text)
+LEFT JOIN (
+	SELECT unit_id, COUNT(DISTINCT student_id) AS active_students FROM act GROUP BY unit_id
+) ac ON ac.unit_id = CAST(u.id AS text)
+LEFT JOIN (
+	SELECT ug.robbo_unit_id, COUNT(DISTINCT t.teacher_id) AS teachers
+	FROM teachers_robbo_groups_dbs t JOIN ug ON ug.group_id = t.robbo_group_id
+	WHERE t.deleted_at IS NULL
+	GROUP BY ug.robbo_unit_id
+) t ON t.robbo_unit_id = CAST(u.id AS text)
+LEFT JOIN (
+	SELECT ug.robbo_unit_id, COUNT(*) AS attendance_marks, COUNT(*) FILTER (WHERE a.status IN @attended) AS attended
+	FROM am a JOIN ug ON ug.group_id = a.robbo_group_id
+	GROUP BY ug.robbo_unit_id
+) a ON a.robbo_unit_id = CAST(u.id AS text)
+LEFT JOIN (
+	SELECT us.unit_id, COUNT(*) AS projects_created, COUNT(*) FILTER (WHERE EXISTS (
+		SELECT 1 FROM project_page_dbs pp
+		WHERE pp.project_id = CAST(p.id AS text) AND pp.is_shared AND pp.deleted_at IS NULL
+	)) AS projects_shared
+	FROM (SELECT DISTINCT unit_id, student_id FROM gs) us
+	JOIN project_dbs p ON p.author_id = us.student_id AND p.author_role = @student
+	WHERE p.deleted_at IS NULL AND p.created_at >= @from AND p.created_at < @to
+	GROUP BY us.unit_id
+) p ON p.unit_id = CAST(u.id AS text)
+LEFT JOIN (
+	SELECT us.unit_id,
+		COUNT(*) FILTER (WHERE e.action = @enroll AND e.status = @done) AS edx_enrolled,
+		COUNT(*) FILTER (WHERE e.status IN @inProgress) AS edx_pending,
+		COUNT(*) FILTER (WHERE e.status = @failed) AS edx_failed
+	FROM (SELECT DISTINCT unit_id, student_id FROM gs) us JOIN edx_enrollment_dbs e ON e.student_id = us.student_id
+	WHERE e.deleted_at IS NULL
+	GROUP BY us.unit_id
+) e ON e.unit_id = CAST(u.id AS text)
+WHERE u.region_id = @region AND u.deleted_at IS NULL
+ORDER BY u.name`, args).Scan(&units).Error
+	})
+	return
+}
+
+func (r *DashboardGatewayImpl) GetRobboGroupStats(robboUnitId string, from, to time.Time) (groups []*models.RobboGroupStatsCore, err error) {
+	args := queryArgs(from, to)
+	args["unit"] = robboUnitId
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(scope(byUnit)+`
+SELECT ug.group_id AS robbo_group_id, ug.name, 1 AS "groups",
+	COALESCE(st.students, 0) AS students,
+	COALESCE(ac.active_students, 0) AS active_students,
+	COALESCE(t.teachers, 0) AS teachers,
+	COALESCE(a.attendance_marks, 0) AS attendance_marks,
+	COALESCE(a.attended, 0) AS attended,
+	COALESCE(p.projects_created, 0) AS projects_created,
+	COALESCE(p.projects_shared, 0) AS projects_shared,
+	COALESCE(e.edx_enrolled, 0) AS edx_enrolled,
+	COALESCE(e.edx_pending, 0) AS edx_pending,
+	COALESCE(e.edx_failed, 0) AS edx_failed
+FROM ug
+LEFT JOIN (
+	SELECT group_id, COUNT(DISTINCT student_id) AS students FROM gs GROUP BY group_id
+) st ON st.group_id = ug.group_id
+LEFT JOIN (
+	SELECT group_id, COUNT(DISTINCT student_id) AS active_students FROM act GROUP BY group_id
+) ac ON ac.group_id = ug.group_id
+LEFT JOIN (
+	SELECT robbo_group_id, COUNT(DISTINCT teacher_id) AS teachers
+	FROM teachers_robbo_groups_dbs WHERE deleted_at IS NULL GROUP BY robbo_group_id
+) t ON t.robbo_group_id = ug.group_id
+LEFT JOIN (
+	SELECT robbo_group_id, COUNT(*) AS attendance_marks, COUNT(*) FILTER (WHERE status IN @attended) AS attended
+	FROM am
+	GROUP BY robbo_group_id
+) a ON a.robbo_group_id = ug.group_id
+LEFT JOIN (
+	SELECT gs.group_id, COUNT(*) AS projects_created, COUNT(*) FILTER (WHERE EXISTS (
+		SELECT 1 FROM project_page_dbs pp
+		WHERE pp.project_id = CAST(p.id AS text) AND pp.is_shared AND pp.deleted_at IS NULL
+	)) AS projects_shared
+	FROM gs JOIN project_dbs p ON p.author_id = gs.student_id AND p.author_role = @student
+	WHERE p.deleted_at IS NULL AND p.created_at >= @from AND p.created_at < @to
+	GROUP BY gs.group_id
+) p ON p.group_id = ug.group_id
+LEFT JOIN (
+	SELECT gs.group_id,
+		COUNT(*) FILTER (WHERE e.action = @enroll AND e.status = @done) AS edx_enrolled,
+		COUNT(*) FILTER (WHERE e.status IN @inProgress) AS edx_pending,
+		COUNT(*) FILTER (WHERE e.status = @failed) AS edx_failed
+	FROM gs JOIN edx_enrollment_dbs e ON e.student_id = gs.student_id
+	WHERE e.deleted_at IS NULL
+	GROUP BY gs.group_id
+) e ON e.group_id = ug.group_id
+ORDER BY ug.name`, args).Scan(&groups).Error
+	})
+	return
+}
+
+// GetRobboUnitTrend returns one point per day, week or month of the period, empty buckets included.
+func (r *DashboardGatewayImpl) GetRobboUnitTrend(robboUnitId string, from, to time.Time, granularity models.DashboardGranularity) (trend []*models.DashboardTrendPointCore, err error) {
+	args := queryArgs(from, to)
+	args["unit"] = robboUnitId
+	args["granularity"] = string(granularity)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(scope(byUnit)+`, buckets AS (
+	SELECT generate_series(
+		date_trunc(@granularity, CAST(@from AS timestamptz)),
+		CAST(@to AS timestamptz) - interval '1 microsecond',
+		CAST('1 ' || @granularity AS interval)
+	) AS period_start
+)
+SELECT b.period_start,
+	COALESCE(a.attendance_marks, 0) AS attendance_marks,
+	COALESCE(a.attended, 0) AS attended,
+	COALESCE(p.projects_created, 0) AS projects_created,
+	COALESCE(j.students_joined, 0) AS students_joined
+FROM buckets b
+LEFT JOIN (
+	SELECT date_trunc(@granularity, a.lesson_start_at) AS period_start,
+		COUNT(*) AS attendance_marks, COUNT(*) FILTER (WHERE a.status IN @attended) AS attended
+	FROM am a JOIN ug ON ug.group_id = a.robbo_group_id
+	GROUP BY 1
+) a ON a.period_start = b.period_start
+LEFT JOIN (
+	SELECT date_trunc(@granularity, p.created_at) AS period_start, COUNT(*) AS projects_created
+	FROM project_dbs p
+	WHERE p.deleted_at IS NULL AND p.created_at >= @from AND p.created_at < @to
+	AND p.author_role = @student AND p.author_id IN (SELECT student_id FROM gs)
+	GROUP BY 1
+) p ON p.period_start = b.period_start
+LEFT JOIN (
+	SELECT date_trunc(@granularity, m.joined_at) AS period_start, COUNT(*) AS students_joined
+	FROM group_membership_dbs m JOIN ug ON ug.group_id = m.robbo_group_id
+	WHERE m.deleted_at IS NULL AND m.joined_at >= @from AND m.joined_at < @to
+	GROUP BY 1
+) j ON j.period_start = b.period_start
+ORDER BY b.period_start`, args).Scan(&trend).Error
+	})
+	return
+}
+
+func queryArgs(from, to time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"from":       from,
+		"to":         to,
+		"student":    models.Student,
+		"attended":   []string{string(models.AttendancePresent), string(models.AttendanceLate)},
+		"enroll":     string(models.EdxEnroll),
+		"done":       string(models.EdxEnrollmentDone),
+		"inProgress": []string{string(models.EdxEnrollmentPending), string(models.EdxEnrollmentRunning)},
+		"failed":     string(models.EdxEnrollmentFailed),
+	}
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDashboardCountsStudentProjectsOnly(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &DashboardGatewayImpl{PostgresClient: postgresClient}
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	_, err = gateway.GetRobboUnitCounters("1", from, to)
+	assert.NoError(t, err)
+	_, err = gateway.GetRobboGroupStats("1", from, to)
+	assert.NoError(t, err)
+	_, err = gateway.GetRobboUnitStats("1", from, to)
+	assert.NoError(t, err)
+	_, err = gateway.GetRobboUnitTrend("1", from, to, models.DashboardWeek)
+	assert.NoError(t, err)
+
+	statements := recorder.Statements()
+	if assert.Len(t, statements, 4) {
+		for _, statement := range statements {
+			// every join of project_dbs is bound to the student role
+			assert.Equal(t, strings.Count(statement.SQL, "FROM project_dbs p")+strings.Count(statement.SQL, "JOIN project_dbs p"),
+				strings.Count(statement.SQL, "p.author_role = "), statement.SQL)
+			assert.Contains(t, statement.Args, models.Student)
+		}
+	}
+}
+
+func TestDashboardTellsActiveStudentsFromMembers(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(func(query string, args []interface{}) ([]string, [][]interface{}) {
+		return []string{"students", "active_students", "groups"}, [][]interface{}{{int64(12), int64(5), int64(2)}}
+	})
+	assert.NoError(t, err)
+	gateway := &DashboardGatewayImpl{PostgresClient: postgresClient}
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	counters, err := gateway.GetRobboUnitCounters("1", from, from.AddDate(0, 1, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, 12, counters.Students)
+	assert.Equal(t, 5, counters.ActiveStudents)
+
+	queries := recorder.Find("act AS (", "login_event_dbs", "COUNT(DISTINCT student_id) AS active_students FROM act")
+	assert.Len(t, queries, 1)
+}
+
+func TestDashboardLeavesOutArchivedGroups(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &DashboardGatewayImpl{PostgresClient: postgresClient}
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err = gateway.GetRobboUnitCounters("1", from, from.AddDate(0, 1, 0))
+	assert.NoError(t, err)
+	_, err = gateway.GetRegionCounters("2", from, from.AddDate(0, 1, 0))
+	assert.NoError(t, err)
+
+	scoped := recorder.Find("WITH ug AS (", "FROM robbo_group_dbs", "archived_at IS NULL")
+	assert.Len(t, scoped, 2, "the groups of a term rolled over are not counted again")
+}
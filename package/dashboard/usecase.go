@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type UseCase interface {
+	GetRobboUnitDashboard(robboUnitId string, from, to *time.Time, granularity models.DashboardGranularity) (dashboard *models.RobboUnitDashboardCore, err error)
+	GetRegionReport(regionId string, from, to *time.Time) (report *models.RegionReportCore, err error)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"time"
+)
+
+// maxTrendPoints keeps a year of daily points while refusing periods that would
+// make the trend query generate thousands of buckets.
+const maxTrendPoints = 400
+
+type DashboardUseCaseImpl struct {
+	dashboardGateway  dashboard.Gateway
+	defaultPeriodDays int
+}
+
+type DashboardUseCaseModule struct {
+	fx.Out
+	dashboard.UseCase
+}
+
+func SetupDashboardUseCase(dashboardGateway dashboard.Gateway) DashboardUseCaseModule {
+	return DashboardUseCaseModule{
+		UseCase: &DashboardUseCaseImpl{
+			dashboardGateway:  dashboardGateway,
+			defaultPeriodDays: viper.GetInt("dashboard.default_period_days"),
+		},
+	}
+}
+
+func (p *DashboardUseCaseImpl) GetRobboUnitDashboard(robboUnitId string, from, to *time.Time, granularity models.DashboardGranularity) (unitDashboard *models.RobboUnitDashboardCore, err error) {
+	fromTime, toTime, granularity, err := resolvePeriod(from, to, granularity, time.Now(), p.defaultPeriodDays)
+	if err != nil {
+		return
+	}
+	unitDashboard = &models.RobboUnitDashboardCore{
+		RobboUnitId: robboUnitId,
+		From:        fromTime,
+		To:          toTime,
+		Granularity: granularity,
+	}
+	if unitDashboard.Totals, err = p.dashboardGateway.GetRobboUnitCounters(robboUnitId, fromTime, toTime); err != nil {
+		return
+	}
+	if unitDashboard.Groups, err = p.dashboardGateway.GetRobboGroupStats(robboUnitId, fromTime, toTime); err != nil {
+		return
+	}
+	unitDashboard.Trend, err = p.dashboardGateway.GetRobboUnitTrend(robboUnitId, fromTime, toTime, granularity)
+	return
+}
+
+// GetRegionReport rolls the unit figures up to the region, the unit dashboards break them down further by group.
+func (p *DashboardUseCaseImpl) GetRegionReport(regionId string, from, to *time.Time) (report *models.RegionReportCore, err error) {
+	// the report has no trend, monthly buckets only keep the bucket limit out of the way of long periods
+	fromTime, toTime, _, err := resolvePeriod(from, to, models.DashboardMonth, time.Now(), p.defaultPeriodDays)
+	if err != nil {
+		return
+	}
+	report = &models.RegionReportCore{
+		RegionId: regionId,
+		From:     fromTime,
+		To:       toTime,
+	}
+	if report.Totals, err = p.dashboardGateway.GetRegionCounters(regionId, fromTime, toTime); err != nil {
+		return
+	}
+	report.Units, err = p.dashboardGateway.GetRobboUnitStats(regionId, fromTime, toTime)
+	return
+}
+
+// resolvePeriod fills in the defaults: the period ends now, lasts defaultDays and is split into weeks.
+func resolvePeriod(from, to *time.Time, granularity models.DashboardGranularity, now time.Time, defaultDays int) (fromTime, toTime time.Time, resolved models.DashboardGranularity, err error) {
+	toTime = now
+	if to != nil {
+		toTime = *to
+	}
+	fromTime = toTime.AddDate(0, 0, -defaultDays)
+	if from != nil {
+		fromTime = *from
+	}
+	if !toTime.After(fromTime) {
+		err = dashboard.ErrBadTimeRange
+		return
+	}
+	resolved = granularity
+	if resolved == "" {
+		resolved = models.DashboardWeek
+	}
+	if !resolved.IsValid() {
+		err = dashboard.ErrBadGranularity
+		return
+	}
+	if trendPoints(fromTime, toTime, resolved) > maxTrendPoints {
+		err = dashboard.ErrTooManyBuckets
+	}
+	return
+}
+
+func trendPoints(from, to time.Time, granularity models.DashboardGranularity) int {
+	days := int(to.Sub(from).Hours()/24) + 1
+	switch granularity {
+	case models.DashboardDay:
+		return days
+	case models.DashboardWeek:
+		return days/7 + 1
+	default:
+		return days/28 + 1
+	}
+}
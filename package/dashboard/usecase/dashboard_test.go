@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestResolvePeriod(t *testing.T) {
+	now := time.Date(2022, 10, 31, 12, 0, 0, 0, time.UTC)
+
+	from, to, granularity, err := resolvePeriod(nil, nil, "", now, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, now, to)
+	assert.Equal(t, time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, models.DashboardWeek, granularity)
+
+	start := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	from, _, granularity, err = resolvePeriod(&start, nil, models.DashboardDay, now, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, start, from)
+	assert.Equal(t, models.DashboardDay, granularity)
+
+	_, _, _, err = resolvePeriod(&now, &start, "", now, 30)
+	assert.Equal(t, dashboard.ErrBadTimeRange, err)
+
+	_, _, _, err = resolvePeriod(nil, nil, "year", now, 30)
+	assert.Equal(t, dashboard.ErrBadGranularity, err)
+
+	longAgo := now.AddDate(-3, 0, 0)
+	_, _, _, err = resolvePeriod(&longAgo, nil, models.DashboardDay, now, 30)
+	assert.Equal(t, dashboard.ErrTooManyBuckets, err)
+	_, _, _, err = resolvePeriod(&longAgo, nil, models.DashboardMonth, now, 30)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,204 @@
+// Package dbtest lets the tests of the gateways see the SQL they run without a database.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Statement is a query or command run through the Recorder with the values of its placeholders.
+type Statement struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Rows answers a query with the names of the columns and the rows, a query it returns no columns
+// for finds nothing. Commands are passed to it too, so that fakes can follow what was stored,
+// the rows it returns for them are ignored.
+type Rows func(query string, args []interface{}) (columns []string, rows [][]interface{})
+
+// Recorder is a database/sql driver that records every statement and stores nothing.
+// Commands affect one row each, queries are answered by Rows.
+type Recorder struct {
+	mutex      sync.Mutex
+	statements []Statement
+	rows       Rows
+}
+
+// Open connects gorm to a new Recorder the way db_client connects it to postgres.
+func Open(rows Rows) (*db_client.PostgresClient, *Recorder, error) {
+	recorder := &Recorder{rows: rows}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(recorder)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &db_client.PostgresClient{Db: db}, recorder, nil
+}
+
+// Statements lists what was run so far in the order it was run in.
+func (r *Recorder) Statements() []Statement {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Statement(nil), r.statements...)
+}
+
+// Find lists the statements whose SQL contains every one of parts.
+func (r *Recorder) Find(parts ...string) (found []Statement) {
+	for _, statement := range r.Statements() {
+		matches := true
+		for _, part := range parts {
+			if !strings.Contains(statement.SQL, part) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			found = append(found, statement)
+		}
+	}
+	return
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) []interface{} {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	r.mutex.Lock()
+	r.statements = append(r.statements, Statement{SQL: query, Args: values})
+	r.mutex.Unlock()
+	return values
+}
+
+func (r *Recorder) Connect(context.Context) (driver.Conn, error) {
+	return &conn{recorder: r}, nil
+}
+
+func (r *Recorder) Driver() driver.Driver {
+	return driverOf{r}
+}
+
+type driverOf struct {
+	recorder *Recorder
+}
+
+func (d driverOf) Open(string) (driver.Conn, error) {
+	return &conn{recorder: d.recorder}, nil
+}
+
+type conn struct {
+	recorder *Recorder
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return tx{}, nil
+}
+
+// CheckNamedValue keeps the values as they are, they are only recorded.
+func (c *conn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := c.recorder.record(query, args)
+	if c.recorder.rows != nil {
+		c.recorder.rows(query, values)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := c.recorder.record(query, args)
+	answer := &rows{}
+	if c.recorder.rows != nil {
+		answer.columns, answer.values = c.recorder.rows(query, values)
+	}
+	return answer, nil
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, named(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, named(args))
+}
+
+func named(args []driver.Value) []driver.NamedValue {
+	namedArgs := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		namedArgs[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return namedArgs
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+type rows struct {
+	columns []string
+	values  [][]interface{}
+	next    int
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	for i, value := range r.values[r.next] {
+		dest[i] = value
+	}
+	r.next++
+	return nil
+}
@@ -56,10 +56,64 @@ func (c *PostgresClient) Migrate() (err error) {
 		&models.FreeListenerDB{},
 		&models.ChildrenOfParentDB{},
 		&models.RobboUnitDB{},
+		&models.RobboUnitPhoneDB{},
 		&models.CoursePacketDB{},
 		&models.RobboGroupDB{},
 		&models.UnitAdminsRobboUnitsDB{},
 		&models.TeachersRobboGroupsDB{},
+		&models.LoginEventDB{},
+		&models.ScheduleSlotDB{},
+		&models.LessonDB{},
+		&models.AttendanceDB{},
+		&models.NotificationDB{},
+		&models.GroupMembershipDB{},
+		&models.WaitlistEntryDB{},
+		&models.CoursePacketCourseDB{},
+		&models.RobboGroupCoursePacketDB{},
+		&models.EdxEnrollmentDB{},
+		&models.TermDB{},
+		&models.RegionDB{},
+		&models.RegionAdminDB{},
+		&models.ProjectRevisionDB{},
+		&models.AssetDB{},
+		&models.StorageBlobDB{},
+		&models.ProjectLikeDB{},
+		&models.ProjectFavoriteDB{},
+		&models.ProjectTagDB{},
+		&models.ProjectViewDB{},
+		&models.ProjectCommentDB{},
+		&models.CommentReportDB{},
+		&models.AssignmentDB{},
+		&models.AssignmentWorkDB{},
+		&models.AssignmentReviewDB{},
 	)
+	if err != nil {
+		return
+	}
+	// attendance used to keep a copy of the start of its lesson, which went stale when the lesson was rescheduled
+	if c.Db.Migrator().HasColumn(&models.AttendanceDB{}, "lesson_start_at") {
+		if err = c.Db.Migrator().DropColumn(&models.AttendanceDB{}, "lesson_start_at"); err != nil {
+			return
+		}
+	}
+	// views used to be counted once per fixed bucket of the view window
+	if c.Db.Migrator().HasColumn(&models.ProjectViewDB{}, "bucket") {
+		if err = c.Db.Migrator().DropColumn(&models.ProjectViewDB{}, "bucket"); err != nil {
+			return
+		}
+	}
+	// unit phones used to be stored comma separated in the unit itself
+	if c.Db.Migrator().HasColumn(&models.RobboUnitDB{}, "phones") {
+		err = c.Db.Transaction(func(tx *gorm.DB) (err error) {
+			if err = tx.Exec(`
+INSERT INTO robbo_unit_phone_dbs (robbo_unit_id, position, phone)
+SELECT u.id, p.position - 1, LEFT(TRIM(p.phone), 64)
+FROM robbo_unit_dbs u, unnest(string_to_array(u.phones, ',')) WITH ORDINALITY AS p(phone, position)
+WHERE TRIM(p.phone) <> ''`).Error; err != nil {
+				return
+			}
+			return tx.Migrator().DropColumn(&models.RobboUnitDB{}, "phones")
+		})
+	}
 	return
 }
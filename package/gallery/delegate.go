@@ -0,0 +1,16 @@
+package gallery
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	GetGallery(viewerId string, viewerRole models.Role, robboUnitId, robboGroupId, tag, from, to, sort *string, page, pageSize *int) (galleryPage *models.GalleryPageHTTP, err error)
+	GetGalleryProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectHTTP, err error)
+	GetFavoriteProjects(userId string, userRole models.Role) (projects []*models.GalleryProjectHTTP, err error)
+
+	LikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error)
+	UnlikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error)
+	AddProjectToFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error)
+	RemoveProjectFromFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error)
+	ViewProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectHTTP, err error)
+	SetProjectTags(projectId string, tags []string) (normalized []string, err error)
+}
@@ -0,0 +1,129 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/gallery"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"time"
+)
+
+type GalleryDelegateImpl struct {
+	gallery.UseCase
+}
+
+type GalleryDelegateModule struct {
+	fx.Out
+	gallery.Delegate
+}
+
+func SetupGalleryDelegate(usecase gallery.UseCase) GalleryDelegateModule {
+	return GalleryDelegateModule{
+		Delegate: &GalleryDelegateImpl{
+			UseCase: usecase,
+		},
+	}
+}
+
+func (p *GalleryDelegateImpl) GetGallery(
+	viewerId string,
+	viewerRole models.Role,
+	robboUnitId, robboGroupId, tag, from, to, sort *string,
+	page, pageSize *int,
+) (galleryPage *models.GalleryPageHTTP, err error) {
+	filter := &models.GalleryFilterCore{
+		RobboUnitId:  valueOf(robboUnitId),
+		RobboGroupId: valueOf(robboGroupId),
+		Tag:          valueOf(tag),
+		Sort:         models.GallerySort(valueOf(sort)),
+		ViewerId:     viewerId,
+		ViewerRole:   viewerRole,
+	}
+	if page != nil {
+		filter.Page = *page
+	}
+	if pageSize != nil {
+		filter.PageSize = *pageSize
+	}
+	if filter.From, err = parseTimestamp(from); err != nil {
+		return
+	}
+	if filter.To, err = parseTimestamp(to); err != nil {
+		return
+	}
+	pageCore, err := p.UseCase.GetGallery(filter)
+	if err != nil {
+		return
+	}
+	galleryPage = &models.GalleryPageHTTP{}
+	galleryPage.FromCore(pageCore)
+	return
+}
+
+func (p *GalleryDelegateImpl) GetGalleryProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectHTTP, err error) {
+	return toHttp(p.UseCase.GetGalleryProject(projectId, viewerId, viewerRole))
+}
+
+func (p *GalleryDelegateImpl) GetFavoriteProjects(userId string, userRole models.Role) (projects []*models.GalleryProjectHTTP, err error) {
+	projectsCore, err := p.UseCase.GetFavoriteProjects(userId, userRole)
+	if err != nil {
+		return
+	}
+	projects = make([]*models.GalleryProjectHTTP, 0, len(projectsCore))
+	for _, projectCore := range projectsCore {
+		project := &models.GalleryProjectHTTP{}
+		project.FromCore(projectCore)
+		projects = append(projects, project)
+	}
+	return
+}
+
+func (p *GalleryDelegateImpl) LikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error) {
+	return toHttp(p.UseCase.LikeProject(projectId, userId, userRole))
+}
+
+func (p *GalleryDelegateImpl) UnlikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error) {
+	return toHttp(p.UseCase.UnlikeProject(projectId, userId, userRole))
+}
+
+func (p *GalleryDelegateImpl) AddProjectToFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error) {
+	return toHttp(p.UseCase.AddProjectToFavorites(projectId, userId, userRole))
+}
+
+func (p *GalleryDelegateImpl) RemoveProjectFromFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectHTTP, err error) {
+	return toHttp(p.UseCase.RemoveProjectFromFavorites(projectId, userId, userRole))
+}
+
+func (p *GalleryDelegateImpl) ViewProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectHTTP, err error) {
+	return toHttp(p.UseCase.ViewProject(projectId, viewerId, viewerRole))
+}
+
+func (p *GalleryDelegateImpl) SetProjectTags(projectId string, tags []string) (normalized []string, err error) {
+	return p.UseCase.SetProjectTags(projectId, tags)
+}
+
+func toHttp(projectCore *models.GalleryProjectCore, err error) (project *models.GalleryProjectHTTP, _ error) {
+	if err != nil {
+		return nil, err
+	}
+	project = &models.GalleryProjectHTTP{}
+	project.FromCore(projectCore)
+	return project, nil
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func parseTimestamp(timestamp *string) (parsed *time.Time, err error) {
+	if timestamp == nil {
+		return
+	}
+	parsedTime, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return nil, gallery.ErrBadTimestampFormat
+	}
+	return &parsedTime, nil
+}
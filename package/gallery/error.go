@@ -0,0 +1,13 @@
+package gallery
+
+import "errors"
+
+var (
+	ErrProjectNotFound    = errors.New("project is not in the gallery")
+	ErrBadTimeRange       = errors.New("end must be after start")
+	ErrBadSort            = errors.New("sort must be one of newest, likes, views")
+	ErrBadPage            = errors.New("page and page size must be positive")
+	ErrBadTimestampFormat = errors.New("timestamp must be in RFC3339 format")
+	ErrBadTag             = errors.New("tags are up to 32 letters, digits, dashes or underscores")
+	ErrTooManyTags        = errors.New("too many tags")
+)
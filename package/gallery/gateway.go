@@ -0,0 +1,19 @@
+package gallery
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type Gateway interface {
+	GetGallery(filter *models.GalleryFilterCore) (page *models.GalleryPageCore, err error)
+	GetGalleryProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectCore, err error)
+	GetFavoriteProjects(userId string, userRole models.Role) (projects []*models.GalleryProjectCore, err error)
+
+	LikeProject(projectId, userId string, userRole models.Role) (err error)
+	UnlikeProject(projectId, userId string, userRole models.Role) (err error)
+	AddProjectToFavorites(projectId, userId string, userRole models.Role) (err error)
+	RemoveProjectFromFavorites(projectId, userId string, userRole models.Role) (err error)
+	RecordView(projectId, viewerId string, viewerRole models.Role, since time.Time) (err error)
+	SetProjectTags(projectId string, tags []string) (err error)
+}
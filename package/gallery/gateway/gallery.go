@@ -0,0 +1,281 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/gallery"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+type GalleryGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type GalleryGatewayModule struct {
+	fx.Out
+	gallery.Gateway
+}
+
+func SetupGalleryGateway(postgresClient db_client.PostgresClient) GalleryGatewayModule {
+	return GalleryGatewayModule{
+		Gateway: &GalleryGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+// Pages shared before sharing was dated fall back to their last update.
+const galleryQuery = `
+SELECT pp.project_id, pp.title, p.author_id, pp.preview, pp.link_scratch,
+	COALESCE(pp.shared_at, pp.updated_at) AS shared_at,
+	COALESCE((SELECT string_agg(t.tag, ',' ORDER BY t.tag) FROM project_tag_dbs t WHERE t.project_id = pp.project_id), '') AS tags,
+	(SELECT COUNT(*) FROM project_like_dbs l WHERE l.project_id = pp.project_id) AS likes,
+	(SELECT COUNT(*) FROM project_favorite_dbs f WHERE f.project_id = pp.project_id) AS favorites,
+	(SELECT COUNT(*) FROM project_view_dbs v WHERE v.project_id = pp.project_id) AS views,
+	EXISTS (
+		SELECT 1 FROM project_like_dbs l
+		WHERE l.project_id = pp.project_id AND l.user_id = @viewer AND l.user_role = @viewerRole
+	) AS liked_by_me,
+	EXISTS (
+		SELECT 1 FROM project_favorite_dbs f
+		WHERE f.project_id = pp.project_id AND f.user_id = @viewer AND f.user_role = @viewerRole
+	) AS favorited_by_me`
+
+func galleryFrom(joins string) string {
+	return `
+FROM project_page_dbs pp
+JOIN project_dbs p ON CAST(p.id AS text) = pp.project_id AND p.deleted_at IS NULL` + joins + `
+WHERE pp.is_shared AND pp.deleted_at IS NULL`
+}
+
+const viewerFavorites = `
+JOIN project_favorite_dbs mf
+	ON mf.project_id = pp.project_id AND mf.user_id = @viewer AND mf.user_role = @viewerRole`
+
+// Students are found the way the dashboard finds them: active memberships plus the legacy primary group.
+// Only projects of students are matched, the ids of the other roles overlap with theirs.
+const (
+	byRobboGroup = `
+	AND p.author_role = @student AND p.author_id IN (
+		SELECT student_id FROM group_membership_dbs
+		WHERE robbo_group_id = @group AND left_at IS NULL AND deleted_at IS NULL
+		UNION
+		SELECT CAST(id AS text) FROM student_dbs WHERE CAST(robbo_group_id AS text) = @group AND deleted_at IS NULL
+	)`
+	byRobboUnit = `
+	AND p.author_role = @student AND p.author_id IN (
+		SELECT student_id FROM group_membership_dbs
+		WHERE robbo_unit_id = @unit AND left_at IS NULL AND deleted_at IS NULL
+		UNION
+		SELECT CAST(id AS text) FROM student_dbs WHERE CAST(robbo_unit_id AS text) = @unit AND deleted_at IS NULL
+	)`
+	byTag = `
+	AND EXISTS (SELECT 1 FROM project_tag_dbs t WHERE t.project_id = pp.project_id AND t.tag = @tag)`
+)
+
+var galleryOrder = map[models.GallerySort]string{
+	models.GalleryNewest:     ` ORDER BY shared_at DESC, pp.id DESC`,
+	models.GalleryMostLiked:  ` ORDER BY likes DESC, shared_at DESC, pp.id DESC`,
+	models.GalleryMostViewed: ` ORDER BY views DESC, shared_at DESC, pp.id DESC`,
+}
+
+type galleryRow struct {
+	ProjectId     string
+	Title         string
+	AuthorId      string
+	Preview       string
+	LinkScratch   string
+	SharedAt      time.Time
+	Tags          string
+	Likes         int
+	Favorites     int
+	Views         int
+	LikedByMe     bool
+	FavoritedByMe bool
+}
+
+func (row *galleryRow) toCore() *models.GalleryProjectCore {
+	tags := []string{}
+	if row.Tags != "" {
+		tags = strings.Split(row.Tags, ",")
+	}
+	return &models.GalleryProjectCore{
+		ProjectId:     row.ProjectId,
+		Title:         row.Title,
+		AuthorId:      row.AuthorId,
+		Preview:       row.Preview,
+		LinkScratch:   row.LinkScratch,
+		Tags:          tags,
+		SharedAt:      row.SharedAt,
+		Likes:         row.Likes,
+		Favorites:     row.Favorites,
+		Views:         row.Views,
+		LikedByMe:     row.LikedByMe,
+		FavoritedByMe: row.FavoritedByMe,
+	}
+}
+
+func viewerArgs(viewerId string, viewerRole models.Role) map[string]interface{} {
+	return map[string]interface{}{
+		"viewer":     viewerId,
+		"viewerRole": uint(viewerRole),
+	}
+}
+
+func (r *GalleryGatewayImpl) GetGallery(filter *models.GalleryFilterCore) (page *models.GalleryPageCore, err error) {
+	args := viewerArgs(filter.ViewerId, filter.ViewerRole)
+	conditions := ""
+	if filter.RobboGroupId != "" {
+		conditions += byRobboGroup
+		args["group"] = filter.RobboGroupId
+		args["student"] = models.Student
+	}
+	if filter.RobboUnitId != "" {
+		conditions += byRobboUnit
+		args["unit"] = filter.RobboUnitId
+		args["student"] = models.Student
+	}
+	if filter.Tag != "" {
+		conditions += byTag
+		args["tag"] = filter.Tag
+	}
+	if filter.From != nil {
+		conditions += ` AND COALESCE(pp.shared_at, pp.updated_at) >= @from`
+		args["from"] = *filter.From
+	}
+	if filter.To != nil {
+		conditions += ` AND COALESCE(pp.shared_at, pp.updated_at) < @to`
+		args["to"] = *filter.To
+	}
+	args["limit"] = filter.PageSize
+	args["offset"] = (filter.Page - 1) * filter.PageSize
+
+	page = &models.GalleryPageCore{
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}
+	var total int64
+	var rows []*galleryRow
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Raw(`SELECT COUNT(*)`+galleryFrom("")+conditions, args).Scan(&total).Error; err != nil {
+			return
+		}
+		return tx.Raw(galleryQuery+galleryFrom("")+conditions+galleryOrder[filter.Sort]+` LIMIT @limit OFFSET @offset`, args).
+			Scan(&rows).Error
+	})
+	if err != nil {
+		return
+	}
+	page.Total = int(total)
+	page.Projects = make([]*models.GalleryProjectCore, 0, len(rows))
+	for _, row := range rows {
+		page.Projects = append(page.Projects, row.toCore())
+	}
+	return
+}
+
+func (r *GalleryGatewayImpl) GetGalleryProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectCore, err error) {
+	args := viewerArgs(viewerId, viewerRole)
+	args["project"] = projectId
+	var rows []*galleryRow
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(galleryQuery+galleryFrom("")+` AND pp.project_id = @project`, args).Scan(&rows).Error
+	})
+	if err != nil {
+		return
+	}
+	if len(rows) == 0 {
+		return nil, gallery.ErrProjectNotFound
+	}
+	return rows[0].toCore(), nil
+}
+
+// GetFavoriteProjects lists the favorites that are still shared, the latest added first.
+func (r *GalleryGatewayImpl) GetFavoriteProjects(userId string, userRole models.Role) (projects []*models.GalleryProjectCore, err error) {
+	var rows []*galleryRow
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(galleryQuery+galleryFrom(viewerFavorites)+` ORDER BY mf.created_at DESC`, viewerArgs(userId, userRole)).
+			Scan(&rows).Error
+	})
+	if err != nil {
+		return
+	}
+	projects = make([]*models.GalleryProjectCore, 0, len(rows))
+	for _, row := range rows {
+		projects = append(projects, row.toCore())
+	}
+	return
+}
+
+func (r *GalleryGatewayImpl) LikeProject(projectId, userId string, userRole models.Role) (err error) {
+	like := models.ProjectLikeDB{ProjectId: projectId, UserId: userId, UserRole: uint(userRole)}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&like).Error
+	})
+	return
+}
+
+func (r *GalleryGatewayImpl) UnlikeProject(projectId, userId string, userRole models.Role) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("project_id = ? AND user_id = ? AND user_role = ?", projectId, userId, uint(userRole)).
+			Delete(&models.ProjectLikeDB{}).Error
+	})
+	return
+}
+
+func (r *GalleryGatewayImpl) AddProjectToFavorites(projectId, userId string, userRole models.Role) (err error) {
+	favorite := models.ProjectFavoriteDB{ProjectId: projectId, UserId: userId, UserRole: uint(userRole)}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&favorite).Error
+	})
+	return
+}
+
+func (r *GalleryGatewayImpl) RemoveProjectFromFavorites(projectId, userId string, userRole models.Role) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("project_id = ? AND user_id = ? AND user_role = ?", projectId, userId, uint(userRole)).
+			Delete(&models.ProjectFavoriteDB{}).Error
+	})
+	return
+}
+
+// RecordView does nothing when a view of the viewer has been counted since the given time.
+// The lock keeps two reloads that come in together from both being counted.
+func (r *GalleryGatewayImpl) RecordView(projectId, viewerId string, viewerRole models.Role, since time.Time) (err error) {
+	args := viewerArgs(viewerId, viewerRole)
+	args["project"] = projectId
+	args["since"] = since
+	args["now"] = time.Now()
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext(@project || '/' || @viewer))`, args).Error; err != nil {
+			return
+		}
+		return tx.Exec(`
+INSERT INTO project_view_dbs (project_id, viewer_id, viewer_role, created_at)
+SELECT @project, @viewer, @viewerRole, @now
+WHERE NOT EXISTS (
+	SELECT 1 FROM project_view_dbs
+	WHERE project_id = @project AND viewer_id = @viewer AND viewer_role = @viewerRole AND created_at > @since
+)`, args).Error
+	})
+	return
+}
+
+func (r *GalleryGatewayImpl) SetProjectTags(projectId string, tags []string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("project_id = ?", projectId).Delete(&models.ProjectTagDB{}).Error; err != nil {
+			return
+		}
+		if len(tags) == 0 {
+			return
+		}
+		tagsDb := make([]models.ProjectTagDB, 0, len(tags))
+		for _, tag := range tags {
+			tagsDb = append(tagsDb, models.ProjectTagDB{ProjectId: projectId, Tag: tag})
+		}
+		return tx.Create(&tagsDb).Error
+	})
+	return
+}
@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRecordViewSlidingWindow(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &GalleryGatewayImpl{PostgresClient: postgresClient}
+
+	since := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, gateway.RecordView("7", "1", models.Student, since))
+
+	assert.Len(t, recorder.Find("pg_advisory_xact_lock"), 1)
+	inserts := recorder.Find("INSERT INTO project_view_dbs", "NOT EXISTS", "created_at >")
+	if assert.Len(t, inserts, 1) {
+		assert.Contains(t, inserts[0].Args, since, "the view window ends at the given time, not at a bucket boundary")
+	}
+}
+
+func TestGalleryByRobboGroupMatchesStudents(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &GalleryGatewayImpl{PostgresClient: postgresClient}
+
+	_, err = gateway.GetGallery(&models.GalleryFilterCore{RobboGroupId: "3", Sort: models.GalleryNewest, Page: 1, PageSize: 20})
+	assert.NoError(t, err)
+	selects := recorder.Find("group_membership_dbs")
+	if assert.Len(t, selects, 2) {
+		for _, statement := range selects {
+			assert.Contains(t, statement.SQL, "p.author_role = ")
+			assert.Contains(t, statement.Args, models.Student)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package gallery
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type UseCase interface {
+	GetGallery(filter *models.GalleryFilterCore) (page *models.GalleryPageCore, err error)
+	GetGalleryProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectCore, err error)
+	GetFavoriteProjects(userId string, userRole models.Role) (projects []*models.GalleryProjectCore, err error)
+
+	LikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error)
+	UnlikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error)
+	AddProjectToFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error)
+	RemoveProjectFromFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error)
+	ViewProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectCore, err error)
+	SetProjectTags(projectId string, tags []string) (normalized []string, err error)
+}
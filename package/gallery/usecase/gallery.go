@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/gallery"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}_-]{1,32}$`)
+
+type GalleryUseCaseImpl struct {
+	galleryGateway gallery.Gateway
+	pageSize       int
+	maxPageSize    int
+	viewWindow     time.Duration
+	maxTags        int
+}
+
+type GalleryUseCaseModule struct {
+	fx.Out
+	gallery.UseCase
+}
+
+func SetupGalleryUseCase(galleryGateway gallery.Gateway) GalleryUseCaseModule {
+	return GalleryUseCaseModule{
+		UseCase: &GalleryUseCaseImpl{
+			galleryGateway: galleryGateway,
+			pageSize:       viper.GetInt("gallery.page_size"),
+			maxPageSize:    viper.GetInt("gallery.max_page_size"),
+			viewWindow:     time.Duration(viper.GetInt("gallery.view_window_hours")) * time.Hour,
+			maxTags:        viper.GetInt("gallery.max_tags"),
+		},
+	}
+}
+
+func (p *GalleryUseCaseImpl) GetGallery(filter *models.GalleryFilterCore) (page *models.GalleryPageCore, err error) {
+	if err = p.resolveFilter(filter); err != nil {
+		return
+	}
+	return p.galleryGateway.GetGallery(filter)
+}
+
+// resolveFilter fills in the defaults: the newest projects first, the first page of the configured size.
+// Larger pages are cut down to the maximum rather than refused.
+func (p *GalleryUseCaseImpl) resolveFilter(filter *models.GalleryFilterCore) error {
+	if filter.Sort == "" {
+		filter.Sort = models.GalleryNewest
+	}
+	if !filter.Sort.IsValid() {
+		return gallery.ErrBadSort
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = p.pageSize
+	}
+	if filter.Page < 0 || filter.PageSize < 0 {
+		return gallery.ErrBadPage
+	}
+	if p.maxPageSize > 0 && filter.PageSize > p.maxPageSize {
+		filter.PageSize = p.maxPageSize
+	}
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return gallery.ErrBadTimeRange
+	}
+	filter.Tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(filter.Tag), "#"))
+	return nil
+}
+
+func (p *GalleryUseCaseImpl) GetGalleryProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectCore, err error) {
+	return p.galleryGateway.GetGalleryProject(projectId, viewerId, viewerRole)
+}
+
+func (p *GalleryUseCaseImpl) GetFavoriteProjects(userId string, userRole models.Role) (projects []*models.GalleryProjectCore, err error) {
+	return p.galleryGateway.GetFavoriteProjects(userId, userRole)
+}
+
+// LikeProject and the other reactions only apply to projects in the gallery, repeating one is a no-op.
+func (p *GalleryUseCaseImpl) LikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error) {
+	return p.react(projectId, userId, userRole, p.galleryGateway.LikeProject)
+}
+
+func (p *GalleryUseCaseImpl) UnlikeProject(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error) {
+	return p.react(projectId, userId, userRole, p.galleryGateway.UnlikeProject)
+}
+
+func (p *GalleryUseCaseImpl) AddProjectToFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error) {
+	return p.react(projectId, userId, userRole, p.galleryGateway.AddProjectToFavorites)
+}
+
+func (p *GalleryUseCaseImpl) RemoveProjectFromFavorites(projectId, userId string, userRole models.Role) (project *models.GalleryProjectCore, err error) {
+	return p.react(projectId, userId, userRole, p.galleryGateway.RemoveProjectFromFavorites)
+}
+
+// ViewProject counts a viewer once per view window however often they reload the project.
+func (p *GalleryUseCaseImpl) ViewProject(projectId, viewerId string, viewerRole models.Role) (project *models.GalleryProjectCore, err error) {
+	since := viewedSince(time.Now(), p.viewWindow)
+	return p.react(projectId, viewerId, viewerRole, func(projectId, viewerId string, viewerRole models.Role) error {
+		return p.galleryGateway.RecordView(projectId, viewerId, viewerRole, since)
+	})
+}
+
+func (p *GalleryUseCaseImpl) react(
+	projectId, userId string,
+	userRole models.Role,
+	reaction func(projectId, userId string, userRole models.Role) error,
+) (project *models.GalleryProjectCore, err error) {
+	if _, err = p.galleryGateway.GetGalleryProject(projectId, userId, userRole); err != nil {
+		return
+	}
+	if err = reaction(projectId, userId, userRole); err != nil {
+		return
+	}
+	return p.galleryGateway.GetGalleryProject(projectId, userId, userRole)
+}
+
+func (p *GalleryUseCaseImpl) SetProjectTags(projectId string, tags []string) (normalized []string, err error) {
+	if normalized, err = normalizeTags(tags, p.maxTags); err != nil {
+		return
+	}
+	if err = p.galleryGateway.SetProjectTags(projectId, normalized); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// normalizeTags lower-cases the tags and drops a leading # and the duplicates, keeping the order they came in.
+func normalizeTags(tags []string, maxTags int) (normalized []string, err error) {
+	normalized = []string{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if !tagPattern.MatchString(tag) {
+			return nil, gallery.ErrBadTag
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if maxTags > 0 && len(normalized) > maxTags {
+		return nil, gallery.ErrTooManyTags
+	}
+	return
+}
+
+// viewedSince is the time a counted view of the viewer must be older than for the next one to count.
+func viewedSince(now time.Time, window time.Duration) time.Time {
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+	return now.Add(-window)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/gallery"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{" Games ", "#games", "робот", "level_2", "maze-runner"}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"games", "робот", "level_2", "maze-runner"}, tags)
+
+	tags, err = normalizeTags(nil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, tags)
+
+	for _, tag := range []string{"", "#", "two words", "a,b", strings.Repeat("a", 33)} {
+		_, err = normalizeTags([]string{tag}, 10)
+		assert.ErrorIs(t, err, gallery.ErrBadTag, tag)
+	}
+	_, err = normalizeTags([]string{"a", "b", "c"}, 2)
+	assert.ErrorIs(t, err, gallery.ErrTooManyTags)
+	// duplicates do not count against the limit
+	_, err = normalizeTags([]string{"a", "A", "#a"}, 1)
+	assert.NoError(t, err)
+}
+
+func TestResolveFilter(t *testing.T) {
+	usecase := &GalleryUseCaseImpl{pageSize: 20, maxPageSize: 100}
+
+	filter := &models.GalleryFilterCore{Tag: " #Games"}
+	assert.NoError(t, usecase.resolveFilter(filter))
+	assert.Equal(t, models.GalleryNewest, filter.Sort)
+	assert.Equal(t, 1, filter.Page)
+	assert.Equal(t, 20, filter.PageSize)
+	assert.Equal(t, "games", filter.Tag)
+
+	filter = &models.GalleryFilterCore{Sort: models.GalleryMostLiked, Page: 3, PageSize: 1000}
+	assert.NoError(t, usecase.resolveFilter(filter))
+	assert.Equal(t, 100, filter.PageSize)
+
+	assert.ErrorIs(t, usecase.resolveFilter(&models.GalleryFilterCore{Sort: "oldest"}), gallery.ErrBadSort)
+	assert.ErrorIs(t, usecase.resolveFilter(&models.GalleryFilterCore{Page: -1}), gallery.ErrBadPage)
+
+	from := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+	assert.ErrorIs(t, usecase.resolveFilter(&models.GalleryFilterCore{From: &from, To: &to}), gallery.ErrBadTimeRange)
+}
+
+func TestViewedSince(t *testing.T) {
+	evening := time.Date(2022, 9, 1, 20, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2022, 8, 31, 20, 0, 0, 0, time.UTC), viewedSince(evening, 24*time.Hour))
+	assert.Equal(t, evening.Add(-time.Hour), viewedSince(evening, time.Hour))
+	// an unset window falls back to a day
+	assert.Equal(t, viewedSince(evening, 24*time.Hour), viewedSince(evening, 0))
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"gorm.io/gorm"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// assetContentTypes lists the data formats scratch-gui stores for costumes and sounds.
+var assetContentTypes = map[string]string{
+	"svg":  "image/svg+xml",
+	"png":  "image/png",
+	"jpg":  "image/jpeg",
+	"jpeg": "image/jpeg",
+	"bmp":  "image/bmp",
+	"gif":  "image/gif",
+	"wav":  "audio/wav",
+	"mp3":  "audio/mpeg",
+}
+
+var md5extPattern = regexp.MustCompile(`^([0-9a-f]{32})\.([a-z0-9]+)$`)
+
+// AssetCore is a costume or a sound addressed the way Scratch does it: md5 of the content
+// followed by the data format, e.g. cd21514d0531fdffb22204e0ec5ed84a.svg.
+type AssetCore struct {
+	Md5ext      string
+	Md5         string
+	DataFormat  string
+	ContentType string
+	Size        int
+	CreatedAt   time.Time
+}
+
+// ParseMd5ext returns the asset named by md5ext, ok is false for names scratch-gui never produces.
+func ParseMd5ext(md5ext string) (asset *AssetCore, ok bool) {
+	match := md5extPattern.FindStringSubmatch(strings.ToLower(md5ext))
+	if match == nil || match[0] != md5ext {
+		return nil, false
+	}
+	contentType, ok := assetContentTypes[match[2]]
+	if !ok {
+		return nil, false
+	}
+	return &AssetCore{
+		Md5ext:      md5ext,
+		Md5:         match[1],
+		DataFormat:  match[2],
+		ContentType: contentType,
+	}, true
+}
+
+// HasContent reports whether the content hashes to the md5 the asset is named by.
+func (a *AssetCore) HasContent(content []byte) bool {
+	sum := md5.Sum(content)
+	return hex.EncodeToString(sum[:]) == a.Md5
+}
+
+// AssetDB records an asset once, whoever uploads the same content again reuses it.
+type AssetDB struct {
+	gorm.Model
+
+	Md5ext      string `gorm:"not null;size:64;uniqueIndex"`
+	Md5         string `gorm:"not null;size:32"`
+	DataFormat  string `gorm:"not null;size:8"`
+	ContentType string `gorm:"not null;size:64"`
+	Size        int    `gorm:"not null"`
+}
+
+func (em *AssetDB) ToCore() *AssetCore {
+	return &AssetCore{
+		Md5ext:      em.Md5ext,
+		Md5:         em.Md5,
+		DataFormat:  em.DataFormat,
+		ContentType: em.ContentType,
+		Size:        em.Size,
+		CreatedAt:   em.CreatedAt,
+	}
+}
+
+func (em *AssetDB) FromCore(asset *AssetCore) {
+	em.Md5ext = asset.Md5ext
+	em.Md5 = asset.Md5
+	em.DataFormat = asset.DataFormat
+	em.ContentType = asset.ContentType
+	em.Size = asset.Size
+}
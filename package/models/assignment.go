@@ -0,0 +1,281 @@
+package models
+
+import (
+	"encoding/json"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type AssignmentWorkStatus string
+
+const (
+	AssignmentAssigned  AssignmentWorkStatus = "assigned"
+	AssignmentSubmitted AssignmentWorkStatus = "submitted"
+	// AssignmentReturned waits for the student to rework and submit again
+	AssignmentReturned AssignmentWorkStatus = "returned"
+	AssignmentGraded   AssignmentWorkStatus = "graded"
+)
+
+// RubricCriterionCore is one line of the rubric a work is scored on, from 0 to MaxScore points.
+type RubricCriterionCore struct {
+	Name     string `json:"name" mapstructure:"name"`
+	MaxScore int    `json:"maxScore" mapstructure:"max_score"`
+}
+
+func (ht *RubricCriterionInput) ToCore() RubricCriterionCore {
+	return RubricCriterionCore{Name: ht.Name, MaxScore: ht.MaxScore}
+}
+
+type AssignmentCore struct {
+	Id                string
+	CreatedAt         time.Time
+	RobboGroupId      string
+	TeacherId         string
+	Title             string
+	Instruction       string
+	TemplateProjectId string
+	// TemplateJson is the template as it was when the assignment was given, every copy starts from it.
+	// It is only read for a single assignment.
+	TemplateJson string
+	// TemplateHash is the blob of TemplateJson, the copies start out sharing it
+	TemplateHash string
+	Deadline     *time.Time
+	Rubric       []RubricCriterionCore
+	Checks       []AssignmentCheckCore
+}
+
+// MaxScore is the most points a work can get on the rubric.
+func (a *AssignmentCore) MaxScore() (maxScore int) {
+	for _, criterion := range a.Rubric {
+		maxScore += criterion.MaxScore
+	}
+	return
+}
+
+type AssignmentDB struct {
+	gorm.Model
+
+	RobboGroupId      string `gorm:"size:256;not null;index"`
+	TeacherId         string `gorm:"size:256;not null"`
+	Title             string `gorm:"size:256;not null"`
+	Instruction       string `gorm:"size:4096;not null"`
+	TemplateProjectId string `gorm:"size:256;not null"`
+	TemplateHash      string `gorm:"not null;size:64;default:''"`
+	// LegacyTemplateJson is where assignments kept the template before the blob storage
+	LegacyTemplateJson string `gorm:"column:template_json;not null;default:''"`
+	Deadline           *time.Time
+	// Rubric is the JSON of []RubricCriterionCore, it is fixed when the assignment is given
+	Rubric string `gorm:"not null;default:'[]'"`
+	// Checks is the JSON of []AssignmentCheckCore
+	Checks string `gorm:"not null;default:'[]'"`
+}
+
+func (em *AssignmentDB) ToCore() *AssignmentCore {
+	return &AssignmentCore{
+		Id:                strconv.FormatUint(uint64(em.ID), 10),
+		CreatedAt:         em.CreatedAt,
+		RobboGroupId:      em.RobboGroupId,
+		TeacherId:         em.TeacherId,
+		Title:             em.Title,
+		Instruction:       em.Instruction,
+		TemplateProjectId: em.TemplateProjectId,
+		TemplateJson:      em.LegacyTemplateJson,
+		TemplateHash:      em.TemplateHash,
+		Deadline:          em.Deadline,
+		Rubric:            em.rubric(),
+		Checks:            em.checks(),
+	}
+}
+
+func (em *AssignmentDB) rubric() (rubric []RubricCriterionCore) {
+	rubric = []RubricCriterionCore{}
+	_ = json.Unmarshal([]byte(em.Rubric), &rubric)
+	return
+}
+
+func (em *AssignmentDB) checks() (checks []AssignmentCheckCore) {
+	checks = []AssignmentCheckCore{}
+	_ = json.Unmarshal([]byte(em.Checks), &checks)
+	return
+}
+
+func (em *AssignmentDB) FromCore(assignment *AssignmentCore) {
+	id, _ := strconv.ParseUint(assignment.Id, 10, 64)
+	em.ID = uint(id)
+	em.RobboGroupId = assignment.RobboGroupId
+	em.TeacherId = assignment.TeacherId
+	em.Title = assignment.Title
+	em.Instruction = assignment.Instruction
+	em.TemplateProjectId = assignment.TemplateProjectId
+	em.TemplateHash = assignment.TemplateHash
+	em.Deadline = assignment.Deadline
+	rubric, _ := json.Marshal(assignment.Rubric)
+	em.Rubric = string(rubric)
+	checks, _ := json.Marshal(assignment.Checks)
+	em.Checks = string(checks)
+}
+
+func (ht *AssignmentHTTP) FromCore(assignment *AssignmentCore) {
+	ht.ID = assignment.Id
+	ht.CreatedAt = assignment.CreatedAt.Format(time.RFC3339)
+	ht.RobboGroupID = assignment.RobboGroupId
+	ht.TeacherID = assignment.TeacherId
+	ht.Title = assignment.Title
+	ht.Instruction = assignment.Instruction
+	ht.TemplateProjectID = assignment.TemplateProjectId
+	if assignment.Deadline != nil {
+		deadline := assignment.Deadline.Format(time.RFC3339)
+		ht.Deadline = &deadline
+	}
+	ht.Rubric = make([]*RubricCriterionHTTP, 0, len(assignment.Rubric))
+	for _, criterion := range assignment.Rubric {
+		ht.Rubric = append(ht.Rubric, &RubricCriterionHTTP{Name: criterion.Name, MaxScore: criterion.MaxScore})
+	}
+	ht.Checks = make([]*AssignmentCheckHTTP, 0, len(assignment.Checks))
+	for _, check := range assignment.Checks {
+		checkHttp := &AssignmentCheckHTTP{}
+		checkHttp.FromCore(check)
+		ht.Checks = append(ht.Checks, checkHttp)
+	}
+}
+
+// AssignmentWorkCore is the personal copy of the template a student works on.
+type AssignmentWorkCore struct {
+	AssignmentId string
+	StudentId    string
+	ProjectId    string
+	Status       AssignmentWorkStatus
+	SubmittedAt  *time.Time
+	// SubmittedJson is the project frozen at the last submission, later edits of the copy do not change it.
+	// It is only read for a single work.
+	SubmittedJson string
+	// SubmittedHash is the blob of SubmittedJson, empty for works submitted before the blob storage
+	SubmittedHash string
+	// CheckResults are the checks of the assignment run on SubmittedJson
+	CheckResults []AssignmentCheckResultCore
+	// Fingerprint is the structure of SubmittedJson, nil until it is built
+	Fingerprint *ProjectFingerprintCore
+}
+
+// Late reports whether the work was submitted after the deadline.
+func (w *AssignmentWorkCore) Late(deadline *time.Time) bool {
+	return deadline != nil && w.SubmittedAt != nil && w.SubmittedAt.After(*deadline)
+}
+
+type AssignmentWorkDB struct {
+	gorm.Model
+
+	AssignmentId  string `gorm:"size:256;not null;uniqueIndex:idx_assignment_student"`
+	StudentId     string `gorm:"size:256;not null;uniqueIndex:idx_assignment_student;index"`
+	ProjectId     string `gorm:"size:256;not null"`
+	Status        string `gorm:"size:16;not null"`
+	SubmittedAt   *time.Time
+	SubmittedHash string `gorm:"size:64;not null;default:''"`
+	// LegacySubmittedJson is where submissions were kept before the blob storage, it is emptied on the next submission
+	LegacySubmittedJson string `gorm:"column:submitted_json"`
+	// CheckResults is the JSON of []AssignmentCheckResultCore
+	CheckResults string `gorm:"not null;default:'[]'"`
+	// Fingerprint is the JSON of ProjectFingerprintCore, empty until it is built
+	Fingerprint string `gorm:"not null;default:''"`
+}
+
+func (em *AssignmentWorkDB) ToCore() *AssignmentWorkCore {
+	return &AssignmentWorkCore{
+		AssignmentId:  em.AssignmentId,
+		StudentId:     em.StudentId,
+		ProjectId:     em.ProjectId,
+		Status:        AssignmentWorkStatus(em.Status),
+		SubmittedAt:   em.SubmittedAt,
+		SubmittedJson: em.LegacySubmittedJson,
+		SubmittedHash: em.SubmittedHash,
+		CheckResults:  em.checkResults(),
+		Fingerprint:   em.fingerprint(),
+	}
+}
+
+func (em *AssignmentWorkDB) fingerprint() *ProjectFingerprintCore {
+	if em.Fingerprint == "" {
+		return nil
+	}
+	fingerprint := &ProjectFingerprintCore{}
+	if err := json.Unmarshal([]byte(em.Fingerprint), fingerprint); err != nil {
+		return nil
+	}
+	return fingerprint
+}
+
+func (em *AssignmentWorkDB) checkResults() (results []AssignmentCheckResultCore) {
+	results = []AssignmentCheckResultCore{}
+	_ = json.Unmarshal([]byte(em.CheckResults), &results)
+	return
+}
+
+func (em *AssignmentWorkDB) FromCore(work *AssignmentWorkCore) {
+	em.AssignmentId = work.AssignmentId
+	em.StudentId = work.StudentId
+	em.ProjectId = work.ProjectId
+	em.Status = string(work.Status)
+	em.SubmittedAt = work.SubmittedAt
+	em.SubmittedHash = work.SubmittedHash
+	checkResults, _ := json.Marshal(work.CheckResults)
+	em.CheckResults = string(checkResults)
+	em.Fingerprint = ""
+	if work.Fingerprint != nil {
+		fingerprint, _ := json.Marshal(work.Fingerprint)
+		em.Fingerprint = string(fingerprint)
+	}
+}
+
+func (ht *AssignmentWorkHTTP) FromCore(work *AssignmentWorkCore, deadline *time.Time) {
+	ht.AssignmentID = work.AssignmentId
+	ht.StudentID = work.StudentId
+	ht.ProjectID = work.ProjectId
+	ht.Status = string(work.Status)
+	if work.SubmittedAt != nil {
+		submittedAt := work.SubmittedAt.Format(time.RFC3339)
+		ht.SubmittedAt = &submittedAt
+	}
+	ht.Late = work.Late(deadline)
+	ht.CheckResults = checkResultsHTTP(work.CheckResults)
+}
+
+type StudentAssignmentCore struct {
+	Assignment *AssignmentCore
+	Work       *AssignmentWorkCore
+	// Review is the latest review of the work, nil until a teacher has looked at it
+	Review *AssignmentReviewCore
+}
+
+func (ht *StudentAssignmentHTTP) FromCore(studentAssignment *StudentAssignmentCore) {
+	ht.Assignment = &AssignmentHTTP{}
+	ht.Assignment.FromCore(studentAssignment.Assignment)
+	ht.Work = &AssignmentWorkHTTP{}
+	ht.Work.FromCore(studentAssignment.Work, studentAssignment.Assignment.Deadline)
+	if studentAssignment.Review != nil {
+		ht.Review = &AssignmentReviewHTTP{}
+		ht.Review.FromCore(studentAssignment.Review)
+	}
+}
+
+// AssignmentProgressCore is the submission status of every current member of the group.
+type AssignmentProgressCore struct {
+	Assignment *AssignmentCore
+	Works      []*AssignmentWorkCore
+}
+
+func (ht *AssignmentProgressHTTP) FromCore(progress *AssignmentProgressCore) {
+	ht.Assignment = &AssignmentHTTP{}
+	ht.Assignment.FromCore(progress.Assignment)
+	ht.Works = make([]*AssignmentWorkHTTP, 0, len(progress.Works))
+	ht.Submitted = 0
+	for _, work := range progress.Works {
+		workHttp := &AssignmentWorkHTTP{}
+		workHttp.FromCore(work, progress.Assignment.Deadline)
+		ht.Works = append(ht.Works, workHttp)
+		if work.Status == AssignmentSubmitted || work.Status == AssignmentGraded {
+			ht.Submitted++
+		}
+	}
+	ht.Total = len(progress.Works)
+}
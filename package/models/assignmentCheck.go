@@ -0,0 +1,67 @@
+package models
+
+type AssignmentCheckKind string
+
+const (
+	// CheckOpcode counts the blocks with the opcode in Value, such as event_whenflagclicked
+	CheckOpcode AssignmentCheckKind = "opcode"
+	// CheckCategory counts the blocks of a palette, such as control or an extension id
+	CheckCategory AssignmentCheckKind = "category"
+	// CheckExtension looks for the extension with the id in Value
+	CheckExtension AssignmentCheckKind = "extension"
+	// CheckSprite looks for a sprite named Value
+	CheckSprite AssignmentCheckKind = "sprite"
+	// CheckMetric compares one of the project metrics, such as loops or variables
+	CheckMetric AssignmentCheckKind = "metric"
+)
+
+// AssignmentCheckCore is an acceptance rule of an assignment: the project passes it
+// when the thing the rule counts is found at least Min times.
+type AssignmentCheckCore struct {
+	Title string              `json:"title"`
+	Kind  AssignmentCheckKind `json:"kind"`
+	Value string              `json:"value"`
+	Min   int                 `json:"min"`
+}
+
+func (ht *AssignmentCheckInput) ToCore() AssignmentCheckCore {
+	check := AssignmentCheckCore{Kind: AssignmentCheckKind(ht.Kind), Value: ht.Value}
+	if ht.Title != nil {
+		check.Title = *ht.Title
+	}
+	if ht.Min != nil {
+		check.Min = *ht.Min
+	}
+	return check
+}
+
+func (ht *AssignmentCheckHTTP) FromCore(check AssignmentCheckCore) {
+	ht.Title = check.Title
+	ht.Kind = string(check.Kind)
+	ht.Value = check.Value
+	ht.Min = check.Min
+}
+
+type AssignmentCheckResultCore struct {
+	AssignmentCheckCore
+	// Actual is how many times the thing the rule counts has been found
+	Actual int  `json:"actual"`
+	Passed bool `json:"passed"`
+}
+
+func (ht *AssignmentCheckResultHTTP) FromCore(result AssignmentCheckResultCore) {
+	ht.Check = &AssignmentCheckHTTP{}
+	ht.Check.FromCore(result.AssignmentCheckCore)
+	ht.Actual = result.Actual
+	ht.Passed = result.Passed
+}
+
+func checkResultsHTTP(results []AssignmentCheckResultCore) []*AssignmentCheckResultHTTP {
+	resultsHttp := make([]*AssignmentCheckResultHTTP, 0, len(results))
+	for _, result := range results {
+		resultHttp := &AssignmentCheckResultHTTP{}
+		resultHttp.FromCore(result)
+		resultsHttp = append(resultsHttp, resultHttp)
+	}
+	return resultsHttp
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"encoding/json"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type ReviewDecision string
+
+const (
+	ReviewGraded ReviewDecision = "graded"
+	// ReviewReturned sends the work back for rework, it is not scored
+	ReviewReturned ReviewDecision = "returned"
+)
+
+func (d ReviewDecision) IsValid() bool {
+	return d == ReviewGraded || d == ReviewReturned
+}
+
+const ReviewNotification NotificationKind = "assignmentReview"
+
+type CriterionScoreCore struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	MaxScore  int    `json:"maxScore"`
+}
+
+// AssignmentReviewCore is a review of one submission. Every submission keeps its reviews,
+// the latest one decides the status of the work.
+type AssignmentReviewCore struct {
+	Id           string
+	CreatedAt    time.Time
+	AssignmentId string
+	StudentId    string
+	ReviewerId   string
+	// SubmittedAt tells which submission of the work has been reviewed
+	SubmittedAt time.Time
+	// ProjectHash is the blob of the reviewed submission, the work and its reviews share it
+	// and a later submission does not change it
+	ProjectHash string
+	// ProjectJson is the reviewed submission, it is only read for a single review
+	ProjectJson string
+	Decision    ReviewDecision
+	Scores      []CriterionScoreCore
+	Score       int
+	MaxScore    int
+	Feedback    string
+}
+
+type AssignmentReviewDB struct {
+	gorm.Model
+
+	AssignmentId string    `gorm:"size:256;not null;index:idx_review_work"`
+	StudentId    string    `gorm:"size:256;not null;index:idx_review_work"`
+	ReviewerId   string    `gorm:"size:256;not null"`
+	SubmittedAt  time.Time `gorm:"not null"`
+	ProjectHash  string    `gorm:"size:64;not null;default:''"`
+	// LegacyProjectJson is where reviews kept a copy of the submission before they pointed to its blob
+	LegacyProjectJson string `gorm:"column:project_json;not null;default:''"`
+	Decision          string `gorm:"size:16;not null"`
+	// Scores is the JSON of []CriterionScoreCore
+	Scores   string `gorm:"not null;default:'[]'"`
+	Score    int    `gorm:"not null"`
+	MaxScore int    `gorm:"not null"`
+	Feedback string `gorm:"size:4096;not null"`
+}
+
+func (em *AssignmentReviewDB) ToCore() *AssignmentReviewCore {
+	scores := []CriterionScoreCore{}
+	_ = json.Unmarshal([]byte(em.Scores), &scores)
+	return &AssignmentReviewCore{
+		Id:           strconv.FormatUint(uint64(em.ID), 10),
+		CreatedAt:    em.CreatedAt,
+		AssignmentId: em.AssignmentId,
+		StudentId:    em.StudentId,
+		ReviewerId:   em.ReviewerId,
+		SubmittedAt:  em.SubmittedAt,
+		ProjectHash:  em.ProjectHash,
+		ProjectJson:  em.LegacyProjectJson,
+		Decision:     ReviewDecision(em.Decision),
+		Scores:       scores,
+		Score:        em.Score,
+		MaxScore:     em.MaxScore,
+		Feedback:     em.Feedback,
+	}
+}
+
+func (em *AssignmentReviewDB) FromCore(review *AssignmentReviewCore) {
+	em.AssignmentId = review.AssignmentId
+	em.StudentId = review.StudentId
+	em.ReviewerId = review.ReviewerId
+	em.SubmittedAt = review.SubmittedAt
+	em.ProjectHash = review.ProjectHash
+	em.Decision = string(review.Decision)
+	scores, _ := json.Marshal(review.Scores)
+	em.Scores = string(scores)
+	em.Score = review.Score
+	em.MaxScore = review.MaxScore
+	em.Feedback = review.Feedback
+}
+
+func (ht *AssignmentReviewHTTP) FromCore(review *AssignmentReviewCore) {
+	ht.ID = review.Id
+	ht.CreatedAt = review.CreatedAt.Format(time.RFC3339)
+	ht.AssignmentID = review.AssignmentId
+	ht.StudentID = review.StudentId
+	ht.ReviewerID = review.ReviewerId
+	ht.SubmittedAt = review.SubmittedAt.Format(time.RFC3339)
+	ht.Decision = string(review.Decision)
+	ht.Scores = make([]*CriterionScoreHTTP, 0, len(review.Scores))
+	for _, score := range review.Scores {
+		ht.Scores = append(ht.Scores, &CriterionScoreHTTP{
+			Criterion: score.Criterion,
+			Score:     score.Score,
+			MaxScore:  score.MaxScore,
+		})
+	}
+	ht.Score = review.Score
+	ht.MaxScore = review.MaxScore
+	ht.Feedback = review.Feedback
+}
+
+type GradebookCellCore struct {
+	AssignmentId string
+	Status       AssignmentWorkStatus
+	Late         bool
+	// Score is nil until the work is graded
+	Score    *int
+	MaxScore int
+}
+
+type GradebookRowCore struct {
+	Student *StudentCore
+	Grades  []*GradebookCellCore
+}
+
+// GradebookCore has a row for every current member of the group and a cell for every assignment of the group in it.
+type GradebookCore struct {
+	RobboGroupId string
+	Assignments  []*AssignmentCore
+	Rows         []*GradebookRowCore
+}
+
+func (ht *GradebookHTTP) FromCore(gradebook *GradebookCore) {
+	ht.RobboGroupID = gradebook.RobboGroupId
+	ht.Assignments = make([]*AssignmentHTTP, 0, len(gradebook.Assignments))
+	for _, assignment := range gradebook.Assignments {
+		assignmentHttp := &AssignmentHTTP{}
+		assignmentHttp.FromCore(assignment)
+		ht.Assignments = append(ht.Assignments, assignmentHttp)
+	}
+	ht.Rows = make([]*GradebookRowHTTP, 0, len(gradebook.Rows))
+	for _, row := range gradebook.Rows {
+		rowHttp := &GradebookRowHTTP{
+			Student: &StudentHTTP{UserHTTP: &UserHTTP{}},
+			Grades:  make([]*GradebookCellHTTP, 0, len(row.Grades)),
+		}
+		rowHttp.Student.FromCore(row.Student)
+		for _, cell := range row.Grades {
+			rowHttp.Grades = append(rowHttp.Grades, &GradebookCellHTTP{
+				AssignmentID: cell.AssignmentId,
+				Status:       string(cell.Status),
+				Late:         cell.Late,
+				Score:        cell.Score,
+				MaxScore:     cell.MaxScore,
+			})
+		}
+		ht.Rows = append(ht.Rows, rowHttp)
+	}
+}
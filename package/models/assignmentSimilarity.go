@@ -0,0 +1,78 @@
+package models
+
+// ScriptFingerprintCore is the structure of one script. Sprite names, block positions and the values
+// typed into blocks are left out, Sprite and BlockId only point the teacher at the script.
+type ScriptFingerprintCore struct {
+	Sprite  string `json:"sprite"`
+	BlockId string `json:"blockId"`
+	Blocks  int    `json:"blocks"`
+	// Hash is the hash of the whole script, Shingles the hashes of every run of a few blocks in it
+	Hash     uint64   `json:"hash"`
+	Shingles []uint64 `json:"shingles"`
+}
+
+// ProjectFingerprintCore is what a submission is compared by.
+type ProjectFingerprintCore struct {
+	Scripts []ScriptFingerprintCore `json:"scripts"`
+}
+
+type ScriptRefCore struct {
+	Sprite  string
+	BlockId string
+	Blocks  int
+}
+
+func (ht *ScriptRefHTTP) FromCore(script ScriptRefCore) {
+	ht.Sprite = script.Sprite
+	ht.BlockID = script.BlockId
+	ht.Blocks = script.Blocks
+}
+
+// SimilarScriptsCore is a script of the first work and the script of the second work most like it.
+type SimilarScriptsCore struct {
+	First     ScriptRefCore
+	Second    ScriptRefCore
+	Identical bool
+}
+
+// SimilarPairCore is two submissions of an assignment that share more than the template gave them.
+type SimilarPairCore struct {
+	AssignmentId    string
+	FirstStudentId  string
+	SecondStudentId string
+	// Similarity is from 0 to 1, the share of the structure of both works they have in common
+	Similarity float64
+	Scripts    []SimilarScriptsCore
+}
+
+// SimilarityReportCore lists the pairs at or above Threshold, most similar first. Compared counts
+// the submissions that have a fingerprint, Pending the ones still waiting for it.
+type SimilarityReportCore struct {
+	Pairs     []*SimilarPairCore
+	Compared  int
+	Pending   int
+	Threshold float64
+}
+
+func (ht *SimilarityReportHTTP) FromCore(report *SimilarityReportCore) {
+	ht.Compared = report.Compared
+	ht.Pending = report.Pending
+	ht.Threshold = report.Threshold
+	ht.Pairs = make([]*SimilarPairHTTP, 0, len(report.Pairs))
+	for _, pair := range report.Pairs {
+		pairHttp := &SimilarPairHTTP{
+			AssignmentID:    pair.AssignmentId,
+			FirstStudentID:  pair.FirstStudentId,
+			SecondStudentID: pair.SecondStudentId,
+			Similarity:      pair.Similarity,
+			Scripts:         make([]*SimilarScriptsHTTP, 0, len(pair.Scripts)),
+		}
+		for _, scripts := range pair.Scripts {
+			scriptsHttp := &SimilarScriptsHTTP{First: &ScriptRefHTTP{}, Second: &ScriptRefHTTP{}, Identical: scripts.Identical}
+			scriptsHttp.First.FromCore(scripts.First)
+			scriptsHttp.Second.FromCore(scripts.Second)
+			pairHttp.Scripts = append(pairHttp.Scripts, scriptsHttp)
+		}
+		ht.Pairs = append(ht.Pairs, pairHttp)
+	}
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type AttendanceStatus string
+
+const (
+	AttendancePresent AttendanceStatus = "present"
+	AttendanceAbsent  AttendanceStatus = "absent"
+	AttendanceLate    AttendanceStatus = "late"
+	AttendanceExcused AttendanceStatus = "excused"
+)
+
+func (s AttendanceStatus) IsValid() bool {
+	switch s {
+	case AttendancePresent, AttendanceAbsent, AttendanceLate, AttendanceExcused:
+		return true
+	}
+	return false
+}
+
+type AttendanceCore struct {
+	Id       string
+	LessonId string
+	// LessonStartAt is read from the lesson, so it follows the lesson when it is rescheduled
+	LessonStartAt time.Time
+	RobboGroupId  string
+	StudentId     string
+	Status        AttendanceStatus
+	Comment       string
+	MarkedBy      string
+	MarkedAt      time.Time
+}
+
+type AttendanceDB struct {
+	gorm.Model
+
+	LessonId     string    `gorm:"not null;uniqueIndex:idx_attendance_lesson_student"`
+	RobboGroupId string    `gorm:"not null;index"`
+	StudentId    string    `gorm:"not null;uniqueIndex:idx_attendance_lesson_student;index"`
+	Status       string    `gorm:"size:32;not null"`
+	Comment      string    `gorm:"size:512"`
+	MarkedBy     string    `gorm:"size:256"`
+	MarkedAt     time.Time `gorm:"not null"`
+}
+
+func (em *AttendanceDB) ToCore() *AttendanceCore {
+	return &AttendanceCore{
+		Id:           strconv.FormatUint(uint64(em.ID), 10),
+		LessonId:     em.LessonId,
+		RobboGroupId: em.RobboGroupId,
+		StudentId:    em.StudentId,
+		Status:       AttendanceStatus(em.Status),
+		Comment:      em.Comment,
+		MarkedBy:     em.MarkedBy,
+		MarkedAt:     em.MarkedAt,
+	}
+}
+
+func (em *AttendanceDB) FromCore(attendance *AttendanceCore) {
+	id, _ := strconv.ParseUint(attendance.Id, 10, 64)
+	em.ID = uint(id)
+	em.LessonId = attendance.LessonId
+	em.RobboGroupId = attendance.RobboGroupId
+	em.StudentId = attendance.StudentId
+	em.Status = string(attendance.Status)
+	em.Comment = attendance.Comment
+	em.MarkedBy = attendance.MarkedBy
+	em.MarkedAt = attendance.MarkedAt
+}
+
+func (ht *AttendanceHTTP) FromCore(attendance *AttendanceCore) {
+	ht.ID = attendance.Id
+	ht.LessonID = attendance.LessonId
+	ht.RobboGroupID = attendance.RobboGroupId
+	ht.StudentID = attendance.StudentId
+	ht.Status = string(attendance.Status)
+	ht.Comment = attendance.Comment
+	ht.MarkedBy = attendance.MarkedBy
+	ht.MarkedAt = attendance.MarkedAt.Format(time.RFC3339)
+}
+
+func (ht *AttendanceMark) ToCore() *AttendanceCore {
+	attendance := &AttendanceCore{
+		StudentId: ht.StudentID,
+		Status:    AttendanceStatus(ht.Status),
+	}
+	if ht.Comment != nil {
+		attendance.Comment = *ht.Comment
+	}
+	return attendance
+}
+
+type AttendanceStatsCore struct {
+	StudentId    string
+	RobboGroupId string
+	Total        int
+	Present      int
+	Absent       int
+	Late         int
+	Excused      int
+}
+
+// Rate is the share of lessons the student actually attended, late arrivals included.
+func (s *AttendanceStatsCore) Rate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Present+s.Late) / float64(s.Total)
+}
+
+func (ht *AttendanceStatsHTTP) FromCore(stats *AttendanceStatsCore) {
+	ht.StudentID = stats.StudentId
+	ht.RobboGroupID = stats.RobboGroupId
+	ht.Total = stats.Total
+	ht.Present = stats.Present
+	ht.Absent = stats.Absent
+	ht.Late = stats.Late
+	ht.Excused = stats.Excused
+	ht.AttendanceRate = stats.Rate()
+}
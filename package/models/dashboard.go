@@ -0,0 +1,151 @@
+package models
+
+import "time"
+
+type DashboardGranularity string
+
+const (
+	DashboardDay   DashboardGranularity = "day"
+	DashboardWeek  DashboardGranularity = "week"
+	DashboardMonth DashboardGranularity = "month"
+)
+
+func (g DashboardGranularity) IsValid() bool {
+	switch g {
+	case DashboardDay, DashboardWeek, DashboardMonth:
+		return true
+	}
+	return false
+}
+
+// DashboardCountersCore holds the figures shown for a whole unit and for each of its groups.
+// Attendance, project and active student counters cover the requested period, the rest are
+// current values. A student is active when they signed in, attended a lesson or worked on a project.
+type DashboardCountersCore struct {
+	Students        int
+	ActiveStudents  int
+	Groups          int
+	Teachers        int
+	AttendanceMarks int
+	Attended        int
+	ProjectsCreated int
+	ProjectsShared  int
+	EdxEnrolled     int
+	EdxPending      int
+	EdxFailed       int
+}
+
+func (c *DashboardCountersCore) AttendanceRate() float64 {
+	if c.AttendanceMarks == 0 {
+		return 0
+	}
+	return float64(c.Attended) / float64(c.AttendanceMarks)
+}
+
+type RobboGroupStatsCore struct {
+	DashboardCountersCore
+	RobboGroupId string
+	Name         string
+}
+
+type DashboardTrendPointCore struct {
+	PeriodStart     time.Time
+	AttendanceMarks int
+	Attended        int
+	ProjectsCreated int
+	StudentsJoined  int
+}
+
+func (p *DashboardTrendPointCore) AttendanceRate() float64 {
+	if p.AttendanceMarks == 0 {
+		return 0
+	}
+	return float64(p.Attended) / float64(p.AttendanceMarks)
+}
+
+type RobboUnitDashboardCore struct {
+	RobboUnitId string
+	From        time.Time
+	To          time.Time
+	Granularity DashboardGranularity
+	Totals      *DashboardCountersCore
+	Groups      []*RobboGroupStatsCore
+	Trend       []*DashboardTrendPointCore
+}
+
+func (ht *DashboardCountersHTTP) FromCore(counters *DashboardCountersCore) {
+	ht.Students = counters.Students
+	ht.ActiveStudents = counters.ActiveStudents
+	ht.Groups = counters.Groups
+	ht.Teachers = counters.Teachers
+	ht.AttendanceMarks = counters.AttendanceMarks
+	ht.AttendanceRate = counters.AttendanceRate()
+	ht.ProjectsCreated = counters.ProjectsCreated
+	ht.ProjectsShared = counters.ProjectsShared
+	ht.EdxEnrolled = counters.EdxEnrolled
+	ht.EdxPending = counters.EdxPending
+	ht.EdxFailed = counters.EdxFailed
+}
+
+func (ht *RobboUnitDashboardHTTP) FromCore(dashboard *RobboUnitDashboardCore) {
+	ht.RobboUnitID = dashboard.RobboUnitId
+	ht.From = dashboard.From.Format(time.RFC3339)
+	ht.To = dashboard.To.Format(time.RFC3339)
+	ht.Granularity = string(dashboard.Granularity)
+	ht.Totals = &DashboardCountersHTTP{}
+	ht.Totals.FromCore(dashboard.Totals)
+	ht.Groups = make([]*RobboGroupStatsHTTP, 0, len(dashboard.Groups))
+	for _, group := range dashboard.Groups {
+		counters := &DashboardCountersHTTP{}
+		counters.FromCore(&group.DashboardCountersCore)
+		ht.Groups = append(ht.Groups, &RobboGroupStatsHTTP{
+			RobboGroupID: group.RobboGroupId,
+			Name:         group.Name,
+			Counters:     counters,
+		})
+	}
+	ht.Trend = make([]*DashboardTrendPointHTTP, 0, len(dashboard.Trend))
+	for _, point := range dashboard.Trend {
+		ht.Trend = append(ht.Trend, &DashboardTrendPointHTTP{
+			PeriodStart:     point.PeriodStart.Format(time.RFC3339),
+			AttendanceMarks: point.AttendanceMarks,
+			AttendanceRate:  point.AttendanceRate(),
+			ProjectsCreated: point.ProjectsCreated,
+			StudentsJoined:  point.StudentsJoined,
+		})
+	}
+}
+
+type RobboUnitStatsCore struct {
+	DashboardCountersCore
+	RobboUnitId string
+	Name        string
+}
+
+// RegionReportCore rolls the unit figures up to the region. Totals count distinct students
+// and teachers, so they may be lower than the sum over the units.
+type RegionReportCore struct {
+	RegionId string
+	From     time.Time
+	To       time.Time
+	Totals   *DashboardCountersCore
+	Units    []*RobboUnitStatsCore
+}
+
+func (ht *RegionReportHTTP) FromCore(report *RegionReportCore) {
+	ht.RegionID = report.RegionId
+	ht.From = report.From.Format(time.RFC3339)
+	ht.To = report.To.Format(time.RFC3339)
+	ht.Totals = &DashboardCountersHTTP{}
+	ht.Totals.FromCore(report.Totals)
+	ht.Units = make([]*RobboUnitStatsHTTP, 0, len(report.Units))
+	for _, unit := range report.Units {
+		counters := &DashboardCountersHTTP{}
+		counters.FromCore(&unit.DashboardCountersCore)
+		ht.Units = append(ht.Units, &RobboUnitStatsHTTP{
+			RobboUnitID: unit.RobboUnitId,
+			Name:        unit.Name,
+			Counters:    counters,
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type CoursePacketCourseCore struct {
+	CoursePacketId string
+	CourseId       string
+}
+
+// CoursePacketCourseDB lists the edX courses (course-v1:... ids) a packet consists of.
+type CoursePacketCourseDB struct {
+	gorm.Model
+
+	CoursePacketId string `gorm:"not null;uniqueIndex:idx_packet_course"`
+	CourseId       string `gorm:"size:256;not null;uniqueIndex:idx_packet_course"`
+}
+
+func (em *CoursePacketCourseDB) ToCore() *CoursePacketCourseCore {
+	return &CoursePacketCourseCore{
+		CoursePacketId: em.CoursePacketId,
+		CourseId:       em.CourseId,
+	}
+}
+
+type RobboGroupCoursePacketCore struct {
+	RobboGroupId   string
+	CoursePacketId string
+	CreatedAt      time.Time
+}
+
+type RobboGroupCoursePacketDB struct {
+	gorm.Model
+
+	RobboGroupId   string `gorm:"not null;uniqueIndex:idx_group_packet"`
+	CoursePacketId string `gorm:"not null;uniqueIndex:idx_group_packet"`
+}
+
+func (em *RobboGroupCoursePacketDB) ToCore() *RobboGroupCoursePacketCore {
+	return &RobboGroupCoursePacketCore{
+		RobboGroupId:   em.RobboGroupId,
+		CoursePacketId: em.CoursePacketId,
+		CreatedAt:      em.CreatedAt,
+	}
+}
+
+func (ht *RobboGroupCoursePacketHTTP) FromCore(relation *RobboGroupCoursePacketCore) {
+	ht.RobboGroupID = relation.RobboGroupId
+	ht.CoursePacketID = relation.CoursePacketId
+	ht.CreatedAt = relation.CreatedAt.Format(time.RFC3339)
+}
+
+type EdxEnrollmentAction string
+
+const (
+	EdxEnroll   EdxEnrollmentAction = "enroll"
+	EdxUnenroll EdxEnrollmentAction = "unenroll"
+)
+
+type EdxEnrollmentStatus string
+
+const (
+	EdxEnrollmentPending EdxEnrollmentStatus = "pending"
+	EdxEnrollmentRunning EdxEnrollmentStatus = "running"
+	EdxEnrollmentDone    EdxEnrollmentStatus = "done"
+	EdxEnrollmentFailed  EdxEnrollmentStatus = "failed"
+)
+
+// EdxEnrollmentCore is the wanted state of one student in one edX course
+// together with the progress of bringing edX to that state.
+type EdxEnrollmentCore struct {
+	Id            string
+	UpdatedAt     time.Time
+	StudentId     string
+	Username      string
+	CourseId      string
+	Action        EdxEnrollmentAction
+	Status        EdxEnrollmentStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+type EdxEnrollmentDB struct {
+	gorm.Model
+
+	StudentId     string    `gorm:"not null;uniqueIndex:idx_edx_enrollment_student_course"`
+	CourseId      string    `gorm:"size:256;not null;uniqueIndex:idx_edx_enrollment_student_course"`
+	Action        string    `gorm:"size:32;not null"`
+	Status        string    `gorm:"size:32;not null;index"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;index"`
+	LastError     string    `gorm:"size:1024"`
+}
+
+func (em *EdxEnrollmentDB) ToCore() *EdxEnrollmentCore {
+	return &EdxEnrollmentCore{
+		Id:            strconv.FormatUint(uint64(em.ID), 10),
+		UpdatedAt:     em.UpdatedAt,
+		StudentId:     em.StudentId,
+		CourseId:      em.CourseId,
+		Action:        EdxEnrollmentAction(em.Action),
+		Status:        EdxEnrollmentStatus(em.Status),
+		Attempts:      em.Attempts,
+		NextAttemptAt: em.NextAttemptAt,
+		LastError:     em.LastError,
+	}
+}
+
+func (ht *EdxEnrollmentHTTP) FromCore(enrollment *EdxEnrollmentCore) {
+	ht.ID = enrollment.Id
+	ht.UpdatedAt = enrollment.UpdatedAt.Format(time.RFC3339)
+	ht.StudentID = enrollment.StudentId
+	ht.CourseID = enrollment.CourseId
+	ht.Action = string(enrollment.Action)
+	ht.Status = string(enrollment.Status)
+	ht.Attempts = enrollment.Attempts
+	ht.NextAttemptAt = enrollment.NextAttemptAt.Format(time.RFC3339)
+	ht.LastError = enrollment.LastError
+}
@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+type GallerySort string
+
+const (
+	GalleryNewest     GallerySort = "newest"
+	GalleryMostLiked  GallerySort = "likes"
+	GalleryMostViewed GallerySort = "views"
+)
+
+func (s GallerySort) IsValid() bool {
+	switch s {
+	case GalleryNewest, GalleryMostLiked, GalleryMostViewed:
+		return true
+	}
+	return false
+}
+
+// GalleryFilterCore narrows the gallery down, empty fields do not filter. The viewer only
+// fills in LikedByMe and FavoritedByMe.
+type GalleryFilterCore struct {
+	RobboUnitId  string
+	RobboGroupId string
+	Tag          string
+	From         *time.Time
+	To           *time.Time
+	Sort         GallerySort
+	Page         int
+	PageSize     int
+	ViewerId     string
+	ViewerRole   Role
+}
+
+type GalleryProjectCore struct {
+	ProjectId     string
+	Title         string
+	AuthorId      string
+	Preview       string
+	LinkScratch   string
+	Tags          []string
+	SharedAt      time.Time
+	Likes         int
+	Favorites     int
+	Views         int
+	LikedByMe     bool
+	FavoritedByMe bool
+}
+
+type GalleryPageCore struct {
+	Projects []*GalleryProjectCore
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// ProjectLikeDB, ProjectFavoriteDB and ProjectTagDB are deleted for good, so a project can
+// be liked again after an unlike without tripping over the unique index.
+type ProjectLikeDB struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	ProjectId string `gorm:"not null;size:256;uniqueIndex:idx_project_like_user"`
+	UserId    string `gorm:"not null;size:256;uniqueIndex:idx_project_like_user"`
+	UserRole  uint   `gorm:"not null;uniqueIndex:idx_project_like_user"`
+}
+
+type ProjectFavoriteDB struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	ProjectId string `gorm:"not null;size:256;uniqueIndex:idx_project_favorite_user"`
+	UserId    string `gorm:"not null;size:256;uniqueIndex:idx_project_favorite_user;index:idx_favorite_user"`
+	UserRole  uint   `gorm:"not null;uniqueIndex:idx_project_favorite_user;index:idx_favorite_user"`
+}
+
+type ProjectTagDB struct {
+	ID        uint   `gorm:"primaryKey"`
+	ProjectId string `gorm:"not null;size:256;uniqueIndex:idx_project_tag"`
+	Tag       string `gorm:"not null;size:32;uniqueIndex:idx_project_tag;index"`
+}
+
+// ProjectViewDB is a counted view. A viewer is counted again once the view window has passed
+// since the last view counted for them, the index finds that view.
+type ProjectViewDB struct {
+	ID         uint      `gorm:"primaryKey"`
+	ProjectId  string    `gorm:"not null;size:256;index:idx_project_view_viewer"`
+	ViewerId   string    `gorm:"not null;size:256;index:idx_project_view_viewer"`
+	ViewerRole uint      `gorm:"not null;index:idx_project_view_viewer"`
+	CreatedAt  time.Time `gorm:"index:idx_project_view_viewer"`
+}
+
+func (ht *GalleryProjectHTTP) FromCore(project *GalleryProjectCore) {
+	ht.ProjectID = project.ProjectId
+	ht.Title = project.Title
+	ht.AuthorID = project.AuthorId
+	ht.Preview = project.Preview
+	ht.LinkScratch = project.LinkScratch
+	ht.Tags = project.Tags
+	ht.SharedAt = project.SharedAt.Format(time.RFC3339)
+	ht.Likes = project.Likes
+	ht.Favorites = project.Favorites
+	ht.Views = project.Views
+	ht.LikedByMe = project.LikedByMe
+	ht.FavoritedByMe = project.FavoritedByMe
+}
+
+func (ht *GalleryPageHTTP) FromCore(page *GalleryPageCore) {
+	ht.Total = page.Total
+	ht.Page = page.Page
+	ht.PageSize = page.PageSize
+	ht.Projects = make([]*GalleryProjectHTTP, 0, len(page.Projects))
+	for _, project := range page.Projects {
+		projectHttp := &GalleryProjectHTTP{}
+		projectHttp.FromCore(project)
+		ht.Projects = append(ht.Projects, projectHttp)
+	}
+}
@@ -8,6 +8,118 @@ type AbsoluteMediaHTTP struct {
 	URIAbsolute string `json:"URI_Absolute"`
 }
 
+type AssignmentCheckHTTP struct {
+	Title string `json:"title"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+	Min   int    `json:"min"`
+}
+
+type AssignmentCheckInput struct {
+	Title *string `json:"title"`
+	Kind  string  `json:"kind"`
+	Value string  `json:"value"`
+	Min   *int    `json:"min"`
+}
+
+type AssignmentCheckResultHTTP struct {
+	Check  *AssignmentCheckHTTP `json:"check"`
+	Actual int                  `json:"actual"`
+	Passed bool                 `json:"passed"`
+}
+
+type AssignmentHTTP struct {
+	ID                string                 `json:"id"`
+	CreatedAt         string                 `json:"createdAt"`
+	RobboGroupID      string                 `json:"robboGroupId"`
+	TeacherID         string                 `json:"teacherId"`
+	Title             string                 `json:"title"`
+	Instruction       string                 `json:"instruction"`
+	TemplateProjectID string                 `json:"templateProjectId"`
+	Deadline          *string                `json:"deadline"`
+	Rubric            []*RubricCriterionHTTP `json:"rubric"`
+	Checks            []*AssignmentCheckHTTP `json:"checks"`
+}
+
+type AssignmentProgressHTTP struct {
+	Assignment *AssignmentHTTP       `json:"assignment"`
+	Works      []*AssignmentWorkHTTP `json:"works"`
+	Submitted  int                   `json:"submitted"`
+	Total      int                   `json:"total"`
+}
+
+type AssignmentReviewHTTP struct {
+	ID           string                `json:"id"`
+	CreatedAt    string                `json:"createdAt"`
+	AssignmentID string                `json:"assignmentId"`
+	StudentID    string                `json:"studentId"`
+	ReviewerID   string                `json:"reviewerId"`
+	SubmittedAt  string                `json:"submittedAt"`
+	Decision     string                `json:"decision"`
+	Scores       []*CriterionScoreHTTP `json:"scores"`
+	Score        int                   `json:"score"`
+	MaxScore     int                   `json:"maxScore"`
+	Feedback     string                `json:"feedback"`
+}
+
+type AssignmentReviewInput struct {
+	AssignmentID string                 `json:"assignmentId"`
+	StudentID    string                 `json:"studentId"`
+	Decision     string                 `json:"decision"`
+	Scores       []*CriterionScoreInput `json:"scores"`
+	Feedback     string                 `json:"feedback"`
+}
+
+type AssignmentWorkHTTP struct {
+	AssignmentID string                       `json:"assignmentId"`
+	StudentID    string                       `json:"studentId"`
+	ProjectID    string                       `json:"projectId"`
+	Status       string                       `json:"status"`
+	SubmittedAt  *string                      `json:"submittedAt"`
+	Late         bool                         `json:"late"`
+	CheckResults []*AssignmentCheckResultHTTP `json:"checkResults"`
+}
+
+type AttendanceHTTP struct {
+	ID           string `json:"id"`
+	LessonID     string `json:"lessonId"`
+	RobboGroupID string `json:"robboGroupId"`
+	StudentID    string `json:"studentId"`
+	Status       string `json:"status"`
+	Comment      string `json:"comment"`
+	MarkedBy     string `json:"markedBy"`
+	MarkedAt     string `json:"markedAt"`
+}
+
+type AttendanceMark struct {
+	StudentID string  `json:"studentId"`
+	Status    string  `json:"status"`
+	Comment   *string `json:"comment"`
+}
+
+type AttendanceStatsHTTP struct {
+	StudentID      string  `json:"studentId"`
+	RobboGroupID   string  `json:"robboGroupId"`
+	Total          int     `json:"total"`
+	Present        int     `json:"present"`
+	Absent         int     `json:"absent"`
+	Late           int     `json:"late"`
+	Excused        int     `json:"excused"`
+	AttendanceRate float64 `json:"attendanceRate"`
+}
+
+type BlockCategoryCountHTTP struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+type CommentReportHTTP struct {
+	ReporterID   string `json:"reporterId"`
+	ReporterRole int    `json:"reporterRole"`
+	Reason       string `json:"reason"`
+	CreatedAt    string `json:"createdAt"`
+}
+
 type CourseAPIMediaCollectionHTTP struct {
 	ID          string             `json:"ID"`
 	BannerImage *AbsoluteMediaHTTP `json:"Banner_Image"`
@@ -44,6 +156,74 @@ type CoursesListHTTP struct {
 	Pagination *Pagination   `json:"Pagination"`
 }
 
+type CriterionScoreHTTP struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	MaxScore  int    `json:"maxScore"`
+}
+
+type CriterionScoreInput struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+}
+
+type CtAveragesHTTP struct {
+	Abstraction        float64 `json:"abstraction"`
+	Parallelism        float64 `json:"parallelism"`
+	Logic              float64 `json:"logic"`
+	Synchronization    float64 `json:"synchronization"`
+	FlowControl        float64 `json:"flowControl"`
+	UserInteractivity  float64 `json:"userInteractivity"`
+	DataRepresentation float64 `json:"dataRepresentation"`
+	Total              float64 `json:"total"`
+}
+
+type CtScoreHTTP struct {
+	Abstraction        int    `json:"abstraction"`
+	Parallelism        int    `json:"parallelism"`
+	Logic              int    `json:"logic"`
+	Synchronization    int    `json:"synchronization"`
+	FlowControl        int    `json:"flowControl"`
+	UserInteractivity  int    `json:"userInteractivity"`
+	DataRepresentation int    `json:"dataRepresentation"`
+	Total              int    `json:"total"`
+	Level              string `json:"level"`
+}
+
+type DashboardCountersHTTP struct {
+	Students        int     `json:"students"`
+	ActiveStudents  int     `json:"activeStudents"`
+	Groups          int     `json:"groups"`
+	Teachers        int     `json:"teachers"`
+	AttendanceMarks int     `json:"attendanceMarks"`
+	AttendanceRate  float64 `json:"attendanceRate"`
+	ProjectsCreated int     `json:"projectsCreated"`
+	ProjectsShared  int     `json:"projectsShared"`
+	EdxEnrolled     int     `json:"edxEnrolled"`
+	EdxPending      int     `json:"edxPending"`
+	EdxFailed       int     `json:"edxFailed"`
+}
+
+type DashboardTrendPointHTTP struct {
+	PeriodStart     string  `json:"periodStart"`
+	AttendanceMarks int     `json:"attendanceMarks"`
+	AttendanceRate  float64 `json:"attendanceRate"`
+	ProjectsCreated int     `json:"projectsCreated"`
+	StudentsJoined  int     `json:"studentsJoined"`
+}
+
+type EdxEnrollmentHTTP struct {
+	ID            string `json:"id"`
+	UpdatedAt     string `json:"updatedAt"`
+	StudentID     string `json:"studentId"`
+	CourseID      string `json:"courseId"`
+	Action        string `json:"action"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt string `json:"nextAttemptAt"`
+	LastError     string `json:"lastError"`
+}
+
 type EnrollmentHTTP struct {
 	Created  string `json:"Created"`
 	Mode     string `json:"Mode"`
@@ -58,6 +238,69 @@ type EnrollmentsListHTTP struct {
 	Results  []*EnrollmentHTTP `json:"Results"`
 }
 
+type GalleryPageHTTP struct {
+	Projects []*GalleryProjectHTTP `json:"projects"`
+	Total    int                   `json:"total"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"pageSize"`
+}
+
+type GalleryProjectHTTP struct {
+	ProjectID     string   `json:"projectId"`
+	Title         string   `json:"title"`
+	AuthorID      string   `json:"authorId"`
+	Preview       string   `json:"preview"`
+	LinkScratch   string   `json:"linkScratch"`
+	Tags          []string `json:"tags"`
+	SharedAt      string   `json:"sharedAt"`
+	Likes         int      `json:"likes"`
+	Favorites     int      `json:"favorites"`
+	Views         int      `json:"views"`
+	LikedByMe     bool     `json:"likedByMe"`
+	FavoritedByMe bool     `json:"favoritedByMe"`
+}
+
+type GradebookCellHTTP struct {
+	AssignmentID string `json:"assignmentId"`
+	Status       string `json:"status"`
+	Late         bool   `json:"late"`
+	Score        *int   `json:"score"`
+	MaxScore     int    `json:"maxScore"`
+}
+
+type GradebookHTTP struct {
+	RobboGroupID string              `json:"robboGroupId"`
+	Assignments  []*AssignmentHTTP   `json:"assignments"`
+	Rows         []*GradebookRowHTTP `json:"rows"`
+}
+
+type GradebookRowHTTP struct {
+	Student *StudentHTTP         `json:"student"`
+	Grades  []*GradebookCellHTTP `json:"grades"`
+}
+
+type GroupAnalysisReportHTTP struct {
+	RobboGroupID string                       `json:"robboGroupId"`
+	Projects     int                          `json:"projects"`
+	Averages     *CtAveragesHTTP              `json:"averages"`
+	Students     []*StudentAnalysisReportHTTP `json:"students"`
+}
+
+type GroupMembershipHTTP struct {
+	ID           string  `json:"id"`
+	StudentID    string  `json:"studentId"`
+	RobboGroupID string  `json:"robboGroupId"`
+	RobboUnitID  string  `json:"robboUnitId"`
+	JoinedAt     string  `json:"joinedAt"`
+	LeftAt       *string `json:"leftAt"`
+	LeaveReason  string  `json:"leaveReason"`
+}
+
+type GroupPromotion struct {
+	FromRobboGroupID string `json:"fromRobboGroupId"`
+	ToRobboGroupID   string `json:"toRobboGroupId"`
+}
+
 type ImageHTTP struct {
 	ID    string `json:"ID"`
 	Raw   string `json:"Raw"`
@@ -65,11 +308,51 @@ type ImageHTTP struct {
 	Large string `json:"Large"`
 }
 
+type LessonHTTP struct {
+	ID              string `json:"id"`
+	RobboGroupID    string `json:"robboGroupId"`
+	ScheduleSlotID  string `json:"scheduleSlotId"`
+	TeacherID       string `json:"teacherId"`
+	Room            string `json:"room"`
+	StartAt         string `json:"startAt"`
+	EndAt           string `json:"endAt"`
+	OriginalStartAt string `json:"originalStartAt"`
+	Status          string `json:"status"`
+	CancelReason    string `json:"cancelReason"`
+}
+
+type LoginEventHTTP struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	UserID    string `json:"userId"`
+	Email     string `json:"email"`
+	Role      int    `json:"role"`
+	Type      string `json:"type"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+	Success   bool   `json:"success"`
+}
+
 type MediaHTTP struct {
 	ID  string `json:"ID"`
 	URI string `json:"URI"`
 }
 
+type NearbyRobboUnitHTTP struct {
+	RobboUnit  *RobboUnitHTTP `json:"robboUnit"`
+	DistanceKm *float64       `json:"distanceKm"`
+}
+
+type NewAssignment struct {
+	RobboGroupID      string                  `json:"robboGroupId"`
+	Title             string                  `json:"title"`
+	Instruction       string                  `json:"instruction"`
+	TemplateProjectID string                  `json:"templateProjectId"`
+	Deadline          *string                 `json:"deadline"`
+	Rubric            []*RubricCriterionInput `json:"rubric"`
+	Checks            []*AssignmentCheckInput `json:"checks"`
+}
+
 type NewParent struct {
 	Email      string `json:"email"`
 	Password   string `json:"password"`
@@ -79,6 +362,37 @@ type NewParent struct {
 	Middlename string `json:"middlename"`
 }
 
+type NewRegion struct {
+	Name string `json:"name"`
+}
+
+type NewRegionAdmin struct {
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	Nickname   string `json:"nickname"`
+	Firstname  string `json:"firstname"`
+	Lastname   string `json:"lastname"`
+	Middlename string `json:"middlename"`
+	RegionID   string `json:"regionId"`
+}
+
+type NewRobboUnit struct {
+	Name         string  `json:"name"`
+	City         string  `json:"city"`
+	Address      *string `json:"address"`
+	WorkingHours *string `json:"workingHours"`
+	RegionID     *string `json:"regionId"`
+}
+
+type NewScheduleSlot struct {
+	RobboGroupID string `json:"robboGroupId"`
+	Weekday      int    `json:"weekday"`
+	StartTime    string `json:"startTime"`
+	EndTime      string `json:"endTime"`
+	TeacherID    string `json:"teacherId"`
+	Room         string `json:"room"`
+}
+
 type NewStudent struct {
 	Email      string `json:"email"`
 	Password   string `json:"password"`
@@ -98,6 +412,13 @@ type NewTeacher struct {
 	Middlename string `json:"middlename"`
 }
 
+type NewTerm struct {
+	RobboUnitID string `json:"robboUnitId"`
+	Name        string `json:"name"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate"`
+}
+
 type NewUnitAdmin struct {
 	Email      string `json:"email"`
 	Password   string `json:"password"`
@@ -107,6 +428,14 @@ type NewUnitAdmin struct {
 	Middlename string `json:"middlename"`
 }
 
+type NotificationHTTP struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	Kind      string `json:"kind"`
+	Text      string `json:"text"`
+	Read      bool   `json:"read"`
+}
+
 type Pagination struct {
 	Next     string `json:"Next"`
 	Previous string `json:"Previous"`
@@ -119,15 +448,123 @@ type ParentHTTP struct {
 	Children []*StudentHTTP `json:"children"`
 }
 
+type ProjectAnalysisHTTP struct {
+	Sprites          int                       `json:"sprites"`
+	Scripts          int                       `json:"scripts"`
+	Blocks           int                       `json:"blocks"`
+	BlocksByCategory []*BlockCategoryCountHTTP `json:"blocksByCategory"`
+	Extensions       []string                  `json:"extensions"`
+	Loops            int                       `json:"loops"`
+	Conditionals     int                       `json:"conditionals"`
+	Variables        int                       `json:"variables"`
+	Lists            int                       `json:"lists"`
+	Broadcasts       int                       `json:"broadcasts"`
+	CustomBlocks     int                       `json:"customBlocks"`
+	Clones           int                       `json:"clones"`
+	ParallelScripts  int                       `json:"parallelScripts"`
+	CtScore          *CtScoreHTTP              `json:"ctScore"`
+}
+
+type ProjectBlockRefHTTP struct {
+	BlockID string `json:"blockId"`
+	Opcode  string `json:"opcode"`
+}
+
+type ProjectCommentHTTP struct {
+	ID         string  `json:"id"`
+	CreatedAt  string  `json:"createdAt"`
+	ProjectID  string  `json:"projectId"`
+	ParentID   string  `json:"parentId"`
+	AuthorID   string  `json:"authorId"`
+	AuthorRole int     `json:"authorRole"`
+	Text       string  `json:"text"`
+	Status     string  `json:"status"`
+	EditedAt   *string `json:"editedAt"`
+}
+
 type ProjectPageHTTP struct {
-	LastModified string `json:"LastModified"`
-	ProjectID    string `json:"ProjectID"`
-	Instruction  string `json:"Instruction"`
-	Notes        string `json:"Notes"`
-	Preview      string `json:"Preview"`
-	LinkScratch  string `json:"LinkScratch"`
-	Title        string `json:"Title"`
-	IsShared     bool   `json:"IsShared"`
+	LastModified     string               `json:"LastModified"`
+	ProjectID        string               `json:"ProjectID"`
+	Instruction      string               `json:"Instruction"`
+	Notes            string               `json:"Notes"`
+	Preview          string               `json:"Preview"`
+	LinkScratch      string               `json:"LinkScratch"`
+	Title            string               `json:"Title"`
+	IsShared         bool                 `json:"IsShared"`
+	RemixedFrom      *RemixCreditHTTP     `json:"RemixedFrom"`
+	CommentsDisabled bool                 `json:"CommentsDisabled"`
+	Analysis         *ProjectAnalysisHTTP `json:"Analysis"`
+}
+
+type ProjectRevisionDiffHTTP struct {
+	FromRevisionID string            `json:"fromRevisionId"`
+	ToRevisionID   string            `json:"toRevisionId"`
+	Sprites        []*SpriteDiffHTTP `json:"sprites"`
+}
+
+type ProjectRevisionHTTP struct {
+	ID             string               `json:"id"`
+	ProjectID      string               `json:"projectId"`
+	CreatedAt      string               `json:"createdAt"`
+	UpdatedAt      string               `json:"updatedAt"`
+	Reason         string               `json:"reason"`
+	RestoredFromID string               `json:"restoredFromId"`
+	Size           int                  `json:"size"`
+	JSON           *string              `json:"json"`
+	Analysis       *ProjectAnalysisHTTP `json:"analysis"`
+}
+
+type RegionAdminHTTP struct {
+	UserHTTP *UserHTTP `json:"userHttp"`
+	RegionID string    `json:"regionId"`
+}
+
+type RegionHTTP struct {
+	ID           string `json:"id"`
+	LastModified string `json:"lastModified"`
+	Name         string `json:"name"`
+}
+
+type RegionReportHTTP struct {
+	RegionID string                 `json:"regionId"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Totals   *DashboardCountersHTTP `json:"totals"`
+	Units    []*RobboUnitStatsHTTP  `json:"units"`
+}
+
+type RemixCountHTTP struct {
+	Direct int `json:"direct"`
+	Total  int `json:"total"`
+}
+
+type RemixCreditHTTP struct {
+	ProjectID string `json:"projectId"`
+	Title     string `json:"title"`
+	AuthorID  string `json:"authorId"`
+}
+
+type RemixNodeHTTP struct {
+	ProjectID  string `json:"projectId"`
+	ParentID   string `json:"parentId"`
+	Title      string `json:"title"`
+	AuthorID   string `json:"authorId"`
+	AuthorRole int    `json:"authorRole"`
+	IsShared   bool   `json:"isShared"`
+	Deleted    bool   `json:"deleted"`
+	Depth      int    `json:"depth"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+type ReportedCommentHTTP struct {
+	Comment *ProjectCommentHTTP  `json:"comment"`
+	Reports []*CommentReportHTTP `json:"reports"`
+}
+
+type RobboGroupCoursePacketHTTP struct {
+	RobboGroupID   string `json:"robboGroupId"`
+	CoursePacketID string `json:"coursePacketId"`
+	CreatedAt      string `json:"createdAt"`
 }
 
 type RobboGroupHTTP struct {
@@ -135,14 +572,134 @@ type RobboGroupHTTP struct {
 	LastModified string         `json:"lastModified"`
 	Name         string         `json:"name"`
 	RobboUnitID  string         `json:"robboUnitId"`
+	Capacity     int            `json:"capacity"`
+	TermID       string         `json:"termId"`
+	Archived     bool           `json:"archived"`
 	Students     []*StudentHTTP `json:"students"`
 }
 
+type RobboGroupStatsHTTP struct {
+	RobboGroupID string                 `json:"robboGroupId"`
+	Name         string                 `json:"name"`
+	Counters     *DashboardCountersHTTP `json:"counters"`
+}
+
+type RobboUnitDashboardHTTP struct {
+	RobboUnitID string                     `json:"robboUnitId"`
+	From        string                     `json:"from"`
+	To          string                     `json:"to"`
+	Granularity string                     `json:"granularity"`
+	Totals      *DashboardCountersHTTP     `json:"totals"`
+	Groups      []*RobboGroupStatsHTTP     `json:"groups"`
+	Trend       []*DashboardTrendPointHTTP `json:"trend"`
+}
+
 type RobboUnitHTTP struct {
+	ID           string   `json:"id"`
+	LastModified string   `json:"lastModified"`
+	Name         string   `json:"name"`
+	City         string   `json:"city"`
+	Address      string   `json:"address"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
+	WorkingHours string   `json:"workingHours"`
+	Phones       []string `json:"phones"`
+	RegionID     string   `json:"regionId"`
+}
+
+type RobboUnitSearch struct {
+	Latitude      *float64 `json:"latitude"`
+	Longitude     *float64 `json:"longitude"`
+	City          *string  `json:"city"`
+	CourseID      *string  `json:"courseId"`
+	PacketLevel   *int     `json:"packetLevel"`
+	MaxDistanceKm *float64 `json:"maxDistanceKm"`
+	Limit         *int     `json:"limit"`
+}
+
+type RobboUnitStatsHTTP struct {
+	RobboUnitID string                 `json:"robboUnitId"`
+	Name        string                 `json:"name"`
+	Counters    *DashboardCountersHTTP `json:"counters"`
+}
+
+type RubricCriterionHTTP struct {
+	Name     string `json:"name"`
+	MaxScore int    `json:"maxScore"`
+}
+
+type RubricCriterionInput struct {
+	Name     string `json:"name"`
+	MaxScore int    `json:"maxScore"`
+}
+
+type ScheduleSlotHTTP struct {
 	ID           string `json:"id"`
-	LastModified string `json:"lastModified"`
-	Name         string `json:"name"`
-	City         string `json:"city"`
+	RobboGroupID string `json:"robboGroupId"`
+	Weekday      int    `json:"weekday"`
+	StartTime    string `json:"startTime"`
+	EndTime      string `json:"endTime"`
+	TeacherID    string `json:"teacherId"`
+	Room         string `json:"room"`
+}
+
+type ScriptRefHTTP struct {
+	Sprite  string `json:"sprite"`
+	BlockID string `json:"blockId"`
+	Blocks  int    `json:"blocks"`
+}
+
+type SimilarPairHTTP struct {
+	AssignmentID    string                `json:"assignmentId"`
+	FirstStudentID  string                `json:"firstStudentId"`
+	SecondStudentID string                `json:"secondStudentId"`
+	Similarity      float64               `json:"similarity"`
+	Scripts         []*SimilarScriptsHTTP `json:"scripts"`
+}
+
+type SimilarScriptsHTTP struct {
+	First     *ScriptRefHTTP `json:"first"`
+	Second    *ScriptRefHTTP `json:"second"`
+	Identical bool           `json:"identical"`
+}
+
+type SimilarityReportHTTP struct {
+	Pairs     []*SimilarPairHTTP `json:"pairs"`
+	Compared  int                `json:"compared"`
+	Pending   int                `json:"pending"`
+	Threshold float64            `json:"threshold"`
+}
+
+type SpriteDiffHTTP struct {
+	Name              string                 `json:"name"`
+	IsStage           bool                   `json:"isStage"`
+	Change            string                 `json:"change"`
+	PropertiesChanged []string               `json:"propertiesChanged"`
+	ScriptsAdded      []string               `json:"scriptsAdded"`
+	ScriptsRemoved    []string               `json:"scriptsRemoved"`
+	ScriptsChanged    []string               `json:"scriptsChanged"`
+	BlocksAdded       []*ProjectBlockRefHTTP `json:"blocksAdded"`
+	BlocksRemoved     []*ProjectBlockRefHTTP `json:"blocksRemoved"`
+	BlocksChanged     []*ProjectBlockRefHTTP `json:"blocksChanged"`
+}
+
+type StudentAnalysisReportHTTP struct {
+	Student     *StudentHTTP    `json:"student"`
+	Projects    int             `json:"projects"`
+	BestCtScore *CtScoreHTTP    `json:"bestCtScore"`
+	Averages    *CtAveragesHTTP `json:"averages"`
+}
+
+type StudentAssignmentHTTP struct {
+	Assignment *AssignmentHTTP       `json:"assignment"`
+	Work       *AssignmentWorkHTTP   `json:"work"`
+	Review     *AssignmentReviewHTTP `json:"review"`
+}
+
+type StudentDuplicateHTTP struct {
+	Student   *StudentHTTP `json:"student"`
+	Duplicate *StudentHTTP `json:"duplicate"`
+	Reasons   []string     `json:"reasons"`
 }
 
 type StudentHTTP struct {
@@ -159,10 +716,37 @@ type TeacherHTTP struct {
 	UserHTTP *UserHTTP `json:"userHttp"`
 }
 
+type TermHTTP struct {
+	ID             string `json:"id"`
+	RobboUnitID    string `json:"robboUnitId"`
+	Name           string `json:"name"`
+	StartDate      string `json:"startDate"`
+	EndDate        string `json:"endDate"`
+	Status         string `json:"status"`
+	PreviousTermID string `json:"previousTermId"`
+}
+
+type TermRollover struct {
+	FromTermID    string            `json:"fromTermId"`
+	Name          string            `json:"name"`
+	StartDate     string            `json:"startDate"`
+	EndDate       string            `json:"endDate"`
+	CarryStudents bool              `json:"carryStudents"`
+	Promotions    []*GroupPromotion `json:"promotions"`
+}
+
 type UnitAdminHTTP struct {
 	UserHTTP *UserHTTP `json:"userHttp"`
 }
 
+type UpdateAssignment struct {
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	Instruction string                  `json:"instruction"`
+	Deadline    *string                 `json:"deadline"`
+	Checks      []*AssignmentCheckInput `json:"checks"`
+}
+
 type UpdateParentHTTP struct {
 	UserHTTP *UpdateUserHTTP `json:"userHttp"`
 }
@@ -171,6 +755,11 @@ type UpdateParentInput struct {
 	ParentHTTP *UpdateParentHTTP `json:"parentHttp"`
 }
 
+type UpdateRegion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type UpdateStudentHTTP struct {
 	UserHTTP *UpdateUserHTTP `json:"userHttp"`
 }
@@ -213,13 +802,23 @@ type UpdateUserHTTP struct {
 }
 
 type UserHTTP struct {
-	ID         string `json:"id"`
-	Email      string `json:"email"`
-	Password   string `json:"password"`
-	Role       int    `json:"role"`
-	Nickname   string `json:"nickname"`
-	Firstname  string `json:"firstname"`
-	Lastname   string `json:"lastname"`
-	Middlename string `json:"middlename"`
-	CreatedAt  string `json:"createdAt"`
+	ID         string  `json:"id"`
+	Email      string  `json:"email"`
+	Password   string  `json:"password"`
+	Role       int     `json:"role"`
+	Nickname   string  `json:"nickname"`
+	Firstname  string  `json:"firstname"`
+	Lastname   string  `json:"lastname"`
+	Middlename string  `json:"middlename"`
+	CreatedAt  string  `json:"createdAt"`
+	LastSeenAt *string `json:"lastSeenAt"`
+}
+
+type WaitlistEntryHTTP struct {
+	ID           string `json:"id"`
+	CreatedAt    string `json:"createdAt"`
+	StudentID    string `json:"studentId"`
+	RobboGroupID string `json:"robboGroupId"`
+	RobboUnitID  string `json:"robboUnitId"`
+	Position     int    `json:"position"`
 }
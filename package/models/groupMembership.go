@@ -0,0 +1,76 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type MembershipLeaveReason string
+
+const (
+	MembershipRemoved     MembershipLeaveReason = "removed"
+	MembershipTransferred MembershipLeaveReason = "transferred"
+	MembershipTermEnded   MembershipLeaveReason = "term_ended"
+)
+
+type GroupMembershipCore struct {
+	Id           string
+	StudentId    string
+	RobboGroupId string
+	RobboUnitId  string
+	JoinedAt     time.Time
+	LeftAt       *time.Time
+	LeaveReason  MembershipLeaveReason
+}
+
+func (m *GroupMembershipCore) IsActive() bool {
+	return m.LeftAt == nil
+}
+
+type GroupMembershipDB struct {
+	gorm.Model
+
+	StudentId    string     `gorm:"not null;index"`
+	RobboGroupId string     `gorm:"not null;index"`
+	RobboUnitId  string     `gorm:"not null"`
+	JoinedAt     time.Time  `gorm:"not null"`
+	LeftAt       *time.Time `gorm:"index"`
+	LeaveReason  string     `gorm:"size:32"`
+}
+
+func (em *GroupMembershipDB) ToCore() *GroupMembershipCore {
+	return &GroupMembershipCore{
+		Id:           strconv.FormatUint(uint64(em.ID), 10),
+		StudentId:    em.StudentId,
+		RobboGroupId: em.RobboGroupId,
+		RobboUnitId:  em.RobboUnitId,
+		JoinedAt:     em.JoinedAt,
+		LeftAt:       em.LeftAt,
+		LeaveReason:  MembershipLeaveReason(em.LeaveReason),
+	}
+}
+
+func (em *GroupMembershipDB) FromCore(membership *GroupMembershipCore) {
+	id, _ := strconv.ParseUint(membership.Id, 10, 64)
+	em.ID = uint(id)
+	em.StudentId = membership.StudentId
+	em.RobboGroupId = membership.RobboGroupId
+	em.RobboUnitId = membership.RobboUnitId
+	em.JoinedAt = membership.JoinedAt
+	em.LeftAt = membership.LeftAt
+	em.LeaveReason = string(membership.LeaveReason)
+}
+
+func (ht *GroupMembershipHTTP) FromCore(membership *GroupMembershipCore) {
+	ht.ID = membership.Id
+	ht.StudentID = membership.StudentId
+	ht.RobboGroupID = membership.RobboGroupId
+	ht.RobboUnitID = membership.RobboUnitId
+	ht.JoinedAt = membership.JoinedAt.Format(time.RFC3339)
+	if membership.LeftAt != nil {
+		leftAt := membership.LeftAt.Format(time.RFC3339)
+		ht.LeftAt = &leftAt
+	}
+	ht.LeaveReason = string(membership.LeaveReason)
+}
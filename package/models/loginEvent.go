@@ -0,0 +1,75 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+)
+
+type LoginEventType string
+
+const (
+	SignInEvent  LoginEventType = "signIn"
+	RefreshEvent LoginEventType = "refresh"
+)
+
+type LoginEventCore struct {
+	Id        string
+	CreatedAt string
+	UserId    string
+	Email     string
+	Role      Role
+	Type      LoginEventType
+	Ip        string
+	UserAgent string
+	Success   bool
+}
+
+type LoginEventDB struct {
+	gorm.Model
+
+	UserId    string `gorm:"size:256;index"`
+	Email     string `gorm:"size:256"`
+	Role      uint   `gorm:"not null"`
+	Type      string `gorm:"size:32;not null"`
+	Ip        string `gorm:"size:64"`
+	UserAgent string `gorm:"size:512"`
+	Success   bool
+}
+
+func (em *LoginEventDB) ToCore() *LoginEventCore {
+	return &LoginEventCore{
+		Id:        strconv.FormatUint(uint64(em.ID), 10),
+		CreatedAt: em.CreatedAt.String(),
+		UserId:    em.UserId,
+		Email:     em.Email,
+		Role:      Role(em.Role),
+		Type:      LoginEventType(em.Type),
+		Ip:        em.Ip,
+		UserAgent: em.UserAgent,
+		Success:   em.Success,
+	}
+}
+
+func (em *LoginEventDB) FromCore(loginEvent *LoginEventCore) {
+	id, _ := strconv.ParseUint(loginEvent.Id, 10, 64)
+	em.ID = uint(id)
+	em.UserId = loginEvent.UserId
+	em.Email = loginEvent.Email
+	em.Role = uint(loginEvent.Role)
+	em.Type = string(loginEvent.Type)
+	em.Ip = loginEvent.Ip
+	em.UserAgent = loginEvent.UserAgent
+	em.Success = loginEvent.Success
+}
+
+func (ht *LoginEventHTTP) FromCore(loginEvent *LoginEventCore) {
+	ht.ID = loginEvent.Id
+	ht.CreatedAt = loginEvent.CreatedAt
+	ht.UserID = loginEvent.UserId
+	ht.Email = loginEvent.Email
+	ht.Role = int(loginEvent.Role)
+	ht.Type = string(loginEvent.Type)
+	ht.IP = loginEvent.Ip
+	ht.UserAgent = loginEvent.UserAgent
+	ht.Success = loginEvent.Success
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type NotificationKind string
+
+const (
+	AbsenceNotification           NotificationKind = "absence"
+	WaitlistPromotionNotification NotificationKind = "waitlistPromotion"
+)
+
+type NotificationCore struct {
+	Id            string
+	CreatedAt     time.Time
+	RecipientId   string
+	RecipientRole Role
+	Kind          NotificationKind
+	Text          string
+	Read          bool
+}
+
+type NotificationDB struct {
+	gorm.Model
+
+	RecipientId   string `gorm:"size:256;not null;index:idx_notification_recipient"`
+	RecipientRole uint   `gorm:"not null;index:idx_notification_recipient"`
+	Kind          string `gorm:"size:32;not null"`
+	Text          string `gorm:"size:1024"`
+	Read          bool
+}
+
+func (em *NotificationDB) ToCore() *NotificationCore {
+	return &NotificationCore{
+		Id:            strconv.FormatUint(uint64(em.ID), 10),
+		CreatedAt:     em.CreatedAt,
+		RecipientId:   em.RecipientId,
+		RecipientRole: Role(em.RecipientRole),
+		Kind:          NotificationKind(em.Kind),
+		Text:          em.Text,
+		Read:          em.Read,
+	}
+}
+
+func (em *NotificationDB) FromCore(notification *NotificationCore) {
+	id, _ := strconv.ParseUint(notification.Id, 10, 64)
+	em.ID = uint(id)
+	em.RecipientId = notification.RecipientId
+	em.RecipientRole = uint(notification.RecipientRole)
+	em.Kind = string(notification.Kind)
+	em.Text = notification.Text
+	em.Read = notification.Read
+}
+
+func (ht *NotificationHTTP) FromCore(notification *NotificationCore) {
+	ht.ID = notification.Id
+	ht.CreatedAt = notification.CreatedAt.Format(time.RFC3339)
+	ht.Kind = string(notification.Kind)
+	ht.Text = notification.Text
+	ht.Read = notification.Read
+}
@@ -0,0 +1,193 @@
+package models
+
+import "encoding/json"
+
+type CtLevel string
+
+const (
+	CtBasic      CtLevel = "basic"
+	CtDeveloping CtLevel = "developing"
+	CtProficient CtLevel = "proficient"
+)
+
+// CtScoreCore is the Dr. Scratch computational thinking score, every dimension is rated from 0 to 3.
+type CtScoreCore struct {
+	Abstraction        int `json:"abstraction"`
+	Parallelism        int `json:"parallelism"`
+	Logic              int `json:"logic"`
+	Synchronization    int `json:"synchronization"`
+	FlowControl        int `json:"flowControl"`
+	UserInteractivity  int `json:"userInteractivity"`
+	DataRepresentation int `json:"dataRepresentation"`
+}
+
+// Total is the sum of the dimensions, from 0 to 21.
+func (s CtScoreCore) Total() int {
+	return s.Abstraction + s.Parallelism + s.Logic + s.Synchronization +
+		s.FlowControl + s.UserInteractivity + s.DataRepresentation
+}
+
+func (s CtScoreCore) Level() CtLevel {
+	switch total := s.Total(); {
+	case total >= 15:
+		return CtProficient
+	case total >= 8:
+		return CtDeveloping
+	default:
+		return CtBasic
+	}
+}
+
+func (ht *CtScoreHTTP) FromCore(score CtScoreCore) {
+	ht.Abstraction = score.Abstraction
+	ht.Parallelism = score.Parallelism
+	ht.Logic = score.Logic
+	ht.Synchronization = score.Synchronization
+	ht.FlowControl = score.FlowControl
+	ht.UserInteractivity = score.UserInteractivity
+	ht.DataRepresentation = score.DataRepresentation
+	ht.Total = score.Total()
+	ht.Level = string(score.Level())
+}
+
+type BlockCategoryCountCore struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// ProjectAnalysisCore are the metrics of a project.json. Shadow blocks, the inputs
+// Scratch fills in by itself, are not counted.
+type ProjectAnalysisCore struct {
+	Sprites          int                      `json:"sprites"`
+	Scripts          int                      `json:"scripts"`
+	Blocks           int                      `json:"blocks"`
+	BlocksByCategory []BlockCategoryCountCore `json:"blocksByCategory"`
+	Extensions       []string                 `json:"extensions"`
+	Loops            int                      `json:"loops"`
+	Conditionals     int                      `json:"conditionals"`
+	Variables        int                      `json:"variables"`
+	Lists            int                      `json:"lists"`
+	Broadcasts       int                      `json:"broadcasts"`
+	CustomBlocks     int                      `json:"customBlocks"`
+	Clones           int                      `json:"clones"`
+	// ParallelScripts counts the scripts started by the same event as another script
+	ParallelScripts int         `json:"parallelScripts"`
+	CtScore         CtScoreCore `json:"ctScore"`
+}
+
+// analysisFromJson reads the analysis a revision has been stored with, nil for revisions saved before analyses existed
+// and for projects the analyzer cannot read.
+func analysisFromJson(analysisJson string) *ProjectAnalysisCore {
+	if analysisJson == "" || analysisJson == unanalyzable {
+		return nil
+	}
+	analysis := &ProjectAnalysisCore{}
+	if err := json.Unmarshal([]byte(analysisJson), analysis); err != nil {
+		return nil
+	}
+	return analysis
+}
+
+// unanalyzable is stored for a project the analyzer cannot read, so that it is not analyzed again
+// as a revision saved before analyses existed.
+const unanalyzable = "null"
+
+func analysisToJson(analysis *ProjectAnalysisCore) string {
+	if analysis == nil {
+		return unanalyzable
+	}
+	analysisJson, _ := json.Marshal(analysis)
+	return string(analysisJson)
+}
+
+func (ht *ProjectAnalysisHTTP) FromCore(analysis *ProjectAnalysisCore) {
+	ht.Sprites = analysis.Sprites
+	ht.Scripts = analysis.Scripts
+	ht.Blocks = analysis.Blocks
+	ht.BlocksByCategory = make([]*BlockCategoryCountHTTP, 0, len(analysis.BlocksByCategory))
+	for _, category := range analysis.BlocksByCategory {
+		ht.BlocksByCategory = append(ht.BlocksByCategory, &BlockCategoryCountHTTP{
+			Category: category.Category,
+			Count:    category.Count,
+		})
+	}
+	ht.Extensions = append([]string{}, analysis.Extensions...)
+	ht.Loops = analysis.Loops
+	ht.Conditionals = analysis.Conditionals
+	ht.Variables = analysis.Variables
+	ht.Lists = analysis.Lists
+	ht.Broadcasts = analysis.Broadcasts
+	ht.CustomBlocks = analysis.CustomBlocks
+	ht.Clones = analysis.Clones
+	ht.ParallelScripts = analysis.ParallelScripts
+	ht.CtScore = &CtScoreHTTP{}
+	ht.CtScore.FromCore(analysis.CtScore)
+}
+
+// ProjectAnalysisOfAuthorCore is the analysis of the latest revision of one project.
+// Analysis is nil when the revision predates analyses or the project has no revisions.
+type ProjectAnalysisOfAuthorCore struct {
+	ProjectId string
+	AuthorId  string
+	Analysis  *ProjectAnalysisCore
+}
+
+// CtAveragesCore averages CtScoreCore over a number of projects.
+type CtAveragesCore struct {
+	Abstraction        float64
+	Parallelism        float64
+	Logic              float64
+	Synchronization    float64
+	FlowControl        float64
+	UserInteractivity  float64
+	DataRepresentation float64
+	Total              float64
+}
+
+func (ht *CtAveragesHTTP) FromCore(averages CtAveragesCore) {
+	ht.Abstraction = averages.Abstraction
+	ht.Parallelism = averages.Parallelism
+	ht.Logic = averages.Logic
+	ht.Synchronization = averages.Synchronization
+	ht.FlowControl = averages.FlowControl
+	ht.UserInteractivity = averages.UserInteractivity
+	ht.DataRepresentation = averages.DataRepresentation
+	ht.Total = averages.Total
+}
+
+type StudentAnalysisReportCore struct {
+	Student  *StudentCore
+	Projects int
+	// BestCtScore is the score of the strongest project of the student
+	BestCtScore CtScoreCore
+	Averages    CtAveragesCore
+}
+
+// GroupAnalysisReportCore has a line for every current member of the group. The averages
+// of the group are taken over the projects of all members, not over the members.
+type GroupAnalysisReportCore struct {
+	RobboGroupId string
+	Projects     int
+	Averages     CtAveragesCore
+	Students     []*StudentAnalysisReportCore
+}
+
+func (ht *GroupAnalysisReportHTTP) FromCore(report *GroupAnalysisReportCore) {
+	ht.RobboGroupID = report.RobboGroupId
+	ht.Projects = report.Projects
+	ht.Averages = &CtAveragesHTTP{}
+	ht.Averages.FromCore(report.Averages)
+	ht.Students = make([]*StudentAnalysisReportHTTP, 0, len(report.Students))
+	for _, line := range report.Students {
+		lineHttp := &StudentAnalysisReportHTTP{
+			Student:     &StudentHTTP{UserHTTP: &UserHTTP{}},
+			Projects:    line.Projects,
+			BestCtScore: &CtScoreHTTP{},
+			Averages:    &CtAveragesHTTP{},
+		}
+		lineHttp.Student.FromCore(line.Student)
+		lineHttp.BestCtScore.FromCore(line.BestCtScore)
+		lineHttp.Averages.FromCore(line.Averages)
+		ht.Students = append(ht.Students, lineHttp)
+	}
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type CommentStatus string
+
+const (
+	CommentVisible CommentStatus = "visible"
+	// CommentHidden is set by a moderator or once a comment collects enough reports
+	CommentHidden  CommentStatus = "hidden"
+	CommentDeleted CommentStatus = "deleted"
+)
+
+const CommentNotification NotificationKind = "projectComment"
+
+type ProjectCommentCore struct {
+	Id         string
+	CreatedAt  time.Time
+	ProjectId  string
+	ParentId   string
+	AuthorId   string
+	AuthorRole Role
+	Text       string
+	Status     CommentStatus
+	EditedAt   *time.Time
+}
+
+// ProjectCommentDB is never removed, a deleted comment keeps its place in the thread
+// so the replies to it stay where they were.
+type ProjectCommentDB struct {
+	gorm.Model
+
+	ProjectId  string `gorm:"size:256;not null;index"`
+	ParentId   string `gorm:"size:256"`
+	AuthorId   string `gorm:"size:256;not null"`
+	AuthorRole uint   `gorm:"not null"`
+	Text       string `gorm:"size:2048;not null"`
+	Status     string `gorm:"size:16;not null"`
+	EditedAt   *time.Time
+}
+
+func (em *ProjectCommentDB) ToCore() *ProjectCommentCore {
+	return &ProjectCommentCore{
+		Id:         strconv.FormatUint(uint64(em.ID), 10),
+		CreatedAt:  em.CreatedAt,
+		ProjectId:  em.ProjectId,
+		ParentId:   em.ParentId,
+		AuthorId:   em.AuthorId,
+		AuthorRole: Role(em.AuthorRole),
+		Text:       em.Text,
+		Status:     CommentStatus(em.Status),
+		EditedAt:   em.EditedAt,
+	}
+}
+
+func (em *ProjectCommentDB) FromCore(comment *ProjectCommentCore) {
+	id, _ := strconv.ParseUint(comment.Id, 10, 64)
+	em.ID = uint(id)
+	em.ProjectId = comment.ProjectId
+	em.ParentId = comment.ParentId
+	em.AuthorId = comment.AuthorId
+	em.AuthorRole = uint(comment.AuthorRole)
+	em.Text = comment.Text
+	em.Status = string(comment.Status)
+	em.EditedAt = comment.EditedAt
+}
+
+func (ht *ProjectCommentHTTP) FromCore(comment *ProjectCommentCore) {
+	ht.ID = comment.Id
+	ht.CreatedAt = comment.CreatedAt.Format(time.RFC3339)
+	ht.ProjectID = comment.ProjectId
+	ht.ParentID = comment.ParentId
+	ht.AuthorID = comment.AuthorId
+	ht.AuthorRole = int(comment.AuthorRole)
+	ht.Text = comment.Text
+	ht.Status = string(comment.Status)
+	if comment.EditedAt != nil {
+		editedAt := comment.EditedAt.Format(time.RFC3339)
+		ht.EditedAt = &editedAt
+	}
+}
+
+// CommentedProjectCore is what commenting needs to know about the project page.
+type CommentedProjectCore struct {
+	ProjectId        string
+	Title            string
+	AuthorId         string
+	AuthorRole       Role
+	IsShared         bool
+	CommentsDisabled bool
+}
+
+type CommentReportCore struct {
+	CommentId    string
+	ReporterId   string
+	ReporterRole Role
+	Reason       string
+	CreatedAt    time.Time
+}
+
+// CommentReportDB allows one report per user and comment. Moderating the comment resolves its reports.
+type CommentReportDB struct {
+	gorm.Model
+
+	CommentId    string `gorm:"size:256;not null;uniqueIndex:idx_comment_reporter"`
+	ReporterId   string `gorm:"size:256;not null;uniqueIndex:idx_comment_reporter"`
+	ReporterRole uint   `gorm:"not null;uniqueIndex:idx_comment_reporter"`
+	Reason       string `gorm:"size:512"`
+	Resolved     bool   `gorm:"not null;default:false"`
+}
+
+func (em *CommentReportDB) ToCore() *CommentReportCore {
+	return &CommentReportCore{
+		CommentId:    em.CommentId,
+		ReporterId:   em.ReporterId,
+		ReporterRole: Role(em.ReporterRole),
+		Reason:       em.Reason,
+		CreatedAt:    em.CreatedAt,
+	}
+}
+
+func (em *CommentReportDB) FromCore(report *CommentReportCore) {
+	em.CommentId = report.CommentId
+	em.ReporterId = report.ReporterId
+	em.ReporterRole = uint(report.ReporterRole)
+	em.Reason = report.Reason
+}
+
+// ReportedCommentCore is a comment waiting for a moderator with its open reports.
+type ReportedCommentCore struct {
+	Comment *ProjectCommentCore
+	Reports []*CommentReportCore
+}
+
+func (ht *ReportedCommentHTTP) FromCore(reported *ReportedCommentCore) {
+	ht.Comment = &ProjectCommentHTTP{}
+	ht.Comment.FromCore(reported.Comment)
+	ht.Reports = make([]*CommentReportHTTP, 0, len(reported.Reports))
+	for _, report := range reported.Reports {
+		ht.Reports = append(ht.Reports, &CommentReportHTTP{
+			ReporterID:   report.ReporterId,
+			ReporterRole: int(report.ReporterRole),
+			Reason:       report.Reason,
+			CreatedAt:    report.CreatedAt.Format(time.RFC3339),
+		})
+	}
+}
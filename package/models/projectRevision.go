@@ -0,0 +1,154 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type ProjectRevisionReason string
+
+const (
+	RevisionCreated  ProjectRevisionReason = "created"
+	RevisionAutosave ProjectRevisionReason = "autosave"
+	RevisionRestore  ProjectRevisionReason = "restore"
+)
+
+type ProjectRevisionCore struct {
+	Id             string
+	ProjectId      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Reason         ProjectRevisionReason
+	RestoredFromId string
+	Checksum       string
+	Size           int
+	Json           string
+	// Analysis is nil for revisions saved before analyses existed and for JSON that could not be analyzed
+	Analysis *ProjectAnalysisCore
+}
+
+// ProjectRevisionDB is a snapshot of the JSON of a project. The newest revision of a project
+// always matches its current JSON, the two share the blob of the body.
+type ProjectRevisionDB struct {
+	gorm.Model
+
+	ProjectId      string `gorm:"not null;index"`
+	Reason         string `gorm:"not null;size:32"`
+	RestoredFromId string
+	Checksum       string `gorm:"not null;size:64"`
+	Size           int    `gorm:"not null"`
+	// BodyHash is the blob of the JSON, empty for revisions that still keep it in LegacyJson
+	BodyHash string `gorm:"not null;size:64;default:''"`
+	// LegacyJson is where revisions kept their JSON before the blob storage
+	LegacyJson string `gorm:"column:json;not null;default:''"`
+	// Analysis is the JSON of ProjectAnalysisCore
+	Analysis string `gorm:"not null;default:''"`
+}
+
+func (em *ProjectRevisionDB) ToCore() *ProjectRevisionCore {
+	return &ProjectRevisionCore{
+		Id:             strconv.FormatUint(uint64(em.ID), 10),
+		ProjectId:      em.ProjectId,
+		CreatedAt:      em.CreatedAt,
+		UpdatedAt:      em.UpdatedAt,
+		Reason:         ProjectRevisionReason(em.Reason),
+		RestoredFromId: em.RestoredFromId,
+		Checksum:       em.Checksum,
+		Size:           em.Size,
+		Json:           em.LegacyJson,
+		Analysis:       analysisFromJson(em.Analysis),
+	}
+}
+
+func (em *ProjectRevisionDB) FromCore(revision *ProjectRevisionCore) {
+	id, _ := strconv.ParseUint(revision.Id, 10, 64)
+	em.ID = uint(id)
+	em.ProjectId = revision.ProjectId
+	em.Reason = string(revision.Reason)
+	em.RestoredFromId = revision.RestoredFromId
+	em.Checksum = revision.Checksum
+	em.Size = revision.Size
+	em.Analysis = analysisToJson(revision.Analysis)
+}
+
+// FromCore leaves Json out when the revision was listed without its body.
+func (ht *ProjectRevisionHTTP) FromCore(revision *ProjectRevisionCore) {
+	ht.ID = revision.Id
+	ht.ProjectID = revision.ProjectId
+	ht.CreatedAt = revision.CreatedAt.String()
+	ht.UpdatedAt = revision.UpdatedAt.String()
+	ht.Reason = string(revision.Reason)
+	ht.RestoredFromID = revision.RestoredFromId
+	ht.Size = revision.Size
+	if revision.Json != "" {
+		json := revision.Json
+		ht.JSON = &json
+	}
+	if revision.Analysis != nil {
+		ht.Analysis = &ProjectAnalysisHTTP{}
+		ht.Analysis.FromCore(revision.Analysis)
+	}
+}
+
+type ProjectChange string
+
+const (
+	ProjectChangeAdded   ProjectChange = "added"
+	ProjectChangeRemoved ProjectChange = "removed"
+	ProjectChangeChanged ProjectChange = "changed"
+)
+
+type ProjectBlockRefCore struct {
+	BlockId string
+	Opcode  string
+}
+
+// SpriteDiffCore describes how one sprite, or the stage, differs between two revisions.
+// Scripts are identified by the id of their top-level block.
+type SpriteDiffCore struct {
+	Name              string
+	IsStage           bool
+	Change            ProjectChange
+	PropertiesChanged []string
+	ScriptsAdded      []string
+	ScriptsRemoved    []string
+	ScriptsChanged    []string
+	BlocksAdded       []ProjectBlockRefCore
+	BlocksRemoved     []ProjectBlockRefCore
+	BlocksChanged     []ProjectBlockRefCore
+}
+
+type ProjectRevisionDiffCore struct {
+	FromRevisionId string
+	ToRevisionId   string
+	Sprites        []*SpriteDiffCore
+}
+
+func blockRefsHTTP(refs []ProjectBlockRefCore) []*ProjectBlockRefHTTP {
+	refsHttp := make([]*ProjectBlockRefHTTP, 0, len(refs))
+	for _, ref := range refs {
+		refsHttp = append(refsHttp, &ProjectBlockRefHTTP{BlockID: ref.BlockId, Opcode: ref.Opcode})
+	}
+	return refsHttp
+}
+
+func (ht *ProjectRevisionDiffHTTP) FromCore(diff *ProjectRevisionDiffCore) {
+	ht.FromRevisionID = diff.FromRevisionId
+	ht.ToRevisionID = diff.ToRevisionId
+	ht.Sprites = make([]*SpriteDiffHTTP, 0, len(diff.Sprites))
+	for _, sprite := range diff.Sprites {
+		ht.Sprites = append(ht.Sprites, &SpriteDiffHTTP{
+			Name:              sprite.Name,
+			IsStage:           sprite.IsStage,
+			Change:            string(sprite.Change),
+			PropertiesChanged: sprite.PropertiesChanged,
+			ScriptsAdded:      sprite.ScriptsAdded,
+			ScriptsRemoved:    sprite.ScriptsRemoved,
+			ScriptsChanged:    sprite.ScriptsChanged,
+			BlocksAdded:       blockRefsHTTP(sprite.BlocksAdded),
+			BlocksRemoved:     blockRefsHTTP(sprite.BlocksRemoved),
+			BlocksChanged:     blockRefsHTTP(sprite.BlocksChanged),
+		})
+	}
+}
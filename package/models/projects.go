@@ -6,33 +6,57 @@ import (
 )
 
 type ProjectCore struct {
-	ID       string
-	Name     string
-	AuthorId string
-	Json     string
+	ID         string
+	Name       string
+	AuthorId   string
+	AuthorRole Role
+	Json       string
+	ParentId   string
+	// Checksum is the sha256 of Json, the ETag of the project
+	Checksum string
 }
 
+// ProjectBodyPrefix is where the bodies of projects are kept in the blob storage,
+// each under the sha256 of its JSON.
+const ProjectBodyPrefix = "projects"
+
+// ProjectDB is the metadata of a project, its JSON lives in the blob storage.
 type ProjectDB struct {
 	gorm.Model
 
 	Name     string `gorm:"not null;size:256"`
 	AuthorId string `gorm:"not null;size:256"`
-	Json     string `gorm:"not null;size:65535"`
+	// AuthorRole tells whose table AuthorId points to, projects created before it was recorded belong to students
+	AuthorRole uint `gorm:"not null;default:0"`
+	// BodyHash is the sha256 of the JSON, empty for projects that have no body or still keep it in LegacyJson
+	BodyHash string `gorm:"not null;size:64;default:''"`
+	BodySize int    `gorm:"not null;default:0"`
+	// LegacyJson is where bodies were kept before the blob storage, it is emptied when the project is saved again
+	LegacyJson string `gorm:"column:json;not null;default:''"`
+	// ParentId is the project this one was remixed from, empty for original work
+	ParentId string `gorm:"size:256;index"`
 }
 
 type ProjectHTTP struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	AuthorId string `json:"authorId"`
-	Json     string `json:"json"`
+	// AuthorRole is only reported, a project is always saved for the user saving it
+	AuthorRole Role   `json:"authorRole"`
+	Json       string `json:"json"`
+	ParentId   string `json:"parentId"`
+	Checksum   string `json:"checksum"`
 }
 
 func (em *ProjectDB) ToCore() *ProjectCore {
 	return &ProjectCore{
-		ID:       strconv.FormatUint(uint64(em.ID), 10),
-		Name:     em.Name,
-		AuthorId: em.AuthorId,
-		Json:     em.Json,
+		ID:         strconv.FormatUint(uint64(em.ID), 10),
+		Name:       em.Name,
+		AuthorId:   em.AuthorId,
+		AuthorRole: Role(em.AuthorRole),
+		Json:       em.LegacyJson,
+		ParentId:   em.ParentId,
+		Checksum:   em.BodyHash,
 	}
 }
 
@@ -41,7 +65,8 @@ func (em *ProjectDB) FromCore(project *ProjectCore) {
 	em.ID = uint(id)
 	em.Name = project.Name
 	em.AuthorId = project.AuthorId
-	em.Json = project.Json
+	em.AuthorRole = uint(project.AuthorRole)
+	em.ParentId = project.ParentId
 }
 
 func (ht *ProjectHTTP) ToCore() *ProjectCore {
@@ -57,5 +82,8 @@ func (ht *ProjectHTTP) FromCore(project *ProjectCore) {
 	ht.ID = project.ID
 	ht.Name = project.Name
 	ht.AuthorId = project.AuthorId
+	ht.AuthorRole = project.AuthorRole
 	ht.Json = project.Json
+	ht.ParentId = project.ParentId
+	ht.Checksum = project.Checksum
 }
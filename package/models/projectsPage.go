@@ -2,6 +2,7 @@ package models
 
 import (
 	"gorm.io/gorm"
+	"time"
 )
 
 type ProjectPageCore struct {
@@ -13,6 +14,20 @@ type ProjectPageCore struct {
 	Preview      string
 	LinkScratch  string
 	IsShared     bool
+	RemixedFrom  *RemixCreditCore
+	// PlaceholderOf is the checksum of the project JSON the preview was generated from,
+	// empty when the preview was uploaded by the editor
+	PlaceholderOf string
+	// CommentsDisabled is only changed through the comments switch, never by a page update
+	CommentsDisabled bool
+}
+
+// RemixCreditCore credits the project a remix was made from. It is copied at remix time,
+// so the credit stays when the original is renamed or deleted.
+type RemixCreditCore struct {
+	ProjectId string
+	Title     string
+	AuthorId  string
 }
 
 type ProjectPageDB struct {
@@ -26,8 +41,22 @@ type ProjectPageDB struct {
 	LinkScratch string    `gorm:"size:256;not null"`
 	Title       string    `gorm:"size:256;not null"`
 	IsShared    bool
+	// SharedAt is set the first time the page is shared and cleared when it stops being shared
+	SharedAt *time.Time `gorm:"index"`
+
+	RemixedFromProjectId string `gorm:"size:256"`
+	RemixedFromTitle     string `gorm:"size:256"`
+	RemixedFromAuthorId  string `gorm:"size:256"`
+
+	CommentsDisabled bool `gorm:"not null;default:false"`
+
+	PlaceholderOf string `gorm:"size:64;not null;default:''"`
 }
 
+// ThumbnailPrefix is where the thumbnails of projects are kept in the blob storage,
+// each under the sha256 of its PNG.
+const ThumbnailPrefix = "thumbnails"
+
 func (em *ProjectPageDB) ToCore() *ProjectPageCore {
 	return &ProjectPageCore{
 		LastModified: em.UpdatedAt.String(),
@@ -38,6 +67,21 @@ func (em *ProjectPageDB) ToCore() *ProjectPageCore {
 		Preview:      em.Preview,
 		LinkScratch:  em.LinkScratch,
 		IsShared:     em.IsShared,
+		RemixedFrom:  em.remixCredit(),
+
+		PlaceholderOf:    em.PlaceholderOf,
+		CommentsDisabled: em.CommentsDisabled,
+	}
+}
+
+func (em *ProjectPageDB) remixCredit() *RemixCreditCore {
+	if em.RemixedFromProjectId == "" {
+		return nil
+	}
+	return &RemixCreditCore{
+		ProjectId: em.RemixedFromProjectId,
+		Title:     em.RemixedFromTitle,
+		AuthorId:  em.RemixedFromAuthorId,
 	}
 }
 
@@ -49,6 +93,12 @@ func (em *ProjectPageDB) FromCore(pp *ProjectPageCore) {
 	em.LinkScratch = pp.LinkScratch
 	em.Title = pp.Title
 	em.IsShared = pp.IsShared
+	em.PlaceholderOf = pp.PlaceholderOf
+	if pp.RemixedFrom != nil {
+		em.RemixedFromProjectId = pp.RemixedFrom.ProjectId
+		em.RemixedFromTitle = pp.RemixedFrom.Title
+		em.RemixedFromAuthorId = pp.RemixedFrom.AuthorId
+	}
 }
 
 func (ht *ProjectPageHTTP) ToCore() *ProjectPageCore {
@@ -73,4 +123,42 @@ func (ht *ProjectPageHTTP) FromCore(pp *ProjectPageCore) {
 	ht.LinkScratch = pp.LinkScratch
 	ht.Title = pp.Title
 	ht.IsShared = pp.IsShared
+	ht.CommentsDisabled = pp.CommentsDisabled
+	if pp.RemixedFrom != nil {
+		ht.RemixedFrom = &RemixCreditHTTP{
+			ProjectID: pp.RemixedFrom.ProjectId,
+			Title:     pp.RemixedFrom.Title,
+			AuthorID:  pp.RemixedFrom.AuthorId,
+		}
+	}
+}
+
+type RemixNodeCore struct {
+	ProjectId  string
+	ParentId   string
+	Title      string
+	AuthorId   string
+	AuthorRole Role
+	IsShared   bool
+	Deleted    bool
+	Depth      int
+	CreatedAt  time.Time
+}
+
+// RemixCountCore counts the remixes that still exist, Total includes remixes of remixes.
+type RemixCountCore struct {
+	Direct int
+	Total  int
+}
+
+func (ht *RemixNodeHTTP) FromCore(node *RemixNodeCore) {
+	ht.ProjectID = node.ProjectId
+	ht.ParentID = node.ParentId
+	ht.Title = node.Title
+	ht.AuthorID = node.AuthorId
+	ht.AuthorRole = int(node.AuthorRole)
+	ht.IsShared = node.IsShared
+	ht.Deleted = node.Deleted
+	ht.Depth = node.Depth
+	ht.CreatedAt = node.CreatedAt.Format(time.RFC3339)
 }
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type RegionCore struct {
+	Id           string
+	LastModified string
+	Name         string
+}
+
+// RegionDB groups RobboUnits of a region or a franchise.
+type RegionDB struct {
+	gorm.Model
+
+	Name string `gorm:"size:256;not null;uniqueIndex"`
+}
+
+func (em *RegionDB) ToCore() *RegionCore {
+	return &RegionCore{
+		Id:           strconv.FormatUint(uint64(em.ID), 10),
+		LastModified: em.UpdatedAt.Format(time.RFC3339),
+		Name:         em.Name,
+	}
+}
+
+func (em *RegionDB) FromCore(region *RegionCore) {
+	id, _ := strconv.ParseUint(region.Id, 10, 64)
+	em.ID = uint(id)
+	em.Name = region.Name
+}
+
+func (ht *RegionHTTP) FromCore(region *RegionCore) {
+	ht.ID = region.Id
+	ht.LastModified = region.LastModified
+	ht.Name = region.Name
+}
+
+type RegionAdminDB struct {
+	UserDB
+	RegionId string `gorm:"index"`
+}
+
+type RegionAdminCore struct {
+	UserCore
+	RegionId string
+}
+
+func (em *RegionAdminDB) ToCore() *RegionAdminCore {
+	return &RegionAdminCore{
+		UserCore: em.UserDB.ToCore(),
+		RegionId: em.RegionId,
+	}
+}
+
+func (em *RegionAdminDB) FromCore(regionAdmin *RegionAdminCore) {
+	id, _ := strconv.ParseUint(regionAdmin.Id, 10, 64)
+	em.ID = uint(id)
+	em.UserDB.FromCore(&regionAdmin.UserCore)
+	em.RegionId = regionAdmin.RegionId
+}
+
+func (ht *RegionAdminHTTP) FromCore(regionAdmin *RegionAdminCore) {
+	ht.UserHTTP = &UserHTTP{}
+	ht.UserHTTP.FromCore(&regionAdmin.UserCore)
+	ht.RegionID = regionAdmin.RegionId
+}
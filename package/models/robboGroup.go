@@ -3,6 +3,7 @@ package models
 import (
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type RobboGroupCore struct {
@@ -10,13 +11,22 @@ type RobboGroupCore struct {
 	LastModified string
 	Name         string
 	RobboUnitId  string
-	Students     []*StudentCore
+	// Capacity is the maximum number of students, zero means the group is not limited
+	Capacity int
+	// TermId is empty for groups created before terms were introduced
+	TermId   string
+	Archived bool
+	Students []*StudentCore
 }
 
 type RobboGroupDB struct {
 	gorm.Model
 	RobboUnitId string `gorm:"not null"`
 	Name        string `gorm:"size:256;not null"`
+	Capacity    uint   `gorm:"not null;default:0"`
+	TermId      string `gorm:"index"`
+	// ArchivedAt is set when the term of the group is archived, archived groups are read-only
+	ArchivedAt *time.Time
 }
 
 func (em *RobboGroupDB) ToCore() *RobboGroupCore {
@@ -25,6 +35,9 @@ func (em *RobboGroupDB) ToCore() *RobboGroupCore {
 		LastModified: em.UpdatedAt.String(),
 		Name:         em.Name,
 		RobboUnitId:  em.RobboUnitId,
+		Capacity:     int(em.Capacity),
+		TermId:       em.TermId,
+		Archived:     em.ArchivedAt != nil,
 	}
 }
 
@@ -33,6 +46,8 @@ func (em *RobboGroupDB) FromCore(robboGroup *RobboGroupCore) {
 	em.ID = uint(id)
 	em.Name = robboGroup.Name
 	em.RobboUnitId = robboGroup.RobboUnitId
+	em.Capacity = uint(robboGroup.Capacity)
+	em.TermId = robboGroup.TermId
 }
 
 func (ht *RobboGroupHTTP) ToCore() *RobboGroupCore {
@@ -45,6 +60,8 @@ func (ht *RobboGroupHTTP) ToCore() *RobboGroupCore {
 		LastModified: ht.LastModified,
 		RobboUnitId:  ht.RobboUnitID,
 		Name:         ht.Name,
+		Capacity:     ht.Capacity,
+		TermId:       ht.TermID,
 		Students:     studentsCore,
 	}
 }
@@ -54,6 +71,9 @@ func (ht *RobboGroupHTTP) FromCore(robboGroup *RobboGroupCore) {
 	ht.LastModified = robboGroup.LastModified
 	ht.Name = robboGroup.Name
 	ht.RobboUnitID = robboGroup.RobboUnitId
+	ht.Capacity = robboGroup.Capacity
+	ht.TermID = robboGroup.TermId
+	ht.Archived = robboGroup.Archived
 	for _, studentCore := range robboGroup.Students {
 		studentHttpTemp := StudentHTTP{
 			UserHTTP:     &UserHTTP{},
@@ -2,6 +2,7 @@ package models
 
 import (
 	"gorm.io/gorm"
+	"sort"
 	"strconv"
 )
 
@@ -10,13 +11,37 @@ type RobboUnitCore struct {
 	LastModified string
 	Name         string
 	City         string
+	Address      string
+	// Latitude and Longitude are both nil for units that have not been placed on the map yet
+	Latitude     *float64
+	Longitude    *float64
+	WorkingHours string
+	// Phones are kept as they are when nil on update, an empty list removes them
+	Phones []string
+	// RegionId is empty for units outside of any region
+	RegionId string
 }
 
 type RobboUnitDB struct {
 	gorm.Model
 
-	Name string `gorm:"size:256;not null"`
-	City string `gorm:"size:256;not null"`
+	Name         string             `gorm:"size:256;not null"`
+	City         string             `gorm:"size:256;not null;index"`
+	Address      string             `gorm:"size:512"`
+	Latitude     *float64           `gorm:"index:idx_robbo_unit_location"`
+	Longitude    *float64           `gorm:"index:idx_robbo_unit_location"`
+	WorkingHours string             `gorm:"size:512"`
+	Phones       []RobboUnitPhoneDB `gorm:"foreignKey:RobboUnitId"`
+	RegionId     string             `gorm:"index"`
+}
+
+// RobboUnitPhoneDB is one contact phone of a unit, Position keeps the order the phones were given in.
+// Phones are deleted for good when the unit's phones are replaced.
+type RobboUnitPhoneDB struct {
+	ID          uint   `gorm:"primaryKey"`
+	RobboUnitId uint   `gorm:"not null;index"`
+	Position    int    `gorm:"not null"`
+	Phone       string `gorm:"size:64;not null"`
 }
 
 func (em *RobboUnitDB) ToCore() *RobboUnitCore {
@@ -25,6 +50,12 @@ func (em *RobboUnitDB) ToCore() *RobboUnitCore {
 		LastModified: em.UpdatedAt.String(),
 		Name:         em.Name,
 		City:         em.City,
+		Address:      em.Address,
+		Latitude:     em.Latitude,
+		Longitude:    em.Longitude,
+		WorkingHours: em.WorkingHours,
+		Phones:       phonesOf(em.Phones),
+		RegionId:     em.RegionId,
 	}
 }
 
@@ -33,6 +64,14 @@ func (em *RobboUnitDB) FromCore(robboUnit *RobboUnitCore) {
 	em.ID = uint(id)
 	em.Name = robboUnit.Name
 	em.City = robboUnit.City
+	em.Address = robboUnit.Address
+	em.Latitude = robboUnit.Latitude
+	em.Longitude = robboUnit.Longitude
+	em.WorkingHours = robboUnit.WorkingHours
+	em.Phones = nil
+	for position, phone := range robboUnit.Phones {
+		em.Phones = append(em.Phones, RobboUnitPhoneDB{RobboUnitId: em.ID, Position: position, Phone: phone})
+	}
 }
 
 func (ht *RobboUnitHTTP) ToCore() *RobboUnitCore {
@@ -41,6 +80,11 @@ func (ht *RobboUnitHTTP) ToCore() *RobboUnitCore {
 		LastModified: ht.LastModified,
 		Name:         ht.Name,
 		City:         ht.City,
+		Address:      ht.Address,
+		Latitude:     ht.Latitude,
+		Longitude:    ht.Longitude,
+		WorkingHours: ht.WorkingHours,
+		Phones:       ht.Phones,
 	}
 }
 
@@ -49,4 +93,49 @@ func (ht *RobboUnitHTTP) FromCore(robboUnit *RobboUnitCore) {
 	ht.LastModified = robboUnit.LastModified
 	ht.Name = robboUnit.Name
 	ht.City = robboUnit.City
+	ht.Address = robboUnit.Address
+	ht.Latitude = robboUnit.Latitude
+	ht.Longitude = robboUnit.Longitude
+	ht.WorkingHours = robboUnit.WorkingHours
+	ht.Phones = robboUnit.Phones
+	ht.RegionID = robboUnit.RegionId
+	if ht.Phones == nil {
+		ht.Phones = []string{}
+	}
+}
+
+// phonesOf lists the phones in their order, the phones may be loaded in any order.
+func phonesOf(phonesDb []RobboUnitPhoneDB) (phones []string) {
+	ordered := append([]RobboUnitPhoneDB(nil), phonesDb...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+	for _, phoneDb := range ordered {
+		phones = append(phones, phoneDb.Phone)
+	}
+	return
+}
+
+// RobboUnitSearchCore looks for units around a point, in a city or both. Course and packet
+// level narrow the result to units whose current groups study that course or packet level.
+type RobboUnitSearchCore struct {
+	Latitude      *float64
+	Longitude     *float64
+	City          string
+	CourseId      string
+	PacketLevel   *int
+	MaxDistanceKm *float64
+	Limit         int
+}
+
+type NearbyRobboUnitCore struct {
+	RobboUnit *RobboUnitCore
+	// DistanceKm is nil when the search had no coordinates
+	DistanceKm *float64
+}
+
+func (ht *NearbyRobboUnitHTTP) FromCore(nearby *NearbyRobboUnitCore) {
+	ht.RobboUnit = &RobboUnitHTTP{}
+	ht.RobboUnit.FromCore(nearby.RobboUnit)
+	ht.DistanceKm = nearby.DistanceKm
 }
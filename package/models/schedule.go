@@ -0,0 +1,149 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type LessonStatus string
+
+const (
+	LessonScheduled   LessonStatus = "scheduled"
+	LessonCancelled   LessonStatus = "cancelled"
+	LessonRescheduled LessonStatus = "rescheduled"
+)
+
+type ScheduleSlotCore struct {
+	Id           string
+	RobboGroupId string
+	Weekday      time.Weekday
+	StartTime    string
+	EndTime      string
+	TeacherId    string
+	Room         string
+}
+
+type ScheduleSlotDB struct {
+	gorm.Model
+
+	RobboGroupId string `gorm:"not null;index"`
+	Weekday      uint   `gorm:"not null"`
+	StartTime    string `gorm:"size:5;not null"`
+	EndTime      string `gorm:"size:5;not null"`
+	TeacherId    string `gorm:"size:256"`
+	Room         string `gorm:"size:256"`
+}
+
+func (em *ScheduleSlotDB) ToCore() *ScheduleSlotCore {
+	return &ScheduleSlotCore{
+		Id:           strconv.FormatUint(uint64(em.ID), 10),
+		RobboGroupId: em.RobboGroupId,
+		Weekday:      time.Weekday(em.Weekday),
+		StartTime:    em.StartTime,
+		EndTime:      em.EndTime,
+		TeacherId:    em.TeacherId,
+		Room:         em.Room,
+	}
+}
+
+func (em *ScheduleSlotDB) FromCore(slot *ScheduleSlotCore) {
+	id, _ := strconv.ParseUint(slot.Id, 10, 64)
+	em.ID = uint(id)
+	em.RobboGroupId = slot.RobboGroupId
+	em.Weekday = uint(slot.Weekday)
+	em.StartTime = slot.StartTime
+	em.EndTime = slot.EndTime
+	em.TeacherId = slot.TeacherId
+	em.Room = slot.Room
+}
+
+func (ht *ScheduleSlotHTTP) FromCore(slot *ScheduleSlotCore) {
+	ht.ID = slot.Id
+	ht.RobboGroupID = slot.RobboGroupId
+	ht.Weekday = int(slot.Weekday)
+	ht.StartTime = slot.StartTime
+	ht.EndTime = slot.EndTime
+	ht.TeacherID = slot.TeacherId
+	ht.Room = slot.Room
+}
+
+func (ht *NewScheduleSlot) ToCore() *ScheduleSlotCore {
+	return &ScheduleSlotCore{
+		RobboGroupId: ht.RobboGroupID,
+		Weekday:      time.Weekday(ht.Weekday),
+		StartTime:    ht.StartTime,
+		EndTime:      ht.EndTime,
+		TeacherId:    ht.TeacherID,
+		Room:         ht.Room,
+	}
+}
+
+type LessonCore struct {
+	Id              string
+	RobboGroupId    string
+	ScheduleSlotId  string
+	TeacherId       string
+	Room            string
+	StartAt         time.Time
+	EndAt           time.Time
+	OriginalStartAt time.Time
+	Status          LessonStatus
+	CancelReason    string
+}
+
+type LessonDB struct {
+	gorm.Model
+
+	RobboGroupId    string    `gorm:"not null;index"`
+	ScheduleSlotId  string    `gorm:"default:null;uniqueIndex:idx_lesson_slot_start"`
+	TeacherId       string    `gorm:"size:256;index"`
+	Room            string    `gorm:"size:256"`
+	StartAt         time.Time `gorm:"not null;index"`
+	EndAt           time.Time `gorm:"not null"`
+	OriginalStartAt time.Time `gorm:"not null;uniqueIndex:idx_lesson_slot_start"`
+	Status          string    `gorm:"size:32;not null"`
+	CancelReason    string    `gorm:"size:256"`
+}
+
+func (em *LessonDB) ToCore() *LessonCore {
+	return &LessonCore{
+		Id:              strconv.FormatUint(uint64(em.ID), 10),
+		RobboGroupId:    em.RobboGroupId,
+		ScheduleSlotId:  em.ScheduleSlotId,
+		TeacherId:       em.TeacherId,
+		Room:            em.Room,
+		StartAt:         em.StartAt,
+		EndAt:           em.EndAt,
+		OriginalStartAt: em.OriginalStartAt,
+		Status:          LessonStatus(em.Status),
+		CancelReason:    em.CancelReason,
+	}
+}
+
+func (em *LessonDB) FromCore(lesson *LessonCore) {
+	id, _ := strconv.ParseUint(lesson.Id, 10, 64)
+	em.ID = uint(id)
+	em.RobboGroupId = lesson.RobboGroupId
+	em.ScheduleSlotId = lesson.ScheduleSlotId
+	em.TeacherId = lesson.TeacherId
+	em.Room = lesson.Room
+	em.StartAt = lesson.StartAt
+	em.EndAt = lesson.EndAt
+	em.OriginalStartAt = lesson.OriginalStartAt
+	em.Status = string(lesson.Status)
+	em.CancelReason = lesson.CancelReason
+}
+
+func (ht *LessonHTTP) FromCore(lesson *LessonCore) {
+	ht.ID = lesson.Id
+	ht.RobboGroupID = lesson.RobboGroupId
+	ht.ScheduleSlotID = lesson.ScheduleSlotId
+	ht.TeacherID = lesson.TeacherId
+	ht.Room = lesson.Room
+	ht.StartAt = lesson.StartAt.String()
+	ht.EndAt = lesson.EndAt.String()
+	ht.OriginalStartAt = lesson.OriginalStartAt.String()
+	ht.Status = string(lesson.Status)
+	ht.CancelReason = lesson.CancelReason
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// StorageBlobDB backs the postgres storage backend. Blobs are deleted for good, so it has no gorm.Model.
+type StorageBlobDB struct {
+	Key       string `gorm:"primaryKey;size:512"`
+	Content   []byte `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -20,6 +20,8 @@ type StudentDB struct {
 	UserDB
 	RobboGroupId uint `gorm:"default:null"`
 	RobboUnitId  uint `gorm:"default:null"`
+	// MergedIntoId points to the surviving student once this record was archived as a duplicate
+	MergedIntoId uint `gorm:"default:null"`
 }
 
 func (em *StudentDB) ToCore() *StudentCore {
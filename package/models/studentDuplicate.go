@@ -0,0 +1,26 @@
+package models
+
+type DuplicateReason string
+
+const (
+	DuplicateByName   DuplicateReason = "name"
+	DuplicateByEmail  DuplicateReason = "email"
+	DuplicateByParent DuplicateReason = "parent"
+)
+
+type StudentDuplicateCore struct {
+	Student   *StudentCore
+	Duplicate *StudentCore
+	Reasons   []DuplicateReason
+}
+
+func (ht *StudentDuplicateHTTP) FromCore(studentDuplicate *StudentDuplicateCore) {
+	ht.Student = &StudentHTTP{UserHTTP: &UserHTTP{}}
+	ht.Student.FromCore(studentDuplicate.Student)
+	ht.Duplicate = &StudentHTTP{UserHTTP: &UserHTTP{}}
+	ht.Duplicate.FromCore(studentDuplicate.Duplicate)
+	ht.Reasons = []string{}
+	for _, reason := range studentDuplicate.Reasons {
+		ht.Reasons = append(ht.Reasons, string(reason))
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type TermStatus string
+
+const (
+	TermActive   TermStatus = "active"
+	TermArchived TermStatus = "archived"
+)
+
+type TermCore struct {
+	Id             string
+	RobboUnitId    string
+	Name           string
+	StartDate      time.Time
+	EndDate        time.Time
+	Status         TermStatus
+	PreviousTermId string
+}
+
+// TermDB is an academic term (autumn, spring) of a RobboUnit. Groups belong to a term,
+// their schedules and memberships follow the group.
+type TermDB struct {
+	gorm.Model
+
+	RobboUnitId    string    `gorm:"not null;index"`
+	Name           string    `gorm:"size:256;not null"`
+	StartDate      time.Time `gorm:"not null"`
+	EndDate        time.Time `gorm:"not null"`
+	Status         string    `gorm:"size:32;not null"`
+	PreviousTermId string
+}
+
+func (em *TermDB) ToCore() *TermCore {
+	return &TermCore{
+		Id:             strconv.FormatUint(uint64(em.ID), 10),
+		RobboUnitId:    em.RobboUnitId,
+		Name:           em.Name,
+		StartDate:      em.StartDate,
+		EndDate:        em.EndDate,
+		Status:         TermStatus(em.Status),
+		PreviousTermId: em.PreviousTermId,
+	}
+}
+
+func (em *TermDB) FromCore(term *TermCore) {
+	id, _ := strconv.ParseUint(term.Id, 10, 64)
+	em.ID = uint(id)
+	em.RobboUnitId = term.RobboUnitId
+	em.Name = term.Name
+	em.StartDate = term.StartDate
+	em.EndDate = term.EndDate
+	em.Status = string(term.Status)
+	em.PreviousTermId = term.PreviousTermId
+}
+
+func (ht *TermHTTP) FromCore(term *TermCore) {
+	ht.ID = term.Id
+	ht.RobboUnitID = term.RobboUnitId
+	ht.Name = term.Name
+	ht.StartDate = term.StartDate.Format(time.RFC3339)
+	ht.EndDate = term.EndDate.Format(time.RFC3339)
+	ht.Status = string(term.Status)
+	ht.PreviousTermID = term.PreviousTermId
+}
+
+// TermRolloverCore describes the next term and where the students of the current one go.
+// Promotions map a group of the current term to the group whose clone receives its students,
+// groups without a promotion keep their students in their own clone.
+type TermRolloverCore struct {
+	FromTermId    string
+	NextTerm      *TermCore
+	CarryStudents bool
+	Promotions    map[string]string
+}
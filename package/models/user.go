@@ -4,6 +4,7 @@ import (
 	"github.com/dgrijalva/jwt-go/v4"
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type Role int
@@ -16,6 +17,9 @@ const (
 	UnitAdmin
 	SuperAdmin
 	Anonymous
+	// RegionAdmin manages every unit of a region, its rights sit between UnitAdmin and SuperAdmin.
+	// It is appended rather than inserted so that roles already stored keep their values.
+	RegionAdmin
 )
 
 type UserClaims struct {
@@ -33,6 +37,7 @@ type UserDB struct {
 	Firstname  string `gorm:"not null;size:256"`
 	Middlename string `gorm:"not null;size:256"`
 	Lastname   string `gorm:"not null;size:256"`
+	LastSeenAt *time.Time
 }
 
 //type UserHttp struct {
@@ -57,9 +62,14 @@ type UserCore struct {
 	Middlename string
 	Lastname   string
 	CreatedAt  string
+	LastSeenAt string
 }
 
 func (em *UserHTTP) ToCore() UserCore {
+	var lastSeenAt string
+	if em.LastSeenAt != nil {
+		lastSeenAt = *em.LastSeenAt
+	}
 	return UserCore{
 		Id:         em.ID,
 		Email:      em.Email,
@@ -70,6 +80,7 @@ func (em *UserHTTP) ToCore() UserCore {
 		Lastname:   em.Lastname,
 		Middlename: em.Middlename,
 		CreatedAt:  em.CreatedAt,
+		LastSeenAt: lastSeenAt,
 	}
 }
 
@@ -83,9 +94,18 @@ func (em *UserHTTP) FromCore(user *UserCore) {
 	em.Lastname = user.Lastname
 	em.Middlename = user.Middlename
 	em.CreatedAt = user.CreatedAt
+	// users who have not signed in since lastSeenAt was introduced have not been seen yet
+	if user.LastSeenAt != "" {
+		lastSeenAt := user.LastSeenAt
+		em.LastSeenAt = &lastSeenAt
+	}
 }
 
 func (em *UserDB) ToCore() UserCore {
+	var lastSeenAt string
+	if em.LastSeenAt != nil {
+		lastSeenAt = em.LastSeenAt.String()
+	}
 	return UserCore{
 		Id:         strconv.FormatUint(uint64(em.ID), 10),
 		Email:      em.Email,
@@ -96,6 +116,7 @@ func (em *UserDB) ToCore() UserCore {
 		Lastname:   em.Lastname,
 		Middlename: em.Middlename,
 		CreatedAt:  em.CreatedAt.String(),
+		LastSeenAt: lastSeenAt,
 	}
 }
 
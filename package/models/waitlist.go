@@ -0,0 +1,61 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+type EnrollmentStatus string
+
+const (
+	EnrollmentEnrolled   EnrollmentStatus = "enrolled"
+	EnrollmentWaitlisted EnrollmentStatus = "waitlisted"
+)
+
+type WaitlistEntryCore struct {
+	Id             string
+	CreatedAt      time.Time
+	StudentId      string
+	RobboGroupId   string
+	RobboGroupName string
+	RobboUnitId    string
+	// Position is the 1-based place in the queue of the group
+	Position int
+}
+
+type WaitlistEntryDB struct {
+	gorm.Model
+
+	// a student waits for a group once, the entries removed from the waitlist stay as history
+	StudentId    string `gorm:"not null;uniqueIndex:idx_waitlist_student_group,where:deleted_at IS NULL"`
+	RobboGroupId string `gorm:"not null;index;uniqueIndex:idx_waitlist_student_group"`
+	RobboUnitId  string `gorm:"not null"`
+}
+
+func (em *WaitlistEntryDB) ToCore() *WaitlistEntryCore {
+	return &WaitlistEntryCore{
+		Id:           strconv.FormatUint(uint64(em.ID), 10),
+		CreatedAt:    em.CreatedAt,
+		StudentId:    em.StudentId,
+		RobboGroupId: em.RobboGroupId,
+		RobboUnitId:  em.RobboUnitId,
+	}
+}
+
+func (em *WaitlistEntryDB) FromCore(entry *WaitlistEntryCore) {
+	id, _ := strconv.ParseUint(entry.Id, 10, 64)
+	em.ID = uint(id)
+	em.StudentId = entry.StudentId
+	em.RobboGroupId = entry.RobboGroupId
+	em.RobboUnitId = entry.RobboUnitId
+}
+
+func (ht *WaitlistEntryHTTP) FromCore(entry *WaitlistEntryCore) {
+	ht.ID = entry.Id
+	ht.CreatedAt = entry.CreatedAt.Format(time.RFC3339)
+	ht.StudentID = entry.StudentId
+	ht.RobboGroupID = entry.RobboGroupId
+	ht.RobboUnitID = entry.RobboUnitId
+	ht.Position = entry.Position
+}
@@ -0,0 +1,48 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"go.uber.org/fx"
+)
+
+type NotificationsDelegateImpl struct {
+	UseCase notifications.UseCase
+}
+
+type NotificationsDelegateModule struct {
+	fx.Out
+	notifications.Delegate
+}
+
+func SetupNotificationsDelegate(usecase notifications.UseCase) NotificationsDelegateModule {
+	return NotificationsDelegateModule{
+		Delegate: &NotificationsDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *NotificationsDelegateImpl) GetNotificationsByRecipient(recipientId string, recipientRole models.Role, unreadOnly bool) (notificationsHttp []*models.NotificationHTTP, err error) {
+	notificationsCore, err := p.UseCase.GetNotificationsByRecipient(recipientId, recipientRole, unreadOnly)
+	if err != nil {
+		return
+	}
+	notificationsHttp = make([]*models.NotificationHTTP, 0, len(notificationsCore))
+	for _, notificationCore := range notificationsCore {
+		var notificationTemp models.NotificationHTTP
+		notificationTemp.FromCore(notificationCore)
+		notificationsHttp = append(notificationsHttp, &notificationTemp)
+	}
+	return
+}
+
+func (p *NotificationsDelegateImpl) ReadNotification(notificationId, recipientId string, recipientRole models.Role) (notificationHttp *models.NotificationHTTP, err error) {
+	notificationCore, err := p.UseCase.ReadNotification(notificationId, recipientId, recipientRole)
+	if err != nil {
+		return
+	}
+	notificationHttp = &models.NotificationHTTP{}
+	notificationHttp.FromCore(notificationCore)
+	return
+}
@@ -0,0 +1,10 @@
+package notifications
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Gateway interface {
+	CreateNotifications(notifications []*models.NotificationCore) (err error)
+	GetNotificationById(notificationId string) (notification *models.NotificationCore, err error)
+	GetNotificationsByRecipient(recipientId string, recipientRole models.Role, unreadOnly bool) (notifications []*models.NotificationCore, err error)
+	MarkNotificationRead(notificationId string) (err error)
+}
@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"log"
+)
+
+type NotificationsGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type NotificationsGatewayModule struct {
+	fx.Out
+	notifications.Gateway
+}
+
+func SetupNotificationsGateway(postgresClient db_client.PostgresClient) NotificationsGatewayModule {
+	return NotificationsGatewayModule{
+		Gateway: &NotificationsGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+func (r *NotificationsGatewayImpl) CreateNotifications(notificationsCore []*models.NotificationCore) (err error) {
+	if len(notificationsCore) == 0 {
+		return
+	}
+	var notificationsDb []*models.NotificationDB
+	for _, notificationCore := range notificationsCore {
+		notificationDb := models.NotificationDB{}
+		notificationDb.FromCore(notificationCore)
+		notificationsDb = append(notificationsDb, &notificationDb)
+	}
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Create(&notificationsDb).Error
+		return
+	})
+	return
+}
+
+func (r *NotificationsGatewayImpl) GetNotificationById(notificationId string) (notification *models.NotificationCore, err error) {
+	var notificationDb models.NotificationDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", notificationId).First(&notificationDb).Error; err != nil {
+			log.Println(err)
+			return notifications.ErrNotificationNotFound
+		}
+		return
+	})
+	notification = notificationDb.ToCore()
+	return
+}
+
+func (r *NotificationsGatewayImpl) GetNotificationsByRecipient(recipientId string, recipientRole models.Role, unreadOnly bool) (notificationsCore []*models.NotificationCore, err error) {
+	var notificationsDb []*models.NotificationDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		query := tx.Where("recipient_id = ? AND recipient_role = ?", recipientId, recipientRole)
+		if unreadOnly {
+			query = query.Where("read = ?", false)
+		}
+		err = query.Order("created_at desc").Find(&notificationsDb).Error
+		return
+	})
+
+	for _, notificationDb := range notificationsDb {
+		notificationsCore = append(notificationsCore, notificationDb.ToCore())
+	}
+	return
+}
+
+func (r *NotificationsGatewayImpl) MarkNotificationRead(notificationId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Model(&models.NotificationDB{}).Where("id = ?", notificationId).Update("read", true).Error
+		return
+	})
+	return
+}
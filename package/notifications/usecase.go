@@ -0,0 +1,8 @@
+package notifications
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type UseCase interface {
+	GetNotificationsByRecipient(recipientId string, recipientRole models.Role, unreadOnly bool) (notifications []*models.NotificationCore, err error)
+	ReadNotification(notificationId, recipientId string, recipientRole models.Role) (notification *models.NotificationCore, err error)
+}
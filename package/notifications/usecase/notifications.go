@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"go.uber.org/fx"
+)
+
+type NotificationsUseCaseImpl struct {
+	notificationsGateway notifications.Gateway
+}
+
+type NotificationsUseCaseModule struct {
+	fx.Out
+	notifications.UseCase
+}
+
+func SetupNotificationsUseCase(notificationsGateway notifications.Gateway) NotificationsUseCaseModule {
+	return NotificationsUseCaseModule{
+		UseCase: &NotificationsUseCaseImpl{
+			notificationsGateway: notificationsGateway,
+		},
+	}
+}
+
+func (p *NotificationsUseCaseImpl) GetNotificationsByRecipient(recipientId string, recipientRole models.Role, unreadOnly bool) (notificationsCore []*models.NotificationCore, err error) {
+	return p.notificationsGateway.GetNotificationsByRecipient(recipientId, recipientRole, unreadOnly)
+}
+
+func (p *NotificationsUseCaseImpl) ReadNotification(notificationId, recipientId string, recipientRole models.Role) (notification *models.NotificationCore, err error) {
+	notification, err = p.notificationsGateway.GetNotificationById(notificationId)
+	if err != nil {
+		return
+	}
+	// someone else's notification is reported as missing rather than forbidden
+	if notification.RecipientId != recipientId || notification.RecipientRole != recipientRole {
+		return nil, notifications.ErrNotificationNotFound
+	}
+	if notification.Read {
+		return
+	}
+	if err = p.notificationsGateway.MarkNotificationRead(notificationId); err != nil {
+		return
+	}
+	notification.Read = true
+	return
+}
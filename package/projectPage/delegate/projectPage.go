@@ -3,11 +3,14 @@ package delegate
 import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
 	"go.uber.org/fx"
+	"log"
 )
 
 type ProjectPageDelegateImpl struct {
 	projectPage.UseCase
+	projectsUseCase projects.UseCase
 }
 
 type ProjectPageDelegateModule struct {
@@ -15,45 +18,136 @@ type ProjectPageDelegateModule struct {
 	projectPage.Delegate
 }
 
-func SetupProjectPageDelegate(usecase projectPage.UseCase) ProjectPageDelegateModule {
+func SetupProjectPageDelegate(usecase projectPage.UseCase, projectsUseCase projects.UseCase) ProjectPageDelegateModule {
 	return ProjectPageDelegateModule{
 		Delegate: &ProjectPageDelegateImpl{
-			UseCase: usecase,
+			UseCase:         usecase,
+			projectsUseCase: projectsUseCase,
 		},
 	}
 }
 
-func (p *ProjectPageDelegateImpl) CreateProjectPage(authorId string) (projectId string, err error) {
-	return p.UseCase.CreateProjectPage(authorId)
+// CreateProjectPage, RemixProject and ImportSb3 start the history of the project they create,
+// the project use case keeps the revisions.
+func (p *ProjectPageDelegateImpl) CreateProjectPage(authorId string, authorRole models.Role) (projectId string, err error) {
+	if projectId, err = p.UseCase.CreateProjectPage(authorId, authorRole); err != nil {
+		return
+	}
+	err = p.projectsUseCase.RecordCreatedRevision(projectId)
+	return
 }
 
 func (p *ProjectPageDelegateImpl) DeleteProjectPage(projectId string) (err error) {
 	return p.UseCase.DeleteProjectPage(projectId)
 }
 
+func (p *ProjectPageDelegateImpl) RemixProject(projectPageId, authorId string, authorRole models.Role) (remix *models.ProjectPageHTTP, err error) {
+	remixCore, err := p.UseCase.RemixProject(projectPageId, authorId, authorRole)
+	if err != nil {
+		return
+	}
+	if err = p.projectsUseCase.RecordCreatedRevision(remixCore.ProjectId); err != nil {
+		return
+	}
+	remix = &models.ProjectPageHTTP{}
+	remix.FromCore(remixCore)
+	return
+}
+
+func (p *ProjectPageDelegateImpl) GetRemixTree(projectId string) (nodes []*models.RemixNodeHTTP, err error) {
+	nodesCore, err := p.UseCase.GetRemixTree(projectId)
+	if err != nil {
+		return
+	}
+	nodes = make([]*models.RemixNodeHTTP, 0, len(nodesCore))
+	for _, nodeCore := range nodesCore {
+		node := &models.RemixNodeHTTP{}
+		node.FromCore(nodeCore)
+		nodes = append(nodes, node)
+	}
+	return
+}
+
+func (p *ProjectPageDelegateImpl) GetRemixCount(projectId string) (count *models.RemixCountHTTP, err error) {
+	countCore, err := p.UseCase.GetRemixCount(projectId)
+	if err != nil {
+		return
+	}
+	return &models.RemixCountHTTP{Direct: countCore.Direct, Total: countCore.Total}, nil
+}
+
+func (p *ProjectPageDelegateImpl) ImportSb3(authorId string, authorRole models.Role, fileName string, archive []byte) (projectId string, err error) {
+	if projectId, err = p.UseCase.ImportSb3(authorId, authorRole, fileName, archive); err != nil {
+		return
+	}
+	err = p.projectsUseCase.RecordCreatedRevision(projectId)
+	return
+}
+
+func (p *ProjectPageDelegateImpl) ExportSb3(projectId string) (fileName string, archive []byte, err error) {
+	return p.UseCase.ExportSb3(projectId)
+}
+
 func (p *ProjectPageDelegateImpl) UpdateProjectPage(projectPage *models.ProjectPageHTTP) (err error) {
 	projectPageCore := projectPage.ToCore()
 	return p.UseCase.UpdateProjectPage(projectPageCore)
 }
 
+func (p *ProjectPageDelegateImpl) SaveThumbnail(projectId string, screenshot []byte) (preview string, err error) {
+	return p.UseCase.SaveThumbnail(projectId, screenshot)
+}
+
+func (p *ProjectPageDelegateImpl) RefreshPlaceholders() (err error) {
+	return p.UseCase.RefreshPlaceholders()
+}
+
+func (p *ProjectPageDelegateImpl) GetThumbnail(name string) (thumbnail []byte, err error) {
+	return p.UseCase.GetThumbnail(name)
+}
+
 func (p *ProjectPageDelegateImpl) GetProjectPageById(projectPageId string) (projectPage models.ProjectPageHTTP, err error) {
 	projectPageCore, err := p.UseCase.GetProjectPageById(projectPageId)
 	if err != nil {
 		return
 	}
 	projectPage.FromCore(projectPageCore)
+	analyses := p.analysesOf([]string{projectPageCore.ProjectId})
+	projectPage.Analysis = analyses[projectPageCore.ProjectId]
 	return
 }
 
-func (p *ProjectPageDelegateImpl) GetAllProjectPagesByUserId(authorId string) (projectPages []*models.ProjectPageHTTP, err error) {
-	projectPagesCore, err := p.UseCase.GetAllProjectPageByUserId(authorId)
+func (p *ProjectPageDelegateImpl) GetAllProjectPagesByUserId(authorId string, authorRole models.Role) (projectPages []*models.ProjectPageHTTP, err error) {
+	projectPagesCore, err := p.UseCase.GetAllProjectPageByUserId(authorId, authorRole)
 	if err != nil {
 		return
 	}
+	projectIds := make([]string, 0, len(projectPagesCore))
+	for _, projectPageCore := range projectPagesCore {
+		projectIds = append(projectIds, projectPageCore.ProjectId)
+	}
+	analyses := p.analysesOf(projectIds)
 	for _, projectPageCore := range projectPagesCore {
 		var projectPageHttp models.ProjectPageHTTP
 		projectPageHttp.FromCore(projectPageCore)
+		projectPageHttp.Analysis = analyses[projectPageCore.ProjectId]
 		projectPages = append(projectPages, &projectPageHttp)
 	}
 	return
 }
+
+// analysesOf reads the stored analyses of the projects in one go. A page goes without an analysis
+// when the project has none stored yet or the analyses cannot be read.
+func (p *ProjectPageDelegateImpl) analysesOf(projectIds []string) (analyses map[string]*models.ProjectAnalysisHTTP) {
+	analyses = make(map[string]*models.ProjectAnalysisHTTP, len(projectIds))
+	analysesCore, err := p.projectsUseCase.GetProjectAnalyses(projectIds)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for projectId, analysisCore := range analysesCore {
+		analysis := &models.ProjectAnalysisHTTP{}
+		analysis.FromCore(analysisCore)
+		analyses[projectId] = analysis
+	}
+	return
+}
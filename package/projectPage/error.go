@@ -7,4 +7,11 @@ var (
 	ErrInternalServerLevel = errors.New("internal server level")
 	ErrBadRequest          = errors.New("bad request")
 	ErrBadRequestBody      = errors.New("bad request body")
+	ErrNoAccess            = errors.New("no access to the project")
+	ErrBadSb3              = errors.New("file is not a scratch 3 project")
+	ErrSb3TooLarge         = errors.New("scratch project file is too large")
+	ErrSb3AssetMismatch    = errors.New("scratch project asset does not match its name")
+	ErrBadThumbnail        = errors.New("thumbnail is not a png, jpeg or gif image")
+	ErrThumbnailTooLarge   = errors.New("thumbnail is too large")
+	ErrThumbnailNotFound   = errors.New("thumbnail not found")
 )
@@ -8,4 +8,13 @@ type Gateway interface {
 	GetProjectPageById(projectPageId string) (projectPage *models.ProjectPageCore, err error)
 	GetProjectPageByProjectId(projectId string) (projectPage *models.ProjectPageCore, err error)
 	UpdateProjectPage(projectPage *models.ProjectPageCore) (err error)
+	SetProjectPagePreview(projectId, preview, placeholderOf string) (err error)
+	SetProjectPagePlaceholder(projectId, preview, placeholderOf string) (err error)
+	GetProjectIdsWithStalePlaceholders(offset, limit int) (projectIds []string, err error)
+
+	SaveThumbnail(thumbnail []byte) (name string, err error)
+	GetThumbnail(name string) (thumbnail []byte, err error)
+
+	GetRemixTree(projectId string) (nodes []*models.RemixNodeCore, err error)
+	GetRemixCount(projectId string) (count *models.RemixCountCore, err error)
 }
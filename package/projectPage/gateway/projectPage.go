@@ -4,13 +4,16 @@ import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type ProjectPageGatewayImpl struct {
 	PostgresClient *db_client.PostgresClient
+	Storage        storage.Storage
 }
 
 type ProjectPageGatewayModule struct {
@@ -18,9 +21,9 @@ type ProjectPageGatewayModule struct {
 	projectPage.Gateway
 }
 
-func SetupProjectPageGateway(postgresClient db_client.PostgresClient) ProjectPageGatewayModule {
+func SetupProjectPageGateway(postgresClient db_client.PostgresClient, blobStorage storage.Storage) ProjectPageGatewayModule {
 	return ProjectPageGatewayModule{
-		Gateway: &ProjectPageGatewayImpl{PostgresClient: &postgresClient},
+		Gateway: &ProjectPageGatewayImpl{PostgresClient: &postgresClient, Storage: blobStorage},
 	}
 }
 
@@ -78,8 +81,14 @@ func (r *ProjectPageGatewayImpl) UpdateProjectPage(projectPage *models.ProjectPa
 	projectPageDb.FromCore(projectPage)
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		err = tx.Model(&projectPageDb).Where("project_id = ?", projectPageDb.ProjectId).Updates(projectPageDb).Error
-		return
+		if err = tx.Model(&projectPageDb).Where("project_id = ?", projectPageDb.ProjectId).Updates(projectPageDb).Error; err != nil {
+			return
+		}
+		// Updates skips false, so unsharing is written separately
+		return tx.Model(&models.ProjectPageDB{}).Where("project_id = ?", projectPageDb.ProjectId).Updates(map[string]interface{}{
+			"is_shared": projectPageDb.IsShared,
+			"shared_at": gorm.Expr("CASE WHEN ? THEN COALESCE(shared_at, ?) END", projectPageDb.IsShared, time.Now()),
+		}).Error
 	})
 	return
 }
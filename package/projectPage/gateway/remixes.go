@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"gorm.io/gorm"
+)
+
+// Remixes are always newer than their parent, so the lineage has no cycles. The depth bound
+// only keeps a corrupted parent_id from running the recursion away. Deleted projects are
+// soft-deleted and still link their remixes to the rest of the tree.
+const maxRemixDepth = 100
+
+const remixTreeQuery = `
+WITH RECURSIVE up AS (
+	SELECT id, parent_id, 0 AS steps FROM project_dbs WHERE id = @project
+	UNION ALL
+	SELECT p.id, p.parent_id, up.steps + 1
+	FROM project_dbs p JOIN up ON CAST(p.id AS text) = up.parent_id
+	WHERE up.steps < @maxDepth
+), tree AS (
+	SELECT id, 0 AS depth FROM project_dbs WHERE id = (SELECT id FROM up ORDER BY steps DESC LIMIT 1)
+	UNION ALL
+	SELECT p.id, tree.depth + 1
+	FROM project_dbs p JOIN tree ON p.parent_id = CAST(tree.id AS text)
+	WHERE tree.depth < @maxDepth
+)
+SELECT CAST(p.id AS text) AS project_id, p.parent_id, tree.depth, p.author_id, p.author_role, p.created_at,
+	p.deleted_at IS NOT NULL AS deleted,
+	COALESCE(pp.title, p.name) AS title, COALESCE(pp.is_shared, false) AS is_shared
+FROM tree
+JOIN project_dbs p ON p.id = tree.id
+LEFT JOIN project_page_dbs pp ON pp.project_id = CAST(p.id AS text) AND pp.deleted_at IS NULL
+ORDER BY tree.depth, p.created_at, p.id`
+
+const remixCountQuery = `
+WITH RECURSIVE tree AS (
+	SELECT id, 0 AS depth FROM project_dbs WHERE parent_id = @project
+	UNION ALL
+	SELECT p.id, tree.depth + 1
+	FROM project_dbs p JOIN tree ON p.parent_id = CAST(tree.id AS text)
+	WHERE tree.depth < @maxDepth
+)
+SELECT COUNT(*) FILTER (WHERE tree.depth = 0) AS direct, COUNT(*) AS total
+FROM tree JOIN project_dbs p ON p.id = tree.id
+WHERE p.deleted_at IS NULL`
+
+// GetRemixTree returns the whole lineage the project belongs to, from its original down,
+// parents before their remixes.
+func (r *ProjectPageGatewayImpl) GetRemixTree(projectId string) (nodes []*models.RemixNodeCore, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(remixTreeQuery, map[string]interface{}{
+			"project":  projectId,
+			"maxDepth": maxRemixDepth,
+		}).Scan(&nodes).Error
+	})
+	return
+}
+
+func (r *ProjectPageGatewayImpl) GetRemixCount(projectId string) (count *models.RemixCountCore, err error) {
+	count = &models.RemixCountCore{}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(remixCountQuery, map[string]interface{}{
+			"project":  projectId,
+			"maxDepth": maxRemixDepth,
+		}).Scan(count).Error
+	})
+	return
+}
@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetRemixTree(t *testing.T) {
+	created := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	postgresClient, recorder, err := dbtest.Open(func(query string, args []interface{}) ([]string, [][]interface{}) {
+		return []string{"project_id", "parent_id", "depth", "author_id", "author_role", "created_at", "deleted", "title", "is_shared"},
+			[][]interface{}{
+				{"1", "", int64(0), "10", int64(0), created, false, "Maze", true},
+				{"2", "1", int64(1), "11", int64(0), created.Add(time.Hour), true, "Maze (remix)", false},
+				{"3", "2", int64(2), "12", int64(1), created.Add(2 * time.Hour), false, "Maze (remix) (remix)", true},
+			}
+	})
+	assert.NoError(t, err)
+	gateway := &ProjectPageGatewayImpl{PostgresClient: postgresClient}
+
+	nodes, err := gateway.GetRemixTree("3")
+	assert.NoError(t, err)
+	if assert.Len(t, nodes, 3) {
+		assert.Equal(t, "", nodes[0].ParentId)
+		assert.Equal(t, "2", nodes[2].ParentId)
+		assert.Equal(t, 2, nodes[2].Depth)
+		assert.Equal(t, models.Teacher, nodes[2].AuthorRole)
+		assert.True(t, nodes[1].Deleted, "a deleted remix still links its remixes to the tree")
+	}
+	queries := recorder.Find("WITH RECURSIVE up AS", "tree AS")
+	if assert.Len(t, queries, 1) {
+		assert.Contains(t, queries[0].Args, "3")
+		assert.Contains(t, queries[0].Args, maxRemixDepth)
+	}
+}
+
+func TestGetRemixCount(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(func(query string, args []interface{}) ([]string, [][]interface{}) {
+		return []string{"direct", "total"}, [][]interface{}{{int64(2), int64(5)}}
+	})
+	assert.NoError(t, err)
+	gateway := &ProjectPageGatewayImpl{PostgresClient: postgresClient}
+
+	count, err := gateway.GetRemixCount("1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count.Direct)
+	assert.Equal(t, 5, count.Total)
+	assert.Len(t, recorder.Find("FILTER (WHERE tree.depth = 0)", "p.deleted_at IS NULL"), 1)
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"gorm.io/gorm"
+)
+
+func thumbnailKey(name string) string {
+	return models.ThumbnailPrefix + "/" + name
+}
+
+// SetProjectPagePreview is the only way the preview of a page changes, page updates leave it as it is.
+func (r *ProjectPageGatewayImpl) SetProjectPagePreview(projectId, preview, placeholderOf string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.ProjectPageDB{}).Where("project_id = ?", projectId).Updates(map[string]interface{}{
+			"preview":        preview,
+			"placeholder_of": placeholderOf,
+		}).Error
+	})
+	return
+}
+
+// SetProjectPagePlaceholder sets a placeholder as the preview unless a thumbnail has been uploaded meanwhile.
+func (r *ProjectPageGatewayImpl) SetProjectPagePlaceholder(projectId, preview, placeholderOf string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.ProjectPageDB{}).
+			Where("project_id = ? AND (preview = '' OR placeholder_of <> '')", projectId).
+			Updates(map[string]interface{}{
+				"preview":        preview,
+				"placeholder_of": placeholderOf,
+			}).Error
+	})
+	return
+}
+
+// GetProjectIdsWithStalePlaceholders lists the projects whose page has no uploaded thumbnail and no placeholder
+// made of the current JSON. Projects saved before the blob storage only get their first placeholder,
+// their checksum is not known without their JSON. The first offset of them are skipped.
+func (r *ProjectPageGatewayImpl) GetProjectIdsWithStalePlaceholders(offset, limit int) (projectIds []string, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(`SELECT pp.project_id FROM project_page_dbs pp
+			JOIN project_dbs p ON CAST(p.id AS TEXT) = pp.project_id AND p.deleted_at IS NULL
+			WHERE pp.deleted_at IS NULL AND (pp.preview = '' OR pp.placeholder_of <> '')
+				AND CASE WHEN p.body_hash = '' THEN pp.placeholder_of = '' ELSE pp.placeholder_of <> p.body_hash END
+			ORDER BY pp.id OFFSET ? LIMIT ?`, offset, limit).Scan(&projectIds).Error
+	})
+	return
+}
+
+// SaveThumbnail names the PNG after its sha256, so a page that gets the same thumbnail again
+// keeps its URL and the browser its cached copy. Thumbnails are never deleted, remixes share them.
+func (r *ProjectPageGatewayImpl) SaveThumbnail(thumbnail []byte) (name string, err error) {
+	sum := sha256.Sum256(thumbnail)
+	name = hex.EncodeToString(sum[:]) + ".png"
+	exists, err := r.Storage.Exists(thumbnailKey(name))
+	if err != nil || exists {
+		return
+	}
+	err = r.Storage.Put(thumbnailKey(name), thumbnail)
+	return
+}
+
+func (r *ProjectPageGatewayImpl) GetThumbnail(name string) (thumbnail []byte, err error) {
+	thumbnail, err = r.Storage.Get(thumbnailKey(name))
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, projectPage.ErrThumbnailNotFound
+	}
+	return
+}
@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetProjectPagePlaceholderKeepsUploads(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &ProjectPageGatewayImpl{PostgresClient: postgresClient}
+
+	assert.NoError(t, gateway.SetProjectPagePlaceholder("7", "preview", "current"))
+	updates := recorder.Find(`UPDATE "project_page_dbs"`, "placeholder_of <> ''")
+	if assert.Len(t, updates, 1, "a thumbnail uploaded while the placeholder was made is kept") {
+		assert.Contains(t, updates[0].Args, "current")
+	}
+}
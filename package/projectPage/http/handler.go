@@ -2,11 +2,16 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/spf13/viper"
+	"io"
+	"io/ioutil"
 	"log"
+	"mime"
 	"net/http"
 )
 
@@ -14,17 +19,20 @@ type Handler struct {
 	authDelegate        auth.Delegate
 	projectsDelegate    projects.Delegate
 	projectPageDelegate projectPage.Delegate
+	accessScope         access.Scope
 }
 
 func NewProjectPageHandler(
 	authDelegate auth.Delegate,
 	projectsDelegate projects.Delegate,
 	projectPageDelegate projectPage.Delegate,
+	accessScope access.Scope,
 ) Handler {
 	return Handler{
 		authDelegate:        authDelegate,
 		projectsDelegate:    projectsDelegate,
 		projectPageDelegate: projectPageDelegate,
+		accessScope:         accessScope,
 	}
 }
 
@@ -37,6 +45,18 @@ func (h *Handler) InitProjectRoutes(router *gin.Engine) {
 		projectPage.PUT("/", h.UpdateProjectPage)
 		projectPage.DELETE("/:projectId", h.DeleteProjectPage)
 	}
+	// gin cannot mix /projectPage/:projectPageId with static paths next to it
+	sb3 := router.Group("/sb3")
+	{
+		sb3.POST("/", h.ImportSb3)
+		sb3.GET("/:projectId", h.ExportSb3)
+	}
+	// scratch-gui uploads the screenshot with the token of the author after saving the project, the previews are public
+	thumbnail := router.Group("/thumbnail")
+	{
+		thumbnail.POST("/:projectId", h.SaveThumbnail)
+		thumbnail.GET("/:name", h.GetThumbnail)
+	}
 }
 
 type createProjectPageResponse struct {
@@ -45,13 +65,13 @@ type createProjectPageResponse struct {
 
 func (h *Handler) CreateProjectPage(c *gin.Context) {
 	log.Println("Create Project Page")
-	userId, _, userIdentityErr := h.authDelegate.UserIdentity(c)
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
 	if userIdentityErr != nil {
 		log.Println(userIdentityErr)
 		ErrorHandling(userIdentityErr, c)
 		return
 	}
-	projectId, err := h.projectPageDelegate.CreateProjectPage(userId)
+	projectId, err := h.projectPageDelegate.CreateProjectPage(userId, role)
 
 	if err != nil {
 		log.Println(err)
@@ -93,14 +113,14 @@ type getAllProjectPageResponse struct {
 
 func (h *Handler) GetAllProjectPageByUserId(c *gin.Context) {
 	log.Println("Get All Project Page By User ID")
-	userId, _, userIdentityErr := h.authDelegate.UserIdentity(c)
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
 	if userIdentityErr != nil {
 		log.Println(userIdentityErr)
 		ErrorHandling(userIdentityErr, c)
 		return
 	}
 
-	projectPages, err := h.projectPageDelegate.GetAllProjectPagesByUserId(userId)
+	projectPages, err := h.projectPageDelegate.GetAllProjectPagesByUserId(userId, role)
 	if err != nil {
 		log.Println(err)
 		ErrorHandling(err, c)
@@ -117,7 +137,7 @@ type updateProjectPageInput struct {
 
 func (h *Handler) UpdateProjectPage(c *gin.Context) {
 	log.Println("Update Project Page")
-	_, _, userIdentityErr := h.authDelegate.UserIdentity(c)
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
 	if userIdentityErr != nil {
 		log.Println(userIdentityErr)
 		ErrorHandling(userIdentityErr, c)
@@ -125,13 +145,18 @@ func (h *Handler) UpdateProjectPage(c *gin.Context) {
 	}
 
 	inp := new(updateProjectPageInput)
-	if err := c.BindJSON(&inp); err != nil {
+	if err := c.BindJSON(&inp); err != nil || inp.ProjectPage == nil {
 		err = projectPage.ErrBadRequestBody
 		log.Println(err)
 		ErrorHandling(err, c)
 		return
 	}
 	log.Println(inp)
+	if err := h.checkAuthor(userId, role, inp.ProjectPage.ProjectID); err != nil {
+		log.Println(err)
+		ErrorHandling(err, c)
+		return
+	}
 	err := h.projectPageDelegate.UpdateProjectPage(inp.ProjectPage)
 	if err != nil {
 		log.Println(err)
@@ -143,13 +168,18 @@ func (h *Handler) UpdateProjectPage(c *gin.Context) {
 
 func (h *Handler) DeleteProjectPage(c *gin.Context) {
 	log.Println("Delete Project Page")
-	_, _, userIdentityErr := h.authDelegate.UserIdentity(c)
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
 	if userIdentityErr != nil {
 		log.Println(userIdentityErr)
 		ErrorHandling(userIdentityErr, c)
 		return
 	}
 	projectId := c.Param("projectId")
+	if err := h.checkAuthor(userId, role, projectId); err != nil {
+		log.Println(err)
+		ErrorHandling(err, c)
+		return
+	}
 
 	err := h.projectPageDelegate.DeleteProjectPage(projectId)
 	if err != nil {
@@ -160,6 +190,147 @@ func (h *Handler) DeleteProjectPage(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (h *Handler) ImportSb3(c *gin.Context) {
+	log.Println("Import Sb3")
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
+	if userIdentityErr != nil {
+		log.Println(userIdentityErr)
+		ErrorHandling(userIdentityErr, c)
+		return
+	}
+	// leaves room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, viper.GetInt64("projectPage.sb3_max_size")+64<<10)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(projectPage.ErrBadRequestBody, c)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(projectPage.ErrBadRequestBody, c)
+		return
+	}
+	defer file.Close()
+	archive, err := ioutil.ReadAll(file)
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(projectPage.ErrBadRequestBody, c)
+		return
+	}
+
+	projectId, err := h.projectPageDelegate.ImportSb3(userId, role, fileHeader.Filename, archive)
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, createProjectPageResponse{
+		projectId,
+	})
+}
+
+func (h *Handler) ExportSb3(c *gin.Context) {
+	log.Println("Export Sb3")
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
+	if userIdentityErr != nil {
+		log.Println(userIdentityErr)
+		ErrorHandling(userIdentityErr, c)
+		return
+	}
+	projectId := c.Param("projectId")
+	project, err := h.projectsDelegate.GetProjectMetadataById(projectId)
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(projectPage.ErrPageNotFound, c)
+		return
+	}
+	// whoever may see the author may take the project with them, parents included
+	if err = h.accessScope.CheckUser(userId, role, project.AuthorId, project.AuthorRole); err != nil {
+		log.Println(err)
+		ErrorHandling(projectPage.ErrNoAccess, c)
+		return
+	}
+
+	fileName, archive, err := h.projectPageDelegate.ExportSb3(projectId)
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(err, c)
+		return
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	c.Data(http.StatusOK, "application/x.scratch.sb3", archive)
+}
+
+type saveThumbnailResponse struct {
+	Preview string `json:"preview"`
+}
+
+// SaveThumbnail takes the screenshot of the stage as the raw body, the project is named in the path.
+func (h *Handler) SaveThumbnail(c *gin.Context) {
+	log.Println("Save Thumbnail")
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
+	if userIdentityErr != nil {
+		log.Println(userIdentityErr)
+		ErrorHandling(userIdentityErr, c)
+		return
+	}
+	projectId := c.Param("projectId")
+	if err := h.checkAuthor(userId, role, projectId); err != nil {
+		log.Println(err)
+		ErrorHandling(err, c)
+		return
+	}
+	// one byte more than allowed lets the use case tell a screenshot that is too large
+	screenshot, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, viper.GetInt64("projectPage.thumbnail_max_size")+1))
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(projectPage.ErrBadRequestBody, c)
+		return
+	}
+	preview, err := h.projectPageDelegate.SaveThumbnail(projectId, screenshot)
+	if err != nil {
+		log.Println(err)
+		ErrorHandling(err, c)
+		return
+	}
+	c.JSON(http.StatusOK, saveThumbnailResponse{
+		Preview: preview,
+	})
+}
+
+func (h *Handler) GetThumbnail(c *gin.Context) {
+	name := c.Param("name")
+	etag := `"` + name + `"`
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	thumbnail, err := h.projectPageDelegate.GetThumbnail(name)
+	if err != nil {
+		ErrorHandling(err, c)
+		return
+	}
+	// named after its content, a new thumbnail gets a new name
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", etag)
+	c.Data(http.StatusOK, "image/png", thumbnail)
+}
+
+// checkAuthor keeps changes of the page to the author of its project, ids are compared together with roles.
+func (h *Handler) checkAuthor(userId string, role models.Role, projectId string) error {
+	project, err := h.projectsDelegate.GetProjectMetadataById(projectId)
+	if err != nil {
+		log.Println(err)
+		return projectPage.ErrPageNotFound
+	}
+	if !access.SameUser(userId, role, project.AuthorId, project.AuthorRole) {
+		return projectPage.ErrNoAccess
+	}
+	return nil
+}
+
 func ErrorHandling(err error, c *gin.Context) {
 	switch err {
 	case projectPage.ErrBadRequest:
@@ -170,6 +341,20 @@ func ErrorHandling(err error, c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
 	case projectPage.ErrBadRequestBody:
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+	case projectPage.ErrBadSb3:
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+	case projectPage.ErrSb3AssetMismatch:
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+	case projectPage.ErrSb3TooLarge:
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, err.Error())
+	case projectPage.ErrBadThumbnail:
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+	case projectPage.ErrThumbnailTooLarge:
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, err.Error())
+	case projectPage.ErrThumbnailNotFound:
+		c.AbortWithStatusJSON(http.StatusNotFound, err.Error())
+	case projectPage.ErrNoAccess:
+		c.AbortWithStatusJSON(http.StatusForbidden, err.Error())
 	case auth.ErrInvalidAccessToken:
 		c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
 	case auth.ErrTokenNotFound:
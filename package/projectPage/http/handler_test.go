@@ -0,0 +1,119 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// identity is who every request of a test is sent by, user 1 of the given role.
+type identity struct {
+	auth.Delegate
+	role models.Role
+}
+
+func (i identity) UserIdentity(*gin.Context) (string, models.Role, error) {
+	return "1", i.role, nil
+}
+
+// projectsDelegate knows project 7 of student 1.
+type projectsDelegate struct {
+	projects.Delegate
+}
+
+func (projectsDelegate) GetProjectMetadataById(projectId string) (models.ProjectHTTP, error) {
+	if projectId != "7" {
+		return models.ProjectHTTP{}, projects.ErrProjectNotFound
+	}
+	return models.ProjectHTTP{ID: "7", AuthorId: "1", AuthorRole: models.Student}, nil
+}
+
+type pagesDelegate struct {
+	projectPage.Delegate
+	updated []string
+}
+
+func (d *pagesDelegate) UpdateProjectPage(page *models.ProjectPageHTTP) error {
+	d.updated = append(d.updated, page.ProjectID)
+	return nil
+}
+
+func (d *pagesDelegate) SaveThumbnail(projectId string, screenshot []byte) (string, error) {
+	d.updated = append(d.updated, projectId)
+	return "preview", nil
+}
+
+func (d *pagesDelegate) ExportSb3(projectId string) (string, []byte, error) {
+	return "project.sb3", []byte("sb3"), nil
+}
+
+// parentScope lets the parent of student 1 in besides the student.
+type parentScope struct {
+	access.Scope
+}
+
+func (parentScope) CheckUser(userId string, userRole models.Role, otherId string, otherRole models.Role) error {
+	if access.SameUser(userId, userRole, otherId, otherRole) || userRole == models.Parent {
+		return nil
+	}
+	return access.ErrNoAccess
+}
+
+func serve(who identity, delegate *pagesDelegate, method, path, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewProjectPageHandler(who, projectsDelegate{}, delegate, parentScope{})
+	handler.InitProjectRoutes(router)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestUpdateProjectPageByAuthor(t *testing.T) {
+	body := `{"projectPage":{"projectId":"7","title":"maze"}}`
+
+	delegate := &pagesDelegate{}
+	assert.Equal(t, http.StatusOK, serve(identity{role: models.Student}, delegate, "PUT", "/projectPage/", body).Code)
+	assert.Equal(t, []string{"7"}, delegate.updated)
+
+	delegate = &pagesDelegate{}
+	response := serve(identity{role: models.Teacher}, delegate, "PUT", "/projectPage/", body)
+	assert.Equal(t, http.StatusForbidden, response.Code, "teacher 1 is not student 1")
+	response = serve(identity{role: models.Parent}, delegate, "PUT", "/projectPage/", body)
+	assert.Equal(t, http.StatusForbidden, response.Code, "parents only look at the projects of their children")
+	assert.Empty(t, delegate.updated)
+
+	response = serve(identity{role: models.Student}, delegate, "PUT", "/projectPage/", `{"projectPage":{"projectId":"8"}}`)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestExportSb3Scoped(t *testing.T) {
+	response := serve(identity{role: models.Parent}, &pagesDelegate{}, "GET", "/sb3/7", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "sb3", response.Body.String())
+	assert.Contains(t, response.Header().Get("Content-Disposition"), "project.sb3")
+
+	response = serve(identity{role: models.Teacher}, &pagesDelegate{}, "GET", "/sb3/7", "")
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestSaveThumbnailByAuthor(t *testing.T) {
+	delegate := &pagesDelegate{}
+	assert.Equal(t, http.StatusOK, serve(identity{role: models.Student}, delegate, "POST", "/thumbnail/7", "png").Code)
+	assert.Equal(t, []string{"7"}, delegate.updated)
+
+	delegate = &pagesDelegate{}
+	response := serve(identity{role: models.Parent}, delegate, "POST", "/thumbnail/7", "png")
+	assert.Equal(t, http.StatusForbidden, response.Code, "only the author takes screenshots of the stage")
+	response = serve(identity{role: models.Student}, delegate, "POST", "/thumbnail/8", "png")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Empty(t, delegate.updated)
+}
@@ -3,9 +3,17 @@ package projectPage
 import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 
 type UseCase interface {
-	CreateProjectPage(authorId string) (projectId string, err error)
+	CreateProjectPage(authorId string, authorRole models.Role) (projectId string, err error)
 	DeleteProjectPage(projectId string) (err error)
-	GetAllProjectPageByUserId(authorId string) (projectPages []*models.ProjectPageCore, err error)
+	GetAllProjectPageByUserId(authorId string, authorRole models.Role) (projectPages []*models.ProjectPageCore, err error)
 	GetProjectPageById(projectPageId string) (projectPage *models.ProjectPageCore, err error)
+	RemixProject(projectPageId, authorId string, authorRole models.Role) (remix *models.ProjectPageCore, err error)
+	GetRemixTree(projectId string) (nodes []*models.RemixNodeCore, err error)
+	GetRemixCount(projectId string) (count *models.RemixCountCore, err error)
+	ImportSb3(authorId string, authorRole models.Role, fileName string, archive []byte) (projectId string, err error)
+	ExportSb3(projectId string) (fileName string, archive []byte, err error)
 	UpdateProjectPage(projectPage *models.ProjectPageCore) (err error)
+	SaveThumbnail(projectId string, screenshot []byte) (preview string, err error)
+	GetThumbnail(name string) (thumbnail []byte, err error)
+	RefreshPlaceholders() (err error)
 }
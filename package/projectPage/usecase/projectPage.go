@@ -1,16 +1,29 @@
 package usecase
 
 import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
+	"log"
+	"strconv"
 )
 
 type ProjectPageUseCaseImpl struct {
 	projectPageGateway projectPage.Gateway
 	projectGateway     projects.Gateway
+	assetsGateway      assets.Gateway
+	sb3Limits          sb3Limits
+	thumbnailLimits    thumbnailLimits
+	assetMaxSize       int
+	// placeholderBatchSize is how many placeholders one run of the job makes
+	placeholderBatchSize int
+	// placeholderFailures counts the pages the current pass of RefreshPlaceholders could not make a placeholder of,
+	// they stay in front of the stale ones and are skipped until the pass gets to the end
+	placeholderFailures int
 }
 
 type ProjectPageUseCaseModule struct {
@@ -18,11 +31,29 @@ type ProjectPageUseCaseModule struct {
 	projectPage.UseCase
 }
 
-func SetupProjectPageUseCase(projectPageGateway projectPage.Gateway, projectGateway projects.Gateway) ProjectPageUseCaseModule {
+func SetupProjectPageUseCase(
+	projectPageGateway projectPage.Gateway,
+	projectGateway projects.Gateway,
+	assetsGateway assets.Gateway,
+) ProjectPageUseCaseModule {
 	return ProjectPageUseCaseModule{
 		UseCase: &ProjectPageUseCaseImpl{
 			projectPageGateway: projectPageGateway,
 			projectGateway:     projectGateway,
+			assetsGateway:      assetsGateway,
+			sb3Limits: sb3Limits{
+				maxSize:         viper.GetInt("projectPage.sb3_max_size"),
+				maxFiles:        viper.GetInt("projectPage.sb3_max_files"),
+				maxUnpackedSize: viper.GetInt64("projectPage.sb3_max_unpacked_size"),
+			},
+			thumbnailLimits: thumbnailLimits{
+				width:   viper.GetInt("projectPage.thumbnail_width"),
+				height:  viper.GetInt("projectPage.thumbnail_height"),
+				maxSize: viper.GetInt("projectPage.thumbnail_max_size"),
+				baseUrl: viper.GetString("projectPage.thumbnail_base_url"),
+			},
+			assetMaxSize:         viper.GetInt("assets.max_size"),
+			placeholderBatchSize: viper.GetInt("projectPage.placeholder_batch_size"),
 		},
 	}
 }
@@ -46,26 +77,23 @@ const emptyProjectJson = "{\"targets\":[{\"isStage\":true,\"name\":\"Stage\",\"v
 	"\":[],\"meta\":{\"semver\":\"3.0.0\",\"vm\":\"0.2.0-prerelease.20220519142410\",\"agent\":\"Mozilla/5.0 (X11;" +
 	" Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.0.0 Safari/537.36\"}}"
 
-func (p *ProjectPageUseCaseImpl) CreateProjectPage(authorId string) (projectId string, err error) {
-	project := models.ProjectCore{}
-	project.AuthorId = authorId
-	project.Json = emptyProjectJson
-	project.Name = "Untitled"
+func (p *ProjectPageUseCaseImpl) CreateProjectPage(authorId string, authorRole models.Role) (projectId string, err error) {
+	return p.createProjectPage(
+		&models.ProjectCore{AuthorId: authorId, AuthorRole: authorRole, Json: emptyProjectJson, Name: "Untitled"},
+		&models.ProjectPageCore{Title: "Untitled"},
+	)
+}
 
-	projectId, err = p.projectGateway.CreateProject(&project)
+// createProjectPage stores the project and then its page, which links to the project in scratch-gui.
+func (p *ProjectPageUseCaseImpl) createProjectPage(project *models.ProjectCore, projectPage *models.ProjectPageCore) (projectId string, err error) {
+	projectId, err = p.projectGateway.CreateProject(project)
 	if err != nil {
 		return "", err
 	}
 
-	projectPage := &models.ProjectPageCore{
-		Title:       "Untitled",
-		ProjectId:   projectId,
-		Instruction: "",
-		Notes:       "",
-		Preview:     "",
-		LinkScratch: viper.GetString("projectPage.scratchLink") + "?#" + projectId,
-		IsShared:    false,
-	}
+	projectPage.ProjectId = projectId
+	projectPage.LinkScratch = viper.GetString("projectPage.scratchLink") + "?#" + projectId
+	projectPage.IsShared = false
 	_, err = p.projectPageGateway.CreateProjectPage(projectPage)
 	if err != nil {
 		return "", err
@@ -73,7 +101,162 @@ func (p *ProjectPageUseCaseImpl) CreateProjectPage(authorId string) (projectId s
 	return
 }
 
+// RemixProject copies the project and its page to the author. The remix starts unshared
+// and credits the page it was made from.
+func (p *ProjectPageUseCaseImpl) RemixProject(projectPageId, authorId string, authorRole models.Role) (remix *models.ProjectPageCore, err error) {
+	source, err := p.projectPageGateway.GetProjectPageById(projectPageId)
+	if err != nil {
+		return
+	}
+	sourceProject, err := p.projectGateway.GetProjectById(source.ProjectId)
+	if err != nil {
+		return
+	}
+	projectId, err := p.createProjectPage(
+		&models.ProjectCore{
+			AuthorId:   authorId,
+			AuthorRole: authorRole,
+			Json:       sourceProject.Json,
+			Name:       remixTitle(sourceProject.Name),
+			ParentId:   sourceProject.ID,
+		},
+		&models.ProjectPageCore{
+			Title:       remixTitle(source.Title),
+			Instruction: source.Instruction,
+			Notes:       source.Notes,
+			Preview:     source.Preview,
+			// the remix starts with the JSON the placeholder was made from
+			PlaceholderOf: source.PlaceholderOf,
+			RemixedFrom: &models.RemixCreditCore{
+				ProjectId: sourceProject.ID,
+				Title:     source.Title,
+				AuthorId:  sourceProject.AuthorId,
+			},
+		},
+	)
+	if err != nil {
+		return
+	}
+	return p.projectPageGateway.GetProjectPageByProjectId(projectId)
+}
+
+// GetRemixTree and GetRemixCount take the id of the project, ids that are not numbers name no project.
+func (p *ProjectPageUseCaseImpl) GetRemixTree(projectId string) (nodes []*models.RemixNodeCore, err error) {
+	if !isProjectId(projectId) {
+		return nil, projectPage.ErrPageNotFound
+	}
+	nodes, err = p.projectPageGateway.GetRemixTree(projectId)
+	if err != nil {
+		return
+	}
+	if len(nodes) == 0 {
+		return nil, projectPage.ErrPageNotFound
+	}
+	return
+}
+
+func (p *ProjectPageUseCaseImpl) GetRemixCount(projectId string) (count *models.RemixCountCore, err error) {
+	if !isProjectId(projectId) {
+		return nil, projectPage.ErrPageNotFound
+	}
+	return p.projectPageGateway.GetRemixCount(projectId)
+}
+
+// isProjectId keeps ids the id column cannot hold away from the queries comparing it.
+func isProjectId(projectId string) bool {
+	_, err := strconv.ParseUint(projectId, 10, 64)
+	return err == nil
+}
+
+// remixTitle marks the copy without growing past the title column.
+func remixTitle(title string) string {
+	const suffix = " (remix)"
+	if runes := []rune(title); len(runes)+len(suffix) > maxTitleLength {
+		title = string(runes[:maxTitleLength-len(suffix)])
+	}
+	return title + suffix
+}
+
+// ImportSb3 checks every asset of the archive before storing any of them. Assets the archive
+// leaves out are not an error, scratch-gui loads them from its library like it does for
+// projects it saved itself.
+func (p *ProjectPageUseCaseImpl) ImportSb3(authorId string, authorRole models.Role, fileName string, archive []byte) (projectId string, err error) {
+	sb3, err := readSb3(archive, p.sb3Limits)
+	if err != nil {
+		return
+	}
+	md5exts, err := projectAssets(sb3.projectJson)
+	if err != nil {
+		return
+	}
+	var packed []*models.AssetCore
+	for _, md5ext := range md5exts {
+		content, ok := sb3.assets[md5ext]
+		if !ok {
+			continue
+		}
+		asset, _ := models.ParseMd5ext(md5ext)
+		if len(content) == 0 || !asset.HasContent(content) {
+			return "", projectPage.ErrSb3AssetMismatch
+		}
+		if p.assetMaxSize > 0 && len(content) > p.assetMaxSize {
+			return "", projectPage.ErrSb3TooLarge
+		}
+		asset.Size = len(content)
+		packed = append(packed, asset)
+	}
+	for _, asset := range packed {
+		if _, contentErr := p.assetsGateway.GetAssetContent(asset.Md5ext); contentErr == nil {
+			continue
+		}
+		if err = p.assetsGateway.CreateAsset(asset, sb3.assets[asset.Md5ext]); err != nil {
+			return
+		}
+	}
+	title := sb3Title(fileName)
+	return p.createProjectPage(
+		&models.ProjectCore{AuthorId: authorId, AuthorRole: authorRole, Json: sb3.projectJson, Name: title},
+		&models.ProjectPageCore{Title: title},
+	)
+}
+
+// ExportSb3 packs the project with the assets this server has, the ones it does not have
+// come from the scratch-gui library.
+func (p *ProjectPageUseCaseImpl) ExportSb3(projectId string) (fileName string, archive []byte, err error) {
+	project, err := p.projectGateway.GetProjectById(projectId)
+	if err != nil {
+		return
+	}
+	md5exts, err := projectAssets(project.Json)
+	if err != nil {
+		return
+	}
+	packed := make(map[string][]byte, len(md5exts))
+	for _, md5ext := range md5exts {
+		content, contentErr := p.assetsGateway.GetAssetContent(md5ext)
+		if errors.Is(contentErr, assets.ErrAssetNotFound) {
+			continue
+		}
+		if contentErr != nil {
+			log.Println(contentErr)
+			return "", nil, projectPage.ErrInternalServerLevel
+		}
+		packed[md5ext] = content
+	}
+	if archive, err = writeSb3(project.Json, packed); err != nil {
+		log.Println(err)
+		return "", nil, projectPage.ErrInternalServerLevel
+	}
+	title := project.Name
+	if page, pageErr := p.projectPageGateway.GetProjectPageByProjectId(projectId); pageErr == nil {
+		title = page.Title
+	}
+	return sb3Title(title) + ".sb3", archive, nil
+}
+
+// UpdateProjectPage leaves the preview alone, it is set by the thumbnail the editor uploads.
 func (p *ProjectPageUseCaseImpl) UpdateProjectPage(projectPage *models.ProjectPageCore) (err error) {
+	projectPage.Preview, projectPage.PlaceholderOf = "", ""
 	return p.projectPageGateway.UpdateProjectPage(projectPage)
 }
 
@@ -85,8 +268,8 @@ func (p *ProjectPageUseCaseImpl) DeleteProjectPage(projectId string) (err error)
 	return p.projectPageGateway.DeleteProjectPage(projectId)
 }
 
-func (p *ProjectPageUseCaseImpl) GetAllProjectPageByUserId(authorId string) (projectPages []*models.ProjectPageCore, err error) {
-	projects, err := p.projectGateway.GetProjectsByAuthorId(authorId)
+func (p *ProjectPageUseCaseImpl) GetAllProjectPageByUserId(authorId string, authorRole models.Role) (projectPages []*models.ProjectPageCore, err error) {
+	projects, err := p.projectGateway.GetProjectsByAuthorId(authorId, authorRole)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRemixTitle(t *testing.T) {
+	assert.Equal(t, "Maze (remix)", remixTitle("Maze"))
+	assert.Equal(t, "Maze (remix) (remix)", remixTitle(remixTitle("Maze")))
+
+	long := remixTitle(strings.Repeat("я", maxTitleLength))
+	assert.Len(t, []rune(long), maxTitleLength)
+	assert.True(t, strings.HasSuffix(long, " (remix)"))
+}
+
+// remixesGateway knows the lineage of project 1 only.
+type remixesGateway struct {
+	projectPage.Gateway
+	asked []string
+}
+
+func (g *remixesGateway) GetRemixTree(projectId string) ([]*models.RemixNodeCore, error) {
+	g.asked = append(g.asked, projectId)
+	if projectId != "1" {
+		return nil, nil
+	}
+	return []*models.RemixNodeCore{{ProjectId: "1"}, {ProjectId: "2", ParentId: "1", Depth: 1}}, nil
+}
+
+func (g *remixesGateway) GetRemixCount(projectId string) (*models.RemixCountCore, error) {
+	g.asked = append(g.asked, projectId)
+	return &models.RemixCountCore{Direct: 1, Total: 1}, nil
+}
+
+func TestGetRemixTree(t *testing.T) {
+	gateway := &remixesGateway{}
+	usecase := &ProjectPageUseCaseImpl{projectPageGateway: gateway}
+
+	nodes, err := usecase.GetRemixTree("1")
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 2)
+
+	_, err = usecase.GetRemixTree("9")
+	assert.ErrorIs(t, err, projectPage.ErrPageNotFound, "a project outside of any lineage does not exist")
+
+	for _, projectId := range []string{"", "abc", "1; DROP", "-1"} {
+		_, err = usecase.GetRemixTree(projectId)
+		assert.ErrorIs(t, err, projectPage.ErrPageNotFound, projectId)
+		_, err = usecase.GetRemixCount(projectId)
+		assert.ErrorIs(t, err, projectPage.ErrPageNotFound, projectId)
+	}
+	assert.Equal(t, []string{"1", "9"}, gateway.asked, "ids that are not numbers never reach the database")
+}
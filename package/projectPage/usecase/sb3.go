@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"io"
+	"math"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	sb3ProjectFile = "project.json"
+	// maxTitleLength is the size of ProjectPageDB.Title
+	maxTitleLength = 256
+)
+
+// sb3Limits bound what an uploaded archive may unpack to, the sizes in its headers are not trusted.
+type sb3Limits struct {
+	maxSize         int
+	maxFiles        int
+	maxUnpackedSize int64
+}
+
+// sb3Archive is the content of a .sb3 file: project.json and the assets stored next to it.
+type sb3Archive struct {
+	projectJson string
+	assets      map[string][]byte
+}
+
+func readSb3(archive []byte, limits sb3Limits) (sb3 *sb3Archive, err error) {
+	if limits.maxSize > 0 && len(archive) > limits.maxSize {
+		return nil, projectPage.ErrSb3TooLarge
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, projectPage.ErrBadSb3
+	}
+	if limits.maxFiles > 0 && len(reader.File) > limits.maxFiles {
+		return nil, projectPage.ErrSb3TooLarge
+	}
+	sb3 = &sb3Archive{assets: make(map[string][]byte)}
+	hasProject := false
+	remaining := limits.maxUnpackedSize
+	if remaining <= 0 {
+		remaining = math.MaxInt64 - 1
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		// some tools pack the project into a folder
+		name := path.Base(file.Name)
+		_, isAsset := models.ParseMd5ext(name)
+		if name != sb3ProjectFile && !isAsset {
+			continue
+		}
+		content, readErr := readSb3File(file, remaining)
+		if readErr != nil {
+			return nil, readErr
+		}
+		remaining -= int64(len(content))
+		if name == sb3ProjectFile {
+			sb3.projectJson = string(content)
+			hasProject = true
+			continue
+		}
+		sb3.assets[name] = content
+	}
+	if !hasProject {
+		return nil, projectPage.ErrBadSb3
+	}
+	return
+}
+
+func readSb3File(file *zip.File, limit int64) (content []byte, err error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, projectPage.ErrBadSb3
+	}
+	defer rc.Close()
+	content, err = io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, projectPage.ErrBadSb3
+	}
+	if int64(len(content)) > limit {
+		return nil, projectPage.ErrSb3TooLarge
+	}
+	return
+}
+
+func writeSb3(projectJson string, assets map[string][]byte) (archive []byte, err error) {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	files := []string{sb3ProjectFile}
+	for name := range assets {
+		files = append(files, name)
+	}
+	sort.Strings(files[1:])
+	for _, name := range files {
+		content := assets[name]
+		if name == sb3ProjectFile {
+			content = []byte(projectJson)
+		}
+		file, createErr := writer.Create(name)
+		if createErr != nil {
+			return nil, createErr
+		}
+		if _, err = file.Write(content); err != nil {
+			return
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	return buffer.Bytes(), nil
+}
+
+type sb3Asset struct {
+	AssetId    string `json:"assetId"`
+	DataFormat string `json:"dataFormat"`
+	Md5ext     string `json:"md5ext"`
+}
+
+// projectAssets validates the shape of a Scratch 3 project.json and returns the costumes
+// and sounds it refers to. Projects saved by old versions of the gui carry no md5ext.
+func projectAssets(projectJson string) (md5exts []string, err error) {
+	var project struct {
+		Targets []struct {
+			IsStage  bool       `json:"isStage"`
+			Costumes []sb3Asset `json:"costumes"`
+			Sounds   []sb3Asset `json:"sounds"`
+		} `json:"targets"`
+	}
+	if err = json.Unmarshal([]byte(projectJson), &project); err != nil {
+		return nil, projectPage.ErrBadSb3
+	}
+	stages := 0
+	seen := make(map[string]bool)
+	for _, target := range project.Targets {
+		if target.IsStage {
+			stages++
+		}
+		for _, asset := range append(target.Costumes, target.Sounds...) {
+			md5ext := asset.Md5ext
+			if md5ext == "" {
+				md5ext = asset.AssetId + "." + asset.DataFormat
+			}
+			if _, ok := models.ParseMd5ext(md5ext); !ok {
+				return nil, projectPage.ErrBadSb3
+			}
+			if !seen[md5ext] {
+				seen[md5ext] = true
+				md5exts = append(md5exts, md5ext)
+			}
+		}
+	}
+	if stages != 1 {
+		return nil, projectPage.ErrBadSb3
+	}
+	sort.Strings(md5exts)
+	return
+}
+
+// sb3Title names the imported project after the uploaded file.
+func sb3Title(fileName string) string {
+	title := strings.TrimSpace(strings.TrimSuffix(path.Base(fileName), path.Ext(fileName)))
+	if title == "" || title == "." || title == "/" {
+		return "Untitled"
+	}
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		return string(runes[:maxTitleLength])
+	}
+	return title
+}
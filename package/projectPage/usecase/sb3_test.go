@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func md5extOf(content, dataFormat string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:]) + "." + dataFormat
+}
+
+func TestProjectAssets(t *testing.T) {
+	md5exts, err := projectAssets(emptyProjectJson)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"0fb9be3e8397c983338cb71dc84d0b25.svg",
+		"83a9787d4cb6f3b7632b4ddfebf74367.wav",
+		"83c36d806dc92327b9e7049a565c6bff.wav",
+		"bcf454acf82e4504149f7ffe07081dbc.svg",
+		"cd21514d0531fdffb22204e0ec5ed84a.svg",
+	}, md5exts)
+
+	md5exts, err = projectAssets(`{"targets":[{"isStage":true,"costumes":[{"assetId":"cd21514d0531fdffb22204e0ec5ed84a","dataFormat":"svg"}]}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cd21514d0531fdffb22204e0ec5ed84a.svg"}, md5exts)
+
+	for _, projectJson := range []string{
+		`not json`,
+		`{"targets":[]}`,
+		`{"targets":[{"isStage":false}]}`,
+		`{"targets":[{"isStage":true},{"isStage":true}]}`,
+		`{"targets":[{"isStage":true,"costumes":[{"md5ext":"../../etc/passwd"}]}]}`,
+	} {
+		_, err = projectAssets(projectJson)
+		assert.ErrorIs(t, err, projectPage.ErrBadSb3, projectJson)
+	}
+}
+
+func TestReadSb3(t *testing.T) {
+	svg := "<svg xmlns=\"http://www.w3.org/2000/svg\"/>"
+	archive := zipFiles(t, map[string]string{
+		"project.json":        emptyProjectJson,
+		md5extOf(svg, "svg"):  svg,
+		"__MACOSX/.DS_Store":  "ignored",
+		"folder/not-an-asset": "ignored",
+		md5extOf("x", "exe"):  "ignored",
+		"folder/":             "",
+	})
+	sb3, err := readSb3(archive, sb3Limits{maxSize: 1 << 20, maxFiles: 10, maxUnpackedSize: 1 << 20})
+	assert.NoError(t, err)
+	assert.Equal(t, emptyProjectJson, sb3.projectJson)
+	assert.Equal(t, map[string][]byte{md5extOf(svg, "svg"): []byte(svg)}, sb3.assets)
+
+	_, err = readSb3([]byte("not a zip"), sb3Limits{})
+	assert.ErrorIs(t, err, projectPage.ErrBadSb3)
+	_, err = readSb3(zipFiles(t, map[string]string{md5extOf(svg, "svg"): svg}), sb3Limits{})
+	assert.ErrorIs(t, err, projectPage.ErrBadSb3)
+
+	_, err = readSb3(archive, sb3Limits{maxSize: 10})
+	assert.ErrorIs(t, err, projectPage.ErrSb3TooLarge)
+	_, err = readSb3(archive, sb3Limits{maxFiles: 2})
+	assert.ErrorIs(t, err, projectPage.ErrSb3TooLarge)
+	// compresses to a few bytes, the unpacked size is what counts
+	bomb := zipFiles(t, map[string]string{"project.json": strings.Repeat(" ", 1<<20)})
+	assert.Less(t, len(bomb), 1<<12)
+	_, err = readSb3(bomb, sb3Limits{maxUnpackedSize: 1 << 16})
+	assert.ErrorIs(t, err, projectPage.ErrSb3TooLarge)
+}
+
+func TestWriteSb3RoundTrip(t *testing.T) {
+	svg := "<svg xmlns=\"http://www.w3.org/2000/svg\"/>"
+	assets := map[string][]byte{md5extOf(svg, "svg"): []byte(svg)}
+	archive, err := writeSb3(emptyProjectJson, assets)
+	assert.NoError(t, err)
+
+	sb3, err := readSb3(archive, sb3Limits{})
+	assert.NoError(t, err)
+	assert.Equal(t, emptyProjectJson, sb3.projectJson)
+	assert.Equal(t, assets, sb3.assets)
+}
+
+func TestSb3Title(t *testing.T) {
+	assert.Equal(t, "My game", sb3Title("My game.sb3"))
+	assert.Equal(t, "game", sb3Title("/home/student/game.sb3"))
+	assert.Equal(t, "Untitled", sb3Title(".sb3"))
+	assert.Equal(t, "Untitled", sb3Title(""))
+	assert.Len(t, []rune(sb3Title(strings.Repeat("я", 300)+".sb3")), maxTitleLength)
+}
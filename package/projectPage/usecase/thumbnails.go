@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assets"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"log"
+	"regexp"
+	"sort"
+)
+
+// maxThumbnailPixels keeps a small file that declares a huge image from being decoded.
+const maxThumbnailPixels = 4096 * 4096
+
+// defaultBackdrop is the blank backdrop of a new project, a placeholder made of it would be empty.
+const defaultBackdrop = "cd21514d0531fdffb22204e0ec5ed84a.svg"
+
+var thumbnailNamePattern = regexp.MustCompile(`^[0-9a-f]{64}\.png$`)
+
+type thumbnailLimits struct {
+	width   int
+	height  int
+	maxSize int
+	// baseUrl is where the browser reaches this server, previews are stored as full URLs
+	baseUrl string
+}
+
+func (l thumbnailLimits) thumbnailUrl(name string) string {
+	return l.baseUrl + "/thumbnail/" + name
+}
+
+func (l thumbnailLimits) assetUrl(md5ext string) string {
+	return l.baseUrl + "/assets/internalapi/asset/" + md5ext
+}
+
+// makeThumbnail scales a PNG, JPEG or GIF down to fit width x height and encodes it as PNG.
+func makeThumbnail(content []byte, width, height int) (thumbnail []byte, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxThumbnailPixels {
+		return nil, projectPage.ErrBadThumbnail
+	}
+	source, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, projectPage.ErrBadThumbnail
+	}
+	var encoded bytes.Buffer
+	if err = png.Encode(&encoded, scaleDown(source, width, height)); err != nil {
+		return
+	}
+	return encoded.Bytes(), nil
+}
+
+// scaleDown fits the image into width x height keeping its aspect ratio. Every pixel is
+// the average of the source pixels it covers, smaller images keep their size.
+func scaleDown(source image.Image, width, height int) *image.NRGBA {
+	bounds := source.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > width || h > height {
+		if w*height > h*width {
+			w, h = width, h*width/w
+		} else {
+			w, h = w*height/h, height
+		}
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	scaled := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := bounds.Min.Y+y*bounds.Dy()/h, bounds.Min.Y+(y+1)*bounds.Dy()/h
+		for x := 0; x < w; x++ {
+			x0, x1 := bounds.Min.X+x*bounds.Dx()/w, bounds.Min.X+(x+1)*bounds.Dx()/w
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := source.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			if a == 0 {
+				continue
+			}
+			// the sums are alpha premultiplied, dividing by the alpha sum undoes that
+			scaled.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r * 0xffff / a >> 8),
+				G: uint8(g * 0xffff / a >> 8),
+				B: uint8(b * 0xffff / a >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return scaled
+}
+
+// placeholderCostumes lists what a placeholder can be made of: the backdrop the stage shows,
+// then the first costume of every sprite from the back layer to the front one.
+func placeholderCostumes(projectJson string) (md5exts []string) {
+	var project struct {
+		Targets []struct {
+			IsStage        bool       `json:"isStage"`
+			CurrentCostume int        `json:"currentCostume"`
+			LayerOrder     int        `json:"layerOrder"`
+			Costumes       []sb3Asset `json:"costumes"`
+		} `json:"targets"`
+	}
+	if err := json.Unmarshal([]byte(projectJson), &project); err != nil {
+		return
+	}
+	var backdrop string
+	sprites := make(map[int]string)
+	var layers []int
+	for _, target := range project.Targets {
+		if len(target.Costumes) == 0 {
+			continue
+		}
+		costume := target.Costumes[0]
+		if target.IsStage {
+			if target.CurrentCostume >= 0 && target.CurrentCostume < len(target.Costumes) {
+				costume = target.Costumes[target.CurrentCostume]
+			}
+		}
+		md5ext := costume.Md5ext
+		if md5ext == "" {
+			md5ext = costume.AssetId + "." + costume.DataFormat
+		}
+		if _, ok := models.ParseMd5ext(md5ext); !ok {
+			continue
+		}
+		if target.IsStage {
+			backdrop = md5ext
+			continue
+		}
+		if _, taken := sprites[target.LayerOrder]; !taken {
+			layers = append(layers, target.LayerOrder)
+			sprites[target.LayerOrder] = md5ext
+		}
+	}
+	if backdrop != "" && backdrop != defaultBackdrop {
+		md5exts = append(md5exts, backdrop)
+	}
+	sort.Ints(layers)
+	for _, layer := range layers {
+		md5exts = append(md5exts, sprites[layer])
+	}
+	return
+}
+
+// placeholderOf makes the preview of a project that has no uploaded thumbnail. Bitmaps are
+// scaled down like uploads, vector costumes cannot be rasterized here and are shown as they are.
+// Costumes scratch-gui takes from its own library are not on this server and are skipped.
+func (p *ProjectPageUseCaseImpl) placeholderOf(projectJson string) (preview string, err error) {
+	for _, md5ext := range placeholderCostumes(projectJson) {
+		asset, _ := models.ParseMd5ext(md5ext)
+		if asset.DataFormat == "svg" {
+			if _, err = p.assetsGateway.GetAssetByMd5ext(md5ext); errors.Is(err, assets.ErrAssetNotFound) {
+				continue
+			}
+			if err != nil {
+				return
+			}
+			return p.thumbnailLimits.assetUrl(md5ext), nil
+		}
+		content, contentErr := p.assetsGateway.GetAssetContent(md5ext)
+		if errors.Is(contentErr, assets.ErrAssetNotFound) {
+			continue
+		}
+		if contentErr != nil {
+			return "", contentErr
+		}
+		thumbnail, thumbnailErr := makeThumbnail(content, p.thumbnailLimits.width, p.thumbnailLimits.height)
+		if thumbnailErr != nil {
+			continue
+		}
+		name, saveErr := p.projectPageGateway.SaveThumbnail(thumbnail)
+		if saveErr != nil {
+			return "", saveErr
+		}
+		return p.thumbnailLimits.thumbnailUrl(name), nil
+	}
+	return "", nil
+}
+
+// RefreshPlaceholders makes the previews of a batch of pages that have no uploaded thumbnail from the
+// current JSON of their projects. The workers run it periodically, pages are read as they are stored.
+// A page that fails is logged and skipped, the next pass tries it again.
+func (p *ProjectPageUseCaseImpl) RefreshPlaceholders() (err error) {
+	projectIds, err := p.projectPageGateway.GetProjectIdsWithStalePlaceholders(p.placeholderFailures, p.placeholderBatchSize)
+	if err != nil {
+		return
+	}
+	if len(projectIds) == 0 {
+		p.placeholderFailures = 0
+		return
+	}
+	for _, projectId := range projectIds {
+		if refreshErr := p.refreshPlaceholder(projectId); refreshErr != nil {
+			log.Println(projectId, refreshErr)
+			p.placeholderFailures++
+		}
+	}
+	return
+}
+
+func (p *ProjectPageUseCaseImpl) refreshPlaceholder(projectId string) (err error) {
+	project, err := p.projectGateway.GetProjectById(projectId)
+	if err != nil {
+		return
+	}
+	preview, err := p.placeholderOf(project.Json)
+	if err != nil {
+		return
+	}
+	return p.projectPageGateway.SetProjectPagePlaceholder(projectId, preview, project.Checksum)
+}
+
+// SaveThumbnail stores the stage screenshot the editor has taken as the preview of the page.
+func (p *ProjectPageUseCaseImpl) SaveThumbnail(projectId string, screenshot []byte) (preview string, err error) {
+	if len(screenshot) == 0 {
+		return "", projectPage.ErrBadThumbnail
+	}
+	if p.thumbnailLimits.maxSize > 0 && len(screenshot) > p.thumbnailLimits.maxSize {
+		return "", projectPage.ErrThumbnailTooLarge
+	}
+	if _, err = p.projectPageGateway.GetProjectPageByProjectId(projectId); err != nil {
+		return "", projectPage.ErrPageNotFound
+	}
+	thumbnail, err := makeThumbnail(screenshot, p.thumbnailLimits.width, p.thumbnailLimits.height)
+	if err != nil {
+		return
+	}
+	name, err := p.projectPageGateway.SaveThumbnail(thumbnail)
+	if err != nil {
+		return
+	}
+	preview = p.thumbnailLimits.thumbnailUrl(name)
+	err = p.projectPageGateway.SetProjectPagePreview(projectId, preview, "")
+	return
+}
+
+func (p *ProjectPageUseCaseImpl) GetThumbnail(name string) (thumbnail []byte, err error) {
+	if !thumbnailNamePattern.MatchString(name) {
+		return nil, projectPage.ErrThumbnailNotFound
+	}
+	return p.projectPageGateway.GetThumbnail(name)
+}
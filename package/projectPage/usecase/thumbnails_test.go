@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodedImage(t *testing.T, width, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	picture := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			picture.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	assert.NoError(t, encode(&encoded, picture))
+	return encoded.Bytes()
+}
+
+func TestMakeThumbnail(t *testing.T) {
+	encodePng := func(buffer *bytes.Buffer, picture image.Image) error { return png.Encode(buffer, picture) }
+	encodeJpeg := func(buffer *bytes.Buffer, picture image.Image) error { return jpeg.Encode(buffer, picture, nil) }
+	sizes := []struct {
+		width, height, expectedWidth, expectedHeight int
+		encode                                       func(*bytes.Buffer, image.Image) error
+	}{
+		{960, 720, 480, 360, encodePng},
+		{1000, 250, 480, 120, encodeJpeg},
+		{100, 50, 100, 50, encodePng},
+	}
+	for _, size := range sizes {
+		thumbnail, err := makeThumbnail(encodedImage(t, size.width, size.height, size.encode), 480, 360)
+		assert.NoError(t, err)
+		config, format, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+		assert.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, []int{size.expectedWidth, size.expectedHeight}, []int{config.Width, config.Height})
+	}
+
+	_, err := makeThumbnail([]byte("<svg/>"), 480, 360)
+	assert.ErrorIs(t, err, projectPage.ErrBadThumbnail)
+}
+
+func TestScaleDown(t *testing.T) {
+	picture := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	picture.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+	picture.SetNRGBA(1, 0, color.NRGBA{B: 255, A: 255})
+	// transparent pixels do not darken the average
+	picture.SetNRGBA(0, 1, color.NRGBA{G: 255})
+	picture.SetNRGBA(1, 1, color.NRGBA{G: 255})
+
+	scaled := scaleDown(picture, 1, 1)
+	assert.Equal(t, image.Rect(0, 0, 1, 1), scaled.Bounds())
+	assert.Equal(t, color.NRGBA{R: 127, G: 0, B: 127, A: 127}, scaled.NRGBAAt(0, 0))
+}
+
+func TestPlaceholderCostumes(t *testing.T) {
+	assert.Equal(t, []string{
+		"11111111111111111111111111111111.png",
+		"33333333333333333333333333333333.svg",
+		"22222222222222222222222222222222.jpg",
+	}, placeholderCostumes(`{"targets":[
+		{"isStage":true,"currentCostume":1,"costumes":[
+			{"md5ext":"cd21514d0531fdffb22204e0ec5ed84a.svg"},
+			{"assetId":"11111111111111111111111111111111","dataFormat":"png"}
+		]},
+		{"isStage":false,"layerOrder":2,"currentCostume":1,"costumes":[
+			{"md5ext":"22222222222222222222222222222222.jpg"},{"md5ext":"44444444444444444444444444444444.png"}
+		]},
+		{"isStage":false,"layerOrder":1,"costumes":[{"md5ext":"33333333333333333333333333333333.svg"}]},
+		{"isStage":false,"layerOrder":3,"costumes":[{"md5ext":"../secret.png"}]}
+	]}`))
+
+	assert.Equal(t, []string{"bcf454acf82e4504149f7ffe07081dbc.svg"}, placeholderCostumes(emptyProjectJson),
+		"the blank backdrop of a new project is skipped")
+	assert.Empty(t, placeholderCostumes("not a project"))
+}
+
+// placeholderPages has the pages of projects 6 and 7 waiting for their placeholders.
+type placeholderPages struct {
+	projectPage.Gateway
+	set []string
+}
+
+func (g *placeholderPages) GetProjectIdsWithStalePlaceholders(offset, limit int) (projectIds []string, err error) {
+	stale := []string{"6"}
+	if len(g.set) == 0 {
+		stale = append(stale, "7")
+	}
+	if offset >= len(stale) {
+		return nil, nil
+	}
+	return stale[offset:], nil
+}
+
+func (g *placeholderPages) SetProjectPagePlaceholder(projectId, preview, placeholderOf string) error {
+	g.set = append(g.set, projectId+":"+preview+":"+placeholderOf)
+	return nil
+}
+
+// costumelessProjects has projects without a costume to make a placeholder of, the body of project 6 is lost.
+type costumelessProjects struct {
+	projects.Gateway
+	reads map[string]int
+}
+
+func (g costumelessProjects) GetProjectById(projectId string) (*models.ProjectCore, error) {
+	g.reads[projectId]++
+	if projectId == "6" {
+		return nil, errors.New("no blob")
+	}
+	return &models.ProjectCore{ID: projectId, Json: `{"targets":[]}`, Checksum: "current"}, nil
+}
+
+func TestRefreshPlaceholders(t *testing.T) {
+	pages := &placeholderPages{}
+	projectsGateway := costumelessProjects{reads: map[string]int{}}
+	useCase := &ProjectPageUseCaseImpl{projectPageGateway: pages, projectGateway: projectsGateway}
+
+	assert.NoError(t, useCase.RefreshPlaceholders(), "a failing page does not fail the batch")
+	assert.Equal(t, []string{"7::current"}, pages.set, "the checksum is kept even without a preview, the page is not tried again")
+
+	assert.NoError(t, useCase.RefreshPlaceholders())
+	assert.Equal(t, 1, projectsGateway.reads["6"], "page 6 is skipped for the rest of the pass")
+	assert.NoError(t, useCase.RefreshPlaceholders())
+	assert.Equal(t, 2, projectsGateway.reads["6"], "the next pass tries page 6 again")
+}
@@ -1,10 +1,27 @@
 package projects
 
-import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"io"
+)
 
 type Delegate interface {
 	CreateProject(project *models.ProjectHTTP) (id string, err error)
 	DeleteProject()
 	GetProjectById(projectId string) (project models.ProjectHTTP, err error)
+	GetProjectMetadataById(projectId string) (project models.ProjectHTTP, err error)
+	OpenProjectBody(projectId string) (project models.ProjectHTTP, body io.ReadCloser, err error)
 	UpdateProject(project *models.ProjectHTTP) (err error)
+	UpdateProjectIfMatch(project *models.ProjectHTTP, ifMatch []string) (checksum string, err error)
+	PatchProject(projectId string, patch []byte, ifMatch []string) (checksum string, err error)
+	CollectOrphanedBodies() (err error)
+
+	GetProjectRevisions(projectId string) (revisions []*models.ProjectRevisionHTTP, err error)
+	GetProjectRevisionById(revisionId string) (revision *models.ProjectRevisionHTTP, err error)
+	GetProjectRevisionDiff(fromRevisionId, toRevisionId string) (diff *models.ProjectRevisionDiffHTTP, err error)
+	RestoreProjectRevision(revisionId string) (revision *models.ProjectRevisionHTTP, err error)
+
+	GetProjectAnalysis(projectId string) (analysis *models.ProjectAnalysisHTTP, err error)
+	AnalyzeProjects() (err error)
+	GetGroupAnalysisReport(robboGroupId, userId string, userRole models.Role) (report *models.GroupAnalysisReportHTTP, err error)
 }
@@ -0,0 +1,29 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+func (p *ProjectDelegateImpl) GetProjectAnalysis(projectId string) (analysis *models.ProjectAnalysisHTTP, err error) {
+	analysisCore, err := p.UseCase.GetProjectAnalysis(projectId)
+	if err != nil {
+		return
+	}
+	analysis = &models.ProjectAnalysisHTTP{}
+	analysis.FromCore(analysisCore)
+	return
+}
+
+func (p *ProjectDelegateImpl) GetGroupAnalysisReport(robboGroupId, userId string, userRole models.Role) (report *models.GroupAnalysisReportHTTP, err error) {
+	reportCore, err := p.UseCase.GetGroupAnalysisReport(robboGroupId, userId, userRole)
+	if err != nil {
+		return
+	}
+	report = &models.GroupAnalysisReportHTTP{}
+	report.FromCore(reportCore)
+	return
+}
+
+func (p *ProjectDelegateImpl) AnalyzeProjects() (err error) {
+	return p.UseCase.AnalyzeProjects()
+}
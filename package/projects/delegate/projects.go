@@ -4,6 +4,7 @@ import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
 	"go.uber.org/fx"
+	"io"
 )
 
 type ProjectDelegateImpl struct {
@@ -35,8 +36,38 @@ func (p *ProjectDelegateImpl) UpdateProject(project *models.ProjectHTTP) (err er
 	return p.UseCase.UpdateProject(projectCore)
 }
 
+func (p *ProjectDelegateImpl) UpdateProjectIfMatch(project *models.ProjectHTTP, ifMatch []string) (checksum string, err error) {
+	return p.UseCase.UpdateProjectIfMatch(project.ToCore(), ifMatch)
+}
+
+func (p *ProjectDelegateImpl) PatchProject(projectId string, patch []byte, ifMatch []string) (checksum string, err error) {
+	return p.UseCase.PatchProject(projectId, patch, ifMatch)
+}
+
+func (p *ProjectDelegateImpl) CollectOrphanedBodies() (err error) {
+	return p.UseCase.CollectOrphanedBodies()
+}
+
 func (p *ProjectDelegateImpl) GetProjectById(projectId string) (project models.ProjectHTTP, err error) {
 	projectCore, err := p.UseCase.GetProjectById(projectId)
 	project.FromCore(projectCore)
 	return
 }
+
+func (p *ProjectDelegateImpl) GetProjectMetadataById(projectId string) (project models.ProjectHTTP, err error) {
+	projectCore, err := p.UseCase.GetProjectMetadataById(projectId)
+	if err != nil {
+		return
+	}
+	project.FromCore(projectCore)
+	return
+}
+
+func (p *ProjectDelegateImpl) OpenProjectBody(projectId string) (project models.ProjectHTTP, body io.ReadCloser, err error) {
+	projectCore, body, err := p.UseCase.OpenProjectBody(projectId)
+	if err != nil {
+		return
+	}
+	project.FromCore(projectCore)
+	return
+}
@@ -0,0 +1,49 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+func (p *ProjectDelegateImpl) GetProjectRevisions(projectId string) (revisions []*models.ProjectRevisionHTTP, err error) {
+	revisionsCore, err := p.UseCase.GetProjectRevisions(projectId)
+	if err != nil {
+		return
+	}
+	revisions = make([]*models.ProjectRevisionHTTP, 0, len(revisionsCore))
+	for _, revisionCore := range revisionsCore {
+		var revisionTemp models.ProjectRevisionHTTP
+		revisionTemp.FromCore(revisionCore)
+		revisions = append(revisions, &revisionTemp)
+	}
+	return
+}
+
+func (p *ProjectDelegateImpl) GetProjectRevisionById(revisionId string) (revision *models.ProjectRevisionHTTP, err error) {
+	revisionCore, err := p.UseCase.GetProjectRevisionById(revisionId)
+	if err != nil {
+		return
+	}
+	revision = &models.ProjectRevisionHTTP{}
+	revision.FromCore(revisionCore)
+	return
+}
+
+func (p *ProjectDelegateImpl) GetProjectRevisionDiff(fromRevisionId, toRevisionId string) (diff *models.ProjectRevisionDiffHTTP, err error) {
+	diffCore, err := p.UseCase.GetProjectRevisionDiff(fromRevisionId, toRevisionId)
+	if err != nil {
+		return
+	}
+	diff = &models.ProjectRevisionDiffHTTP{}
+	diff.FromCore(diffCore)
+	return
+}
+
+func (p *ProjectDelegateImpl) RestoreProjectRevision(revisionId string) (revision *models.ProjectRevisionHTTP, err error) {
+	revisionCore, err := p.UseCase.RestoreProjectRevision(revisionId)
+	if err != nil {
+		return
+	}
+	revision = &models.ProjectRevisionHTTP{}
+	revision.FromCore(revisionCore)
+	return
+}
@@ -0,0 +1,19 @@
+package projects
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/scratch"
+)
+
+var (
+	ErrProjectNotFound       = errors.New("project not found")
+	ErrRevisionNotFound      = errors.New("project revision not found")
+	ErrRevisionsFromProjects = errors.New("revisions belong to different projects")
+	ErrBadProjectJson        = scratch.ErrBadProject
+	ErrNoGroupAccess         = errors.New("no access to the group")
+	ErrPreconditionFailed    = errors.New("project has been changed since it was read")
+	ErrEmptyProject          = errors.New("project json is empty")
+	ErrBadPatch              = errors.New("patch is not a valid json patch document")
+	ErrPatchNotApplicable    = errors.New("patch does not apply to the project")
+	ErrPatchTestFailed       = errors.New("test operation of the patch has failed")
+)
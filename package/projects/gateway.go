@@ -1,11 +1,30 @@
 package projects
 
-import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"io"
+	"time"
+)
 
 type Gateway interface {
 	CreateProject(project *models.ProjectCore) (id string, err error)
 	DeleteProject(projectId string) (err error)
 	GetProjectById(projectId string) (project *models.ProjectCore, err error)
-	GetProjectsByAuthorId(authorId string) (projects []*models.ProjectCore, err error)
+	GetProjectMetadataById(projectId string) (project *models.ProjectCore, err error)
+	OpenProjectBody(projectId string) (project *models.ProjectCore, body io.ReadCloser, err error)
+	GetProjectsByAuthorId(authorId string, authorRole models.Role) (projects []*models.ProjectCore, err error)
 	UpdateProject(project *models.ProjectCore) (err error)
+	UpdateProjectJsonIfMatch(projectId, projectJson string, ifMatch []string) (err error)
+	DeleteOrphanedBodies(before time.Time) (deleted int, err error)
+
+	CreateProjectRevision(revision *models.ProjectRevisionCore) (revisionId string, err error)
+	AmendProjectRevision(revision *models.ProjectRevisionCore) (err error)
+	GetLatestProjectRevision(projectId string) (revision *models.ProjectRevisionCore, err error)
+	GetProjectRevisionById(revisionId string) (revision *models.ProjectRevisionCore, err error)
+	GetProjectRevisions(projectId string) (revisions []*models.ProjectRevisionCore, err error)
+	PruneProjectRevisions(projectId string, keep int, olderThan time.Time) (err error)
+	SetProjectRevisionAnalysis(revision *models.ProjectRevisionCore) (err error)
+	GetUnanalyzedProjectIds(offset, limit int) (projectIds []string, err error)
+	GetLatestProjectAnalyses(projectIds []string) (analyses []*models.ProjectAnalysisOfAuthorCore, err error)
+	GetLatestProjectAnalysesByAuthors(authorIds []string, authorRole models.Role) (analyses []*models.ProjectAnalysisOfAuthorCore, err error)
 }
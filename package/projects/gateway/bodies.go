@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"gorm.io/gorm"
+	"time"
+)
+
+// orphanedBatchSize is how many blobs are looked up in one query.
+const orphanedBatchSize = 500
+
+// DeleteOrphanedBodies deletes the blobs of project bodies last written before the given time that no row points to.
+// Projects, revisions, templates, submissions and reviews share the blobs, soft deleted rows keep theirs.
+// A blob is written before its row, the time leaves the saves in progress their blobs. A blob a save has
+// touched since it was listed is kept.
+func (r *ProjectsGatewayImpl) DeleteOrphanedBodies(before time.Time) (deleted int, err error) {
+	hashes, err := storage.CompressedHashes(r.Storage, models.ProjectBodyPrefix, before)
+	if err != nil {
+		return
+	}
+	for start := 0; start < len(hashes); start += orphanedBatchSize {
+		end := start + orphanedBatchSize
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		var referenced []string
+		if referenced, err = r.referencedBodies(hashes[start:end]); err != nil {
+			return
+		}
+		isReferenced := make(map[string]bool, len(referenced))
+		for _, hash := range referenced {
+			isReferenced[hash] = true
+		}
+		for _, hash := range hashes[start:end] {
+			if isReferenced[hash] {
+				continue
+			}
+			var isDeleted bool
+			if isDeleted, err = storage.DeleteCompressed(r.Storage, models.ProjectBodyPrefix, hash, before); err != nil {
+				return
+			}
+			if isDeleted {
+				deleted++
+			}
+		}
+	}
+	return
+}
+
+func (r *ProjectsGatewayImpl) referencedBodies(hashes []string) (referenced []string, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(`SELECT body_hash FROM project_dbs WHERE body_hash IN @hashes
+			UNION SELECT body_hash FROM project_revision_dbs WHERE body_hash IN @hashes
+			UNION SELECT template_hash FROM assignment_dbs WHERE template_hash IN @hashes
+			UNION SELECT submitted_hash FROM assignment_work_dbs WHERE submitted_hash IN @hashes
+			UNION SELECT project_hash FROM assignment_review_dbs WHERE project_hash IN @hashes`,
+			map[string]interface{}{"hashes": hashes}).Scan(&referenced).Error
+	})
+	return
+}
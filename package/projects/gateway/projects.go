@@ -1,16 +1,24 @@
 package gateway
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"io"
 	"strconv"
+	"strings"
 )
 
 type ProjectsGatewayImpl struct {
 	PostgresClient *db_client.PostgresClient
+	Storage        storage.Storage
 }
 
 type ProjectsGatewayModule struct {
@@ -18,15 +26,71 @@ type ProjectsGatewayModule struct {
 	projects.Gateway
 }
 
-func SetupProjectsGateway(postgresClient db_client.PostgresClient) ProjectsGatewayModule {
+func SetupProjectsGateway(postgresClient db_client.PostgresClient, blobStorage storage.Storage) ProjectsGatewayModule {
 	return ProjectsGatewayModule{
-		Gateway: &ProjectsGatewayImpl{PostgresClient: &postgresClient},
+		Gateway: &ProjectsGatewayImpl{PostgresClient: &postgresClient, Storage: blobStorage},
 	}
 }
 
+// putBody stores the JSON in the blob storage before the row that points to it is written.
+// Projects without a body keep no blob.
+func (r *ProjectsGatewayImpl) putBody(projectDb *models.ProjectDB, projectJson string) (err error) {
+	if projectJson == "" {
+		return
+	}
+	projectDb.BodyHash, projectDb.BodySize, err = r.putJson(projectJson)
+	return
+}
+
+// putJson stores the JSON of a project or of a revision, a revision of the current JSON shares the blob of the project.
+func (r *ProjectsGatewayImpl) putJson(projectJson string) (hash string, size int, err error) {
+	hash, written, err := storage.PutCompressed(r.Storage, models.ProjectBodyPrefix, strings.NewReader(projectJson))
+	return hash, int(written), err
+}
+
+// openBody streams the JSON out of the blob storage, it is decompressed as it is read.
+// Rows saved before the blob storage keep the JSON in legacyJson.
+func (r *ProjectsGatewayImpl) openBody(hash, legacyJson string) (body io.ReadCloser, err error) {
+	if hash == "" {
+		return io.NopCloser(strings.NewReader(legacyJson)), nil
+	}
+	return storage.GetCompressed(r.Storage, models.ProjectBodyPrefix, hash)
+}
+
+// body reads the whole JSON for the use cases that parse it, sizeHint is its length when known.
+func (r *ProjectsGatewayImpl) body(hash, legacyJson string, sizeHint int) (projectJson string, err error) {
+	if hash == "" {
+		return legacyJson, nil
+	}
+	content, err := r.openBody(hash, legacyJson)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+	var body strings.Builder
+	body.Grow(sizeHint)
+	if _, err = io.Copy(&body, content); err != nil {
+		return
+	}
+	return body.String(), nil
+}
+
+// checksumOf is the sha256 of the JSON of the project. The body hash is that already,
+// projects saved before the blob storage have it computed from their row.
+func checksumOf(projectDb *models.ProjectDB) string {
+	if projectDb.BodyHash != "" {
+		return projectDb.BodyHash
+	}
+	sum := sha256.Sum256([]byte(projectDb.LegacyJson))
+	return hex.EncodeToString(sum[:])
+}
+
 func (r *ProjectsGatewayImpl) CreateProject(project *models.ProjectCore) (id string, err error) {
 	projectDb := models.ProjectDB{}
 	projectDb.FromCore(project)
+	if err = r.putBody(&projectDb, project.Json); err != nil {
+		return
+	}
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
 		err = tx.Create(&projectDb).Error
@@ -45,16 +109,74 @@ func (r *ProjectsGatewayImpl) GetProjectById(projectId string) (project *models.
 		}
 		return
 	})
+	if err != nil {
+		return
+	}
 
 	project = projectDb.ToCore()
+	project.Checksum = checksumOf(&projectDb)
+	project.Json, err = r.body(projectDb.BodyHash, projectDb.LegacyJson, projectDb.BodySize)
+	return
+}
+
+// OpenProjectBody returns the project without its JSON and a stream of the JSON, the caller closes the stream.
+func (r *ProjectsGatewayImpl) OpenProjectBody(projectId string) (project *models.ProjectCore, body io.ReadCloser, err error) {
+	var projectDb models.ProjectDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", projectId).First(&projectDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return projects.ErrProjectNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	project = projectDb.ToCore()
+	project.Checksum = checksumOf(&projectDb)
+	project.Json = ""
+	body, err = r.openBody(projectDb.BodyHash, projectDb.LegacyJson)
+	return
+}
 
+// GetProjectMetadataById leaves the body of the project out. Only for a project saved before the blob storage
+// the JSON is read, its checksum is computed from it.
+func (r *ProjectsGatewayImpl) GetProjectMetadataById(projectId string) (project *models.ProjectCore, err error) {
+	var projectDb models.ProjectDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Omit("json").Where("id = ?", projectId).First(&projectDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return projects.ErrProjectNotFound
+			}
+			return
+		}
+		if projectDb.BodyHash != "" {
+			return
+		}
+		var legacyJson []string
+		if err = tx.Model(&models.ProjectDB{}).Where("id = ?", projectId).Pluck("json", &legacyJson).Error; err != nil {
+			return
+		}
+		if len(legacyJson) > 0 {
+			projectDb.LegacyJson = legacyJson[0]
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	project = projectDb.ToCore()
+	project.Checksum = checksumOf(&projectDb)
+	project.Json = ""
 	return
 }
 
-func (r *ProjectsGatewayImpl) GetProjectsByAuthorId(authorId string) (projects []*models.ProjectCore, err error) {
+// GetProjectsByAuthorId lists the projects of the author with the role without their bodies.
+func (r *ProjectsGatewayImpl) GetProjectsByAuthorId(authorId string, authorRole models.Role) (projects []*models.ProjectCore, err error) {
 	var projectsDb []*models.ProjectDB
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		if err = tx.Where("author_id = ?", authorId).Find(&projectsDb).Error; err != nil {
+		if err = tx.Omit("json").Where("author_id = ? AND author_role = ?", authorId, authorRole).Find(&projectsDb).Error; err != nil {
 			return
 		}
 		return
@@ -75,13 +197,53 @@ func (r *ProjectsGatewayImpl) DeleteProject(projectId string) (err error) {
 	return
 }
 
+// UpdateProject moves the body of a project saved before the blob storage out of its row.
+// The blobs a project no longer points to are kept, other projects and revisions may share them.
 func (r *ProjectsGatewayImpl) UpdateProject(project *models.ProjectCore) (err error) {
 	projectDb := models.ProjectDB{}
 	projectDb.FromCore(project)
+	if err = r.putBody(&projectDb, project.Json); err != nil {
+		return
+	}
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
 		err = tx.Model(&projectDb).Where("ID = ?", projectDb.ID).Updates(projectDb).Error
+		if err != nil || project.Json == "" {
+			return
+		}
+		return tx.Model(&projectDb).Where("ID = ?", projectDb.ID).Update("json", "").Error
+	})
+	return
+}
+
+// UpdateProjectJsonIfMatch saves the JSON only while the project still has the JSON with one of the checksums ifMatch.
+// The row is locked until the save, so of two saves made from the same JSON only the first one wins.
+func (r *ProjectsGatewayImpl) UpdateProjectJsonIfMatch(projectId, projectJson string, ifMatch []string) (err error) {
+	projectDb := models.ProjectDB{}
+	if err = r.putBody(&projectDb, projectJson); err != nil {
 		return
+	}
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		var current models.ProjectDB
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", projectId).First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return projects.ErrProjectNotFound
+			}
+			return
+		}
+		checksum, matched := checksumOf(&current), false
+		for _, expected := range ifMatch {
+			matched = matched || expected == checksum
+		}
+		if !matched {
+			return projects.ErrPreconditionFailed
+		}
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"body_hash": projectDb.BodyHash,
+			"body_size": projectDb.BodySize,
+			"json":      "",
+		}).Error
 	})
 	return
 }
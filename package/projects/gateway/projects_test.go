@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/storage"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpdateProjectMovesLegacyJsonToBlob(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &ProjectsGatewayImpl{PostgresClient: postgresClient, Storage: storage.NewPostgresStorage(*postgresClient)}
+
+	projectJson := `{"targets":[]}`
+	sum := sha256.Sum256([]byte(projectJson))
+	assert.NoError(t, gateway.UpdateProject(&models.ProjectCore{ID: "7", Name: "maze", Json: projectJson}))
+
+	assert.Len(t, recorder.Find(`INSERT INTO "storage_blob_dbs"`), 1)
+	updates := recorder.Find(`UPDATE "project_dbs"`, `"body_hash"=`)
+	if assert.Len(t, updates, 1) {
+		assert.Contains(t, updates[0].Args, hex.EncodeToString(sum[:]))
+		assert.NotContains(t, updates[0].Args, projectJson, "the row keeps no copy of the project")
+	}
+	cleared := recorder.Find(`UPDATE "project_dbs"`, `"json"=`)
+	if assert.Len(t, cleared, 1, "the legacy column is emptied") {
+		assert.Contains(t, cleared[0].Args, "")
+	}
+}
+
+func TestCreateProjectRevisionSharesBlob(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &ProjectsGatewayImpl{PostgresClient: postgresClient, Storage: storage.NewPostgresStorage(*postgresClient)}
+
+	projectJson := `{"targets":[]}`
+	sum := sha256.Sum256([]byte(projectJson))
+	_, err = gateway.CreateProjectRevision(&models.ProjectRevisionCore{ProjectId: "7", Reason: models.RevisionCreated, Json: projectJson})
+	assert.NoError(t, err)
+
+	inserts := recorder.Find(`INSERT INTO "project_revision_dbs"`)
+	if assert.Len(t, inserts, 1) {
+		assert.Contains(t, inserts[0].Args, hex.EncodeToString(sum[:]))
+		assert.NotContains(t, inserts[0].Args, projectJson)
+	}
+}
+
+func TestDeleteOrphanedBodies(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	blobStorage, err := storage.NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+	gateway := &ProjectsGatewayImpl{PostgresClient: postgresClient, Storage: blobStorage}
+	hash, _, err := storage.PutCompressed(blobStorage, models.ProjectBodyPrefix, strings.NewReader(`{"targets":[]}`))
+	assert.NoError(t, err)
+
+	deleted, err := gateway.DeleteOrphanedBodies(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Zero(t, deleted, "a young blob may belong to a save in progress")
+	assert.Empty(t, recorder.Find("UNION"))
+
+	deleted, err = gateway.DeleteOrphanedBodies(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted, "no row points to the blob")
+	selects := recorder.Find("project_revision_dbs", "assignment_dbs", "assignment_work_dbs", "assignment_review_dbs")
+	if assert.Len(t, selects, 1) {
+		assert.NotContains(t, selects[0].SQL, "deleted_at", "soft deleted rows keep their blobs")
+	}
+	exists, err := blobStorage.Exists(models.ProjectBodyPrefix + "/" + hash + ".gz")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGetProjectsByAuthorIdMatchesRole(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &ProjectsGatewayImpl{PostgresClient: postgresClient}
+
+	_, err = gateway.GetProjectsByAuthorId("10", models.Student)
+	assert.NoError(t, err)
+	selects := recorder.Find(`FROM "project_dbs"`, "author_role = ")
+	if assert.Len(t, selects, 1, "a teacher with the same id has other projects") {
+		assert.Contains(t, selects[0].Args, models.Student)
+	}
+}
@@ -0,0 +1,185 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (r *ProjectsGatewayImpl) CreateProjectRevision(revision *models.ProjectRevisionCore) (revisionId string, err error) {
+	revisionDb := models.ProjectRevisionDB{}
+	revisionDb.FromCore(revision)
+	if revisionDb.BodyHash, _, err = r.putJson(revision.Json); err != nil {
+		return
+	}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Create(&revisionDb).Error
+	})
+	revisionId = strconv.FormatUint(uint64(revisionDb.ID), 10)
+	return
+}
+
+// AmendProjectRevision replaces the body of the revision, updated_at records the last merged autosave.
+func (r *ProjectsGatewayImpl) AmendProjectRevision(revision *models.ProjectRevisionCore) (err error) {
+	revisionDb := models.ProjectRevisionDB{}
+	revisionDb.FromCore(revision)
+	if revisionDb.BodyHash, _, err = r.putJson(revision.Json); err != nil {
+		return
+	}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.ProjectRevisionDB{}).Where("id = ?", revision.Id).Updates(map[string]interface{}{
+			"body_hash":  revisionDb.BodyHash,
+			"json":       "",
+			"checksum":   revisionDb.Checksum,
+			"size":       revisionDb.Size,
+			"analysis":   revisionDb.Analysis,
+			"updated_at": time.Now(),
+		}).Error
+	})
+	return
+}
+
+// GetLatestProjectRevision returns nil without an error for projects saved before revisions existed.
+func (r *ProjectsGatewayImpl) GetLatestProjectRevision(projectId string) (revision *models.ProjectRevisionCore, err error) {
+	var revisionsDb []*models.ProjectRevisionDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Omit("json").Where("project_id = ?", projectId).Order("id desc").Limit(1).Find(&revisionsDb).Error
+	})
+	if err != nil || len(revisionsDb) == 0 {
+		return
+	}
+	revision = revisionsDb[0].ToCore()
+	return
+}
+
+func (r *ProjectsGatewayImpl) GetProjectRevisionById(revisionId string) (revision *models.ProjectRevisionCore, err error) {
+	var revisionDb models.ProjectRevisionDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", revisionId).First(&revisionDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return projects.ErrRevisionNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	revision = revisionDb.ToCore()
+	revision.Json, err = r.body(revisionDb.BodyHash, revisionDb.LegacyJson, revisionDb.Size)
+	return
+}
+
+// GetProjectRevisions lists the revisions newest first without their bodies.
+func (r *ProjectsGatewayImpl) GetProjectRevisions(projectId string) (revisions []*models.ProjectRevisionCore, err error) {
+	var revisionsDb []*models.ProjectRevisionDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Omit("json").Where("project_id = ?", projectId).Order("id desc").Find(&revisionsDb).Error
+	})
+	for _, revisionDb := range revisionsDb {
+		revisions = append(revisions, revisionDb.ToCore())
+	}
+	return
+}
+
+// PruneProjectRevisions drops revisions beyond the newest keep ones and those created before olderThan.
+// A zero keep or olderThan leaves out its rule, the newest revision survives both rules.
+// Pruned revisions are deleted for good, they only take space.
+func (r *ProjectsGatewayImpl) PruneProjectRevisions(projectId string, keep int, olderThan time.Time) (err error) {
+	if keep <= 0 && olderThan.IsZero() {
+		return
+	}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		newest := tx.Model(&models.ProjectRevisionDB{}).Select("MAX(id)").Where("project_id = ?", projectId)
+		var rules []string
+		var args []interface{}
+		if keep > 0 {
+			kept := tx.Model(&models.ProjectRevisionDB{}).Select("id").
+				Where("project_id = ?", projectId).Order("id desc").Limit(keep)
+			rules = append(rules, "id NOT IN (?)")
+			args = append(args, kept)
+		}
+		if !olderThan.IsZero() {
+			rules = append(rules, "created_at < ?")
+			args = append(args, olderThan)
+		}
+		return tx.Unscoped().
+			Where("project_id = ? AND id <> (?)", projectId, newest).
+			Where(strings.Join(rules, " OR "), args...).
+			Delete(&models.ProjectRevisionDB{}).Error
+	})
+	return
+}
+
+// SetProjectRevisionAnalysis stores the analysis of a revision saved before analyses existed.
+func (r *ProjectsGatewayImpl) SetProjectRevisionAnalysis(revision *models.ProjectRevisionCore) (err error) {
+	revisionDb := models.ProjectRevisionDB{}
+	revisionDb.FromCore(revision)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.ProjectRevisionDB{}).Where("id = ?", revision.Id).
+			Update("analysis", revisionDb.Analysis).Error
+	})
+	return
+}
+
+// GetUnanalyzedProjectIds lists projects whose latest revision has no analysis stored, or that have no revisions at all.
+// The first offset of them are skipped.
+func (r *ProjectsGatewayImpl) GetUnanalyzedProjectIds(offset, limit int) (projectIds []string, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(`SELECT CAST(p.id AS TEXT) FROM project_dbs p
+			LEFT JOIN LATERAL (
+				SELECT r.analysis FROM project_revision_dbs r
+				WHERE r.project_id = CAST(p.id AS TEXT) AND r.deleted_at IS NULL
+				ORDER BY r.id DESC LIMIT 1
+			) latest ON true
+			WHERE p.deleted_at IS NULL AND COALESCE(latest.analysis, '') = ''
+			ORDER BY p.id OFFSET ? LIMIT ?`, offset, limit).Scan(&projectIds).Error
+	})
+	return
+}
+
+// GetLatestProjectAnalyses returns the analysis of the latest revision of every one of the projects.
+func (r *ProjectsGatewayImpl) GetLatestProjectAnalyses(projectIds []string) (analyses []*models.ProjectAnalysisOfAuthorCore, err error) {
+	if len(projectIds) == 0 {
+		return
+	}
+	return r.getLatestProjectAnalyses("CAST(p.id AS TEXT) IN @ids", map[string]interface{}{"ids": projectIds})
+}
+
+// GetLatestProjectAnalysesByAuthors returns the analysis of the latest revision of every project of the authors
+// of the role.
+func (r *ProjectsGatewayImpl) GetLatestProjectAnalysesByAuthors(authorIds []string, authorRole models.Role) (analyses []*models.ProjectAnalysisOfAuthorCore, err error) {
+	if len(authorIds) == 0 {
+		return
+	}
+	return r.getLatestProjectAnalyses("p.author_id IN @ids AND p.author_role = @role",
+		map[string]interface{}{"ids": authorIds, "role": authorRole})
+}
+
+func (r *ProjectsGatewayImpl) getLatestProjectAnalyses(condition string, args map[string]interface{}) (analyses []*models.ProjectAnalysisOfAuthorCore, err error) {
+	var rows []struct {
+		ProjectId string
+		AuthorId  string
+		Analysis  string
+	}
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Raw(`SELECT DISTINCT ON (p.id) CAST(p.id AS TEXT) AS project_id, p.author_id, COALESCE(r.analysis, '') AS analysis
+			FROM project_dbs p
+			LEFT JOIN project_revision_dbs r ON r.project_id = CAST(p.id AS TEXT) AND r.deleted_at IS NULL
+			WHERE `+condition+` AND p.deleted_at IS NULL
+			ORDER BY p.id, r.id DESC`, args).Scan(&rows).Error
+	})
+	for _, row := range rows {
+		revisionDb := models.ProjectRevisionDB{ProjectId: row.ProjectId, Analysis: row.Analysis}
+		analyses = append(analyses, &models.ProjectAnalysisOfAuthorCore{
+			ProjectId: row.ProjectId,
+			AuthorId:  row.AuthorId,
+			Analysis:  revisionDb.ToCore().Analysis,
+		})
+	}
+	return
+}
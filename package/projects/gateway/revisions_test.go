@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPruneProjectRevisions(t *testing.T) {
+	olderThan := time.Date(2026, 7, 21, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		keep      int
+		olderThan time.Time
+		rules     []string
+	}{
+		{"both limits", 50, olderThan, []string{"id NOT IN (SELECT", "created_at < "}},
+		{"no count limit", 0, olderThan, []string{"created_at < "}},
+		{"no age limit", 50, time.Time{}, []string{"id NOT IN (SELECT"}},
+		{"no limits", 0, time.Time{}, nil},
+	}
+	for _, c := range cases {
+		postgresClient, recorder, err := dbtest.Open(nil)
+		assert.NoError(t, err)
+		gateway := &ProjectsGatewayImpl{PostgresClient: postgresClient}
+
+		assert.NoError(t, gateway.PruneProjectRevisions("7", c.keep, c.olderThan), c.name)
+		deletes := recorder.Find(`DELETE FROM "project_revision_dbs"`)
+		if c.rules == nil {
+			assert.Empty(t, deletes, c.name)
+			continue
+		}
+		if assert.Len(t, deletes, 1, c.name) {
+			for _, rule := range c.rules {
+				assert.Contains(t, deletes[0].SQL, rule, c.name)
+			}
+			assert.Equal(t, len(c.rules) == 2, strings.Contains(deletes[0].SQL, " OR "), c.name)
+		}
+	}
+}
+
+func TestGetLatestProjectAnalysesByAuthorsMatchesRole(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &ProjectsGatewayImpl{PostgresClient: postgresClient}
+
+	_, err = gateway.GetLatestProjectAnalysesByAuthors([]string{"10"}, models.Student)
+	assert.NoError(t, err)
+	selects := recorder.Find("FROM project_dbs p", "p.author_role = ")
+	if assert.Len(t, selects, 1) {
+		assert.Contains(t, selects[0].Args, models.Student)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/spf13/viper"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"strings"
 )
 
 type Handler struct {
@@ -31,6 +34,7 @@ func (h *Handler) InitProjectRoutes(router *gin.Engine) {
 		project.POST("/", h.CreateProject)
 		project.GET("/:projectId", h.GetProject)
 		project.PUT("/:projectId", h.UpdateProject)
+		project.PATCH("/:projectId", h.PatchProject)
 		project.DELETE("/", h.DeleteProject)
 	}
 }
@@ -43,11 +47,11 @@ type testResponse struct {
 
 func (h *Handler) CreateProject(c *gin.Context) {
 	fmt.Println("Create Project")
-	jsonDataBytes, err := ioutil.ReadAll(c.Request.Body)
+	jsonDataBytes, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, viper.GetInt64("projects.max_size")))
 
 	if err != nil {
 		fmt.Println(err)
-		c.AbortWithStatus(http.StatusInternalServerError)
+		c.AbortWithStatus(http.StatusRequestEntityTooLarge)
 		return
 	}
 
@@ -66,30 +70,99 @@ func (h *Handler) CreateProject(c *gin.Context) {
 	})
 }
 
+// etagOf is the strong ETag of a project, the checksum of its JSON.
+func etagOf(checksum string) string {
+	return `"` + checksum + `"`
+}
+
+// etagsOf lists the checksums of an If-None-Match or If-Match header, anyEtag is set by *.
+// Weak ETags are read by their value.
+func etagsOf(header string) (checksums []string, anyEtag bool) {
+	for _, etag := range strings.Split(header, ",") {
+		etag = strings.TrimSpace(etag)
+		if etag == "*" {
+			return nil, true
+		}
+		if etag = strings.Trim(strings.TrimPrefix(etag, "W/"), `"`); etag != "" {
+			checksums = append(checksums, etag)
+		}
+	}
+	return
+}
+
+// etagMatches tells whether an If-None-Match or If-Match header lists the checksum, * matches any project.
+func etagMatches(header, checksum string) bool {
+	checksums, anyEtag := etagsOf(header)
+	if anyEtag {
+		return true
+	}
+	for _, etag := range checksums {
+		if etag == checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchOf lists the checksums a save is conditional on, none when the header is missing or is *.
+func ifMatchOf(c *gin.Context) []string {
+	header := c.GetHeader("If-Match")
+	checksums, anyEtag := etagsOf(header)
+	if anyEtag || strings.TrimSpace(header) == "" {
+		return nil
+	}
+	// a header that lists no ETag matches no project
+	return append([]string{}, checksums...)
+}
+
 func (h *Handler) GetProject(c *gin.Context) {
 	projectId := c.Param("projectId")
 	if projectId == "" {
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
-	project, err := h.projectsDelegate.GetProjectById(projectId)
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if ifNoneMatch != "" {
+		// the editor polls the project, an unchanged one is answered without reading its body
+		metadata, err := h.projectsDelegate.GetProjectMetadataById(projectId)
+		if err == nil && metadata.Checksum != "" && etagMatches(ifNoneMatch, metadata.Checksum) {
+			c.Header("ETag", etagOf(metadata.Checksum))
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	project, body, err := h.projectsDelegate.OpenProjectBody(projectId)
 	if err != nil {
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
+	defer body.Close()
 
-	//var jsonMap map[string]interface{}
-	//json.Unmarshal([]byte(project.Json), &jsonMap)
+	c.Header("ETag", etagOf(project.Checksum))
+	c.Header("Cache-Control", "no-cache")
+	if ifNoneMatch != "" && etagMatches(ifNoneMatch, project.Checksum) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 
-	c.JSON(http.StatusOK, project.Json)
+	// the body is streamed out of the blob storage as the JSON string c.JSON would have written
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err = writeJsonString(c.Writer, body); err != nil {
+		log.Println(err)
+	}
 }
 
 func (h *Handler) UpdateProject(c *gin.Context) {
 	fmt.Println("Update Project")
-	jsonDataBytes, err := ioutil.ReadAll(c.Request.Body)
+	jsonDataBytes, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, viper.GetInt64("projects.max_size")))
 
 	if err != nil {
 		fmt.Println(err)
+		c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if len(jsonDataBytes) == 0 {
 		c.AbortWithStatus(http.StatusBadRequest)
 		return
 	}
@@ -100,18 +173,64 @@ func (h *Handler) UpdateProject(c *gin.Context) {
 	projectHTTP.ID = projectId
 	projectHTTP.Json = string(jsonDataBytes)
 
-	err = h.projectsDelegate.UpdateProject(&projectHTTP)
+	checksum, err := h.projectsDelegate.UpdateProjectIfMatch(&projectHTTP, ifMatchOf(c))
 
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
+		fmt.Println(err)
+		c.AbortWithStatus(saveErrorStatus(err))
 		return
 	}
 
+	c.Header("ETag", etagOf(checksum))
 	c.JSON(http.StatusOK, testResponse{
 		Id: projectId,
 	})
 }
 
+// PatchProject autosaves the changes the editor has made as an RFC 6902 JSON Patch,
+// so that a small change does not send the whole project.
+func (h *Handler) PatchProject(c *gin.Context) {
+	fmt.Println("Patch Project")
+	patch, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, viper.GetInt64("projects.max_size")))
+
+	if err != nil {
+		fmt.Println(err)
+		c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	projectId := c.Param("projectId")
+	checksum, err := h.projectsDelegate.PatchProject(projectId, patch, ifMatchOf(c))
+
+	if err != nil {
+		fmt.Println(err)
+		c.AbortWithStatus(saveErrorStatus(err))
+		return
+	}
+
+	c.Header("ETag", etagOf(checksum))
+	c.JSON(http.StatusOK, testResponse{
+		Id: projectId,
+	})
+}
+
+func saveErrorStatus(err error) int {
+	switch err {
+	case projects.ErrProjectNotFound:
+		return http.StatusNotFound
+	case projects.ErrPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case projects.ErrBadPatch, projects.ErrEmptyProject:
+		return http.StatusBadRequest
+	case projects.ErrPatchTestFailed:
+		return http.StatusConflict
+	case projects.ErrPatchNotApplicable, projects.ErrBadProjectJson:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (h *Handler) DeleteProject(c *gin.Context) {
 
 }
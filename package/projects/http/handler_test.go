@@ -0,0 +1,94 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// projectDelegate knows project 7 with the JSON {} and the checksum current.
+type projectDelegate struct {
+	projects.Delegate
+	saved []string
+}
+
+func (d *projectDelegate) GetProjectMetadataById(projectId string) (models.ProjectHTTP, error) {
+	if projectId != "7" {
+		return models.ProjectHTTP{}, projects.ErrProjectNotFound
+	}
+	return models.ProjectHTTP{ID: "7", Checksum: "current"}, nil
+}
+
+func (d *projectDelegate) OpenProjectBody(projectId string) (models.ProjectHTTP, io.ReadCloser, error) {
+	project, err := d.GetProjectMetadataById(projectId)
+	return project, io.NopCloser(strings.NewReader("{}")), err
+}
+
+func (d *projectDelegate) UpdateProjectIfMatch(project *models.ProjectHTTP, ifMatch []string) (string, error) {
+	if ifMatch != nil && !etagMatches(strings.Join(ifMatch, ","), "current") {
+		return "", projects.ErrPreconditionFailed
+	}
+	d.saved = append(d.saved, project.Json)
+	return "next", nil
+}
+
+func serve(delegate *projectDelegate, method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	viper.Set("projects.max_size", 1024)
+	router := gin.New()
+	handler := NewProjectsHandler(nil, delegate)
+	handler.InitProjectRoutes(router)
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	for name, value := range header {
+		request.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetProjectNotModified(t *testing.T) {
+	response := serve(&projectDelegate{}, "GET", "/project/7", "", nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, `"{}"`, response.Body.String())
+	assert.Equal(t, `"current"`, response.Header().Get("ETag"))
+
+	for _, ifNoneMatch := range []string{`"current"`, `W/"current"`, `"old", "current"`, `*`} {
+		response = serve(&projectDelegate{}, "GET", "/project/7", "", map[string]string{"If-None-Match": ifNoneMatch})
+		assert.Equal(t, http.StatusNotModified, response.Code, ifNoneMatch)
+		assert.Empty(t, response.Body.String(), ifNoneMatch)
+	}
+	response = serve(&projectDelegate{}, "GET", "/project/7", "", map[string]string{"If-None-Match": `"old"`})
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestUpdateProjectIfMatch(t *testing.T) {
+	for _, ifMatch := range []string{`"current"`, `W/"current"`, `"old", "current"`, `*`, ``} {
+		delegate := &projectDelegate{}
+		response := serve(delegate, "PUT", "/project/7", "{}", map[string]string{"If-Match": ifMatch})
+		assert.Equal(t, http.StatusOK, response.Code, ifMatch)
+		assert.Equal(t, `"next"`, response.Header().Get("ETag"), ifMatch)
+		assert.Equal(t, []string{"{}"}, delegate.saved, ifMatch)
+	}
+
+	for _, ifMatch := range []string{`"old"`, `W/"old", "older"`, `""`} {
+		delegate := &projectDelegate{}
+		response := serve(delegate, "PUT", "/project/7", "{}", map[string]string{"If-Match": ifMatch})
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code, ifMatch)
+		assert.Empty(t, delegate.saved, ifMatch)
+	}
+}
+
+func TestUpdateProjectRejectsEmptyBody(t *testing.T) {
+	delegate := &projectDelegate{}
+	response := serve(delegate, "PUT", "/project/7", "", map[string]string{"If-Match": `"current"`})
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Empty(t, delegate.saved)
+}
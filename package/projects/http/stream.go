@@ -0,0 +1,50 @@
+package http
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+const hex = "0123456789abcdef"
+
+// writeJsonString writes the content as one JSON string the way encoding/json would marshal it,
+// so a streamed project reads the same as one answered with c.JSON. Only a rune at a time is held.
+func writeJsonString(w io.Writer, content io.Reader) (err error) {
+	reader := bufio.NewReader(content)
+	writer := bufio.NewWriter(w)
+	writer.WriteByte('"')
+	for {
+		r, size, readErr := reader.ReadRune()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+		switch {
+		case r == '"' || r == '\\':
+			writer.WriteByte('\\')
+			writer.WriteRune(r)
+		case r == '\n':
+			writer.WriteString(`\n`)
+		case r == '\r':
+			writer.WriteString(`\r`)
+		case r == '\t':
+			writer.WriteString(`\t`)
+		case r < 0x20 || r == '<' || r == '>' || r == '&':
+			writer.WriteString(`\u00`)
+			writer.WriteByte(hex[r>>4])
+			writer.WriteByte(hex[r&0xF])
+		case r == utf8.RuneError && size == 1:
+			writer.WriteRune(utf8.RuneError)
+		case r == '\u2028' || r == '\u2029':
+			writer.WriteString(`\u202`)
+			writer.WriteByte(hex[r&0xF])
+		default:
+			writer.WriteRune(r)
+		}
+	}
+	writer.WriteByte('"')
+	return writer.Flush()
+}
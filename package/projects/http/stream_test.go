@@ -0,0 +1,22 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWriteJsonStringMatchesMarshal(t *testing.T) {
+	for _, content := range []string{
+		"",
+		`{"targets":[{"name":"Кот","blocks":{}}]}`,
+		"quote \" backslash \\ tab \t line\nfeed \r <b>&</b> \x01 \u2028 \u2029 \xff",
+	} {
+		var written strings.Builder
+		assert.NoError(t, writeJsonString(&written, strings.NewReader(content)))
+		marshalled, err := json.Marshal(content)
+		assert.NoError(t, err)
+		assert.Equal(t, string(marshalled), written.String())
+	}
+}
@@ -1,10 +1,29 @@
 package projects
 
-import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"io"
+)
 
 type UseCase interface {
 	CreateProject(project *models.ProjectCore) (id string, err error)
 	DeleteProject()
 	GetProjectById(projectId string) (project *models.ProjectCore, err error)
+	GetProjectMetadataById(projectId string) (project *models.ProjectCore, err error)
+	OpenProjectBody(projectId string) (project *models.ProjectCore, body io.ReadCloser, err error)
 	UpdateProject(project *models.ProjectCore) (err error)
+	UpdateProjectIfMatch(project *models.ProjectCore, ifMatch []string) (checksum string, err error)
+	PatchProject(projectId string, patch []byte, ifMatch []string) (checksum string, err error)
+	CollectOrphanedBodies() (err error)
+
+	GetProjectRevisions(projectId string) (revisions []*models.ProjectRevisionCore, err error)
+	GetProjectRevisionById(revisionId string) (revision *models.ProjectRevisionCore, err error)
+	GetProjectRevisionDiff(fromRevisionId, toRevisionId string) (diff *models.ProjectRevisionDiffCore, err error)
+	RestoreProjectRevision(revisionId string) (revision *models.ProjectRevisionCore, err error)
+	RecordCreatedRevision(projectId string) (err error)
+
+	GetProjectAnalysis(projectId string) (analysis *models.ProjectAnalysisCore, err error)
+	GetProjectAnalyses(projectIds []string) (analyses map[string]*models.ProjectAnalysisCore, err error)
+	AnalyzeProjects() (err error)
+	GetGroupAnalysisReport(robboGroupId, userId string, userRole models.Role) (report *models.GroupAnalysisReportCore, err error)
 }
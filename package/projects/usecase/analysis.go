@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/scratch"
+	"log"
+)
+
+// averageCtScores averages the scores dimension by dimension, zero for no scores.
+func averageCtScores(scores []models.CtScoreCore) (averages models.CtAveragesCore) {
+	if len(scores) == 0 {
+		return
+	}
+	for _, score := range scores {
+		averages.Abstraction += float64(score.Abstraction)
+		averages.Parallelism += float64(score.Parallelism)
+		averages.Logic += float64(score.Logic)
+		averages.Synchronization += float64(score.Synchronization)
+		averages.FlowControl += float64(score.FlowControl)
+		averages.UserInteractivity += float64(score.UserInteractivity)
+		averages.DataRepresentation += float64(score.DataRepresentation)
+		averages.Total += float64(score.Total())
+	}
+	n := float64(len(scores))
+	averages.Abstraction /= n
+	averages.Parallelism /= n
+	averages.Logic /= n
+	averages.Synchronization /= n
+	averages.FlowControl /= n
+	averages.UserInteractivity /= n
+	averages.DataRepresentation /= n
+	averages.Total /= n
+	return
+}
+
+// GetProjectAnalysis returns the analysis of the latest revision. Projects saved before
+// analyses existed are analyzed as they are now.
+func (p *ProjectUseCaseImpl) GetProjectAnalysis(projectId string) (analysis *models.ProjectAnalysisCore, err error) {
+	latest, err := p.Gateway.GetLatestProjectRevision(projectId)
+	if err != nil {
+		return
+	}
+	if latest != nil && latest.Analysis != nil {
+		return latest.Analysis, nil
+	}
+	project, err := p.Gateway.GetProjectById(projectId)
+	if err != nil {
+		return
+	}
+	return scratch.Analyze(project.Json)
+}
+
+// GetProjectAnalyses reads the stored analyses of the projects at once, the projects the analyzer
+// has not got to yet are left out.
+func (p *ProjectUseCaseImpl) GetProjectAnalyses(projectIds []string) (analyses map[string]*models.ProjectAnalysisCore, err error) {
+	latest, err := p.Gateway.GetLatestProjectAnalyses(projectIds)
+	if err != nil {
+		return
+	}
+	analyses = make(map[string]*models.ProjectAnalysisCore, len(latest))
+	for _, analysis := range latest {
+		if analysis.Analysis != nil {
+			analyses[analysis.ProjectId] = analysis.Analysis
+		}
+	}
+	return
+}
+
+// AnalyzeProjects stores the analyses of a batch of projects saved before analyses existed.
+// The workers run it periodically until none are left. A project that fails is logged and
+// skipped, the next pass tries it again.
+func (p *ProjectUseCaseImpl) AnalyzeProjects() (err error) {
+	projectIds, err := p.Gateway.GetUnanalyzedProjectIds(p.analysisFailures, p.analysisBatchSize)
+	if err != nil {
+		return
+	}
+	if len(projectIds) == 0 {
+		p.analysisFailures = 0
+		return
+	}
+	for _, projectId := range projectIds {
+		if analyzeErr := p.analyzeProject(projectId); analyzeErr != nil {
+			log.Println(projectId, analyzeErr)
+			p.analysisFailures++
+		}
+	}
+	return
+}
+
+func (p *ProjectUseCaseImpl) analyzeProject(projectId string) (err error) {
+	project, err := p.Gateway.GetProjectById(projectId)
+	if err != nil {
+		return
+	}
+	latest, err := p.Gateway.GetLatestProjectRevision(projectId)
+	if err != nil {
+		return
+	}
+	if latest == nil {
+		_, err = p.snapshot(projectId, project.Json, models.RevisionCreated, "")
+		return
+	}
+	analysis, analyzeErr := scratch.Analyze(project.Json)
+	if analyzeErr != nil {
+		// stored as unreadable, the next save analyzes the project again
+		log.Println(analyzeErr)
+	}
+	latest.Analysis = analysis
+	return p.Gateway.SetProjectRevisionAnalysis(latest)
+}
+
+// GetGroupAnalysisReport is for the staff who have access to the group. Projects the analyzer
+// has not got to yet are left out of the report.
+func (p *ProjectUseCaseImpl) GetGroupAnalysisReport(robboGroupId, userId string, userRole models.Role) (report *models.GroupAnalysisReportCore, err error) {
+	err = p.accessScope.CheckRobboGroup(userId, userRole, robboGroupId)
+	if errors.Is(err, access.ErrNoAccess) {
+		return nil, projects.ErrNoGroupAccess
+	}
+	if err != nil {
+		return
+	}
+	students, err := p.usersGateway.GetStudentsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	studentIds := make([]string, 0, len(students))
+	for _, student := range students {
+		studentIds = append(studentIds, student.Id)
+	}
+	analyses, err := p.Gateway.GetLatestProjectAnalysesByAuthors(studentIds, models.Student)
+	if err != nil {
+		return
+	}
+	return buildGroupAnalysisReport(robboGroupId, students, analyses), nil
+}
+
+func buildGroupAnalysisReport(
+	robboGroupId string,
+	students []*models.StudentCore,
+	analyses []*models.ProjectAnalysisOfAuthorCore,
+) *models.GroupAnalysisReportCore {
+	scoresOf := make(map[string][]models.CtScoreCore, len(students))
+	var groupScores []models.CtScoreCore
+	for _, analysis := range analyses {
+		if analysis.Analysis == nil {
+			continue
+		}
+		scoresOf[analysis.AuthorId] = append(scoresOf[analysis.AuthorId], analysis.Analysis.CtScore)
+		groupScores = append(groupScores, analysis.Analysis.CtScore)
+	}
+	report := &models.GroupAnalysisReportCore{
+		RobboGroupId: robboGroupId,
+		Projects:     len(groupScores),
+		Averages:     averageCtScores(groupScores),
+		Students:     make([]*models.StudentAnalysisReportCore, 0, len(students)),
+	}
+	for _, student := range students {
+		line := &models.StudentAnalysisReportCore{
+			Student:  student,
+			Projects: len(scoresOf[student.Id]),
+			Averages: averageCtScores(scoresOf[student.Id]),
+		}
+		for _, score := range scoresOf[student.Id] {
+			if score.Total() > line.BestCtScore.Total() {
+				line.BestCtScore = score
+			}
+		}
+		report.Students = append(report.Students, line)
+	}
+	return report
+}
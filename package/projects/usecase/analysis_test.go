@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildGroupAnalysisReport(t *testing.T) {
+	students := []*models.StudentCore{
+		{UserCore: models.UserCore{Id: "1"}},
+		{UserCore: models.UserCore{Id: "2"}},
+	}
+	weak := models.CtScoreCore{Abstraction: 1, FlowControl: 1}
+	strong := models.CtScoreCore{Abstraction: 3, FlowControl: 3, Logic: 2}
+	analyses := []*models.ProjectAnalysisOfAuthorCore{
+		{ProjectId: "10", AuthorId: "1", Analysis: &models.ProjectAnalysisCore{CtScore: weak}},
+		{ProjectId: "11", AuthorId: "1", Analysis: &models.ProjectAnalysisCore{CtScore: strong}},
+		{ProjectId: "12", AuthorId: "1"},
+	}
+
+	report := buildGroupAnalysisReport("7", students, analyses)
+
+	assert.Equal(t, 2, report.Projects, "a project without an analysis is left out")
+	assert.Equal(t, 2.0, report.Averages.Abstraction)
+	assert.Equal(t, 5.0, report.Averages.Total)
+	assert.Len(t, report.Students, 2)
+	assert.Equal(t, strong, report.Students[0].BestCtScore)
+	assert.Equal(t, 2, report.Students[0].Projects)
+	assert.Equal(t, 0, report.Students[1].Projects)
+	assert.Equal(t, models.CtAveragesCore{}, report.Students[1].Averages)
+}
+
+const twoSprites = `{"targets":[{"isStage":false,"name":"Cat","blocks":{}},{"isStage":false,"name":"Dog","blocks":{}}]}`
+
+// backlogGateway has project 6 whose body is lost, project 7 with no history and project 8 with a revision
+// saved before analyses existed.
+type backlogGateway struct {
+	historyGateway
+	analyzed []*models.ProjectRevisionCore
+	reads    int
+}
+
+func (g *backlogGateway) GetUnanalyzedProjectIds(offset, limit int) (projectIds []string, err error) {
+	unanalyzed := []string{"6"}
+	if len(g.revisions) == 0 {
+		unanalyzed = append(unanalyzed, "7")
+	}
+	if len(g.analyzed) == 0 {
+		unanalyzed = append(unanalyzed, "8")
+	}
+	if offset >= len(unanalyzed) {
+		return nil, nil
+	}
+	return unanalyzed[offset:], nil
+}
+
+func (g *backlogGateway) GetProjectById(projectId string) (*models.ProjectCore, error) {
+	if projectId == "6" {
+		g.reads++
+		return nil, errors.New("no blob")
+	}
+	return g.historyGateway.GetProjectById(projectId)
+}
+
+func (g *backlogGateway) GetLatestProjectRevision(projectId string) (*models.ProjectRevisionCore, error) {
+	if projectId == "8" {
+		return &models.ProjectRevisionCore{Id: "3", ProjectId: "8"}, nil
+	}
+	return g.historyGateway.GetLatestProjectRevision(projectId)
+}
+
+func (g *backlogGateway) SetProjectRevisionAnalysis(revision *models.ProjectRevisionCore) error {
+	g.analyzed = append(g.analyzed, revision)
+	return nil
+}
+
+func TestAnalyzeProjects(t *testing.T) {
+	gateway := &backlogGateway{historyGateway: historyGateway{json: twoSprites}}
+	usecase := &ProjectUseCaseImpl{Gateway: gateway}
+	assert.NoError(t, usecase.AnalyzeProjects(), "a failing project does not fail the batch")
+
+	if assert.Len(t, gateway.revisions, 1, "the history of project 7 is started") {
+		assert.Equal(t, "7", gateway.revisions[0].ProjectId)
+		assert.NotNil(t, gateway.revisions[0].Analysis)
+	}
+	if assert.Len(t, gateway.analyzed, 1, "the revision of project 8 gets its analysis") {
+		assert.Equal(t, "3", gateway.analyzed[0].Id)
+		assert.Equal(t, 2, gateway.analyzed[0].Analysis.Sprites)
+	}
+
+	assert.NoError(t, usecase.AnalyzeProjects())
+	assert.Equal(t, 1, gateway.reads, "project 6 is skipped for the rest of the pass")
+	assert.NoError(t, usecase.AnalyzeProjects())
+	assert.Equal(t, 2, gateway.reads, "the next pass tries project 6 again")
+}
+
+// reportScope lets unit admin 4 into group 3 only.
+type reportScope struct {
+	access.Scope
+}
+
+func (reportScope) CheckRobboGroup(userId string, userRole models.Role, robboGroupId string) error {
+	if robboGroupId == "3" && userId == "4" && userRole == models.UnitAdmin {
+		return nil
+	}
+	return access.ErrNoAccess
+}
+
+type reportStudents struct {
+	users.Gateway
+}
+
+func (reportStudents) GetStudentsByRobboGroupId(string) ([]*models.StudentCore, error) {
+	return []*models.StudentCore{{UserCore: models.UserCore{Id: "10"}}}, nil
+}
+
+type reportGateway struct {
+	projects.Gateway
+	roles []models.Role
+}
+
+func (g *reportGateway) GetLatestProjectAnalysesByAuthors(authorIds []string, authorRole models.Role) ([]*models.ProjectAnalysisOfAuthorCore, error) {
+	g.roles = append(g.roles, authorRole)
+	return []*models.ProjectAnalysisOfAuthorCore{{ProjectId: "7", AuthorId: "10", Analysis: &models.ProjectAnalysisCore{}}}, nil
+}
+
+func TestGroupAnalysisReportScoped(t *testing.T) {
+	gateway := &reportGateway{}
+	usecase := &ProjectUseCaseImpl{Gateway: gateway, usersGateway: reportStudents{}, accessScope: reportScope{}}
+
+	report, err := usecase.GetGroupAnalysisReport("3", "4", models.UnitAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Projects)
+	assert.Equal(t, []models.Role{models.Student}, gateway.roles)
+
+	_, err = usecase.GetGroupAnalysisReport("5", "4", models.UnitAdmin)
+	assert.ErrorIs(t, err, projects.ErrNoGroupAccess, "a group of another unit")
+	_, err = usecase.GetGroupAnalysisReport("3", "4", models.RegionAdmin)
+	assert.ErrorIs(t, err, projects.ErrNoGroupAccess)
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/scratch"
+	"reflect"
+	"sort"
+)
+
+// scriptOf walks up to the top-level block of the script the block belongs to.
+func scriptOf(blocks map[string]*scratch.Block, id string) string {
+	for seen := 0; seen <= len(blocks); seen++ {
+		block, ok := blocks[id]
+		if !ok || block.TopLevel || block.Parent == "" {
+			return id
+		}
+		id = block.Parent
+	}
+	return id
+}
+
+// diffProjects compares two project.json documents sprite by sprite. Sprites are matched
+// by name, blocks by id, which Scratch keeps stable across saves.
+func diffProjects(fromJson, toJson string) (sprites []*models.SpriteDiffCore, err error) {
+	fromTargets, err := scratch.ParseTargets(fromJson)
+	if err != nil {
+		return
+	}
+	toTargets, err := scratch.ParseTargets(toJson)
+	if err != nil {
+		return
+	}
+	fromByName := make(map[string]*scratch.Target, len(fromTargets))
+	for _, target := range fromTargets {
+		fromByName[target.Name] = target
+	}
+	matched := make(map[string]bool, len(toTargets))
+	for _, to := range toTargets {
+		matched[to.Name] = true
+		sprite, diffErr := diffTarget(fromByName[to.Name], to)
+		if diffErr != nil {
+			return nil, diffErr
+		}
+		if sprite != nil {
+			sprites = append(sprites, sprite)
+		}
+	}
+	for _, from := range fromTargets {
+		if matched[from.Name] {
+			continue
+		}
+		sprite, diffErr := diffTarget(from, nil)
+		if diffErr != nil {
+			return nil, diffErr
+		}
+		sprites = append(sprites, sprite)
+	}
+	return
+}
+
+// diffTarget returns nil when nothing but script positions changed.
+func diffTarget(from, to *scratch.Target) (sprite *models.SpriteDiffCore, err error) {
+	sprite = &models.SpriteDiffCore{
+		Change:            models.ProjectChangeChanged,
+		PropertiesChanged: []string{},
+		ScriptsAdded:      []string{},
+		ScriptsRemoved:    []string{},
+		ScriptsChanged:    []string{},
+		BlocksAdded:       []models.ProjectBlockRefCore{},
+		BlocksRemoved:     []models.ProjectBlockRefCore{},
+		BlocksChanged:     []models.ProjectBlockRefCore{},
+	}
+	empty := &scratch.Target{}
+	switch {
+	case from == nil:
+		sprite.Change = models.ProjectChangeAdded
+		from = empty
+		sprite.Name, sprite.IsStage = to.Name, to.IsStage
+	case to == nil:
+		sprite.Change = models.ProjectChangeRemoved
+		to = empty
+		sprite.Name, sprite.IsStage = from.Name, from.IsStage
+	default:
+		sprite.Name, sprite.IsStage = to.Name, to.IsStage
+		for key := range union(from.Properties, to.Properties) {
+			if !reflect.DeepEqual(from.Properties[key], to.Properties[key]) {
+				sprite.PropertiesChanged = append(sprite.PropertiesChanged, key)
+			}
+		}
+		sort.Strings(sprite.PropertiesChanged)
+	}
+
+	fromBlocks, err := scratch.ParseBlocks(from.Blocks)
+	if err != nil {
+		return
+	}
+	toBlocks, err := scratch.ParseBlocks(to.Blocks)
+	if err != nil {
+		return
+	}
+	touchedScripts := make(map[string]bool)
+	for id, toBlock := range toBlocks {
+		fromBlock, ok := fromBlocks[id]
+		switch {
+		case !ok:
+			sprite.BlocksAdded = append(sprite.BlocksAdded, models.ProjectBlockRefCore{BlockId: id, Opcode: toBlock.Opcode})
+			if toBlock.TopLevel {
+				sprite.ScriptsAdded = append(sprite.ScriptsAdded, id)
+				continue
+			}
+		case !reflect.DeepEqual(fromBlock.Value, toBlock.Value):
+			sprite.BlocksChanged = append(sprite.BlocksChanged, models.ProjectBlockRefCore{BlockId: id, Opcode: toBlock.Opcode})
+			// a block moved between scripts changes both of them
+			touchedScripts[scriptOf(fromBlocks, id)] = true
+			// dragging blocks out of a script starts a new one, dropping a script onto another ends it
+			if toBlock.TopLevel && !fromBlock.TopLevel {
+				sprite.ScriptsAdded = append(sprite.ScriptsAdded, id)
+				continue
+			}
+			if fromBlock.TopLevel && !toBlock.TopLevel {
+				sprite.ScriptsRemoved = append(sprite.ScriptsRemoved, id)
+			}
+		default:
+			continue
+		}
+		touchedScripts[scriptOf(toBlocks, id)] = true
+	}
+	for id, fromBlock := range fromBlocks {
+		if _, ok := toBlocks[id]; ok {
+			continue
+		}
+		sprite.BlocksRemoved = append(sprite.BlocksRemoved, models.ProjectBlockRefCore{BlockId: id, Opcode: fromBlock.Opcode})
+		if fromBlock.TopLevel {
+			sprite.ScriptsRemoved = append(sprite.ScriptsRemoved, id)
+			continue
+		}
+		// a block removed from a script that still exists changes that script
+		touchedScripts[scriptOf(fromBlocks, id)] = true
+	}
+	for id := range touchedScripts {
+		toBlock, inTo := toBlocks[id]
+		fromBlock, inFrom := fromBlocks[id]
+		if inTo && inFrom && toBlock.TopLevel && fromBlock.TopLevel {
+			sprite.ScriptsChanged = append(sprite.ScriptsChanged, id)
+		}
+	}
+
+	sort.Strings(sprite.ScriptsAdded)
+	sort.Strings(sprite.ScriptsRemoved)
+	sort.Strings(sprite.ScriptsChanged)
+	for _, refs := range [][]models.ProjectBlockRefCore{sprite.BlocksAdded, sprite.BlocksRemoved, sprite.BlocksChanged} {
+		sort.Slice(refs, func(i, j int) bool { return refs[i].BlockId < refs[j].BlockId })
+	}
+	if sprite.Change == models.ProjectChangeChanged && len(sprite.PropertiesChanged) == 0 &&
+		len(sprite.BlocksAdded) == 0 && len(sprite.BlocksRemoved) == 0 && len(sprite.BlocksChanged) == 0 {
+		return nil, nil
+	}
+	return
+}
+
+func union(a, b map[string]interface{}) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+	return keys
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const baseProject = `{"targets":[
+	{"isStage":true,"name":"Stage","blocks":{},"costumes":[{"name":"backdrop1"}]},
+	{"isStage":false,"name":"Cat","x":0,"blocks":{
+		"a":{"opcode":"event_whenflagclicked","next":"b","parent":null,"topLevel":true,"x":10,"y":10},
+		"b":{"opcode":"motion_movesteps","next":"c","parent":"a","inputs":{"STEPS":[1,[4,"10"]]},"topLevel":false},
+		"c":{"opcode":"looks_say","next":null,"parent":"b","topLevel":false},
+		"v":[12,"score","id1",50,60]
+	}},
+	{"isStage":false,"name":"Dog","blocks":{}}
+]}`
+
+func TestDiffProjectsUnchanged(t *testing.T) {
+	moved := `{"targets":[
+	{"isStage":true,"name":"Stage","blocks":{},"costumes":[{"name":"backdrop1"}]},
+	{"isStage":false,"name":"Cat","x":0,"blocks":{
+		"a":{"opcode":"event_whenflagclicked","next":"b","parent":null,"topLevel":true,"x":300,"y":120},
+		"b":{"opcode":"motion_movesteps","next":"c","parent":"a","inputs":{"STEPS":[1,[4,"10"]]},"topLevel":false},
+		"c":{"opcode":"looks_say","next":null,"parent":"b","topLevel":false},
+		"v":[12,"score","id1",0,0]
+	}},
+	{"isStage":false,"name":"Dog","blocks":{}}
+]}`
+	sprites, err := diffProjects(baseProject, moved)
+	assert.NoError(t, err)
+	assert.Empty(t, sprites, "moving scripts around the workspace is not a change")
+}
+
+func TestDiffProjects(t *testing.T) {
+	edited := `{"targets":[
+	{"isStage":true,"name":"Stage","blocks":{},"costumes":[{"name":"backdrop2"}]},
+	{"isStage":false,"name":"Cat","x":0,"blocks":{
+		"a":{"opcode":"event_whenflagclicked","next":"b","parent":null,"topLevel":true,"x":10,"y":10},
+		"b":{"opcode":"motion_movesteps","next":null,"parent":"a","inputs":{"STEPS":[1,[4,"25"]]},"topLevel":false},
+		"c":{"opcode":"looks_say","next":null,"parent":null,"topLevel":true,"x":0,"y":200},
+		"d":{"opcode":"event_whenkeypressed","next":null,"parent":null,"topLevel":true}
+	}},
+	{"isStage":false,"name":"Bird","blocks":{}}
+]}`
+	sprites, err := diffProjects(baseProject, edited)
+	assert.NoError(t, err)
+	assert.Len(t, sprites, 4)
+
+	stage := sprites[0]
+	assert.True(t, stage.IsStage)
+	assert.Equal(t, models.ProjectChangeChanged, stage.Change)
+	assert.Equal(t, []string{"costumes"}, stage.PropertiesChanged)
+
+	cat := sprites[1]
+	assert.Equal(t, "Cat", cat.Name)
+	assert.Empty(t, cat.PropertiesChanged)
+	assert.Equal(t, []string{"c", "d"}, cat.ScriptsAdded)
+	assert.Equal(t, []string{"v"}, cat.ScriptsRemoved)
+	assert.Equal(t, []string{"a"}, cat.ScriptsChanged)
+	assert.Equal(t, []models.ProjectBlockRefCore{{BlockId: "d", Opcode: "event_whenkeypressed"}}, cat.BlocksAdded)
+	assert.Equal(t, []models.ProjectBlockRefCore{{BlockId: "v", Opcode: "primitive"}}, cat.BlocksRemoved)
+	assert.Equal(t, []models.ProjectBlockRefCore{
+		{BlockId: "b", Opcode: "motion_movesteps"},
+		{BlockId: "c", Opcode: "looks_say"},
+	}, cat.BlocksChanged)
+
+	assert.Equal(t, "Bird", sprites[2].Name)
+	assert.Equal(t, models.ProjectChangeAdded, sprites[2].Change)
+	assert.Equal(t, "Dog", sprites[3].Name)
+	assert.Equal(t, models.ProjectChangeRemoved, sprites[3].Change)
+}
+
+func TestDiffProjectsBadJson(t *testing.T) {
+	_, err := diffProjects(baseProject, `{"targets":[{"name":"Cat","blocks":{"a":"oops"}}]}`)
+	assert.ErrorIs(t, err, projects.ErrBadProjectJson)
+
+	_, err = diffProjects("not json", baseProject)
+	assert.ErrorIs(t, err, projects.ErrBadProjectJson)
+}
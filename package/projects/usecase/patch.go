@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type patchOperation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// applyJsonPatch applies an RFC 6902 JSON Patch to the document. Either every operation
+// applies or the document is left as it was. Numbers keep their text, keys are written
+// in sorted order.
+func applyJsonPatch(document string, patch []byte) (patched string, err error) {
+	var operations []patchOperation
+	if err = json.Unmarshal(patch, &operations); err != nil {
+		return "", projects.ErrBadPatch
+	}
+	root, err := decodeJson([]byte(document))
+	if err != nil {
+		return "", projects.ErrBadProjectJson
+	}
+	for _, operation := range operations {
+		if root, err = applyPatchOperation(root, operation); err != nil {
+			return
+		}
+	}
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(root); err != nil {
+		return
+	}
+	return strings.TrimSuffix(encoded.String(), "\n"), nil
+}
+
+func decodeJson(data []byte) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&value)
+	return
+}
+
+func applyPatchOperation(root interface{}, operation patchOperation) (interface{}, error) {
+	if operation.Path == nil {
+		return nil, projects.ErrBadPatch
+	}
+	path, err := parsePointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, projects.ErrBadPatch
+		}
+		value, decodeErr := decodeJson(*operation.Value)
+		if decodeErr != nil {
+			return nil, projects.ErrBadPatch
+		}
+		switch operation.Op {
+		case "add":
+			return addAt(root, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if root, err = removeAt(root, path); err != nil {
+				return nil, err
+			}
+			return addAt(root, path, value)
+		default:
+			current, getErr := valueAt(root, path)
+			if getErr != nil {
+				return nil, getErr
+			}
+			if !jsonEqual(current, value) {
+				return nil, projects.ErrPatchTestFailed
+			}
+			return root, nil
+		}
+	case "remove":
+		return removeAt(root, path)
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, projects.ErrBadPatch
+		}
+		from, fromErr := parsePointer(*operation.From)
+		if fromErr != nil {
+			return nil, fromErr
+		}
+		value, getErr := valueAt(root, from)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if operation.Op == "move" {
+			if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+				// a value cannot be moved into one of its own children
+				return nil, projects.ErrPatchNotApplicable
+			}
+			if root, err = removeAt(root, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+		return addAt(root, path, value)
+	default:
+		return nil, projects.ErrBadPatch
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped tokens.
+func parsePointer(pointer string) (tokens []string, err error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, projects.ErrBadPatch
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		tokens = append(tokens, strings.NewReplacer("~1", "/", "~0", "~").Replace(token))
+	}
+	return
+}
+
+func arrayIndex(token string, length int, appending bool) (int, error) {
+	if appending && token == "-" {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, projects.ErrPatchNotApplicable
+	}
+	limit := length - 1
+	if appending {
+		limit = length
+	}
+	if index > limit {
+		return 0, projects.ErrPatchNotApplicable
+	}
+	return index, nil
+}
+
+func valueAt(root interface{}, path []string) (interface{}, error) {
+	current := root
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, projects.ErrPatchNotApplicable
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, projects.ErrPatchNotApplicable
+		}
+	}
+	return current, nil
+}
+
+// addAt and removeAt return the new root, arrays change length so their parents are updated as well.
+func addAt(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		default:
+			return nil, projects.ErrPatchNotApplicable
+		}
+	})
+}
+
+func removeAt(root interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, projects.ErrPatchNotApplicable
+	}
+	return updateParent(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, projects.ErrPatchNotApplicable
+			}
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:index], node[index+1:]...), nil
+		default:
+			return nil, projects.ErrPatchNotApplicable
+		}
+	})
+}
+
+func updateParent(root interface{}, path []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	parent, err := valueAt(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := update(parent, path[len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 1 {
+		return updated, nil
+	}
+	if _, isArray := updated.([]interface{}); !isArray {
+		return root, nil
+	}
+	// the slice header of the array may have changed, put it back into its own parent
+	return updateParent(root, path[:len(path)-1], func(grandparent interface{}, token string) (interface{}, error) {
+		switch node := grandparent.(type) {
+		case map[string]interface{}:
+			node[token] = updated
+			return node, nil
+		case []interface{}:
+			index, _ := arrayIndex(token, len(node), false)
+			node[index] = updated
+			return node, nil
+		default:
+			return nil, projects.ErrPatchNotApplicable
+		}
+	})
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aNumber, aIsNumber := a.(json.Number)
+	bNumber, bIsNumber := b.(json.Number)
+	if aIsNumber && bIsNumber {
+		aFloat, aErr := aNumber.Float64()
+		bFloat, bErr := bNumber.Float64()
+		return aErr == nil && bErr == nil && aFloat == bFloat
+	}
+	switch aNode := a.(type) {
+	case map[string]interface{}:
+		bNode, ok := b.(map[string]interface{})
+		if !ok || len(aNode) != len(bNode) {
+			return false
+		}
+		for key, value := range aNode {
+			other, ok := bNode[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bNode, ok := b.([]interface{})
+		if !ok || len(aNode) != len(bNode) {
+			return false
+		}
+		for i := range aNode {
+			if !jsonEqual(aNode[i], bNode[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for key, child := range node {
+			copied[key] = deepCopy(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, child := range node {
+			copied[i] = deepCopy(child)
+		}
+		return copied
+	default:
+		return node
+	}
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const patchedProject = `{"targets":[{"name":"Stage","blocks":{}},{"name":"Cat","x":10,"costumes":["a","b"]}],"meta":{"semver":"3.0.0"}}`
+
+func TestApplyJsonPatch(t *testing.T) {
+	patched, err := applyJsonPatch(patchedProject, []byte(`[
+		{"op":"test","path":"/targets/1/x","value":10.0},
+		{"op":"replace","path":"/targets/1/x","value":42},
+		{"op":"add","path":"/targets/1/costumes/-","value":"c"},
+		{"op":"add","path":"/targets/1/costumes/0","value":"z"},
+		{"op":"remove","path":"/targets/1/costumes/2"},
+		{"op":"copy","from":"/targets/1/costumes","path":"/targets/0/costumes"},
+		{"op":"move","from":"/meta/semver","path":"/meta/version"},
+		{"op":"add","path":"/meta/a~1b~0c","value":"<tag>"}
+	]`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"meta":{"a/b~c":"<tag>","version":"3.0.0"},"targets":[`+
+		`{"blocks":{},"costumes":["z","a","c"],"name":"Stage"},`+
+		`{"costumes":["z","a","c"],"name":"Cat","x":42}]}`, patched)
+
+	patched, err = applyJsonPatch(patchedProject, []byte(`[{"op":"replace","path":"","value":{"targets":[]}}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"targets":[]}`, patched)
+}
+
+func TestApplyJsonPatchFails(t *testing.T) {
+	failures := map[string]error{
+		`{"op":"add"}`:                                          projects.ErrBadPatch,
+		`[{"op":"rename","path":"/meta"}]`:                      projects.ErrBadPatch,
+		`[{"op":"add","path":"meta","value":1}]`:                projects.ErrBadPatch,
+		`[{"op":"add","path":"/meta/x"}]`:                       projects.ErrBadPatch,
+		`[{"op":"remove","path":"/missing"}]`:                   projects.ErrPatchNotApplicable,
+		`[{"op":"replace","path":"/targets/5","value":1}]`:      projects.ErrPatchNotApplicable,
+		`[{"op":"add","path":"/targets/01","value":1}]`:         projects.ErrPatchNotApplicable,
+		`[{"op":"move","from":"/meta","path":"/meta/inner"}]`:   projects.ErrPatchNotApplicable,
+		`[{"op":"test","path":"/meta/semver","value":"2.0.0"}]`: projects.ErrPatchTestFailed,
+	}
+	for patch, expected := range failures {
+		_, err := applyJsonPatch(patchedProject, []byte(patch))
+		assert.ErrorIs(t, err, expected, patch)
+	}
+
+	_, err := applyJsonPatch("not a project", []byte(`[]`))
+	assert.ErrorIs(t, err, projects.ErrBadProjectJson)
+}
@@ -1,13 +1,26 @@
 package usecase
 
 import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/spf13/viper"
 	"go.uber.org/fx"
+	"time"
 )
 
 type ProjectUseCaseImpl struct {
 	projects.Gateway
+	usersGateway      users.Gateway
+	accessScope       access.Scope
+	revisionPolicy    revisionPolicy
+	analysisBatchSize int
+	// analysisFailures counts the projects the current pass of AnalyzeProjects could not analyze, they stay
+	// in front of the unanalyzed ones and are skipped until the pass gets to the end
+	analysisFailures int
+	// bodyGracePeriod is how long an unused blob is kept, a save may not have written its row yet
+	bodyGracePeriod time.Duration
 }
 
 type ProjectUseCaseModule struct {
@@ -15,20 +28,106 @@ type ProjectUseCaseModule struct {
 	projects.UseCase
 }
 
-func SetupProjectUseCase(gateway projects.Gateway) ProjectUseCaseModule {
+func SetupProjectUseCase(gateway projects.Gateway, usersGateway users.Gateway, accessScope access.Scope) ProjectUseCaseModule {
 	return ProjectUseCaseModule{
 		UseCase: &ProjectUseCaseImpl{
-			Gateway: gateway,
+			Gateway:      gateway,
+			usersGateway: usersGateway,
+			accessScope:  accessScope,
+			revisionPolicy: revisionPolicy{
+				minInterval: time.Duration(viper.GetInt("projects.revision_min_interval")) * time.Second,
+				maxCount:    viper.GetInt("projects.revision_max_count"),
+				maxAge:      time.Duration(viper.GetInt("projects.revision_max_age_days")) * 24 * time.Hour,
+			},
+			analysisBatchSize: viper.GetInt("projects.analysis_batch_size"),
+			bodyGracePeriod:   time.Duration(viper.GetInt("projects.body_gc_grace")) * time.Second,
 		},
 	}
 }
 
 func (p *ProjectUseCaseImpl) CreateProject(project *models.ProjectCore) (id string, err error) {
-	return p.Gateway.CreateProject(project)
+	if id, err = p.Gateway.CreateProject(project); err != nil {
+		return
+	}
+	_, err = p.snapshot(id, project.Json, models.RevisionCreated, "")
+	return
 }
 
 func (p *ProjectUseCaseImpl) UpdateProject(project *models.ProjectCore) (err error) {
-	return p.Gateway.UpdateProject(project)
+	if project.Json == "" {
+		return p.Gateway.UpdateProject(project)
+	}
+	_, err = p.UpdateProjectIfMatch(project, nil)
+	return
+}
+
+// UpdateProjectIfMatch saves the JSON of the project and returns its checksum. With ifMatch
+// the save only goes through while the project still has the JSON with one of those checksums.
+// A project is never saved empty, an editor that sends nothing has failed to serialize it.
+func (p *ProjectUseCaseImpl) UpdateProjectIfMatch(project *models.ProjectCore, ifMatch []string) (checksum string, err error) {
+	if project.Json == "" {
+		return "", projects.ErrEmptyProject
+	}
+	latest, err := p.Gateway.GetLatestProjectRevision(project.ID)
+	if err != nil {
+		return
+	}
+	if latest == nil {
+		// projects saved before revisions existed keep the JSON this save is about to replace
+		current, getProjectErr := p.Gateway.GetProjectById(project.ID)
+		if getProjectErr != nil {
+			return "", projects.ErrProjectNotFound
+		}
+		if !matches(ifMatch, current.Checksum) {
+			return "", projects.ErrPreconditionFailed
+		}
+		if _, err = p.snapshot(project.ID, current.Json, models.RevisionCreated, ""); err != nil {
+			return
+		}
+	}
+	if ifMatch == nil {
+		err = p.Gateway.UpdateProject(project)
+	} else {
+		err = p.Gateway.UpdateProjectJsonIfMatch(project.ID, project.Json, ifMatch)
+	}
+	if err != nil {
+		return
+	}
+	if _, err = p.snapshot(project.ID, project.Json, models.RevisionAutosave, ""); err != nil {
+		return
+	}
+	return checksumOf(project.Json), nil
+}
+
+// PatchProject applies a JSON Patch to the project. The patch is made against the JSON it reads,
+// so a save that comes in between fails the patch instead of being overwritten.
+func (p *ProjectUseCaseImpl) PatchProject(projectId string, patch []byte, ifMatch []string) (checksum string, err error) {
+	current, err := p.Gateway.GetProjectById(projectId)
+	if err != nil {
+		return "", projects.ErrProjectNotFound
+	}
+	if !matches(ifMatch, current.Checksum) {
+		return "", projects.ErrPreconditionFailed
+	}
+	patched, err := applyJsonPatch(current.Json, patch)
+	if err != nil {
+		return
+	}
+	return p.UpdateProjectIfMatch(&models.ProjectCore{ID: projectId, Json: patched}, []string{current.Checksum})
+}
+
+// matches tells whether a save conditional on ifMatch may replace the JSON with the checksum,
+// a save with no condition always may.
+func matches(ifMatch []string, checksum string) bool {
+	if ifMatch == nil {
+		return true
+	}
+	for _, expected := range ifMatch {
+		if expected == checksum {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *ProjectUseCaseImpl) DeleteProject() {
@@ -38,3 +137,14 @@ func (p *ProjectUseCaseImpl) DeleteProject() {
 func (p *ProjectUseCaseImpl) GetProjectById(projectId string) (project *models.ProjectCore, err error) {
 	return p.Gateway.GetProjectById(projectId)
 }
+
+func (p *ProjectUseCaseImpl) GetProjectMetadataById(projectId string) (project *models.ProjectCore, err error) {
+	return p.Gateway.GetProjectMetadataById(projectId)
+}
+
+// CollectOrphanedBodies deletes the blobs no project, revision or assignment points to anymore.
+// The workers run it periodically.
+func (p *ProjectUseCaseImpl) CollectOrphanedBodies() (err error) {
+	_, err = p.Gateway.DeleteOrphanedBodies(time.Now().Add(-p.bodyGracePeriod))
+	return
+}
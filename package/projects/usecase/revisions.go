@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/scratch"
+	"log"
+	"time"
+)
+
+type revisionAction int
+
+const (
+	revisionSkip revisionAction = iota
+	revisionAmend
+	revisionAppend
+)
+
+// revisionPolicy throttles and retains the history of a project. Zero maxCount or maxAge
+// keeps the revisions regardless of their number or age.
+type revisionPolicy struct {
+	minInterval time.Duration
+	maxCount    int
+	maxAge      time.Duration
+}
+
+// plan decides what an autosave does to the history. Saves that change nothing are skipped,
+// a burst of autosaves within minInterval of the revision it started is merged into that revision,
+// so that the history keeps one snapshot per editing session rather than one per keystroke.
+func (r revisionPolicy) plan(latest *models.ProjectRevisionCore, checksum string, now time.Time) revisionAction {
+	switch {
+	case latest == nil:
+		return revisionAppend
+	case latest.Checksum == checksum:
+		return revisionSkip
+	case latest.Reason == models.RevisionAutosave && now.Sub(latest.CreatedAt) < r.minInterval:
+		return revisionAmend
+	default:
+		return revisionAppend
+	}
+}
+
+func checksumOf(projectJson string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(projectJson)))
+}
+
+// snapshot records the JSON the project has just been saved with.
+func (p *ProjectUseCaseImpl) snapshot(projectId, projectJson string, reason models.ProjectRevisionReason, restoredFromId string) (revision *models.ProjectRevisionCore, err error) {
+	revision = &models.ProjectRevisionCore{
+		ProjectId:      projectId,
+		Reason:         reason,
+		RestoredFromId: restoredFromId,
+		Checksum:       checksumOf(projectJson),
+		Size:           len(projectJson),
+		Json:           projectJson,
+	}
+	if revision.Analysis, err = scratch.Analyze(projectJson); err != nil {
+		// a project the analyzer cannot read is still saved, it just goes without metrics
+		log.Println(err)
+		err = nil
+	}
+	now := time.Now()
+	if reason == models.RevisionAutosave {
+		latest, latestErr := p.Gateway.GetLatestProjectRevision(projectId)
+		if latestErr != nil {
+			return nil, latestErr
+		}
+		switch p.revisionPolicy.plan(latest, revision.Checksum, now) {
+		case revisionSkip:
+			return latest, nil
+		case revisionAmend:
+			revision.Id = latest.Id
+			return revision, p.Gateway.AmendProjectRevision(revision)
+		}
+	}
+	if revision.Id, err = p.Gateway.CreateProjectRevision(revision); err != nil {
+		return
+	}
+	err = p.Gateway.PruneProjectRevisions(projectId, p.revisionPolicy.maxCount, p.revisionPolicy.prunedBefore(now))
+	return
+}
+
+// RecordCreatedRevision starts the history of a project created by the project pages, which
+// write the project through the gateway. A project that already has a history is left alone.
+func (p *ProjectUseCaseImpl) RecordCreatedRevision(projectId string) (err error) {
+	latest, err := p.Gateway.GetLatestProjectRevision(projectId)
+	if err != nil || latest != nil {
+		return
+	}
+	project, err := p.Gateway.GetProjectById(projectId)
+	if err != nil {
+		return
+	}
+	_, err = p.snapshot(projectId, project.Json, models.RevisionCreated, "")
+	return
+}
+
+// prunedBefore is the time revisions must be created after to be kept, zero when age doesn't matter.
+func (r revisionPolicy) prunedBefore(now time.Time) time.Time {
+	if r.maxAge <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-r.maxAge)
+}
+
+func (p *ProjectUseCaseImpl) GetProjectRevisions(projectId string) (revisions []*models.ProjectRevisionCore, err error) {
+	return p.Gateway.GetProjectRevisions(projectId)
+}
+
+func (p *ProjectUseCaseImpl) GetProjectRevisionById(revisionId string) (revision *models.ProjectRevisionCore, err error) {
+	return p.Gateway.GetProjectRevisionById(revisionId)
+}
+
+func (p *ProjectUseCaseImpl) GetProjectRevisionDiff(fromRevisionId, toRevisionId string) (diff *models.ProjectRevisionDiffCore, err error) {
+	from, err := p.Gateway.GetProjectRevisionById(fromRevisionId)
+	if err != nil {
+		return
+	}
+	to, err := p.Gateway.GetProjectRevisionById(toRevisionId)
+	if err != nil {
+		return
+	}
+	if from.ProjectId != to.ProjectId {
+		return nil, projects.ErrRevisionsFromProjects
+	}
+	diff = &models.ProjectRevisionDiffCore{
+		FromRevisionId: fromRevisionId,
+		ToRevisionId:   toRevisionId,
+	}
+	diff.Sprites, err = diffProjects(from.Json, to.Json)
+	return
+}
+
+// RestoreProjectRevision puts the old JSON back into the project. The restore is a revision
+// of its own, so the state it replaced stays in the history and can be restored in turn.
+func (p *ProjectUseCaseImpl) RestoreProjectRevision(revisionId string) (revision *models.ProjectRevisionCore, err error) {
+	restored, err := p.Gateway.GetProjectRevisionById(revisionId)
+	if err != nil {
+		return
+	}
+	if err = p.Gateway.UpdateProject(&models.ProjectCore{ID: restored.ProjectId, Json: restored.Json}); err != nil {
+		return
+	}
+	return p.snapshot(restored.ProjectId, restored.Json, models.RevisionRestore, restored.Id)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRevisionPolicyPlan(t *testing.T) {
+	policy := revisionPolicy{minInterval: 5 * time.Minute}
+	now := time.Date(2022, 10, 3, 12, 0, 0, 0, time.UTC)
+	autosave := &models.ProjectRevisionCore{
+		Reason:    models.RevisionAutosave,
+		Checksum:  checksumOf(`{"targets":[]}`),
+		CreatedAt: now.Add(-time.Minute),
+	}
+
+	assert.Equal(t, revisionAppend, policy.plan(nil, checksumOf("{}"), now))
+	assert.Equal(t, revisionSkip, policy.plan(autosave, checksumOf(`{"targets":[]}`), now))
+	assert.Equal(t, revisionAmend, policy.plan(autosave, checksumOf("{}"), now))
+
+	stale := *autosave
+	stale.CreatedAt = now.Add(-10 * time.Minute)
+	assert.Equal(t, revisionAppend, policy.plan(&stale, checksumOf("{}"), now))
+
+	restore := *autosave
+	restore.Reason = models.RevisionRestore
+	assert.Equal(t, revisionAppend, policy.plan(&restore, checksumOf("{}"), now), "a restore is never merged away")
+}
+
+func TestRevisionPolicyPrunedBefore(t *testing.T) {
+	now := time.Date(2022, 10, 3, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, now.AddDate(0, 0, -90), revisionPolicy{maxAge: 90 * 24 * time.Hour}.prunedBefore(now))
+	assert.True(t, revisionPolicy{}.prunedBefore(now).IsZero(), "no age limit")
+}
+
+// historyGateway keeps the revisions of a single project in memory.
+type historyGateway struct {
+	projects.Gateway
+	json      string
+	revisions []*models.ProjectRevisionCore
+}
+
+func (g *historyGateway) GetProjectById(projectId string) (*models.ProjectCore, error) {
+	return &models.ProjectCore{ID: projectId, Json: g.json}, nil
+}
+
+func (g *historyGateway) GetLatestProjectRevision(string) (*models.ProjectRevisionCore, error) {
+	if len(g.revisions) == 0 {
+		return nil, nil
+	}
+	return g.revisions[len(g.revisions)-1], nil
+}
+
+func (g *historyGateway) CreateProjectRevision(revision *models.ProjectRevisionCore) (string, error) {
+	g.revisions = append(g.revisions, revision)
+	return "1", nil
+}
+
+func (g *historyGateway) PruneProjectRevisions(string, int, time.Time) error {
+	return nil
+}
+
+func TestRecordCreatedRevision(t *testing.T) {
+	gateway := &historyGateway{json: `{"targets":[]}`}
+	usecase := &ProjectUseCaseImpl{Gateway: gateway}
+	assert.NoError(t, usecase.RecordCreatedRevision("7"))
+	assert.NoError(t, usecase.RecordCreatedRevision("7"), "the history is started once")
+	if assert.Len(t, gateway.revisions, 1) {
+		assert.Equal(t, models.RevisionCreated, gateway.revisions[0].Reason)
+		assert.Equal(t, checksumOf(gateway.json), gateway.revisions[0].Checksum)
+	}
+}
+
+func TestUpdateProjectIfMatchRejectsEmptyJson(t *testing.T) {
+	gateway := &historyGateway{json: "{}"}
+	useCase := &ProjectUseCaseImpl{Gateway: gateway}
+
+	_, err := useCase.UpdateProjectIfMatch(&models.ProjectCore{ID: "7"}, nil)
+	assert.ErrorIs(t, err, projects.ErrEmptyProject)
+	assert.Empty(t, gateway.revisions)
+}
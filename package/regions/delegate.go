@@ -0,0 +1,13 @@
+package regions
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	CreateRegion(region *models.NewRegion) (newRegion *models.RegionHTTP, err error)
+	UpdateRegion(region *models.UpdateRegion) (updatedRegion *models.RegionHTTP, err error)
+	DeleteRegion(regionId string) (err error)
+	GetRegionById(regionId string) (region *models.RegionHTTP, err error)
+	GetAllRegions() (regions []*models.RegionHTTP, err error)
+	SetRobboUnitRegion(robboUnitId string, regionId *string) (robboUnit *models.RobboUnitHTTP, err error)
+	GetRobboUnitsByRegionId(regionId string) (robboUnits []*models.RobboUnitHTTP, err error)
+}
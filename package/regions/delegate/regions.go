@@ -0,0 +1,96 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	"go.uber.org/fx"
+)
+
+type RegionsDelegateImpl struct {
+	UseCase regions.UseCase
+}
+
+type RegionsDelegateModule struct {
+	fx.Out
+	regions.Delegate
+}
+
+func SetupRegionsDelegate(usecase regions.UseCase) RegionsDelegateModule {
+	return RegionsDelegateModule{
+		Delegate: &RegionsDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *RegionsDelegateImpl) CreateRegion(region *models.NewRegion) (newRegion *models.RegionHTTP, err error) {
+	regionCore, err := p.UseCase.CreateRegion(&models.RegionCore{Name: region.Name})
+	if err != nil {
+		return
+	}
+	newRegion = &models.RegionHTTP{}
+	newRegion.FromCore(regionCore)
+	return
+}
+
+func (p *RegionsDelegateImpl) UpdateRegion(region *models.UpdateRegion) (updatedRegion *models.RegionHTTP, err error) {
+	regionCore, err := p.UseCase.UpdateRegion(&models.RegionCore{Id: region.ID, Name: region.Name})
+	if err != nil {
+		return
+	}
+	updatedRegion = &models.RegionHTTP{}
+	updatedRegion.FromCore(regionCore)
+	return
+}
+
+func (p *RegionsDelegateImpl) DeleteRegion(regionId string) (err error) {
+	return p.UseCase.DeleteRegion(regionId)
+}
+
+func (p *RegionsDelegateImpl) GetRegionById(regionId string) (region *models.RegionHTTP, err error) {
+	regionCore, err := p.UseCase.GetRegionById(regionId)
+	if err != nil {
+		return
+	}
+	region = &models.RegionHTTP{}
+	region.FromCore(regionCore)
+	return
+}
+
+func (p *RegionsDelegateImpl) GetAllRegions() (regionsHttp []*models.RegionHTTP, err error) {
+	regionsCore, err := p.UseCase.GetAllRegions()
+	if err != nil {
+		return
+	}
+	regionsHttp = make([]*models.RegionHTTP, 0, len(regionsCore))
+	for _, regionCore := range regionsCore {
+		var regionTemp models.RegionHTTP
+		regionTemp.FromCore(regionCore)
+		regionsHttp = append(regionsHttp, &regionTemp)
+	}
+	return
+}
+
+func (p *RegionsDelegateImpl) SetRobboUnitRegion(robboUnitId string, regionId *string) (robboUnit *models.RobboUnitHTTP, err error) {
+	robboUnitCore, err := p.UseCase.SetRobboUnitRegion(robboUnitId, regionId)
+	if err != nil {
+		return
+	}
+	robboUnit = &models.RobboUnitHTTP{}
+	robboUnit.FromCore(robboUnitCore)
+	return
+}
+
+func (p *RegionsDelegateImpl) GetRobboUnitsByRegionId(regionId string) (robboUnits []*models.RobboUnitHTTP, err error) {
+	robboUnitsCore, err := p.UseCase.GetRobboUnitsByRegionId(regionId)
+	if err != nil {
+		return
+	}
+	robboUnits = make([]*models.RobboUnitHTTP, 0, len(robboUnitsCore))
+	for _, robboUnitCore := range robboUnitsCore {
+		var robboUnitTemp models.RobboUnitHTTP
+		robboUnitTemp.FromCore(robboUnitCore)
+		robboUnits = append(robboUnits, &robboUnitTemp)
+	}
+	return
+}
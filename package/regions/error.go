@@ -0,0 +1,9 @@
+package regions
+
+import "errors"
+
+var (
+	ErrRegionNotFound    = errors.New("region not found")
+	ErrRobboUnitNotFound = errors.New("robbo unit not found")
+	ErrEmptyRegionName   = errors.New("region name must not be empty")
+)
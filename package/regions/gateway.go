@@ -0,0 +1,13 @@
+package regions
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Gateway interface {
+	CreateRegion(region *models.RegionCore) (regionId string, err error)
+	UpdateRegion(region *models.RegionCore) (err error)
+	DeleteRegion(regionId string) (err error)
+	GetRegionById(regionId string) (region *models.RegionCore, err error)
+	GetAllRegions() (regions []*models.RegionCore, err error)
+	SetRobboUnitRegion(robboUnitId, regionId string) (robboUnit *models.RobboUnitCore, err error)
+	GetRobboUnitsByRegionId(regionId string) (robboUnits []*models.RobboUnitCore, err error)
+}
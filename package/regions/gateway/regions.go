@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+type RegionsGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type RegionsGatewayModule struct {
+	fx.Out
+	regions.Gateway
+}
+
+func SetupRegionsGateway(postgresClient db_client.PostgresClient) RegionsGatewayModule {
+	return RegionsGatewayModule{
+		Gateway: &RegionsGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+func (r *RegionsGatewayImpl) CreateRegion(region *models.RegionCore) (regionId string, err error) {
+	regionDb := models.RegionDB{}
+	regionDb.FromCore(region)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Create(&regionDb).Error
+	})
+	regionId = strconv.FormatUint(uint64(regionDb.ID), 10)
+	return
+}
+
+func (r *RegionsGatewayImpl) UpdateRegion(region *models.RegionCore) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Model(&models.RegionDB{}).Where("id = ?", region.Id).Update("name", region.Name)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return regions.ErrRegionNotFound
+		}
+		return
+	})
+	return
+}
+
+// DeleteRegion leaves the units of the region without one, their region admins lose access to them.
+func (r *RegionsGatewayImpl) DeleteRegion(regionId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Where("id = ?", regionId).Delete(&models.RegionDB{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return regions.ErrRegionNotFound
+		}
+		return tx.Model(&models.RobboUnitDB{}).Where("region_id = ?", regionId).Update("region_id", "").Error
+	})
+	return
+}
+
+func (r *RegionsGatewayImpl) GetRegionById(regionId string) (region *models.RegionCore, err error) {
+	var regionDb models.RegionDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", regionId).First(&regionDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return regions.ErrRegionNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	region = regionDb.ToCore()
+	return
+}
+
+func (r *RegionsGatewayImpl) GetAllRegions() (regionsCore []*models.RegionCore, err error) {
+	var regionsDb []*models.RegionDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Order("name").Find(&regionsDb).Error
+	})
+	for _, regionDb := range regionsDb {
+		regionsCore = append(regionsCore, regionDb.ToCore())
+	}
+	return
+}
+
+// SetRobboUnitRegion moves the unit to the region, an empty regionId takes it out of any region.
+func (r *RegionsGatewayImpl) SetRobboUnitRegion(robboUnitId, regionId string) (robboUnit *models.RobboUnitCore, err error) {
+	var robboUnitDb models.RobboUnitDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if regionId != "" {
+			if err = tx.Where("id = ?", regionId).First(&models.RegionDB{}).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return regions.ErrRegionNotFound
+				}
+				return
+			}
+		}
+		if err = tx.Preload("Phones").Where("id = ?", robboUnitId).First(&robboUnitDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return regions.ErrRobboUnitNotFound
+			}
+			return
+		}
+		return tx.Model(&robboUnitDb).Update("region_id", regionId).Error
+	})
+	if err != nil {
+		return
+	}
+	robboUnit = robboUnitDb.ToCore()
+	return
+}
+
+func (r *RegionsGatewayImpl) GetRobboUnitsByRegionId(regionId string) (robboUnits []*models.RobboUnitCore, err error) {
+	var robboUnitsDb []*models.RobboUnitDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Preload("Phones").Where("region_id = ?", regionId).Order("name").Find(&robboUnitsDb).Error
+	})
+	for _, robboUnitDb := range robboUnitsDb {
+		robboUnits = append(robboUnits, robboUnitDb.ToCore())
+	}
+	return
+}
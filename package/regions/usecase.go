@@ -0,0 +1,13 @@
+package regions
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type UseCase interface {
+	CreateRegion(region *models.RegionCore) (newRegion *models.RegionCore, err error)
+	UpdateRegion(region *models.RegionCore) (updatedRegion *models.RegionCore, err error)
+	DeleteRegion(regionId string) (err error)
+	GetRegionById(regionId string) (region *models.RegionCore, err error)
+	GetAllRegions() (regions []*models.RegionCore, err error)
+	SetRobboUnitRegion(robboUnitId string, regionId *string) (robboUnit *models.RobboUnitCore, err error)
+	GetRobboUnitsByRegionId(regionId string) (robboUnits []*models.RobboUnitCore, err error)
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	"go.uber.org/fx"
+	"strings"
+)
+
+type RegionsUseCaseImpl struct {
+	regionsGateway regions.Gateway
+}
+
+type RegionsUseCaseModule struct {
+	fx.Out
+	regions.UseCase
+}
+
+func SetupRegionsUseCase(regionsGateway regions.Gateway) RegionsUseCaseModule {
+	return RegionsUseCaseModule{
+		UseCase: &RegionsUseCaseImpl{
+			regionsGateway: regionsGateway,
+		},
+	}
+}
+
+func (p *RegionsUseCaseImpl) CreateRegion(region *models.RegionCore) (newRegion *models.RegionCore, err error) {
+	if err = normalizeRegion(region); err != nil {
+		return
+	}
+	regionId, err := p.regionsGateway.CreateRegion(region)
+	if err != nil {
+		return
+	}
+	return p.regionsGateway.GetRegionById(regionId)
+}
+
+func (p *RegionsUseCaseImpl) UpdateRegion(region *models.RegionCore) (updatedRegion *models.RegionCore, err error) {
+	if err = normalizeRegion(region); err != nil {
+		return
+	}
+	if err = p.regionsGateway.UpdateRegion(region); err != nil {
+		return
+	}
+	return p.regionsGateway.GetRegionById(region.Id)
+}
+
+func (p *RegionsUseCaseImpl) DeleteRegion(regionId string) (err error) {
+	return p.regionsGateway.DeleteRegion(regionId)
+}
+
+func (p *RegionsUseCaseImpl) GetRegionById(regionId string) (region *models.RegionCore, err error) {
+	return p.regionsGateway.GetRegionById(regionId)
+}
+
+func (p *RegionsUseCaseImpl) GetAllRegions() (regionsCore []*models.RegionCore, err error) {
+	return p.regionsGateway.GetAllRegions()
+}
+
+func (p *RegionsUseCaseImpl) SetRobboUnitRegion(robboUnitId string, regionId *string) (robboUnit *models.RobboUnitCore, err error) {
+	var id string
+	if regionId != nil {
+		id = *regionId
+	}
+	return p.regionsGateway.SetRobboUnitRegion(robboUnitId, id)
+}
+
+func (p *RegionsUseCaseImpl) GetRobboUnitsByRegionId(regionId string) (robboUnits []*models.RobboUnitCore, err error) {
+	return p.regionsGateway.GetRobboUnitsByRegionId(regionId)
+}
+
+func normalizeRegion(region *models.RegionCore) error {
+	region.Name = strings.TrimSpace(region.Name)
+	if region.Name == "" {
+		return regions.ErrEmptyRegionName
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	region := &models.RegionCore{Name: "  North-West  "}
+	assert.NoError(t, normalizeRegion(region))
+	assert.Equal(t, "North-West", region.Name)
+
+	assert.ErrorIs(t, normalizeRegion(&models.RegionCore{Name: " \t"}), regions.ErrEmptyRegionName)
+}
@@ -0,0 +1,74 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// GetLoginEventsByUserID is the resolver for the GetLoginEventsByUserId field.
+func (r *queryResolver) GetLoginEventsByUserID(ctx context.Context, userID string, role int) ([]*models.LoginEventHTTP, error) {
+	ginContext, getGinContextErr := GinContextFromContext(ctx)
+	if getGinContextErr != nil {
+		err := errors.New("internal server error")
+		return nil, err
+	}
+	identityId, identityRole, userIdentityErr := r.authDelegate.UserIdentity(ginContext)
+	if userIdentityErr != nil || !access.IsAdmin(identityRole) {
+		err := errors.New("status unauthorized")
+		return nil, err
+	}
+	if err := r.checkUserAccess(identityId, identityRole, userID, models.Role(role)); err != nil {
+		return nil, err
+	}
+	return r.activityDelegate.GetLoginEventsByUserId(userID, models.Role(role))
+}
+
+// GetInactiveStudentsByRobboUnitID is the resolver for the GetInactiveStudentsByRobboUnitId field.
+func (r *queryResolver) GetInactiveStudentsByRobboUnitID(ctx context.Context, robboUnitID string, periodDays *int) ([]*models.StudentHTTP, error) {
+	ginContext, getGinContextErr := GinContextFromContext(ctx)
+	if getGinContextErr != nil {
+		err := errors.New("internal server error")
+		return nil, err
+	}
+	identityId, identityRole, userIdentityErr := r.authDelegate.UserIdentity(ginContext)
+	if userIdentityErr != nil {
+		err := errors.New("status unauthorized")
+		return nil, err
+	}
+	if err := r.accessScope.CheckRobboUnit(identityId, identityRole, robboUnitID); err != nil {
+		return nil, err
+	}
+	var period int
+	if periodDays != nil {
+		period = *periodDays
+	}
+	return r.activityDelegate.GetInactiveStudentsByRobboUnitId(robboUnitID, period)
+}
+
+// GetInactiveParentsByRobboUnitID is the resolver for the GetInactiveParentsByRobboUnitId field.
+func (r *queryResolver) GetInactiveParentsByRobboUnitID(ctx context.Context, robboUnitID string, periodDays *int) ([]*models.ParentHTTP, error) {
+	ginContext, getGinContextErr := GinContextFromContext(ctx)
+	if getGinContextErr != nil {
+		err := errors.New("internal server error")
+		return nil, err
+	}
+	identityId, identityRole, userIdentityErr := r.authDelegate.UserIdentity(ginContext)
+	if userIdentityErr != nil {
+		err := errors.New("status unauthorized")
+		return nil, err
+	}
+	if err := r.accessScope.CheckRobboUnit(identityId, identityRole, robboUnitID); err != nil {
+		return nil, err
+	}
+	var period int
+	if periodDays != nil {
+		period = *periodDays
+	}
+	return r.activityDelegate.GetInactiveParentsByRobboUnitId(robboUnitID, period)
+}
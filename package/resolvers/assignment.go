@@ -0,0 +1,134 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// CreateAssignment is the resolver for the createAssignment field.
+func (r *mutationResolver) CreateAssignment(ctx context.Context, input models.NewAssignment) (*models.AssignmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.CreateAssignment(&input, identityId, identityRole)
+}
+
+// UpdateAssignment is the resolver for the updateAssignment field.
+func (r *mutationResolver) UpdateAssignment(ctx context.Context, input models.UpdateAssignment) (*models.AssignmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.UpdateAssignment(&input, identityId, identityRole)
+}
+
+// DeleteAssignment is the resolver for the deleteAssignment field.
+func (r *mutationResolver) DeleteAssignment(ctx context.Context, assignmentID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if !isStaff(identityRole) {
+		return "", errors.New("status unauthorized")
+	}
+	if err := r.assignmentsDelegate.DeleteAssignment(assignmentID, identityId, identityRole); err != nil {
+		return "", err
+	}
+	return assignmentID, nil
+}
+
+// StartAssignment is the resolver for the startAssignment field.
+func (r *mutationResolver) StartAssignment(ctx context.Context, assignmentID string) (*models.AssignmentWorkHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.Student {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.StartAssignment(assignmentID, identityId)
+}
+
+// SubmitAssignment is the resolver for the submitAssignment field.
+func (r *mutationResolver) SubmitAssignment(ctx context.Context, assignmentID string) (*models.AssignmentWorkHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.Student {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.SubmitAssignment(assignmentID, identityId)
+}
+
+// GetAssignmentsByRobboGroupID is the resolver for the GetAssignmentsByRobboGroupId field.
+func (r *queryResolver) GetAssignmentsByRobboGroupID(ctx context.Context, robboGroupID string) ([]*models.AssignmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetAssignmentsByRobboGroupId(robboGroupID, identityId, identityRole)
+}
+
+// GetAssignmentProgress is the resolver for the GetAssignmentProgress field.
+func (r *queryResolver) GetAssignmentProgress(ctx context.Context, assignmentID string) (*models.AssignmentProgressHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetAssignmentProgress(assignmentID, identityId, identityRole)
+}
+
+// GetSubmittedProject is the resolver for the GetSubmittedProject field.
+func (r *queryResolver) GetSubmittedProject(ctx context.Context, assignmentID string, studentID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if !isStaff(identityRole) {
+		return "", errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetSubmittedProject(assignmentID, studentID, identityId, identityRole)
+}
+
+// GetMyAssignments is the resolver for the GetMyAssignments field.
+func (r *queryResolver) GetMyAssignments(ctx context.Context) ([]*models.StudentAssignmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.Student {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetStudentAssignments(identityId)
+}
+
+// CheckAssignment is the resolver for the CheckAssignment field.
+func (r *queryResolver) CheckAssignment(ctx context.Context, assignmentID string) ([]*models.AssignmentCheckResultHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.Student {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.CheckAssignment(assignmentID, identityId)
+}
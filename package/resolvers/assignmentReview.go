@@ -0,0 +1,62 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// ReviewAssignment is the resolver for the reviewAssignment field.
+func (r *mutationResolver) ReviewAssignment(ctx context.Context, input models.AssignmentReviewInput) (*models.AssignmentReviewHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.ReviewAssignment(&input, identityId, identityRole)
+}
+
+// GetGradebook is the resolver for the GetGradebook field.
+func (r *queryResolver) GetGradebook(ctx context.Context, robboGroupID string) (*models.GradebookHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetGradebook(robboGroupID, identityId, identityRole)
+}
+
+// GetAssignmentReviews is the resolver for the GetAssignmentReviews field.
+func (r *queryResolver) GetAssignmentReviews(ctx context.Context, assignmentID string, studentID string) ([]*models.AssignmentReviewHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.assignmentsDelegate.GetAssignmentReviews(assignmentID, studentID, identityId, identityRole)
+}
+
+// GetReviewedProject is the resolver for the GetReviewedProject field.
+func (r *queryResolver) GetReviewedProject(ctx context.Context, reviewID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	return r.assignmentsDelegate.GetReviewedProject(reviewID, identityId, identityRole)
+}
+
+// GetAssignmentFeedbackByParentID is the resolver for the GetAssignmentFeedbackByParentId field.
+func (r *queryResolver) GetAssignmentFeedbackByParentID(ctx context.Context, parentID string) ([]*models.StudentAssignmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.assignmentsDelegate.GetStudentAssignmentsByParentId(parentID, identityId, identityRole)
+}
@@ -0,0 +1,35 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// GetAssignmentSimilarity is the resolver for the GetAssignmentSimilarity field.
+func (r *queryResolver) GetAssignmentSimilarity(ctx context.Context, assignmentID string) (*models.SimilarityReportHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetAssignmentSimilarity(assignmentID, identityId, identityRole)
+}
+
+// GetGroupSimilarity is the resolver for the GetGroupSimilarity field.
+func (r *queryResolver) GetGroupSimilarity(ctx context.Context, robboGroupID string) (*models.SimilarityReportHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.assignmentsDelegate.GetGroupSimilarity(robboGroupID, identityId, identityRole)
+}
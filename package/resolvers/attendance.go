@@ -0,0 +1,59 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// MarkAttendance is the resolver for the markAttendance field.
+func (r *mutationResolver) MarkAttendance(ctx context.Context, lessonID string, marks []*models.AttendanceMark, notifyParents *bool) ([]*models.AttendanceHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.attendanceDelegate.MarkAttendance(lessonID, identityId, marks, notifyParents)
+}
+
+// GetAttendanceByLessonID is the resolver for the GetAttendanceByLessonId field.
+func (r *queryResolver) GetAttendanceByLessonID(ctx context.Context, lessonID string) ([]*models.AttendanceHTTP, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.attendanceDelegate.GetAttendanceByLessonId(lessonID)
+}
+
+// GetAttendanceStatsByStudentID is the resolver for the GetAttendanceStatsByStudentId field.
+func (r *queryResolver) GetAttendanceStatsByStudentID(ctx context.Context, studentID string, from *string, to *string) (*models.AttendanceStatsHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole == models.Student && identityId != studentID {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.attendanceDelegate.GetAttendanceStatsByStudentId(studentID, from, to)
+}
+
+// GetAttendanceStatsByRobboGroupID is the resolver for the GetAttendanceStatsByRobboGroupId field.
+func (r *queryResolver) GetAttendanceStatsByRobboGroupID(ctx context.Context, robboGroupID string, from *string, to *string) ([]*models.AttendanceStatsHTTP, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.attendanceDelegate.GetAttendanceStatsByRobboGroupId(robboGroupID, from, to)
+}
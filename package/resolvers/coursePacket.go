@@ -0,0 +1,126 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// AddCourseToCoursePacket is the resolver for the addCourseToCoursePacket field.
+func (r *mutationResolver) AddCourseToCoursePacket(ctx context.Context, coursePacketID string, courseID string) ([]string, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	// packets are shared by every unit, there is no unit to check
+	if !access.IsAdmin(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.coursePacketDelegate.AddCourseToCoursePacket(coursePacketID, courseID)
+}
+
+// RemoveCourseFromCoursePacket is the resolver for the removeCourseFromCoursePacket field.
+func (r *mutationResolver) RemoveCourseFromCoursePacket(ctx context.Context, coursePacketID string, courseID string) ([]string, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !access.IsAdmin(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.coursePacketDelegate.RemoveCourseFromCoursePacket(coursePacketID, courseID)
+}
+
+// AttachCoursePacketToRobboGroup is the resolver for the attachCoursePacketToRobboGroup field.
+func (r *mutationResolver) AttachCoursePacketToRobboGroup(ctx context.Context, robboGroupID string, coursePacketID string) (*models.RobboGroupCoursePacketHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return nil, err
+	}
+	return r.coursePacketDelegate.AttachCoursePacketToRobboGroup(robboGroupID, coursePacketID)
+}
+
+// DetachCoursePacketFromRobboGroup is the resolver for the detachCoursePacketFromRobboGroup field.
+func (r *mutationResolver) DetachCoursePacketFromRobboGroup(ctx context.Context, robboGroupID string, coursePacketID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return "", err
+	}
+	err := r.coursePacketDelegate.DetachCoursePacketFromRobboGroup(robboGroupID, coursePacketID)
+	if err != nil {
+		return "", err
+	}
+	return coursePacketID, nil
+}
+
+// RetryEdxEnrollment is the resolver for the retryEdxEnrollment field.
+func (r *mutationResolver) RetryEdxEnrollment(ctx context.Context, enrollmentID string) (*models.EdxEnrollmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !access.IsAdmin(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	enrollment, err := r.coursePacketDelegate.GetEdxEnrollmentById(enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.accessScope.CheckStudent(identityId, identityRole, enrollment.StudentID); err != nil {
+		return nil, err
+	}
+	return r.coursePacketDelegate.RetryEdxEnrollment(enrollmentID)
+}
+
+// GetCourseIdsByCoursePacketID is the resolver for the GetCourseIdsByCoursePacketId field.
+func (r *queryResolver) GetCourseIdsByCoursePacketID(ctx context.Context, coursePacketID string) ([]string, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.coursePacketDelegate.GetCourseIdsByCoursePacketId(coursePacketID)
+}
+
+// GetCoursePacketsByRobboGroupID is the resolver for the GetCoursePacketsByRobboGroupId field.
+func (r *queryResolver) GetCoursePacketsByRobboGroupID(ctx context.Context, robboGroupID string) ([]*models.RobboGroupCoursePacketHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.coursePacketDelegate.GetCoursePacketsByRobboGroupId(robboGroupID)
+}
+
+// GetEdxEnrollmentsByStudentID is the resolver for the GetEdxEnrollmentsByStudentId field.
+func (r *queryResolver) GetEdxEnrollmentsByStudentID(ctx context.Context, studentID string) ([]*models.EdxEnrollmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckStudent(identityId, identityRole, studentID); err != nil {
+		return nil, err
+	}
+	return r.coursePacketDelegate.GetEdxEnrollmentsByStudentId(studentID)
+}
+
+// GetEdxEnrollmentsByRobboGroupID is the resolver for the GetEdxEnrollmentsByRobboGroupId field.
+func (r *queryResolver) GetEdxEnrollmentsByRobboGroupID(ctx context.Context, robboGroupID string) ([]*models.EdxEnrollmentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroup(identityId, identityRole, robboGroupID); err != nil {
+		return nil, err
+	}
+	return r.coursePacketDelegate.GetEdxEnrollmentsByRobboGroupId(robboGroupID)
+}
@@ -0,0 +1,34 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// GetRobboUnitDashboard is the resolver for the GetRobboUnitDashboard field.
+func (r *queryResolver) GetRobboUnitDashboard(ctx context.Context, robboUnitID string, from *string, to *string, granularity *string) (*models.RobboUnitDashboardHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboUnit(identityId, identityRole, robboUnitID); err != nil {
+		return nil, err
+	}
+	return r.dashboardDelegate.GetRobboUnitDashboard(robboUnitID, from, to, granularity)
+}
+
+// GetRegionReport is the resolver for the GetRegionReport field.
+func (r *queryResolver) GetRegionReport(ctx context.Context, regionID string, from *string, to *string) (*models.RegionReportHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRegion(identityId, identityRole, regionID); err != nil {
+		return nil, err
+	}
+	return r.dashboardDelegate.GetRegionReport(regionID, from, to)
+}
@@ -0,0 +1,94 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// LikeProject is the resolver for the likeProject field.
+func (r *mutationResolver) LikeProject(ctx context.Context, projectID string) (*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.LikeProject(projectID, identityId, identityRole)
+}
+
+// UnlikeProject is the resolver for the unlikeProject field.
+func (r *mutationResolver) UnlikeProject(ctx context.Context, projectID string) (*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.UnlikeProject(projectID, identityId, identityRole)
+}
+
+// AddProjectToFavorites is the resolver for the addProjectToFavorites field.
+func (r *mutationResolver) AddProjectToFavorites(ctx context.Context, projectID string) (*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.AddProjectToFavorites(projectID, identityId, identityRole)
+}
+
+// RemoveProjectFromFavorites is the resolver for the removeProjectFromFavorites field.
+func (r *mutationResolver) RemoveProjectFromFavorites(ctx context.Context, projectID string) (*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.RemoveProjectFromFavorites(projectID, identityId, identityRole)
+}
+
+// ViewProject is the resolver for the viewProject field.
+func (r *mutationResolver) ViewProject(ctx context.Context, projectID string) (*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.ViewProject(projectID, identityId, identityRole)
+}
+
+// SetProjectTags is the resolver for the setProjectTags field.
+func (r *mutationResolver) SetProjectTags(ctx context.Context, projectID string, tags []string) ([]string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkProjectEditAccess(identityId, identityRole, projectID); err != nil {
+		return nil, err
+	}
+	return r.galleryDelegate.SetProjectTags(projectID, tags)
+}
+
+// GetGallery is the resolver for the GetGallery field.
+func (r *queryResolver) GetGallery(ctx context.Context, robboUnitID *string, robboGroupID *string, tag *string, from *string, to *string, sort *string, page *int, pageSize *int) (*models.GalleryPageHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.GetGallery(identityId, identityRole, robboUnitID, robboGroupID, tag, from, to, sort, page, pageSize)
+}
+
+// GetGalleryProject is the resolver for the GetGalleryProject field.
+func (r *queryResolver) GetGalleryProject(ctx context.Context, projectID string) (*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.GetGalleryProject(projectID, identityId, identityRole)
+}
+
+// GetFavoriteProjects is the resolver for the GetFavoriteProjects field.
+func (r *queryResolver) GetFavoriteProjects(ctx context.Context) ([]*models.GalleryProjectHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.galleryDelegate.GetFavoriteProjects(identityId, identityRole)
+}
@@ -0,0 +1,95 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// RemoveStudentFromRobboGroup is the resolver for the removeStudentFromRobboGroup field.
+func (r *mutationResolver) RemoveStudentFromRobboGroup(ctx context.Context, studentID string, robboGroupID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return "", err
+	}
+	err := r.usersDelegate.RemoveStudentFromRobboGroup(studentID, robboGroupID)
+	if err != nil {
+		return "", err
+	}
+	return studentID, nil
+}
+
+// RemoveStudentFromWaitlist is the resolver for the removeStudentFromWaitlist field.
+func (r *mutationResolver) RemoveStudentFromWaitlist(ctx context.Context, studentID string, robboGroupID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return "", err
+	}
+	err := r.usersDelegate.RemoveFromWaitlist(studentID, robboGroupID)
+	if err != nil {
+		return "", err
+	}
+	return studentID, nil
+}
+
+// TransferStudentToRobboGroup is the resolver for the transferStudentToRobboGroup field.
+func (r *mutationResolver) TransferStudentToRobboGroup(ctx context.Context, studentID string, fromRobboGroupID string, toRobboGroupID string, toRobboUnitID string) (*models.GroupMembershipHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	// the student leaves one group and joins the other, both have to be the admin's
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, fromRobboGroupID); err != nil {
+		return nil, err
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, toRobboGroupID); err != nil {
+		return nil, err
+	}
+	return r.usersDelegate.TransferStudent(studentID, fromRobboGroupID, toRobboGroupID, toRobboUnitID)
+}
+
+// GetGroupMembershipsByStudentID is the resolver for the GetGroupMembershipsByStudentId field.
+func (r *queryResolver) GetGroupMembershipsByStudentID(ctx context.Context, studentID string, activeOnly *bool) ([]*models.GroupMembershipHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole == models.Student && identityId != studentID {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.usersDelegate.GetGroupMembershipsByStudentId(studentID, activeOnly != nil && *activeOnly)
+}
+
+// GetGroupMembershipsByRobboGroupID is the resolver for the GetGroupMembershipsByRobboGroupId field.
+func (r *queryResolver) GetGroupMembershipsByRobboGroupID(ctx context.Context, robboGroupID string, activeOnly *bool) ([]*models.GroupMembershipHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroup(identityId, identityRole, robboGroupID); err != nil {
+		return nil, err
+	}
+	return r.usersDelegate.GetGroupMembershipsByRobboGroupId(robboGroupID, activeOnly != nil && *activeOnly)
+}
+
+// GetWaitlistByRobboGroupID is the resolver for the GetWaitlistByRobboGroupId field.
+func (r *queryResolver) GetWaitlistByRobboGroupID(ctx context.Context, robboGroupID string) ([]*models.WaitlistEntryHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroup(identityId, identityRole, robboGroupID); err != nil {
+		return nil, err
+	}
+	return r.usersDelegate.GetWaitlistByRobboGroupId(robboGroupID)
+}
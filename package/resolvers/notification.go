@@ -0,0 +1,28 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// ReadNotification is the resolver for the readNotification field.
+func (r *mutationResolver) ReadNotification(ctx context.Context, notificationID string) (*models.NotificationHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.notificationsDelegate.ReadNotification(notificationID, identityId, identityRole)
+}
+
+// GetNotificationsByAccessToken is the resolver for the GetNotificationsByAccessToken field.
+func (r *queryResolver) GetNotificationsByAccessToken(ctx context.Context, unreadOnly *bool) ([]*models.NotificationHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.notificationsDelegate.GetNotificationsByRecipient(identityId, identityRole, unreadOnly != nil && *unreadOnly)
+}
@@ -0,0 +1,35 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// GetProjectAnalysis is the resolver for the GetProjectAnalysis field.
+func (r *queryResolver) GetProjectAnalysis(ctx context.Context, projectID string) (*models.ProjectAnalysisHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkProjectAccess(identityId, identityRole, projectID); err != nil {
+		return nil, err
+	}
+	return r.projectsDelegate.GetProjectAnalysis(projectID)
+}
+
+// GetGroupAnalysisReport is the resolver for the GetGroupAnalysisReport field.
+func (r *queryResolver) GetGroupAnalysisReport(ctx context.Context, robboGroupID string) (*models.GroupAnalysisReportHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if !isStaff(identityRole) {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.projectsDelegate.GetGroupAnalysisReport(robboGroupID, identityId, identityRole)
+}
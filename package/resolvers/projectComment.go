@@ -0,0 +1,91 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// PostProjectComment is the resolver for the postProjectComment field.
+func (r *mutationResolver) PostProjectComment(ctx context.Context, projectID string, text string, parentID *string) (*models.ProjectCommentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.commentsDelegate.PostComment(projectID, parentID, text, identityId, identityRole)
+}
+
+// EditProjectComment is the resolver for the editProjectComment field.
+func (r *mutationResolver) EditProjectComment(ctx context.Context, commentID string, text string) (*models.ProjectCommentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.commentsDelegate.EditComment(commentID, text, identityId, identityRole)
+}
+
+// DeleteProjectComment is the resolver for the deleteProjectComment field.
+func (r *mutationResolver) DeleteProjectComment(ctx context.Context, commentID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if err := r.commentsDelegate.DeleteComment(commentID, identityId, identityRole); err != nil {
+		return "", err
+	}
+	return commentID, nil
+}
+
+// ReportProjectComment is the resolver for the reportProjectComment field.
+func (r *mutationResolver) ReportProjectComment(ctx context.Context, commentID string, reason string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if err := r.commentsDelegate.ReportComment(commentID, reason, identityId, identityRole); err != nil {
+		return "", err
+	}
+	return commentID, nil
+}
+
+// ModerateProjectComment is the resolver for the moderateProjectComment field.
+func (r *mutationResolver) ModerateProjectComment(ctx context.Context, commentID string, hidden bool) (*models.ProjectCommentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.commentsDelegate.ModerateComment(commentID, hidden, identityId, identityRole)
+}
+
+// SetProjectCommentsDisabled is the resolver for the setProjectCommentsDisabled field.
+func (r *mutationResolver) SetProjectCommentsDisabled(ctx context.Context, projectID string, disabled bool) (bool, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return false, identityErr
+	}
+	if err := r.commentsDelegate.SetCommentsDisabled(projectID, disabled, identityId, identityRole); err != nil {
+		return false, err
+	}
+	return disabled, nil
+}
+
+// GetProjectComments is the resolver for the GetProjectComments field.
+func (r *queryResolver) GetProjectComments(ctx context.Context, projectID string) ([]*models.ProjectCommentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.commentsDelegate.GetProjectComments(projectID, identityId, identityRole)
+}
+
+// GetReportedComments is the resolver for the GetReportedComments field.
+func (r *queryResolver) GetReportedComments(ctx context.Context) ([]*models.ReportedCommentHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.commentsDelegate.GetReportedComments(identityId, identityRole)
+}
@@ -10,6 +10,24 @@ import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 )
 
+// RemixProject is the resolver for the remixProject field.
+func (r *mutationResolver) RemixProject(ctx context.Context, projectPageID string) (*models.ProjectPageHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	source, err := r.projectPageDelegate.GetProjectPageById(projectPageID)
+	if err != nil {
+		return nil, err
+	}
+	if !source.IsShared {
+		if err = r.checkProjectAccess(identityId, identityRole, source.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	return r.projectPageDelegate.RemixProject(projectPageID, identityId, identityRole)
+}
+
 // GetProjectPageByID is the resolver for the GetProjectPageById field.
 func (r *queryResolver) GetProjectPageByID(ctx context.Context, projectPageID string) (*models.ProjectPageHTTP, error) {
 	ginContext, getGinContextErr := GinContextFromContext(ctx)
@@ -37,15 +55,42 @@ func (r *queryResolver) GetAllProjectPageByUserID(ctx context.Context, userID st
 		err := errors.New("internal server error")
 		return nil, err
 	}
-	_, _, userIdentityErr := r.authDelegate.UserIdentity(ginContext)
+	identityId, identityRole, userIdentityErr := r.authDelegate.UserIdentity(ginContext)
 	if userIdentityErr != nil {
 		err := errors.New("status unauthorized")
 		return nil, err
 	}
-	projectPageListHttp, getAllProjectPagesErr := r.projectPageDelegate.GetAllProjectPagesByUserId(userID)
+	// the pages of another user are those of the student with the id, ids are only unique within a role
+	authorRole := models.Student
+	if userID == identityId {
+		authorRole = identityRole
+	}
+	projectPageListHttp, getAllProjectPagesErr := r.projectPageDelegate.GetAllProjectPagesByUserId(userID, authorRole)
 	if getAllProjectPagesErr != nil {
 		err := errors.New("baq request")
 		return nil, err
 	}
 	return projectPageListHttp, nil
 }
+
+// GetRemixTree is the resolver for the GetRemixTree field.
+func (r *queryResolver) GetRemixTree(ctx context.Context, projectID string) ([]*models.RemixNodeHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	nodes, err := r.projectPageDelegate.GetRemixTree(projectID)
+	if err != nil {
+		return nil, err
+	}
+	hideRemixNodes(nodes, identityId, identityRole)
+	return nodes, nil
+}
+
+// GetRemixCount is the resolver for the GetRemixCount field.
+func (r *queryResolver) GetRemixCount(ctx context.Context, projectID string) (*models.RemixCountHTTP, error) {
+	if _, _, identityErr := r.identityFromContext(ctx); identityErr != nil {
+		return nil, identityErr
+	}
+	return r.projectPageDelegate.GetRemixCount(projectID)
+}
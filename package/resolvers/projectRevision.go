@@ -0,0 +1,70 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// RestoreProjectRevision is the resolver for the restoreProjectRevision field.
+func (r *mutationResolver) RestoreProjectRevision(ctx context.Context, revisionID string) (*models.ProjectRevisionHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	revision, err := r.projectsDelegate.GetProjectRevisionById(revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.checkProjectEditAccess(identityId, identityRole, revision.ProjectID); err != nil {
+		return nil, err
+	}
+	return r.projectsDelegate.RestoreProjectRevision(revisionID)
+}
+
+// GetProjectRevisions is the resolver for the GetProjectRevisions field.
+func (r *queryResolver) GetProjectRevisions(ctx context.Context, projectID string) ([]*models.ProjectRevisionHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkProjectAccess(identityId, identityRole, projectID); err != nil {
+		return nil, err
+	}
+	return r.projectsDelegate.GetProjectRevisions(projectID)
+}
+
+// GetProjectRevisionByID is the resolver for the GetProjectRevisionById field.
+func (r *queryResolver) GetProjectRevisionByID(ctx context.Context, revisionID string) (*models.ProjectRevisionHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	revision, err := r.projectsDelegate.GetProjectRevisionById(revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.checkProjectAccess(identityId, identityRole, revision.ProjectID); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// GetProjectRevisionDiff is the resolver for the GetProjectRevisionDiff field.
+func (r *queryResolver) GetProjectRevisionDiff(ctx context.Context, fromRevisionID string, toRevisionID string) (*models.ProjectRevisionDiffHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	from, err := r.projectsDelegate.GetProjectRevisionById(fromRevisionID)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.checkProjectAccess(identityId, identityRole, from.ProjectID); err != nil {
+		return nil, err
+	}
+	return r.projectsDelegate.GetProjectRevisionDiff(fromRevisionID, toRevisionID)
+}
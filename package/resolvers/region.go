@@ -0,0 +1,151 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// CreateRegion is the resolver for the createRegion field.
+func (r *mutationResolver) CreateRegion(ctx context.Context, input models.NewRegion) (*models.RegionHTTP, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.SuperAdmin {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.regionsDelegate.CreateRegion(&input)
+}
+
+// UpdateRegion is the resolver for the updateRegion field.
+func (r *mutationResolver) UpdateRegion(ctx context.Context, input models.UpdateRegion) (*models.RegionHTTP, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.SuperAdmin {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.regionsDelegate.UpdateRegion(&input)
+}
+
+// DeleteRegion is the resolver for the deleteRegion field.
+func (r *mutationResolver) DeleteRegion(ctx context.Context, regionID string) (string, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if identityRole != models.SuperAdmin {
+		return "", errors.New("status unauthorized")
+	}
+	if err := r.regionsDelegate.DeleteRegion(regionID); err != nil {
+		return "", err
+	}
+	return regionID, nil
+}
+
+// SetRobboUnitRegion is the resolver for the setRobboUnitRegion field.
+func (r *mutationResolver) SetRobboUnitRegion(ctx context.Context, robboUnitID string, regionID *string) (*models.RobboUnitHTTP, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.SuperAdmin {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.regionsDelegate.SetRobboUnitRegion(robboUnitID, regionID)
+}
+
+// CreateRegionAdmin is the resolver for the createRegionAdmin field.
+func (r *mutationResolver) CreateRegionAdmin(ctx context.Context, input models.NewRegionAdmin) (*models.RegionAdminHTTP, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if identityRole != models.SuperAdmin {
+		return nil, errors.New("status unauthorized")
+	}
+	if _, err := r.regionsDelegate.GetRegionById(input.RegionID); err != nil {
+		return nil, err
+	}
+	regionAdminId, err := r.usersDelegate.CreateRegionAdmin(&models.RegionAdminHTTP{
+		UserHTTP: &models.UserHTTP{
+			Email:      input.Email,
+			Password:   input.Password,
+			Firstname:  input.Firstname,
+			Lastname:   input.Lastname,
+			Middlename: input.Middlename,
+			Nickname:   input.Nickname,
+		},
+		RegionID: input.RegionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	regionAdmin, err := r.usersDelegate.GetRegionAdminById(regionAdminId)
+	return &regionAdmin, err
+}
+
+// DeleteRegionAdmin is the resolver for the deleteRegionAdmin field.
+func (r *mutationResolver) DeleteRegionAdmin(ctx context.Context, regionAdminID string) (string, error) {
+	_, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if identityRole != models.SuperAdmin {
+		return "", errors.New("status unauthorized")
+	}
+	id, _ := strconv.ParseUint(regionAdminID, 10, 64)
+	if err := r.usersDelegate.DeleteRegionAdmin(uint(id)); err != nil {
+		return "", err
+	}
+	return regionAdminID, nil
+}
+
+// GetAllRegions is the resolver for the GetAllRegions field.
+func (r *queryResolver) GetAllRegions(ctx context.Context) ([]*models.RegionHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.regionsDelegate.GetAllRegions()
+}
+
+// GetRegionByID is the resolver for the GetRegionById field.
+func (r *queryResolver) GetRegionByID(ctx context.Context, regionID string) (*models.RegionHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.regionsDelegate.GetRegionById(regionID)
+}
+
+// GetRobboUnitsByRegionID is the resolver for the GetRobboUnitsByRegionId field.
+func (r *queryResolver) GetRobboUnitsByRegionID(ctx context.Context, regionID string) ([]*models.RobboUnitHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRegion(identityId, identityRole, regionID); err != nil {
+		return nil, err
+	}
+	return r.regionsDelegate.GetRobboUnitsByRegionId(regionID)
+}
+
+// GetRegionAdminsByRegionID is the resolver for the GetRegionAdminsByRegionId field.
+func (r *queryResolver) GetRegionAdminsByRegionID(ctx context.Context, regionID string) ([]*models.RegionAdminHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRegion(identityId, identityRole, regionID); err != nil {
+		return nil, err
+	}
+	return r.usersDelegate.GetRegionAdminsByRegionId(regionID)
+}
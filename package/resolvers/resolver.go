@@ -2,13 +2,28 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/activity"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/assignments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/attendance"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/comments"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/coursePacket"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/courses"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/dashboard"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/gallery"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/projectPage"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/projects"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
 )
 
@@ -17,12 +32,25 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	authDelegate        auth.Delegate
-	usersDelegate       users.Delegate
-	robboGroupDelegate  robboGroup.Delegate
-	robboUnitsDelegate  robboUnits.Delegate
-	coursesDelegate     courses.Delegate
-	projectPageDelegate projectPage.Delegate
+	authDelegate          auth.Delegate
+	usersDelegate         users.Delegate
+	robboGroupDelegate    robboGroup.Delegate
+	robboUnitsDelegate    robboUnits.Delegate
+	coursesDelegate       courses.Delegate
+	projectPageDelegate   projectPage.Delegate
+	activityDelegate      activity.Delegate
+	scheduleDelegate      schedule.Delegate
+	notificationsDelegate notifications.Delegate
+	attendanceDelegate    attendance.Delegate
+	coursePacketDelegate  coursePacket.Delegate
+	dashboardDelegate     dashboard.Delegate
+	termsDelegate         terms.Delegate
+	regionsDelegate       regions.Delegate
+	projectsDelegate      projects.Delegate
+	galleryDelegate       gallery.Delegate
+	commentsDelegate      comments.Delegate
+	assignmentsDelegate   assignments.Delegate
+	accessScope           access.Scope
 }
 
 type MutationResolver struct{ *Resolver }
@@ -50,13 +78,127 @@ func NewResolver(
 	robboUnitsDelegate robboUnits.Delegate,
 	coursesDelegate courses.Delegate,
 	projectPageDelegate projectPage.Delegate,
+	activityDelegate activity.Delegate,
+	scheduleDelegate schedule.Delegate,
+	notificationsDelegate notifications.Delegate,
+	attendanceDelegate attendance.Delegate,
+	coursePacketDelegate coursePacket.Delegate,
+	dashboardDelegate dashboard.Delegate,
+	termsDelegate terms.Delegate,
+	regionsDelegate regions.Delegate,
+	projectsDelegate projects.Delegate,
+	galleryDelegate gallery.Delegate,
+	commentsDelegate comments.Delegate,
+	assignmentsDelegate assignments.Delegate,
+	accessScope access.Scope,
 ) Resolver {
 	return Resolver{
-		authDelegate:        authDelegate,
-		usersDelegate:       usersDelegate,
-		robboGroupDelegate:  robboGroupDelegate,
-		robboUnitsDelegate:  robboUnitsDelegate,
-		coursesDelegate:     coursesDelegate,
-		projectPageDelegate: projectPageDelegate,
+		authDelegate:          authDelegate,
+		usersDelegate:         usersDelegate,
+		robboGroupDelegate:    robboGroupDelegate,
+		robboUnitsDelegate:    robboUnitsDelegate,
+		coursesDelegate:       coursesDelegate,
+		projectPageDelegate:   projectPageDelegate,
+		activityDelegate:      activityDelegate,
+		scheduleDelegate:      scheduleDelegate,
+		notificationsDelegate: notificationsDelegate,
+		attendanceDelegate:    attendanceDelegate,
+		coursePacketDelegate:  coursePacketDelegate,
+		dashboardDelegate:     dashboardDelegate,
+		termsDelegate:         termsDelegate,
+		regionsDelegate:       regionsDelegate,
+		projectsDelegate:      projectsDelegate,
+		galleryDelegate:       galleryDelegate,
+		commentsDelegate:      commentsDelegate,
+		assignmentsDelegate:   assignmentsDelegate,
+		accessScope:           accessScope,
 	}
 }
+
+// identityFromContext returns the id and role of the user that sent the request.
+func (r *Resolver) identityFromContext(ctx context.Context) (id string, role models.Role, err error) {
+	ginContext, getGinContextErr := GinContextFromContext(ctx)
+	if getGinContextErr != nil {
+		err = errors.New("internal server error")
+		return
+	}
+	id, role, userIdentityErr := r.authDelegate.UserIdentity(ginContext)
+	if userIdentityErr != nil {
+		err = errors.New("status unauthorized")
+	}
+	return
+}
+
+// isStaff reports whether the role belongs to the teaching or administrative staff.
+func isStaff(role models.Role) bool {
+	return access.IsStaff(role)
+}
+
+// checkTermAccess lets the admins of the unit of the term manage it.
+func (r *Resolver) checkTermAccess(identityId string, identityRole models.Role, termId string) error {
+	term, err := r.termsDelegate.GetTermById(termId)
+	if err != nil {
+		return err
+	}
+	return r.accessScope.CheckRobboUnit(identityId, identityRole, term.RobboUnitID)
+}
+
+// checkLessonAccess lets the teachers of the group of the lesson and the admins of its unit change it.
+func (r *Resolver) checkLessonAccess(identityId string, identityRole models.Role, lessonId string) error {
+	lesson, err := r.scheduleDelegate.GetLessonById(lessonId)
+	if err != nil {
+		return err
+	}
+	return r.accessScope.CheckRobboGroup(identityId, identityRole, lesson.RobboGroupID)
+}
+
+// checkUserAccess lets the admins in on the users of their units. Users other than students,
+// teachers and parents are not bound to a unit and are left to super admins.
+func (r *Resolver) checkUserAccess(identityId string, identityRole models.Role, userId string, userRole models.Role) error {
+	return r.accessScope.CheckUser(identityId, identityRole, userId, userRole)
+}
+
+// checkProjectAccess lets the author of the project read it, and whoever may see the author:
+// the parents of a student, the teachers of their groups and the admins of their units.
+func (r *Resolver) checkProjectAccess(identityId string, identityRole models.Role, projectId string) error {
+	project, err := r.projectsDelegate.GetProjectMetadataById(projectId)
+	if err != nil {
+		return err
+	}
+	return r.checkUserAccess(identityId, identityRole, project.AuthorId, project.AuthorRole)
+}
+
+// checkProjectEditAccess is checkProjectAccess for changes, parents only look at the projects of their children.
+func (r *Resolver) checkProjectEditAccess(identityId string, identityRole models.Role, projectId string) error {
+	project, err := r.projectsDelegate.GetProjectMetadataById(projectId)
+	if err != nil {
+		return err
+	}
+	if access.SameUser(identityId, identityRole, project.AuthorId, project.AuthorRole) {
+		return nil
+	}
+	if identityRole == models.Parent {
+		return errors.New("status unauthorized")
+	}
+	return r.checkUserAccess(identityId, identityRole, project.AuthorId, project.AuthorRole)
+}
+
+// hideRemixNodes keeps who made unshared and deleted projects of a remix tree to their authors and the staff.
+func hideRemixNodes(nodes []*models.RemixNodeHTTP, identityId string, identityRole models.Role) {
+	if isStaff(identityRole) {
+		return
+	}
+	for _, node := range nodes {
+		if node.Deleted || (!node.IsShared && !access.SameUser(identityId, identityRole, node.AuthorID, models.Role(node.AuthorRole))) {
+			node.Title, node.AuthorID = "", ""
+		}
+	}
+}
+
+// daysOrDefault unwraps an optional period; zero lets the usecase apply its configured default.
+func daysOrDefault(days *int) int {
+	if days == nil {
+		return 0
+	}
+	return *days
+}
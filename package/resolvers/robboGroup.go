@@ -6,9 +6,62 @@ package resolvers
 import (
 	"context"
 	"errors"
+
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 )
 
+// CreateRobboGroup is the resolver for the createRobboGroup field.
+func (r *mutationResolver) CreateRobboGroup(ctx context.Context, robboUnitID string, name string, termID *string) (*models.RobboGroupHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboUnit(identityId, identityRole, robboUnitID); err != nil {
+		return nil, err
+	}
+	robboGroupHttp := &models.RobboGroupHTTP{RobboUnitID: robboUnitID, Name: name}
+	if termID != nil {
+		robboGroupHttp.TermID = *termID
+	}
+	robboGroupId, err := r.robboGroupDelegate.CreateRobboGroup(robboGroupHttp)
+	if err != nil {
+		return nil, err
+	}
+	robboGroup, err := r.robboGroupDelegate.GetRobboGroupById(robboGroupId)
+	return &robboGroup, err
+}
+
+// DeleteRobboGroup is the resolver for the deleteRobboGroup field.
+func (r *mutationResolver) DeleteRobboGroup(ctx context.Context, robboGroupID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return "", err
+	}
+	if err := r.robboGroupDelegate.DeleteRobboGroup(robboGroupID); err != nil {
+		return "", err
+	}
+	return robboGroupID, nil
+}
+
+// SetRobboGroupCapacity is the resolver for the setRobboGroupCapacity field.
+func (r *mutationResolver) SetRobboGroupCapacity(ctx context.Context, robboGroupID string, capacity int) (*models.RobboGroupHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return nil, err
+	}
+	if err := r.usersDelegate.SetRobboGroupCapacity(robboGroupID, capacity); err != nil {
+		return nil, err
+	}
+	robboGroup, err := r.robboGroupDelegate.GetRobboGroupById(robboGroupID)
+	return &robboGroup, err
+}
+
 // GetRobboGroupByID is the resolver for the GetRobboGroupById field.
 func (r *queryResolver) GetRobboGroupByID(ctx context.Context, id string) (*models.RobboGroupHTTP, error) {
 	ginContext, err := GinContextFromContext(ctx)
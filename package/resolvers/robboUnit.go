@@ -5,9 +5,73 @@ package resolvers
 
 import (
 	"context"
+	"errors"
+
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 )
 
+// CreateRobboUnit is the resolver for the createRobboUnit field.
+func (r *mutationResolver) CreateRobboUnit(ctx context.Context, input models.NewRobboUnit) (*models.RobboUnitHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	var regionId string
+	switch identityRole {
+	case models.SuperAdmin:
+		if input.RegionID != nil {
+			regionId = *input.RegionID
+		}
+	case models.RegionAdmin:
+		// a region admin opens units in their own region only
+		regionAdmin, err := r.usersDelegate.GetRegionAdminById(identityId)
+		if err != nil {
+			return nil, err
+		}
+		if input.RegionID != nil && *input.RegionID != regionAdmin.RegionID {
+			return nil, errors.New("status unauthorized")
+		}
+		regionId = regionAdmin.RegionID
+	default:
+		return nil, errors.New("status unauthorized")
+	}
+	robboUnitHttp := &models.RobboUnitHTTP{Name: input.Name, City: input.City}
+	if input.Address != nil {
+		robboUnitHttp.Address = *input.Address
+	}
+	if input.WorkingHours != nil {
+		robboUnitHttp.WorkingHours = *input.WorkingHours
+	}
+	robboUnitId, err := r.robboUnitsDelegate.CreateRobboUnit(robboUnitHttp)
+	if err != nil {
+		return nil, err
+	}
+	if regionId != "" {
+		return r.regionsDelegate.SetRobboUnitRegion(robboUnitId, &regionId)
+	}
+	robboUnit, err := r.robboUnitsDelegate.GetRobboUnitById(robboUnitId)
+	return &robboUnit, err
+}
+
+// DeleteRobboUnit is the resolver for the deleteRobboUnit field.
+func (r *mutationResolver) DeleteRobboUnit(ctx context.Context, robboUnitID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	// unit admins are bound to their units and can not remove them
+	if identityRole != models.SuperAdmin && identityRole != models.RegionAdmin {
+		return "", errors.New("status unauthorized")
+	}
+	if err := r.accessScope.CheckRobboUnit(identityId, identityRole, robboUnitID); err != nil {
+		return "", err
+	}
+	if err := r.robboUnitsDelegate.DeleteRobboUnit(robboUnitID); err != nil {
+		return "", err
+	}
+	return robboUnitID, nil
+}
+
 // GetRobboUnitByID is the resolver for the GetRobboUnitById field.
 func (r *queryResolver) GetRobboUnitByID(ctx context.Context, id string) (*models.RobboUnitHTTP, error) {
 	ginContext, err := GinContextFromContext(ctx)
@@ -50,3 +114,9 @@ func (r *queryResolver) GetRobboUnitsByUnitAdminID(ctx context.Context, unitAdmi
 	robboUnitsHttp, err := r.robboUnitsDelegate.GetRobboUnitsByUnitAdminId(unitAdminID)
 	return robboUnitsHttp, err
 }
+
+// SearchNearestRobboUnits is the resolver for the SearchNearestRobboUnits field.
+func (r *queryResolver) SearchNearestRobboUnits(ctx context.Context, search models.RobboUnitSearch) ([]*models.NearbyRobboUnitHTTP, error) {
+	// the search is public so that parents can find a unit before they have an account
+	return r.robboUnitsDelegate.SearchNearestRobboUnits(&search)
+}
@@ -0,0 +1,150 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// CreateScheduleSlot is the resolver for the createScheduleSlot field.
+func (r *mutationResolver) CreateScheduleSlot(ctx context.Context, input models.NewScheduleSlot) (*models.ScheduleSlotHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroup(identityId, identityRole, input.RobboGroupID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.CreateScheduleSlot(&input)
+}
+
+// DeleteScheduleSlot is the resolver for the deleteScheduleSlot field.
+func (r *mutationResolver) DeleteScheduleSlot(ctx context.Context, scheduleSlotID string) (string, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return "", identityErr
+	}
+	slot, err := r.scheduleDelegate.GetScheduleSlotById(scheduleSlotID)
+	if err != nil {
+		return "", err
+	}
+	if err = r.accessScope.CheckRobboGroup(identityId, identityRole, slot.RobboGroupID); err != nil {
+		return "", err
+	}
+	err = r.scheduleDelegate.DeleteScheduleSlot(scheduleSlotID)
+	if err != nil {
+		return "", err
+	}
+	return scheduleSlotID, nil
+}
+
+// GenerateLessons is the resolver for the generateLessons field.
+func (r *mutationResolver) GenerateLessons(ctx context.Context, robboGroupID string, from string, to string) ([]*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboGroup(identityId, identityRole, robboGroupID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.GenerateLessons(robboGroupID, from, to)
+}
+
+// CancelLesson is the resolver for the cancelLesson field.
+func (r *mutationResolver) CancelLesson(ctx context.Context, lessonID string, reason string) (*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkLessonAccess(identityId, identityRole, lessonID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.CancelLesson(lessonID, reason)
+}
+
+// RescheduleLesson is the resolver for the rescheduleLesson field.
+func (r *mutationResolver) RescheduleLesson(ctx context.Context, lessonID string, startAt string, endAt string, room string) (*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkLessonAccess(identityId, identityRole, lessonID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.RescheduleLesson(lessonID, startAt, endAt, room)
+}
+
+// GetScheduleSlotsByRobboGroupID is the resolver for the GetScheduleSlotsByRobboGroupId field.
+func (r *queryResolver) GetScheduleSlotsByRobboGroupID(ctx context.Context, robboGroupID string) ([]*models.ScheduleSlotHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.scheduleDelegate.GetScheduleSlotsByRobboGroupId(robboGroupID)
+}
+
+// GetLessonsByRobboGroupID is the resolver for the GetLessonsByRobboGroupId field.
+func (r *queryResolver) GetLessonsByRobboGroupID(ctx context.Context, robboGroupID string, from string, to string) ([]*models.LessonHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.scheduleDelegate.GetLessonsByRobboGroupId(robboGroupID, from, to)
+}
+
+// GetUpcomingLessonsByTeacherID is the resolver for the GetUpcomingLessonsByTeacherId field.
+func (r *queryResolver) GetUpcomingLessonsByTeacherID(ctx context.Context, teacherID string, days *int) ([]*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckTeacher(identityId, identityRole, teacherID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.GetUpcomingLessonsByTeacherId(teacherID, daysOrDefault(days))
+}
+
+// GetUpcomingLessonsByStudentID is the resolver for the GetUpcomingLessonsByStudentId field.
+func (r *queryResolver) GetUpcomingLessonsByStudentID(ctx context.Context, studentID string, days *int) ([]*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckStudent(identityId, identityRole, studentID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.GetUpcomingLessonsByStudentId(studentID, daysOrDefault(days))
+}
+
+// GetUpcomingLessonsByParentID is the resolver for the GetUpcomingLessonsByParentId field.
+func (r *queryResolver) GetUpcomingLessonsByParentID(ctx context.Context, parentID string, days *int) ([]*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckParent(identityId, identityRole, parentID); err != nil {
+		return nil, err
+	}
+	return r.scheduleDelegate.GetUpcomingLessonsByParentId(parentID, daysOrDefault(days))
+}
+
+// GetUpcomingLessonsByAccessToken is the resolver for the GetUpcomingLessonsByAccessToken field.
+func (r *queryResolver) GetUpcomingLessonsByAccessToken(ctx context.Context, days *int) ([]*models.LessonHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	switch identityRole {
+	case models.Student:
+		return r.scheduleDelegate.GetUpcomingLessonsByStudentId(identityId, daysOrDefault(days))
+	case models.Parent:
+		return r.scheduleDelegate.GetUpcomingLessonsByParentId(identityId, daysOrDefault(days))
+	case models.Teacher:
+		return r.scheduleDelegate.GetUpcomingLessonsByTeacherId(identityId, daysOrDefault(days))
+	default:
+		return []*models.LessonHTTP{}, nil
+	}
+}
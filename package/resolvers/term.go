@@ -0,0 +1,73 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+// CreateTerm is the resolver for the createTerm field.
+func (r *mutationResolver) CreateTerm(ctx context.Context, input models.NewTerm) (*models.TermHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.accessScope.CheckRobboUnit(identityId, identityRole, input.RobboUnitID); err != nil {
+		return nil, err
+	}
+	return r.termsDelegate.CreateTerm(&input)
+}
+
+// RolloverTerm is the resolver for the rolloverTerm field.
+func (r *mutationResolver) RolloverTerm(ctx context.Context, input models.TermRollover) (*models.TermHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkTermAccess(identityId, identityRole, input.FromTermID); err != nil {
+		return nil, err
+	}
+	return r.termsDelegate.RolloverTerm(&input)
+}
+
+// ArchiveTerm is the resolver for the archiveTerm field.
+func (r *mutationResolver) ArchiveTerm(ctx context.Context, termID string) (*models.TermHTTP, error) {
+	identityId, identityRole, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if err := r.checkTermAccess(identityId, identityRole, termID); err != nil {
+		return nil, err
+	}
+	return r.termsDelegate.ArchiveTerm(termID)
+}
+
+// GetTermByID is the resolver for the GetTermById field.
+func (r *queryResolver) GetTermByID(ctx context.Context, termID string) (*models.TermHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.termsDelegate.GetTermById(termID)
+}
+
+// GetTermsByRobboUnitID is the resolver for the GetTermsByRobboUnitId field.
+func (r *queryResolver) GetTermsByRobboUnitID(ctx context.Context, robboUnitID string) ([]*models.TermHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.termsDelegate.GetTermsByRobboUnitId(robboUnitID)
+}
+
+// GetRobboGroupsByTermID is the resolver for the GetRobboGroupsByTermId field.
+func (r *queryResolver) GetRobboGroupsByTermID(ctx context.Context, termID string) ([]*models.RobboGroupHTTP, error) {
+	_, _, identityErr := r.identityFromContext(ctx)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	return r.robboGroupDelegate.GetRobboGroupsByTermId(termID)
+}
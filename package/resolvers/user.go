@@ -90,13 +90,38 @@ func (r *mutationResolver) SetRobboGroupIDForStudent(ctx context.Context, studen
 	if err != nil {
 		return "", err
 	}
-	_, _, identityErr := r.authDelegate.UserIdentity(ginContext)
+	identityId, identityRole, identityErr := r.authDelegate.UserIdentity(ginContext)
 	if identityErr != nil {
 		return "", identityErr
 	}
+	if err = r.accessScope.CheckRobboGroupAdmin(identityId, identityRole, robboGroupID); err != nil {
+		return "", err
+	}
 
-	err = r.usersDelegate.AddStudentToRobboGroup(studentID, robboGroupID, robboUnitID)
-	return "", err
+	waitlistEntry, err := r.usersDelegate.AddStudentToRobboGroup(studentID, robboGroupID, robboUnitID)
+	if err != nil {
+		return "", err
+	}
+	if waitlistEntry != nil {
+		return string(models.EnrollmentWaitlisted), nil
+	}
+	return string(models.EnrollmentEnrolled), nil
+}
+
+// MergeStudents is the resolver for the mergeStudents field.
+func (r *mutationResolver) MergeStudents(ctx context.Context, survivorID string, duplicateID string) (*models.StudentHTTP, error) {
+	ginContext, err := GinContextFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, role, identityErr := r.authDelegate.UserIdentity(ginContext)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if role != models.SuperAdmin {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.usersDelegate.MergeStudents(survivorID, duplicateID)
 }
 
 // CreateTeacher is the resolver for the createTeacher field.
@@ -399,6 +424,22 @@ func (r *queryResolver) SearchStudentsByEmail(ctx context.Context, email string)
 	return r.usersDelegate.SearchStudentByEmail(email)
 }
 
+// FindDuplicateStudents is the resolver for the FindDuplicateStudents field.
+func (r *queryResolver) FindDuplicateStudents(ctx context.Context) ([]*models.StudentDuplicateHTTP, error) {
+	ginContext, err := GinContextFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, role, identityErr := r.authDelegate.UserIdentity(ginContext)
+	if identityErr != nil {
+		return nil, identityErr
+	}
+	if role != models.SuperAdmin {
+		return nil, errors.New("status unauthorized")
+	}
+	return r.usersDelegate.FindDuplicateStudents()
+}
+
 // GetAllTeachers is the resolver for the GetAllTeachers field.
 func (r *queryResolver) GetAllTeachers(ctx context.Context) ([]*models.TeacherHTTP, error) {
 	ginContext, err := GinContextFromContext(ctx)
@@ -6,6 +6,7 @@ type Delegate interface {
 	CreateRobboGroup(robboGroup *models.RobboGroupHTTP) (robboGroupId string, err error)
 	DeleteRobboGroup(robboGroupId string) (err error)
 	GetRobboGroupsByRobboUnitId(robboUnitId string) (robboGroups []*models.RobboGroupHTTP, err error)
+	GetRobboGroupsByTermId(termId string) (robboGroups []*models.RobboGroupHTTP, err error)
 	GetRobboGroupById(robboGroupId string) (robboGroup models.RobboGroupHTTP, err error)
 	GetRobboGroupsByTeacherId(teacherId string) (robboGroups []*models.RobboGroupHTTP, err error)
 	SetTeacherForRobboGroup(teacherId, robboGroupId string) (err error)
@@ -60,6 +60,20 @@ func (r *RobboGroupDelegateImpl) GetRobboGroupsByRobboUnitId(robboUnitId string)
 	return
 }
 
+func (r *RobboGroupDelegateImpl) GetRobboGroupsByTermId(termId string) (robboGroups []*models.RobboGroupHTTP, err error) {
+	robboGroupsCore, err := r.UseCase.GetRobboGroupsByTermId(termId)
+	if err != nil {
+		return
+	}
+	robboGroups = make([]*models.RobboGroupHTTP, 0, len(robboGroupsCore))
+	for _, robboGroupCore := range robboGroupsCore {
+		var robboGroupTemp models.RobboGroupHTTP
+		robboGroupTemp.FromCore(robboGroupCore)
+		robboGroups = append(robboGroups, &robboGroupTemp)
+	}
+	return
+}
+
 func (r *RobboGroupDelegateImpl) GetRobboGroupById(robboGroupId string) (robboGroup models.RobboGroupHTTP, err error) {
 	robboGroupCore, err := r.UseCase.GetRobboGroupById(robboGroupId)
 	if err != nil {
@@ -0,0 +1,8 @@
+package robboGroup
+
+import "errors"
+
+var (
+	ErrRobboGroupArchived = errors.New("robbo group belongs to an archived term")
+	ErrTermNotActive      = errors.New("robbo groups can only be added to an active term of their unit")
+)
@@ -6,6 +6,7 @@ type Gateway interface {
 	CreateRobboGroup(robboGroup *models.RobboGroupCore) (robboGroupId string, err error)
 	DeleteRobboGroup(robboGroupId string) (err error)
 	GetRobboGroupsByRobboUnitId(robboUnitId string) (robboGroups []*models.RobboGroupCore, err error)
+	GetRobboGroupsByTermId(termId string) (robboGroups []*models.RobboGroupCore, err error)
 	GetRobboGroupById(robboGroupId string) (robboGroup *models.RobboGroupCore, err error)
 	//UpdateRobboUnit(robboUnit *models.RobboGroupCore) (err error)
 	SetTeacherForRobboGroup(relation *models.TeachersRobboGroupsCore) (err error)
@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"errors"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
@@ -28,11 +29,26 @@ func (r *RobboGroupGatewayImpl) SearchRobboGroupsByTitle(title string) (robboGro
 	return
 }
 
-func (r *RobboGroupGatewayImpl) CreateRobboGroup(robboGroup *models.RobboGroupCore) (robboGroupId string, err error) {
+// CreateRobboGroup puts a group without an explicit term into the latest active term of its unit, if there is one.
+func (r *RobboGroupGatewayImpl) CreateRobboGroup(robboGroupCore *models.RobboGroupCore) (robboGroupId string, err error) {
 	robboGroupDb := models.RobboGroupDB{}
-	robboGroupDb.FromCore(robboGroup)
+	robboGroupDb.FromCore(robboGroupCore)
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		var term models.TermDB
+		query := tx.Where("robbo_unit_id = ? AND status = ?", robboGroupDb.RobboUnitId, string(models.TermActive))
+		if robboGroupDb.TermId != "" {
+			query = query.Where("id = ?", robboGroupDb.TermId)
+		}
+		err = query.Order("start_date desc").First(&term).Error
+		switch {
+		case err == nil:
+			robboGroupDb.TermId = strconv.FormatUint(uint64(term.ID), 10)
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return
+		case robboGroupDb.TermId != "":
+			return robboGroup.ErrTermNotActive
+		}
 		err = tx.Create(&robboGroupDb).Error
 		return
 	})
@@ -42,8 +58,12 @@ func (r *RobboGroupGatewayImpl) CreateRobboGroup(robboGroup *models.RobboGroupCo
 	return
 }
 
+// DeleteRobboGroup takes the waitlist of the group with it, nobody can get a seat in it anymore.
 func (r *RobboGroupGatewayImpl) DeleteRobboGroup(robboGroupId string) (err error) {
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("robbo_group_id = ?", robboGroupId).Delete(&models.WaitlistEntryDB{}).Error; err != nil {
+			return
+		}
 		err = tx.Delete(&models.RobboGroupDB{}, robboGroupId).Error
 		return
 	})
@@ -66,6 +86,17 @@ func (r *RobboGroupGatewayImpl) GetRobboGroupsByRobboUnitId(robboUnitId string)
 	return
 }
 
+func (r *RobboGroupGatewayImpl) GetRobboGroupsByTermId(termId string) (robboGroups []*models.RobboGroupCore, err error) {
+	var robboGroupsDB []*models.RobboGroupDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("term_id = ?", termId).Order("name").Find(&robboGroupsDB).Error
+	})
+	for _, robboGroupDb := range robboGroupsDB {
+		robboGroups = append(robboGroups, robboGroupDb.ToCore())
+	}
+	return
+}
+
 func (r *RobboGroupGatewayImpl) GetRobboGroupById(robboGroupId string) (robboGroup *models.RobboGroupCore, err error) {
 	var robboGroupDB models.RobboGroupDB
 
@@ -12,6 +12,9 @@ func (r *RobboGroupUseCaseImpl) CreateRobboGroup(robboGroup *models.RobboGroupCo
 }
 
 func (r *RobboGroupUseCaseImpl) DeleteRobboGroup(robboGroupId string) (err error) {
+	if err = r.ensureNotArchived(robboGroupId); err != nil {
+		return
+	}
 	// TODO set robboGroupId = null for student
 	return r.robboGroupGateway.DeleteRobboGroup(robboGroupId)
 }
@@ -20,6 +23,10 @@ func (r *RobboGroupUseCaseImpl) GetRobboGroupsByRobboUnitId(robboUnitId string)
 	return r.robboGroupGateway.GetRobboGroupsByRobboUnitId(robboUnitId)
 }
 
+func (r *RobboGroupUseCaseImpl) GetRobboGroupsByTermId(termId string) (robboGroups []*models.RobboGroupCore, err error) {
+	return r.robboGroupGateway.GetRobboGroupsByTermId(termId)
+}
+
 func (r *RobboGroupUseCaseImpl) GetRobboGroupById(robboGroupId string) (robboGroup *models.RobboGroupCore, err error) {
 	robboGroup, err = r.robboGroupGateway.GetRobboGroupById(robboGroupId)
 	if err != nil {
@@ -45,6 +52,9 @@ func (r *RobboGroupUseCaseImpl) SearchRobboGroupsByTitle(title string) (robboGro
 }
 
 func (r *RobboGroupUseCaseImpl) SetTeacherForRobboGroup(teacherId, robboGroupId string) (err error) {
+	if err = r.ensureNotArchived(robboGroupId); err != nil {
+		return
+	}
 	relationCore := &models.TeachersRobboGroupsCore{
 		TeacherId:    teacherId,
 		RobboGroupId: robboGroupId,
@@ -53,6 +63,9 @@ func (r *RobboGroupUseCaseImpl) SetTeacherForRobboGroup(teacherId, robboGroupId
 }
 
 func (r *RobboGroupUseCaseImpl) DeleteTeacherForRobboGroup(teacherId, robboGroupId string) (err error) {
+	if err = r.ensureNotArchived(robboGroupId); err != nil {
+		return
+	}
 	relationCore := &models.TeachersRobboGroupsCore{
 		TeacherId:    teacherId,
 		RobboGroupId: robboGroupId,
@@ -77,6 +90,18 @@ func (r *RobboGroupUseCaseImpl) GetRobboGroupsByTeacherId(teacherId string) (rob
 	return
 }
 
+// ensureNotArchived keeps groups of archived terms read-only.
+func (r *RobboGroupUseCaseImpl) ensureNotArchived(robboGroupId string) (err error) {
+	robboGroupCore, err := r.robboGroupGateway.GetRobboGroupById(robboGroupId)
+	if err != nil {
+		return
+	}
+	if robboGroupCore.Archived {
+		return robboGroup.ErrRobboGroupArchived
+	}
+	return
+}
+
 type RobboGroupUseCaseModule struct {
 	fx.Out
 	robboGroup.UseCase
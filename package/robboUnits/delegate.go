@@ -8,5 +8,7 @@ type Delegate interface {
 	GetAllRobboUnit() (robboUnits []*models.RobboUnitHTTP, err error)
 	GetRobboUnitById(robboUnitId string) (robboUnit models.RobboUnitHTTP, err error)
 	GetRobboUnitsByUnitAdminId(unitAdminId string) (robboUnits []*models.RobboUnitHTTP, err error)
+	GetRobboUnitsByRegionAdminId(regionAdminId string) (robboUnits []*models.RobboUnitHTTP, err error)
 	UpdateRobboUnit(robboUnit *models.RobboUnitHTTP) (err error)
+	SearchNearestRobboUnits(search *models.RobboUnitSearch) (robboUnits []*models.NearbyRobboUnitHTTP, err error)
 }
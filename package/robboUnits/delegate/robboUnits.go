@@ -61,6 +61,19 @@ func (r RobboUnitsDelegateImpl) GetRobboUnitsByUnitAdminId(unitAdminId string) (
 	return
 }
 
+func (r RobboUnitsDelegateImpl) GetRobboUnitsByRegionAdminId(regionAdminId string) (robboUnits []*models.RobboUnitHTTP, err error) {
+	robboUnitsCore, err := r.UseCase.GetRobboUnitsByRegionAdminId(regionAdminId)
+	if err != nil {
+		return
+	}
+	for _, robboUnitCore := range robboUnitsCore {
+		var robboUnitTemp models.RobboUnitHTTP
+		robboUnitTemp.FromCore(robboUnitCore)
+		robboUnits = append(robboUnits, &robboUnitTemp)
+	}
+	return
+}
+
 func (r RobboUnitsDelegateImpl) UpdateRobboUnit(robboUnit *models.RobboUnitHTTP) (err error) {
 	robboUnitCore := robboUnit.ToCore()
 	return r.UseCase.UpdateRobboUnit(robboUnitCore)
@@ -73,3 +86,32 @@ func (r RobboUnitsDelegateImpl) CreateRobboUnit(robboUnit *models.RobboUnitHTTP)
 func (r RobboUnitsDelegateImpl) DeleteRobboUnit(robboUnitId string) (err error) {
 	return r.UseCase.DeleteRobboUnit(robboUnitId)
 }
+
+func (r RobboUnitsDelegateImpl) SearchNearestRobboUnits(search *models.RobboUnitSearch) (robboUnits []*models.NearbyRobboUnitHTTP, err error) {
+	searchCore := &models.RobboUnitSearchCore{
+		Latitude:      search.Latitude,
+		Longitude:     search.Longitude,
+		PacketLevel:   search.PacketLevel,
+		MaxDistanceKm: search.MaxDistanceKm,
+	}
+	if search.City != nil {
+		searchCore.City = *search.City
+	}
+	if search.CourseID != nil {
+		searchCore.CourseId = *search.CourseID
+	}
+	if search.Limit != nil {
+		searchCore.Limit = *search.Limit
+	}
+	robboUnitsCore, err := r.UseCase.SearchNearestRobboUnits(searchCore)
+	if err != nil {
+		return
+	}
+	robboUnits = make([]*models.NearbyRobboUnitHTTP, 0, len(robboUnitsCore))
+	for _, robboUnitCore := range robboUnitsCore {
+		var robboUnitTemp models.NearbyRobboUnitHTTP
+		robboUnitTemp.FromCore(robboUnitCore)
+		robboUnits = append(robboUnits, &robboUnitTemp)
+	}
+	return
+}
@@ -0,0 +1,12 @@
+package robboUnits
+
+import "errors"
+
+var (
+	ErrBadCoordinates  = errors.New("latitude must be within [-90, 90] and longitude within [-180, 180], both or neither set")
+	ErrNoSearchOrigin  = errors.New("search needs coordinates or a city")
+	ErrBadSearchRadius = errors.New("search radius must be positive")
+	ErrBadPhone        = errors.New("phone must have 5 to 15 digits and only spaces, dashes, parentheses and a leading plus besides")
+	ErrTooManyPhones   = errors.New("robbo unit can have at most 5 phones")
+	ErrBadWorkingHours = errors.New("working hours must be at most 512 characters")
+)
@@ -8,4 +8,5 @@ type Gateway interface {
 	GetAllRobboUnit() (robboUnits []*models.RobboUnitCore, err error)
 	GetRobboUnitById(robboUnitId string) (robboUnit *models.RobboUnitCore, err error)
 	UpdateRobboUnit(robboUnit *models.RobboUnitCore) (err error)
+	SearchRobboUnits(search *models.RobboUnitSearchCore) (robboUnits []*models.NearbyRobboUnitCore, err error)
 }
@@ -49,7 +49,7 @@ func (r *RobboUnitsGatewayImpl) DeleteRobboUnit(robboUnitId string) (err error)
 func (r *RobboUnitsGatewayImpl) GetAllRobboUnit() (robboUnits []*models.RobboUnitCore, err error) {
 	var robboUnitsDB []*models.RobboUnitDB
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		if err = tx.Find(&robboUnitsDB).Error; err != nil {
+		if err = tx.Preload("Phones").Find(&robboUnitsDB).Error; err != nil {
 			return
 		}
 		return
@@ -65,7 +65,7 @@ func (r *RobboUnitsGatewayImpl) GetRobboUnitById(robboUnitId string) (robboUnit
 	var robboUnitDb models.RobboUnitDB
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		if err = tx.Where("id = ?", robboUnitId).First(&robboUnitDb).Error; err != nil {
+		if err = tx.Preload("Phones").Where("id = ?", robboUnitId).First(&robboUnitDb).Error; err != nil {
 			// TODO init err robboUnit not found
 			log.Println(err)
 			return
@@ -81,8 +81,19 @@ func (r *RobboUnitsGatewayImpl) UpdateRobboUnit(robboUnit *models.RobboUnitCore)
 	robboUnitDb.FromCore(robboUnit)
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		err = tx.Model(&robboUnitDb).Where("id = ?", robboUnitDb.ID).Updates(robboUnitDb).Error
-		return
+		if err = tx.Model(&robboUnitDb).Omit("Phones").Where("id = ?", robboUnitDb.ID).Updates(robboUnitDb).Error; err != nil {
+			return
+		}
+		if robboUnit.Phones == nil {
+			return
+		}
+		if err = tx.Where("robbo_unit_id = ?", robboUnitDb.ID).Delete(&models.RobboUnitPhoneDB{}).Error; err != nil {
+			return
+		}
+		if len(robboUnitDb.Phones) == 0 {
+			return
+		}
+		return tx.Create(&robboUnitDb.Phones).Error
 	})
 	return
 }
@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUpdateRobboUnitPhones(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &RobboUnitsGatewayImpl{PostgresClient: postgresClient}
+
+	assert.NoError(t, gateway.UpdateRobboUnit(&models.RobboUnitCore{Id: "4", Name: "Arbat", Phones: []string{"+7 495 000-00-01", "+7 495 000-00-02"}}))
+	assert.Len(t, recorder.Find(`DELETE FROM "robbo_unit_phone_dbs"`), 1)
+	inserts := recorder.Find(`INSERT INTO "robbo_unit_phone_dbs"`)
+	if assert.Len(t, inserts, 1) {
+		assert.Equal(t, []interface{}{uint(4), 0, "+7 495 000-00-01", uint(4), 1, "+7 495 000-00-02"}, inserts[0].Args)
+	}
+
+	postgresClient, recorder, err = dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway = &RobboUnitsGatewayImpl{PostgresClient: postgresClient}
+	assert.NoError(t, gateway.UpdateRobboUnit(&models.RobboUnitCore{Id: "4", Name: "Arbat"}))
+	assert.Empty(t, recorder.Find(`"robbo_unit_phone_dbs"`), "phones not given are kept")
+}
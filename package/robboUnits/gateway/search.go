@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"gorm.io/gorm"
+)
+
+// distanceKm is the great-circle (haversine) distance between the unit and @lat, @lon.
+const distanceKm = `6371 * 2 * ASIN(SQRT(
+	POWER(SIN(RADIANS(robbo_unit_dbs.latitude - @lat) / 2), 2) +
+	COS(RADIANS(@lat)) * COS(RADIANS(robbo_unit_dbs.latitude)) *
+	POWER(SIN(RADIANS(robbo_unit_dbs.longitude - @lon) / 2), 2)
+))`
+
+// offeredPackets joins the course packets studied by the live groups of the unit.
+const offeredPackets = `SELECT 1 FROM robbo_group_dbs g
+	JOIN robbo_group_course_packet_dbs gp ON gp.robbo_group_id = CAST(g.id AS text) AND gp.deleted_at IS NULL
+	JOIN course_packet_dbs p ON CAST(p.id AS text) = gp.course_packet_id AND p.deleted_at IS NULL
+	WHERE g.robbo_unit_id = CAST(robbo_unit_dbs.id AS text) AND g.deleted_at IS NULL AND g.archived_at IS NULL`
+
+type nearbyRobboUnitDB struct {
+	models.RobboUnitDB
+	DistanceKm *float64
+}
+
+func (r *RobboUnitsGatewayImpl) SearchRobboUnits(search *models.RobboUnitSearchCore) (robboUnits []*models.NearbyRobboUnitCore, err error) {
+	var rows []*nearbyRobboUnitDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		query := tx.Model(&models.RobboUnitDB{})
+		if search.Latitude != nil {
+			origin := map[string]interface{}{"lat": *search.Latitude, "lon": *search.Longitude}
+			query = query.
+				Select("robbo_unit_dbs.*, "+distanceKm+" AS distance_km", origin).
+				Where("robbo_unit_dbs.latitude IS NOT NULL AND robbo_unit_dbs.longitude IS NOT NULL").
+				Order("distance_km")
+			if search.MaxDistanceKm != nil {
+				origin["radius"] = *search.MaxDistanceKm
+				query = query.Where(distanceKm+" <= @radius", origin)
+			}
+		} else {
+			query = query.Select("robbo_unit_dbs.*").Order("robbo_unit_dbs.name")
+		}
+		if search.City != "" {
+			query = query.Where("LOWER(robbo_unit_dbs.city) = LOWER(?)", search.City)
+		}
+		if search.CourseId != "" {
+			query = query.Where("EXISTS ("+offeredPackets+` AND EXISTS (
+				SELECT 1 FROM course_packet_course_dbs pc
+				WHERE pc.course_packet_id = gp.course_packet_id AND pc.course_id = ? AND pc.deleted_at IS NULL
+			))`, search.CourseId)
+		}
+		if search.PacketLevel != nil {
+			query = query.Where("EXISTS ("+offeredPackets+" AND p.level = ?)", *search.PacketLevel)
+		}
+		if err = query.Limit(search.Limit).Scan(&rows).Error; err != nil {
+			return
+		}
+		return loadPhones(tx, rows)
+	})
+	for _, row := range rows {
+		robboUnits = append(robboUnits, &models.NearbyRobboUnitCore{
+			RobboUnit:  row.RobboUnitDB.ToCore(),
+			DistanceKm: row.DistanceKm,
+		})
+	}
+	return
+}
+
+// loadPhones fills in the phones of the found units with one query, Scan doesn't preload them.
+func loadPhones(tx *gorm.DB, rows []*nearbyRobboUnitDB) (err error) {
+	if len(rows) == 0 {
+		return
+	}
+	robboUnitIds := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		robboUnitIds = append(robboUnitIds, row.ID)
+	}
+	var phonesDb []models.RobboUnitPhoneDB
+	if err = tx.Where("robbo_unit_id IN ?", robboUnitIds).Find(&phonesDb).Error; err != nil {
+		return
+	}
+	phonesByUnit := make(map[uint][]models.RobboUnitPhoneDB)
+	for _, phoneDb := range phonesDb {
+		phonesByUnit[phoneDb.RobboUnitId] = append(phonesByUnit[phoneDb.RobboUnitId], phoneDb)
+	}
+	for _, row := range rows {
+		row.Phones = phonesByUnit[row.ID]
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// nearbyUnits finds two units around the origin, the nearer first, and their phones out of order.
+func nearbyUnits(query string, args []interface{}) ([]string, [][]interface{}) {
+	switch {
+	case strings.Contains(query, `FROM "robbo_unit_dbs"`):
+		return []string{"id", "name", "city", "latitude", "longitude", "distance_km"}, [][]interface{}{
+			{int64(2), "Arbat", "Moscow", 55.75, 37.59, 1.9},
+			{int64(1), "Sokol", "Moscow", 55.80, 37.51, 8.3},
+		}
+	case strings.Contains(query, `FROM "robbo_unit_phone_dbs"`):
+		return []string{"id", "robbo_unit_id", "position", "phone"}, [][]interface{}{
+			{int64(3), int64(2), int64(1), "+7 495 000-00-02"},
+			{int64(1), int64(1), int64(0), "+7 495 000-00-01"},
+			{int64(2), int64(2), int64(0), "+7 495 000-00-03"},
+		}
+	}
+	return nil, nil
+}
+
+func TestSearchRobboUnitsByDistance(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nearbyUnits)
+	assert.NoError(t, err)
+	gateway := &RobboUnitsGatewayImpl{PostgresClient: postgresClient}
+	latitude, longitude, radius := 55.75, 37.62, 10.0
+
+	found, err := gateway.SearchRobboUnits(&models.RobboUnitSearchCore{
+		Latitude: &latitude, Longitude: &longitude, City: "moscow", MaxDistanceKm: &radius, Limit: 20,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, found, 2) {
+		assert.Equal(t, "2", found[0].RobboUnit.Id)
+		assert.Equal(t, 1.9, *found[0].DistanceKm)
+		assert.Equal(t, []string{"+7 495 000-00-03", "+7 495 000-00-02"}, found[0].RobboUnit.Phones)
+		assert.Equal(t, []string{"+7 495 000-00-01"}, found[1].RobboUnit.Phones)
+	}
+
+	units := recorder.Find(`FROM "robbo_unit_dbs"`, "ORDER BY distance_km", "LIMIT 20")
+	if assert.Len(t, units, 1) {
+		assert.Contains(t, units[0].SQL, "<= $")
+		assert.Contains(t, units[0].Args, radius)
+		assert.Contains(t, units[0].Args, "moscow")
+	}
+	assert.Len(t, recorder.Find(`FROM "robbo_unit_phone_dbs"`), 1, "the phones of every unit are read at once")
+}
+
+func TestSearchRobboUnitsInCity(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &RobboUnitsGatewayImpl{PostgresClient: postgresClient}
+
+	found, err := gateway.SearchRobboUnits(&models.RobboUnitSearchCore{City: "Moscow", Limit: 20})
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+	assert.Len(t, recorder.Find(`FROM "robbo_unit_dbs"`, "ORDER BY robbo_unit_dbs.name"), 1)
+	assert.Empty(t, recorder.Find(`FROM "robbo_unit_phone_dbs"`), "nothing found, nothing to load")
+}
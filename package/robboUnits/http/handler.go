@@ -101,13 +101,25 @@ func (h *Handler) GetRobboUnitsByUnitAdminId(c *gin.Context) {
 	fmt.Println("GetRobboUnitsByUnitAdminId")
 
 	id, role, identityErr := h.authDelegate.UserIdentity(c)
-
-	if identityErr != nil || role != models.UnitAdmin {
+	if identityErr != nil {
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
 
-	robboUnits, err := h.robboUnitsDelegate.GetRobboUnitsByUnitAdminId(id)
+	// every admin gets the units they manage
+	var robboUnits []*models.RobboUnitHTTP
+	var err error
+	switch role {
+	case models.UnitAdmin:
+		robboUnits, err = h.robboUnitsDelegate.GetRobboUnitsByUnitAdminId(id)
+	case models.RegionAdmin:
+		robboUnits, err = h.robboUnitsDelegate.GetRobboUnitsByRegionAdminId(id)
+	case models.SuperAdmin:
+		robboUnits, err = h.robboUnitsDelegate.GetAllRobboUnit()
+	default:
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
 	if err != nil {
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
@@ -8,5 +8,7 @@ type UseCase interface {
 	GetAllRobboUnit() (robboUnits []*models.RobboUnitCore, err error)
 	GetRobboUnitById(robboUnitId string) (robboUnit *models.RobboUnitCore, err error)
 	GetRobboUnitsByUnitAdminId(unitAdminId string) (robboUnits []*models.RobboUnitCore, err error)
+	GetRobboUnitsByRegionAdminId(regionAdminId string) (robboUnits []*models.RobboUnitCore, err error)
 	UpdateRobboUnit(robboUnit *models.RobboUnitCore) (err error)
+	SearchNearestRobboUnits(search *models.RobboUnitSearchCore) (robboUnits []*models.NearbyRobboUnitCore, err error)
 }
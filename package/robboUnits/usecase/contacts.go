@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxPhones       = 5
+	maxWorkingHours = 512
+)
+
+// normalizeContacts trims the phones and working hours of the unit and checks them. Blank phones
+// are dropped, a nil list stays nil so that an update keeps the phones the unit has.
+func normalizeContacts(robboUnit *models.RobboUnitCore) (err error) {
+	robboUnit.WorkingHours = strings.TrimSpace(robboUnit.WorkingHours)
+	if utf8.RuneCountInString(robboUnit.WorkingHours) > maxWorkingHours {
+		return robboUnits.ErrBadWorkingHours
+	}
+	if robboUnit.Phones == nil {
+		return
+	}
+	phones := make([]string, 0, len(robboUnit.Phones))
+	for _, phone := range robboUnit.Phones {
+		if phone = strings.TrimSpace(phone); phone == "" {
+			continue
+		}
+		if !validPhone(phone) {
+			return robboUnits.ErrBadPhone
+		}
+		phones = append(phones, phone)
+	}
+	if len(phones) > maxPhones {
+		return robboUnits.ErrTooManyPhones
+	}
+	robboUnit.Phones = phones
+	return
+}
+
+func validPhone(phone string) bool {
+	digits := 0
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return digits >= 5 && digits <= 15
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeContacts(t *testing.T) {
+	robboUnit := &models.RobboUnitCore{
+		Phones:       []string{" +7 (495) 123-45-67 ", "", "8-800-555-35-35"},
+		WorkingHours: " Mon-Fri 10:00-20:00 ",
+	}
+	assert.NoError(t, normalizeContacts(robboUnit))
+	assert.Equal(t, []string{"+7 (495) 123-45-67", "8-800-555-35-35"}, robboUnit.Phones)
+	assert.Equal(t, "Mon-Fri 10:00-20:00", robboUnit.WorkingHours)
+
+	kept := &models.RobboUnitCore{}
+	assert.NoError(t, normalizeContacts(kept))
+	assert.Nil(t, kept.Phones, "an update without phones keeps the phones of the unit")
+
+	cleared := &models.RobboUnitCore{Phones: []string{" "}}
+	assert.NoError(t, normalizeContacts(cleared))
+	assert.NotNil(t, cleared.Phones)
+	assert.Empty(t, cleared.Phones)
+
+	for _, phone := range []string{"1234", "call me", "+7 495 123,45,67", "7+4951234567", "1234567890123456"} {
+		assert.Equal(t, robboUnits.ErrBadPhone, normalizeContacts(&models.RobboUnitCore{Phones: []string{phone}}), phone)
+	}
+	assert.Equal(t, robboUnits.ErrTooManyPhones, normalizeContacts(&models.RobboUnitCore{
+		Phones: []string{"11111", "22222", "33333", "44444", "55555", "66666"},
+	}))
+	assert.Equal(t, robboUnits.ErrBadWorkingHours, normalizeContacts(&models.RobboUnitCore{
+		WorkingHours: strings.Repeat("ч", maxWorkingHours+1),
+	}))
+}
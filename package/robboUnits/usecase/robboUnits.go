@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/regions"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
 	"go.uber.org/fx"
@@ -10,6 +11,7 @@ import (
 type RobboUnitsUseCaseImpl struct {
 	robboUnitsGateway robboUnits.Gateway
 	usersGateway      users.Gateway
+	regionsGateway    regions.Gateway
 }
 
 type RobboUnitsUseCaseModule struct {
@@ -17,16 +19,23 @@ type RobboUnitsUseCaseModule struct {
 	robboUnits.UseCase
 }
 
-func SetupRobboUnitsUseCase(robboUnitsGateway robboUnits.Gateway, usersGateway users.Gateway) RobboUnitsUseCaseModule {
+func SetupRobboUnitsUseCase(robboUnitsGateway robboUnits.Gateway, usersGateway users.Gateway, regionsGateway regions.Gateway) RobboUnitsUseCaseModule {
 	return RobboUnitsUseCaseModule{
 		UseCase: &RobboUnitsUseCaseImpl{
 			robboUnitsGateway,
 			usersGateway,
+			regionsGateway,
 		},
 	}
 }
 
 func (p *RobboUnitsUseCaseImpl) CreateRobboUnit(robboUnit *models.RobboUnitCore) (robboUnitId string, err error) {
+	if err = validateCoordinates(robboUnit.Latitude, robboUnit.Longitude); err != nil {
+		return
+	}
+	if err = normalizeContacts(robboUnit); err != nil {
+		return
+	}
 	return p.robboUnitsGateway.CreateRobboUnit(robboUnit)
 }
 
@@ -60,6 +69,20 @@ func (p *RobboUnitsUseCaseImpl) GetRobboUnitsByUnitAdminId(unitAdminId string) (
 	return
 }
 
+func (p *RobboUnitsUseCaseImpl) GetRobboUnitsByRegionAdminId(regionAdminId string) (robboUnits []*models.RobboUnitCore, err error) {
+	regionAdmin, err := p.usersGateway.GetRegionAdminById(regionAdminId)
+	if err != nil {
+		return
+	}
+	return p.regionsGateway.GetRobboUnitsByRegionId(regionAdmin.RegionId)
+}
+
 func (p *RobboUnitsUseCaseImpl) UpdateRobboUnit(robboUnit *models.RobboUnitCore) (err error) {
+	if err = validateCoordinates(robboUnit.Latitude, robboUnit.Longitude); err != nil {
+		return
+	}
+	if err = normalizeContacts(robboUnit); err != nil {
+		return
+	}
 	return p.robboUnitsGateway.UpdateRobboUnit(robboUnit)
 }
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"strings"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+func (p *RobboUnitsUseCaseImpl) SearchNearestRobboUnits(search *models.RobboUnitSearchCore) (robboUnitsCore []*models.NearbyRobboUnitCore, err error) {
+	if err = normalizeSearch(search); err != nil {
+		return
+	}
+	return p.robboUnitsGateway.SearchRobboUnits(search)
+}
+
+func validateCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil ||
+		*latitude < -90 || *latitude > 90 || *longitude < -180 || *longitude > 180 {
+		return robboUnits.ErrBadCoordinates
+	}
+	return nil
+}
+
+// normalizeSearch checks that the search has an origin and clamps its limit.
+func normalizeSearch(search *models.RobboUnitSearchCore) (err error) {
+	if err = validateCoordinates(search.Latitude, search.Longitude); err != nil {
+		return
+	}
+	search.City = strings.TrimSpace(search.City)
+	if search.Latitude == nil && search.City == "" {
+		return robboUnits.ErrNoSearchOrigin
+	}
+	if search.MaxDistanceKm != nil && *search.MaxDistanceKm <= 0 {
+		return robboUnits.ErrBadSearchRadius
+	}
+	if search.Limit <= 0 {
+		search.Limit = defaultSearchLimit
+	}
+	if search.Limit > maxSearchLimit {
+		search.Limit = maxSearchLimit
+	}
+	return
+}
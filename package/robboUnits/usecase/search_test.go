@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboUnits"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeSearch(t *testing.T) {
+	latitude, longitude := 55.75, 37.62
+
+	search := &models.RobboUnitSearchCore{Latitude: &latitude, Longitude: &longitude}
+	assert.NoError(t, normalizeSearch(search))
+	assert.Equal(t, defaultSearchLimit, search.Limit)
+
+	search = &models.RobboUnitSearchCore{City: " Moscow ", Limit: 1000}
+	assert.NoError(t, normalizeSearch(search))
+	assert.Equal(t, "Moscow", search.City)
+	assert.Equal(t, maxSearchLimit, search.Limit)
+
+	assert.Equal(t, robboUnits.ErrNoSearchOrigin, normalizeSearch(&models.RobboUnitSearchCore{City: "  "}))
+	assert.Equal(t, robboUnits.ErrBadCoordinates, normalizeSearch(&models.RobboUnitSearchCore{Latitude: &latitude}))
+
+	tooFar := 120.0
+	assert.Equal(t, robboUnits.ErrBadCoordinates, normalizeSearch(&models.RobboUnitSearchCore{Latitude: &tooFar, Longitude: &longitude}))
+
+	radius := 0.0
+	assert.Equal(t, robboUnits.ErrBadSearchRadius, normalizeSearch(&models.RobboUnitSearchCore{City: "Moscow", MaxDistanceKm: &radius}))
+}
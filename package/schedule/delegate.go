@@ -0,0 +1,19 @@
+package schedule
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	CreateScheduleSlot(slot *models.NewScheduleSlot) (newSlot *models.ScheduleSlotHTTP, err error)
+	DeleteScheduleSlot(slotId string) (err error)
+	GetScheduleSlotById(slotId string) (slot *models.ScheduleSlotHTTP, err error)
+	GetScheduleSlotsByRobboGroupId(robboGroupId string) (slots []*models.ScheduleSlotHTTP, err error)
+
+	GenerateLessons(robboGroupId, from, to string) (lessons []*models.LessonHTTP, err error)
+	CancelLesson(lessonId, reason string) (lesson *models.LessonHTTP, err error)
+	RescheduleLesson(lessonId, startAt, endAt, room string) (lesson *models.LessonHTTP, err error)
+	GetLessonById(lessonId string) (lesson *models.LessonHTTP, err error)
+	GetLessonsByRobboGroupId(robboGroupId, from, to string) (lessons []*models.LessonHTTP, err error)
+	GetUpcomingLessonsByTeacherId(teacherId string, days int) (lessons []*models.LessonHTTP, err error)
+	GetUpcomingLessonsByStudentId(studentId string, days int) (lessons []*models.LessonHTTP, err error)
+	GetUpcomingLessonsByParentId(parentId string, days int) (lessons []*models.LessonHTTP, err error)
+}
@@ -0,0 +1,181 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"go.uber.org/fx"
+	"time"
+)
+
+type ScheduleDelegateImpl struct {
+	UseCase schedule.UseCase
+}
+
+type ScheduleDelegateModule struct {
+	fx.Out
+	schedule.Delegate
+}
+
+func SetupScheduleDelegate(usecase schedule.UseCase) ScheduleDelegateModule {
+	return ScheduleDelegateModule{
+		Delegate: &ScheduleDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *ScheduleDelegateImpl) CreateScheduleSlot(slot *models.NewScheduleSlot) (newSlot *models.ScheduleSlotHTTP, err error) {
+	slotCore, err := p.UseCase.CreateScheduleSlot(slot.ToCore())
+	if err != nil {
+		return
+	}
+	newSlot = &models.ScheduleSlotHTTP{}
+	newSlot.FromCore(slotCore)
+	return
+}
+
+func (p *ScheduleDelegateImpl) DeleteScheduleSlot(slotId string) (err error) {
+	return p.UseCase.DeleteScheduleSlot(slotId)
+}
+
+func (p *ScheduleDelegateImpl) GetScheduleSlotById(slotId string) (slot *models.ScheduleSlotHTTP, err error) {
+	slotCore, err := p.UseCase.GetScheduleSlotById(slotId)
+	if err != nil {
+		return
+	}
+	slot = &models.ScheduleSlotHTTP{}
+	slot.FromCore(slotCore)
+	return
+}
+
+func (p *ScheduleDelegateImpl) GetScheduleSlotsByRobboGroupId(robboGroupId string) (slots []*models.ScheduleSlotHTTP, err error) {
+	slotsCore, err := p.UseCase.GetScheduleSlotsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	for _, slotCore := range slotsCore {
+		var slotTemp models.ScheduleSlotHTTP
+		slotTemp.FromCore(slotCore)
+		slots = append(slots, &slotTemp)
+	}
+	return
+}
+
+// GenerateLessons takes an empty from or to for the start or the end of the term of the group.
+func (p *ScheduleDelegateImpl) GenerateLessons(robboGroupId, from, to string) (lessons []*models.LessonHTTP, err error) {
+	var fromTime, toTime time.Time
+	if fromTime, err = parseOptionalTime(from); err != nil {
+		return
+	}
+	if toTime, err = parseOptionalTime(to); err != nil {
+		return
+	}
+	lessonsCore, err := p.UseCase.GenerateLessons(robboGroupId, fromTime, toTime)
+	if err != nil {
+		return
+	}
+	return lessonsToHTTP(lessonsCore), nil
+}
+
+func (p *ScheduleDelegateImpl) CancelLesson(lessonId, reason string) (lesson *models.LessonHTTP, err error) {
+	lessonCore, err := p.UseCase.CancelLesson(lessonId, reason)
+	if err != nil {
+		return
+	}
+	lesson = &models.LessonHTTP{}
+	lesson.FromCore(lessonCore)
+	return
+}
+
+func (p *ScheduleDelegateImpl) RescheduleLesson(lessonId, startAt, endAt, room string) (lesson *models.LessonHTTP, err error) {
+	startAtTime, endAtTime, err := parsePeriod(startAt, endAt)
+	if err != nil {
+		return
+	}
+	lessonCore, err := p.UseCase.RescheduleLesson(lessonId, startAtTime, endAtTime, room)
+	if err != nil {
+		return
+	}
+	lesson = &models.LessonHTTP{}
+	lesson.FromCore(lessonCore)
+	return
+}
+
+func (p *ScheduleDelegateImpl) GetLessonById(lessonId string) (lesson *models.LessonHTTP, err error) {
+	lessonCore, err := p.UseCase.GetLessonById(lessonId)
+	if err != nil {
+		return
+	}
+	lesson = &models.LessonHTTP{}
+	lesson.FromCore(lessonCore)
+	return
+}
+
+func (p *ScheduleDelegateImpl) GetLessonsByRobboGroupId(robboGroupId, from, to string) (lessons []*models.LessonHTTP, err error) {
+	fromTime, toTime, err := parsePeriod(from, to)
+	if err != nil {
+		return
+	}
+	lessonsCore, err := p.UseCase.GetLessonsByRobboGroupId(robboGroupId, fromTime, toTime)
+	if err != nil {
+		return
+	}
+	return lessonsToHTTP(lessonsCore), nil
+}
+
+func (p *ScheduleDelegateImpl) GetUpcomingLessonsByTeacherId(teacherId string, days int) (lessons []*models.LessonHTTP, err error) {
+	lessonsCore, err := p.UseCase.GetUpcomingLessonsByTeacherId(teacherId, days)
+	if err != nil {
+		return
+	}
+	return lessonsToHTTP(lessonsCore), nil
+}
+
+func (p *ScheduleDelegateImpl) GetUpcomingLessonsByStudentId(studentId string, days int) (lessons []*models.LessonHTTP, err error) {
+	lessonsCore, err := p.UseCase.GetUpcomingLessonsByStudentId(studentId, days)
+	if err != nil {
+		return
+	}
+	return lessonsToHTTP(lessonsCore), nil
+}
+
+func (p *ScheduleDelegateImpl) GetUpcomingLessonsByParentId(parentId string, days int) (lessons []*models.LessonHTTP, err error) {
+	lessonsCore, err := p.UseCase.GetUpcomingLessonsByParentId(parentId, days)
+	if err != nil {
+		return
+	}
+	return lessonsToHTTP(lessonsCore), nil
+}
+
+func parsePeriod(from, to string) (fromTime, toTime time.Time, err error) {
+	fromTime, err = time.Parse(time.RFC3339, from)
+	if err != nil {
+		err = schedule.ErrBadTimestampFormat
+		return
+	}
+	toTime, err = time.Parse(time.RFC3339, to)
+	if err != nil {
+		err = schedule.ErrBadTimestampFormat
+	}
+	return
+}
+
+func parseOptionalTime(value string) (parsed time.Time, err error) {
+	if value == "" {
+		return
+	}
+	if parsed, err = time.Parse(time.RFC3339, value); err != nil {
+		err = schedule.ErrBadTimestampFormat
+	}
+	return
+}
+
+func lessonsToHTTP(lessonsCore []*models.LessonCore) (lessons []*models.LessonHTTP) {
+	lessons = make([]*models.LessonHTTP, 0, len(lessonsCore))
+	for _, lessonCore := range lessonsCore {
+		var lessonTemp models.LessonHTTP
+		lessonTemp.FromCore(lessonCore)
+		lessons = append(lessons, &lessonTemp)
+	}
+	return
+}
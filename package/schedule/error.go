@@ -0,0 +1,14 @@
+package schedule
+
+import "errors"
+
+var (
+	ErrBadTimeFormat      = errors.New("time must be in HH:MM format")
+	ErrBadTimestampFormat = errors.New("timestamp must be in RFC3339 format")
+	ErrBadWeekday         = errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+	ErrBadTimeRange       = errors.New("end must be after start")
+	ErrLessonNotFound     = errors.New("lesson not found")
+	ErrSlotNotFound       = errors.New("schedule slot not found")
+	ErrLessonCancelled    = errors.New("lesson is cancelled")
+	ErrRobboGroupArchived = errors.New("robbo group belongs to an archived term")
+)
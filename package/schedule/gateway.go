@@ -0,0 +1,19 @@
+package schedule
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type Gateway interface {
+	CreateScheduleSlot(slot *models.ScheduleSlotCore) (slotId string, err error)
+	DeleteScheduleSlot(slotId string) (err error)
+	GetScheduleSlotById(slotId string) (slot *models.ScheduleSlotCore, err error)
+	GetScheduleSlotsByRobboGroupId(robboGroupId string) (slots []*models.ScheduleSlotCore, err error)
+
+	CreateLessons(lessons []*models.LessonCore) (err error)
+	GetLessonById(lessonId string) (lesson *models.LessonCore, err error)
+	UpdateLesson(lesson *models.LessonCore) (err error)
+	GetLessonsByRobboGroupIds(robboGroupIds []string, from, to time.Time) (lessons []*models.LessonCore, err error)
+	GetLessonsByTeacherId(teacherId string, from, to time.Time) (lessons []*models.LessonCore, err error)
+}
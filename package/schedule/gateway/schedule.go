@@ -0,0 +1,156 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"strconv"
+	"time"
+)
+
+type ScheduleGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type ScheduleGatewayModule struct {
+	fx.Out
+	schedule.Gateway
+}
+
+func SetupScheduleGateway(postgresClient db_client.PostgresClient) ScheduleGatewayModule {
+	return ScheduleGatewayModule{
+		Gateway: &ScheduleGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+func (r *ScheduleGatewayImpl) CreateScheduleSlot(slot *models.ScheduleSlotCore) (slotId string, err error) {
+	slotDb := models.ScheduleSlotDB{}
+	slotDb.FromCore(slot)
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Create(&slotDb).Error
+		return
+	})
+
+	slotId = strconv.FormatUint(uint64(slotDb.ID), 10)
+	return
+}
+
+// DeleteScheduleSlot removes the slot together with its lessons that have not taken place yet.
+func (r *ScheduleGatewayImpl) DeleteScheduleSlot(slotId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("schedule_slot_id = ? AND start_at > ? AND status = ?", slotId, time.Now(), models.LessonScheduled).
+			Delete(&models.LessonDB{}).Error; err != nil {
+			return
+		}
+		err = tx.Delete(&models.ScheduleSlotDB{}, slotId).Error
+		return
+	})
+	return
+}
+
+func (r *ScheduleGatewayImpl) GetScheduleSlotById(slotId string) (slot *models.ScheduleSlotCore, err error) {
+	var slotDb models.ScheduleSlotDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", slotId).First(&slotDb).Error; err != nil {
+			log.Println(err)
+			return schedule.ErrSlotNotFound
+		}
+		return
+	})
+	slot = slotDb.ToCore()
+	return
+}
+
+func (r *ScheduleGatewayImpl) GetScheduleSlotsByRobboGroupId(robboGroupId string) (slots []*models.ScheduleSlotCore, err error) {
+	var slotsDb []*models.ScheduleSlotDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("robbo_group_id = ?", robboGroupId).Order("weekday, start_time").Find(&slotsDb).Error
+		return
+	})
+
+	for _, slotDb := range slotsDb {
+		slots = append(slots, slotDb.ToCore())
+	}
+	return
+}
+
+// CreateLessons inserts generated lessons, silently skipping the ones already generated for the same slot and date.
+func (r *ScheduleGatewayImpl) CreateLessons(lessons []*models.LessonCore) (err error) {
+	if len(lessons) == 0 {
+		return
+	}
+	var lessonsDb []*models.LessonDB
+	for _, lesson := range lessons {
+		lessonDb := models.LessonDB{}
+		lessonDb.FromCore(lesson)
+		lessonsDb = append(lessonsDb, &lessonDb)
+	}
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&lessonsDb).Error
+		return
+	})
+	return
+}
+
+func (r *ScheduleGatewayImpl) GetLessonById(lessonId string) (lesson *models.LessonCore, err error) {
+	var lessonDb models.LessonDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", lessonId).First(&lessonDb).Error; err != nil {
+			log.Println(err)
+			return schedule.ErrLessonNotFound
+		}
+		return
+	})
+	lesson = lessonDb.ToCore()
+	return
+}
+
+func (r *ScheduleGatewayImpl) UpdateLesson(lesson *models.LessonCore) (err error) {
+	lessonDb := models.LessonDB{}
+	lessonDb.FromCore(lesson)
+
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Model(&lessonDb).Where("id = ?", lessonDb.ID).Updates(lessonDb).Error
+		return
+	})
+	return
+}
+
+func (r *ScheduleGatewayImpl) GetLessonsByRobboGroupIds(robboGroupIds []string, from, to time.Time) (lessons []*models.LessonCore, err error) {
+	if len(robboGroupIds) == 0 {
+		return
+	}
+	var lessonsDb []*models.LessonDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("robbo_group_id IN ? AND start_at >= ? AND start_at < ?", robboGroupIds, from, to).
+			Order("start_at").
+			Find(&lessonsDb).Error
+		return
+	})
+
+	for _, lessonDb := range lessonsDb {
+		lessons = append(lessons, lessonDb.ToCore())
+	}
+	return
+}
+
+func (r *ScheduleGatewayImpl) GetLessonsByTeacherId(teacherId string, from, to time.Time) (lessons []*models.LessonCore, err error) {
+	var lessonsDb []*models.LessonDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("teacher_id = ? AND start_at >= ? AND start_at < ?", teacherId, from, to).
+			Order("start_at").
+			Find(&lessonsDb).Error
+		return
+	})
+
+	for _, lessonDb := range lessonsDb {
+		lessons = append(lessons, lessonDb.ToCore())
+	}
+	return
+}
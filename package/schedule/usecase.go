@@ -0,0 +1,22 @@
+package schedule
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"time"
+)
+
+type UseCase interface {
+	CreateScheduleSlot(slot *models.ScheduleSlotCore) (newSlot *models.ScheduleSlotCore, err error)
+	DeleteScheduleSlot(slotId string) (err error)
+	GetScheduleSlotById(slotId string) (slot *models.ScheduleSlotCore, err error)
+	GetScheduleSlotsByRobboGroupId(robboGroupId string) (slots []*models.ScheduleSlotCore, err error)
+
+	GenerateLessons(robboGroupId string, from, to time.Time) (lessons []*models.LessonCore, err error)
+	CancelLesson(lessonId, reason string) (lesson *models.LessonCore, err error)
+	RescheduleLesson(lessonId string, startAt, endAt time.Time, room string) (lesson *models.LessonCore, err error)
+	GetLessonById(lessonId string) (lesson *models.LessonCore, err error)
+	GetLessonsByRobboGroupId(robboGroupId string, from, to time.Time) (lessons []*models.LessonCore, err error)
+	GetUpcomingLessonsByTeacherId(teacherId string, days int) (lessons []*models.LessonCore, err error)
+	GetUpcomingLessonsByStudentId(studentId string, days int) (lessons []*models.LessonCore, err error)
+	GetUpcomingLessonsByParentId(parentId string, days int) (lessons []*models.LessonCore, err error)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"time"
+)
+
+func parseClock(clock string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, schedule.ErrBadTimeFormat
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func validateSlot(slot *models.ScheduleSlotCore) (err error) {
+	if slot.Weekday < time.Sunday || slot.Weekday > time.Saturday {
+		return schedule.ErrBadWeekday
+	}
+	startHour, startMinute, err := parseClock(slot.StartTime)
+	if err != nil {
+		return
+	}
+	endHour, endMinute, err := parseClock(slot.EndTime)
+	if err != nil {
+		return
+	}
+	if endHour*60+endMinute <= startHour*60+startMinute {
+		return schedule.ErrBadTimeRange
+	}
+	return
+}
+
+// generateLessons expands weekly slots into concrete lessons in [from, to).
+// Slot times are wall-clock times of the given location, so lessons keep
+// their local hour across daylight saving changes.
+func generateLessons(slots []*models.ScheduleSlotCore, from, to time.Time, location *time.Location) (lessons []*models.LessonCore) {
+	from = from.In(location)
+	to = to.In(location)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, slot := range slots {
+			if day.Weekday() != slot.Weekday {
+				continue
+			}
+			startHour, startMinute, err := parseClock(slot.StartTime)
+			if err != nil {
+				continue
+			}
+			endHour, endMinute, err := parseClock(slot.EndTime)
+			if err != nil {
+				continue
+			}
+			startAt := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, location)
+			if startAt.Before(from) || !startAt.Before(to) {
+				continue
+			}
+			lessons = append(lessons, &models.LessonCore{
+				RobboGroupId:    slot.RobboGroupId,
+				ScheduleSlotId:  slot.Id,
+				TeacherId:       slot.TeacherId,
+				Room:            slot.Room,
+				StartAt:         startAt,
+				EndAt:           time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, location),
+				OriginalStartAt: startAt,
+				Status:          models.LessonScheduled,
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGenerateLessons(t *testing.T) {
+	location := time.FixedZone("MSK", 3*60*60)
+	slots := []*models.ScheduleSlotCore{
+		{Id: "1", RobboGroupId: "7", Weekday: time.Monday, StartTime: "10:00", EndTime: "11:30", TeacherId: "3", Room: "101"},
+		{Id: "2", RobboGroupId: "7", Weekday: time.Wednesday, StartTime: "18:00", EndTime: "19:00"},
+	}
+	// 2022-10-03 is a Monday
+	from := time.Date(2022, 10, 3, 10, 30, 0, 0, location)
+	to := time.Date(2022, 10, 17, 10, 0, 0, 0, location)
+
+	lessons := generateLessons(slots, from, to, location)
+
+	assert.Len(t, lessons, 3)
+	assert.Equal(t, time.Date(2022, 10, 5, 18, 0, 0, 0, location), lessons[0].StartAt)
+	assert.Equal(t, "2", lessons[0].ScheduleSlotId)
+	assert.Equal(t, time.Date(2022, 10, 10, 10, 0, 0, 0, location), lessons[1].StartAt)
+	assert.Equal(t, time.Date(2022, 10, 10, 11, 30, 0, 0, location), lessons[1].EndAt)
+	assert.Equal(t, "101", lessons[1].Room)
+	assert.Equal(t, models.LessonScheduled, lessons[1].Status)
+	assert.Equal(t, lessons[1].StartAt, lessons[1].OriginalStartAt)
+	assert.Equal(t, time.Date(2022, 10, 12, 18, 0, 0, 0, location), lessons[2].StartAt)
+}
+
+func TestValidateSlot(t *testing.T) {
+	assert.NoError(t, validateSlot(&models.ScheduleSlotCore{Weekday: time.Friday, StartTime: "09:00", EndTime: "09:45"}))
+	assert.Equal(t, schedule.ErrBadTimeFormat, validateSlot(&models.ScheduleSlotCore{StartTime: "9", EndTime: "10:00"}))
+	assert.Equal(t, schedule.ErrBadTimeRange, validateSlot(&models.ScheduleSlotCore{StartTime: "10:00", EndTime: "10:00"}))
+	assert.Equal(t, schedule.ErrBadWeekday, validateSlot(&models.ScheduleSlotCore{Weekday: 7, StartTime: "10:00", EndTime: "11:00"}))
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"log"
+	"time"
+)
+
+type ScheduleUseCaseImpl struct {
+	scheduleGateway   schedule.Gateway
+	usersGateway      users.Gateway
+	robboGroupGateway robboGroup.Gateway
+	termsGateway      terms.Gateway
+	location          *time.Location
+	upcomingDays      int
+}
+
+type ScheduleUseCaseModule struct {
+	fx.Out
+	schedule.UseCase
+}
+
+func SetupScheduleUseCase(
+	scheduleGateway schedule.Gateway,
+	usersGateway users.Gateway,
+	robboGroupGateway robboGroup.Gateway,
+	termsGateway terms.Gateway,
+) ScheduleUseCaseModule {
+	location, err := time.LoadLocation(viper.GetString("schedule.timezone"))
+	if err != nil {
+		log.Println(err)
+		location = time.Local
+	}
+	return ScheduleUseCaseModule{
+		UseCase: &ScheduleUseCaseImpl{
+			scheduleGateway:   scheduleGateway,
+			usersGateway:      usersGateway,
+			robboGroupGateway: robboGroupGateway,
+			termsGateway:      termsGateway,
+			location:          location,
+			upcomingDays:      viper.GetInt("schedule.upcoming_days"),
+		},
+	}
+}
+
+func (p *ScheduleUseCaseImpl) CreateScheduleSlot(slot *models.ScheduleSlotCore) (newSlot *models.ScheduleSlotCore, err error) {
+	if err = validateSlot(slot); err != nil {
+		return
+	}
+	if err = p.ensureNotArchived(slot.RobboGroupId); err != nil {
+		return
+	}
+	slotId, err := p.scheduleGateway.CreateScheduleSlot(slot)
+	if err != nil {
+		return
+	}
+	newSlot = slot
+	newSlot.Id = slotId
+	return
+}
+
+func (p *ScheduleUseCaseImpl) DeleteScheduleSlot(slotId string) (err error) {
+	slot, err := p.scheduleGateway.GetScheduleSlotById(slotId)
+	if err != nil {
+		return
+	}
+	if err = p.ensureNotArchived(slot.RobboGroupId); err != nil {
+		return
+	}
+	return p.scheduleGateway.DeleteScheduleSlot(slotId)
+}
+
+func (p *ScheduleUseCaseImpl) GetScheduleSlotById(slotId string) (slot *models.ScheduleSlotCore, err error) {
+	return p.scheduleGateway.GetScheduleSlotById(slotId)
+}
+
+func (p *ScheduleUseCaseImpl) GetScheduleSlotsByRobboGroupId(robboGroupId string) (slots []*models.ScheduleSlotCore, err error) {
+	return p.scheduleGateway.GetScheduleSlotsByRobboGroupId(robboGroupId)
+}
+
+// GenerateLessons makes the lessons of the weekly slots of the group within the term of the group.
+// A zero from or to stands for the start or the end of the term, a period reaching out of the term is cut
+// to it, so lessons never run into the group the next term has cloned.
+func (p *ScheduleUseCaseImpl) GenerateLessons(robboGroupId string, from, to time.Time) (lessons []*models.LessonCore, err error) {
+	if err = p.ensureNotArchived(robboGroupId); err != nil {
+		return
+	}
+	if from, to, err = p.termPeriod(robboGroupId, from, to); err != nil {
+		return
+	}
+	if !to.After(from) {
+		return nil, schedule.ErrBadTimeRange
+	}
+	slots, err := p.scheduleGateway.GetScheduleSlotsByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	if err = p.scheduleGateway.CreateLessons(generateLessons(slots, from, to, p.location)); err != nil {
+		return
+	}
+	return p.scheduleGateway.GetLessonsByRobboGroupIds([]string{robboGroupId}, from, to)
+}
+
+func (p *ScheduleUseCaseImpl) CancelLesson(lessonId, reason string) (lesson *models.LessonCore, err error) {
+	lesson, err = p.scheduleGateway.GetLessonById(lessonId)
+	if err != nil {
+		return
+	}
+	if err = p.ensureNotArchived(lesson.RobboGroupId); err != nil {
+		return nil, err
+	}
+	lesson.Status = models.LessonCancelled
+	lesson.CancelReason = reason
+	err = p.scheduleGateway.UpdateLesson(lesson)
+	return
+}
+
+func (p *ScheduleUseCaseImpl) RescheduleLesson(lessonId string, startAt, endAt time.Time, room string) (lesson *models.LessonCore, err error) {
+	if !endAt.After(startAt) {
+		return nil, schedule.ErrBadTimeRange
+	}
+	lesson, err = p.scheduleGateway.GetLessonById(lessonId)
+	if err != nil {
+		return
+	}
+	if lesson.Status == models.LessonCancelled {
+		return nil, schedule.ErrLessonCancelled
+	}
+	if err = p.ensureNotArchived(lesson.RobboGroupId); err != nil {
+		return nil, err
+	}
+	lesson.StartAt = startAt
+	lesson.EndAt = endAt
+	if room != "" {
+		lesson.Room = room
+	}
+	lesson.Status = models.LessonRescheduled
+	err = p.scheduleGateway.UpdateLesson(lesson)
+	return
+}
+
+func (p *ScheduleUseCaseImpl) GetLessonById(lessonId string) (lesson *models.LessonCore, err error) {
+	return p.scheduleGateway.GetLessonById(lessonId)
+}
+
+func (p *ScheduleUseCaseImpl) GetLessonsByRobboGroupId(robboGroupId string, from, to time.Time) (lessons []*models.LessonCore, err error) {
+	if !to.After(from) {
+		return nil, schedule.ErrBadTimeRange
+	}
+	return p.scheduleGateway.GetLessonsByRobboGroupIds([]string{robboGroupId}, from, to)
+}
+
+func (p *ScheduleUseCaseImpl) GetUpcomingLessonsByTeacherId(teacherId string, days int) (lessons []*models.LessonCore, err error) {
+	from, to := p.upcomingPeriod(days)
+	return p.scheduleGateway.GetLessonsByTeacherId(teacherId, from, to)
+}
+
+func (p *ScheduleUseCaseImpl) GetUpcomingLessonsByStudentId(studentId string, days int) (lessons []*models.LessonCore, err error) {
+	robboGroupIds, err := p.robboGroupIdsOf(studentId)
+	if err != nil {
+		return
+	}
+	from, to := p.upcomingPeriod(days)
+	return p.scheduleGateway.GetLessonsByRobboGroupIds(robboGroupIds, from, to)
+}
+
+func (p *ScheduleUseCaseImpl) GetUpcomingLessonsByParentId(parentId string, days int) (lessons []*models.LessonCore, err error) {
+	relations, err := p.usersGateway.GetRelationByParentId(parentId)
+	if err != nil {
+		return
+	}
+	var robboGroupIds []string
+	for _, relation := range relations {
+		childGroupIds, getGroupsErr := p.robboGroupIdsOf(relation.ChildId)
+		if getGroupsErr != nil {
+			return nil, getGroupsErr
+		}
+		robboGroupIds = append(robboGroupIds, childGroupIds...)
+	}
+	from, to := p.upcomingPeriod(days)
+	return p.scheduleGateway.GetLessonsByRobboGroupIds(robboGroupIds, from, to)
+}
+
+// ensureNotArchived keeps the timetable of groups of archived terms read-only.
+func (p *ScheduleUseCaseImpl) ensureNotArchived(robboGroupId string) (err error) {
+	robboGroupCore, err := p.robboGroupGateway.GetRobboGroupById(robboGroupId)
+	if err != nil {
+		return
+	}
+	if robboGroupCore.Archived {
+		return schedule.ErrRobboGroupArchived
+	}
+	return
+}
+
+// termPeriod fits the period into the term of the group. Groups created before terms keep the period as it is.
+func (p *ScheduleUseCaseImpl) termPeriod(robboGroupId string, from, to time.Time) (termFrom, termTo time.Time, err error) {
+	robboGroupCore, err := p.robboGroupGateway.GetRobboGroupById(robboGroupId)
+	if err != nil || robboGroupCore.TermId == "" {
+		return from, to, err
+	}
+	term, err := p.termsGateway.GetTermById(robboGroupCore.TermId)
+	if err != nil {
+		return
+	}
+	if from.IsZero() || from.Before(term.StartDate) {
+		from = term.StartDate
+	}
+	if to.IsZero() || to.After(term.EndDate) {
+		to = term.EndDate
+	}
+	return from, to, nil
+}
+
+func (p *ScheduleUseCaseImpl) upcomingPeriod(days int) (from, to time.Time) {
+	if days <= 0 {
+		days = p.upcomingDays
+	}
+	from = time.Now()
+	to = from.AddDate(0, 0, days)
+	return
+}
+
+// robboGroupIdsOf lists every group the student currently studies in, the primary one included.
+func (p *ScheduleUseCaseImpl) robboGroupIdsOf(studentId string) (robboGroupIds []string, err error) {
+	student, err := p.usersGateway.GetStudentById(studentId)
+	if err != nil {
+		return
+	}
+	memberships, err := p.usersGateway.GetGroupMembershipsByStudentId(studentId, true)
+	if err != nil {
+		return
+	}
+	seen := make(map[string]bool)
+	if student.RobboGroupId != "" && student.RobboGroupId != "0" {
+		seen[student.RobboGroupId] = true
+		robboGroupIds = append(robboGroupIds, student.RobboGroupId)
+	}
+	for _, membership := range memberships {
+		if !seen[membership.RobboGroupId] {
+			seen[membership.RobboGroupId] = true
+			robboGroupIds = append(robboGroupIds, membership.RobboGroupId)
+		}
+	}
+	return
+}
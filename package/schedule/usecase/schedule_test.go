@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/robboGroup"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/schedule"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeLessons struct {
+	schedule.Gateway
+	lesson  *models.LessonCore
+	updated []*models.LessonCore
+}
+
+func (f *fakeLessons) GetLessonById(lessonId string) (*models.LessonCore, error) {
+	copied := *f.lesson
+	return &copied, nil
+}
+
+func (f *fakeLessons) UpdateLesson(lesson *models.LessonCore) error {
+	f.updated = append(f.updated, lesson)
+	return nil
+}
+
+type fakeRobboGroups struct {
+	robboGroup.Gateway
+	archived map[string]bool
+}
+
+func (f *fakeRobboGroups) GetRobboGroupById(robboGroupId string) (*models.RobboGroupCore, error) {
+	return &models.RobboGroupCore{Id: robboGroupId, Archived: f.archived[robboGroupId]}, nil
+}
+
+func TestArchivedLessonsStayAsTheyAre(t *testing.T) {
+	startAt := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+	lessons := &fakeLessons{lesson: &models.LessonCore{Id: "1", RobboGroupId: "7", StartAt: startAt, EndAt: startAt.Add(time.Hour)}}
+	groups := &fakeRobboGroups{archived: map[string]bool{"7": true}}
+	p := &ScheduleUseCaseImpl{scheduleGateway: lessons, robboGroupGateway: groups}
+
+	_, err := p.CancelLesson("1", "holiday")
+	assert.Equal(t, schedule.ErrRobboGroupArchived, err)
+	_, err = p.RescheduleLesson("1", startAt.Add(time.Hour), startAt.Add(2*time.Hour), "")
+	assert.Equal(t, schedule.ErrRobboGroupArchived, err)
+	assert.Empty(t, lessons.updated)
+
+	groups.archived["7"] = false
+	lesson, err := p.CancelLesson("1", "holiday")
+	assert.NoError(t, err)
+	assert.Equal(t, models.LessonCancelled, lesson.Status)
+	lesson, err = p.RescheduleLesson("1", startAt.Add(time.Hour), startAt.Add(2*time.Hour), "")
+	assert.NoError(t, err)
+	assert.Equal(t, models.LessonRescheduled, lesson.Status)
+	assert.Len(t, lessons.updated, 2)
+}
+
+// termSlots has a Monday slot of group 7 and records the lessons generated from it.
+type termSlots struct {
+	schedule.Gateway
+	created []*models.LessonCore
+}
+
+func (f *termSlots) GetScheduleSlotsByRobboGroupId(robboGroupId string) ([]*models.ScheduleSlotCore, error) {
+	return []*models.ScheduleSlotCore{{Id: "1", RobboGroupId: robboGroupId, Weekday: time.Monday, StartTime: "15:00", EndTime: "16:00"}}, nil
+}
+
+func (f *termSlots) CreateLessons(lessons []*models.LessonCore) error {
+	f.created = append(f.created, lessons...)
+	return nil
+}
+
+func (f *termSlots) GetLessonsByRobboGroupIds([]string, time.Time, time.Time) ([]*models.LessonCore, error) {
+	return f.created, nil
+}
+
+type termGroups struct {
+	robboGroup.Gateway
+}
+
+func (termGroups) GetRobboGroupById(robboGroupId string) (*models.RobboGroupCore, error) {
+	return &models.RobboGroupCore{Id: robboGroupId, TermId: "3"}, nil
+}
+
+// autumnTerm runs through September 2026.
+type autumnTerm struct {
+	terms.Gateway
+}
+
+func (autumnTerm) GetTermById(termId string) (*models.TermCore, error) {
+	return &models.TermCore{
+		Id:        termId,
+		StartDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, nil
+}
+
+func TestGenerateLessonsWithinTerm(t *testing.T) {
+	slots := &termSlots{}
+	p := &ScheduleUseCaseImpl{scheduleGateway: slots, robboGroupGateway: termGroups{}, termsGateway: autumnTerm{}, location: time.UTC}
+
+	lessons, err := p.GenerateLessons("7", time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, lessons, 4, "every Monday of September")
+
+	slots.created = nil
+	lessons, err = p.GenerateLessons("7", time.Date(2026, 9, 22, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	if assert.Len(t, lessons, 1, "the period is cut to the end of the term") {
+		assert.Equal(t, time.Date(2026, 9, 28, 15, 0, 0, 0, time.UTC), lessons[0].StartAt)
+	}
+
+	_, err = p.GenerateLessons("7", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, schedule.ErrBadTimeRange, err, "a period after the term has no lessons of the group")
+}
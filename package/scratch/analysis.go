@@ -0,0 +1,222 @@
+package scratch
+
+import (
+	"encoding/json"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"sort"
+	"strings"
+)
+
+var (
+	loopOpcodes        = []string{"control_repeat", "control_forever", "control_repeat_until", "control_while", "control_for_each"}
+	conditionalOpcodes = []string{"control_if", "control_if_else"}
+	broadcastOpcodes   = []string{"event_broadcast", "event_broadcastandwait"}
+	// propertyReporters only read what a sprite looks like, the rest of motion and looks changes it
+	propertyReporters = []string{
+		"motion_xposition", "motion_yposition", "motion_direction",
+		"looks_costumenumbername", "looks_backdropnumbername", "looks_size",
+	}
+)
+
+// hatTriggers name the field that tells apart the events of the same kind, such as the key of "when key pressed".
+var hatTriggers = map[string]string{
+	"event_whenflagclicked":              "",
+	"event_whenkeypressed":               "KEY_OPTION",
+	"event_whenthisspriteclicked":        "",
+	"event_whenstageclicked":             "",
+	"event_whenbroadcastreceived":        "BROADCAST_OPTION",
+	"event_whenbackdropswitchesto":       "BACKDROP",
+	"event_whengreaterthan":              "WHENGREATERTHANMENU",
+	"control_start_as_clone":             "",
+	"videoSensing_whenMotionGreaterThan": "",
+}
+
+// spriteHats are the events every sprite gets on its own, two sprites clicked are not parallel scripts.
+var spriteHats = []string{"event_whenthisspriteclicked", "control_start_as_clone"}
+
+func countIn(counts map[string]int, opcodes []string) (count int) {
+	for _, opcode := range opcodes {
+		count += counts[opcode]
+	}
+	return
+}
+
+func lengthOf(property interface{}) int {
+	values, _ := property.(map[string]interface{})
+	return len(values)
+}
+
+// Inventory is what a project is made of. Shadow blocks, the inputs Scratch fills in by itself, are not counted.
+type Inventory struct {
+	// Sprites are the names of the sprites, the stage is not one of them
+	Sprites    []string
+	Opcodes    map[string]int
+	Categories map[string]int
+	Analysis   *models.ProjectAnalysisCore
+}
+
+// Analyze computes the metrics of a project.json and rates it on the Dr. Scratch
+// computational thinking dimensions.
+func Analyze(projectJson string) (analysis *models.ProjectAnalysisCore, err error) {
+	inventory, err := Inspect(projectJson)
+	if err != nil {
+		return
+	}
+	return inventory.Analysis, nil
+}
+
+// Inspect counts the blocks of a project.json by opcode and by category and analyzes it.
+func Inspect(projectJson string) (inventory *Inventory, err error) {
+	targets, err := ParseTargets(projectJson)
+	if err != nil {
+		return
+	}
+	var project struct {
+		Extensions []string `json:"extensions"`
+	}
+	if err = json.Unmarshal([]byte(projectJson), &project); err != nil {
+		return nil, ErrBadProject
+	}
+	analysis := &models.ProjectAnalysisCore{
+		BlocksByCategory: []models.BlockCategoryCountCore{},
+		Extensions:       append([]string{}, project.Extensions...),
+	}
+	inventory = &Inventory{
+		Sprites:    []string{},
+		Opcodes:    make(map[string]int),
+		Categories: make(map[string]int),
+		Analysis:   analysis,
+	}
+	triggers := make(map[string]int)
+	sequences := false
+	for _, target := range targets {
+		if !target.IsStage {
+			inventory.Sprites = append(inventory.Sprites, target.Name)
+		}
+		analysis.Variables += lengthOf(target.Properties["variables"])
+		analysis.Lists += lengthOf(target.Properties["lists"])
+		blocks, parseErr := ParseBlocks(target.Blocks)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		for _, block := range blocks {
+			if block.IsShadow() {
+				continue
+			}
+			analysis.Blocks++
+			inventory.Opcodes[block.Opcode]++
+			inventory.Categories[CategoryOf(block.Opcode)]++
+			sequences = sequences || block.HasNext()
+			if !block.TopLevel {
+				continue
+			}
+			analysis.Scripts++
+			field, isHat := hatTriggers[block.Opcode]
+			if !isHat {
+				continue
+			}
+			trigger := block.Opcode + "/" + block.Field(field)
+			if contains(spriteHats, block.Opcode) {
+				trigger += "/" + target.Name
+			}
+			triggers[trigger]++
+		}
+	}
+	analysis.Sprites = len(inventory.Sprites)
+	for category, count := range inventory.Categories {
+		analysis.BlocksByCategory = append(analysis.BlocksByCategory, models.BlockCategoryCountCore{Category: category, Count: count})
+	}
+	sort.Slice(analysis.BlocksByCategory, func(i, j int) bool {
+		a, b := analysis.BlocksByCategory[i], analysis.BlocksByCategory[j]
+		return a.Count > b.Count || a.Count == b.Count && a.Category < b.Category
+	})
+	opcodes := inventory.Opcodes
+	analysis.Loops = countIn(opcodes, loopOpcodes)
+	analysis.Conditionals = countIn(opcodes, conditionalOpcodes)
+	analysis.Broadcasts = countIn(opcodes, broadcastOpcodes)
+	analysis.CustomBlocks = opcodes["procedures_definition"]
+	analysis.Clones = opcodes["control_create_clone_of"]
+	parallel := make(map[string]bool)
+	for trigger, count := range triggers {
+		if count > 1 {
+			analysis.ParallelScripts += count
+			parallel[trigger[:strings.Index(trigger, "/")]] = true
+		}
+	}
+	analysis.CtScore = ctScoreOf(analysis, opcodes, parallel, sequences)
+	return
+}
+
+// level is the highest of the ratings whose rule holds, 0 when none does.
+func level(rules ...bool) (rating int) {
+	for i, holds := range rules {
+		if holds {
+			rating = i + 1
+		}
+	}
+	return
+}
+
+// ctScoreOf follows the rubric of Dr. Scratch. parallel has the hats that start more than one script.
+func ctScoreOf(analysis *models.ProjectAnalysisCore, opcodes map[string]int, parallel map[string]bool, sequences bool) (score models.CtScoreCore) {
+	has := func(names ...string) bool {
+		return countIn(opcodes, names) > 0
+	}
+	changesProperties := false
+	for opcode, count := range opcodes {
+		if count > 0 && (strings.HasPrefix(opcode, "motion_") || strings.HasPrefix(opcode, "looks_")) &&
+			!contains(propertyReporters, opcode) {
+			changesProperties = true
+			break
+		}
+	}
+	score.Abstraction = level(
+		analysis.Scripts > 1,
+		analysis.CustomBlocks > 0,
+		has("control_start_as_clone"),
+	)
+	score.Parallelism = level(
+		parallel["event_whenflagclicked"],
+		parallel["event_whenkeypressed"] || parallel["event_whenthisspriteclicked"] || parallel["event_whenstageclicked"],
+		parallel["event_whenbroadcastreceived"] || parallel["event_whenbackdropswitchesto"] ||
+			parallel["event_whengreaterthan"] || parallel["control_start_as_clone"] ||
+			parallel["videoSensing_whenMotionGreaterThan"],
+	)
+	score.Logic = level(
+		has("control_if"),
+		has("control_if_else"),
+		has("operator_and", "operator_or", "operator_not"),
+	)
+	score.Synchronization = level(
+		has("control_wait"),
+		has("event_broadcast", "event_whenbroadcastreceived", "control_stop"),
+		has("control_wait_until", "event_whenbackdropswitchesto", "event_broadcastandwait"),
+	)
+	score.FlowControl = level(
+		sequences,
+		has("control_repeat", "control_forever"),
+		has("control_repeat_until"),
+	)
+	score.UserInteractivity = level(
+		has("event_whenflagclicked"),
+		has("event_whenkeypressed", "event_whenthisspriteclicked", "sensing_askandwait",
+			"sensing_keypressed", "sensing_mousedown", "sensing_mousex", "sensing_mousey"),
+		has("event_whengreaterthan", "sensing_loudness", "videoSensing_videoOn",
+			"videoSensing_whenMotionGreaterThan", "videoSensing_videoToggle"),
+	)
+	score.DataRepresentation = level(
+		changesProperties,
+		has("data_setvariableto", "data_changevariableby"),
+		has("data_addtolist", "data_deleteoflist", "data_deletealloflist", "data_insertatlist", "data_replaceitemoflist"),
+	)
+	return
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
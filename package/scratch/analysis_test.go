@@ -0,0 +1,76 @@
+package scratch
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const analyzedProject = `{"targets":[
+	{"isStage":true,"name":"Stage","variables":{"v1":["score",0]},"lists":{"l1":["items",[]]},"blocks":{
+		"s1":{"opcode":"event_whenbroadcastreceived","next":"s2","parent":null,"topLevel":true,"fields":{"BROADCAST_OPTION":["go","b1"]}},
+		"s2":{"opcode":"data_addtolist","next":null,"parent":"s1","topLevel":false}
+	}},
+	{"isStage":false,"name":"Cat","variables":{"v2":["speed",1]},"blocks":{
+		"a":{"opcode":"event_whenflagclicked","next":"b","parent":null,"topLevel":true},
+		"b":{"opcode":"control_forever","next":null,"parent":"a","inputs":{"SUBSTACK":[2,"c"]},"topLevel":false},
+		"c":{"opcode":"control_if","next":null,"parent":"b","inputs":{"CONDITION":[2,"d"],"SUBSTACK":[2,"e"]},"topLevel":false},
+		"d":{"opcode":"sensing_keypressed","next":null,"parent":"c","inputs":{"KEY_OPTION":[1,"m"]},"topLevel":false},
+		"m":{"opcode":"sensing_keyoptions","next":null,"parent":"d","shadow":true,"topLevel":false},
+		"e":{"opcode":"motion_movesteps","next":"f","parent":"c","topLevel":false},
+		"f":{"opcode":"event_broadcast","next":null,"parent":"e","topLevel":false},
+		"g":{"opcode":"event_whenflagclicked","next":"h","parent":null,"topLevel":true},
+		"h":{"opcode":"pen_clear","next":null,"parent":"g","topLevel":false},
+		"k":{"opcode":"event_whenthisspriteclicked","next":null,"parent":null,"topLevel":true},
+		"r":[12,"score","v1",10,10]
+	}},
+	{"isStage":false,"name":"Dog","blocks":{
+		"k":{"opcode":"event_whenthisspriteclicked","next":null,"parent":null,"topLevel":true},
+		"p":{"opcode":"event_whenbroadcastreceived","next":null,"parent":null,"topLevel":true,"fields":{"BROADCAST_OPTION":["go","b1"]}}
+	}}
+],"extensions":["pen"]}`
+
+func TestAnalyzeProject(t *testing.T) {
+	analysis, err := Analyze(analyzedProject)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, analysis.Sprites)
+	assert.Equal(t, 7, analysis.Scripts)
+	assert.Equal(t, 14, analysis.Blocks, "the shadow key menu is not counted")
+	assert.Equal(t, []string{"pen"}, analysis.Extensions)
+	assert.Equal(t, 1, analysis.Loops)
+	assert.Equal(t, 1, analysis.Conditionals)
+	assert.Equal(t, 2, analysis.Variables)
+	assert.Equal(t, 1, analysis.Lists)
+	assert.Equal(t, 1, analysis.Broadcasts)
+	assert.Equal(t, 4, analysis.ParallelScripts, "two flag scripts and two receivers of go, sprites clicked are not parallel")
+	assert.Equal(t, []models.BlockCategoryCountCore{
+		{Category: "event", Count: 7},
+		{Category: "control", Count: 2},
+		{Category: "data", Count: 2},
+		{Category: "motion", Count: 1},
+		{Category: "pen", Count: 1},
+		{Category: "sensing", Count: 1},
+	}, analysis.BlocksByCategory)
+	assert.Equal(t, models.CtScoreCore{
+		Abstraction:        1,
+		Parallelism:        3,
+		Logic:              1,
+		Synchronization:    2,
+		FlowControl:        2,
+		UserInteractivity:  2,
+		DataRepresentation: 3,
+	}, analysis.CtScore)
+	assert.Equal(t, 14, analysis.CtScore.Total())
+	assert.Equal(t, models.CtDeveloping, analysis.CtScore.Level())
+}
+
+func TestAnalyzeEmptyProject(t *testing.T) {
+	analysis, err := Analyze(`{"targets":[{"isStage":true,"name":"Stage","blocks":{}}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, models.CtScoreCore{}, analysis.CtScore)
+	assert.Equal(t, models.CtBasic, analysis.CtScore.Level())
+	assert.Empty(t, analysis.BlocksByCategory)
+
+	_, err = Analyze("not a project")
+	assert.ErrorIs(t, err, ErrBadProject)
+}
@@ -0,0 +1,5 @@
+package scratch
+
+import "errors"
+
+var ErrBadProject = errors.New("project json is not a valid scratch project")
@@ -0,0 +1,153 @@
+// Package scratch reads Scratch 3 project.json documents. The history, the analysis and the checks
+// of assignments all read projects through it.
+package scratch
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Target is a sprite or the stage of a project.json. Blocks stay raw because top-level
+// reporters are stored as arrays rather than objects.
+type Target struct {
+	Name       string
+	IsStage    bool
+	Blocks     map[string]json.RawMessage
+	Properties map[string]interface{}
+}
+
+type Block struct {
+	Opcode   string
+	Parent   string
+	TopLevel bool
+	Value    interface{}
+}
+
+// PrimitiveOpcode is given to the variable and list reporters dropped on the workspace, they are stored
+// as arrays and have no opcode of their own.
+const PrimitiveOpcode = "primitive"
+
+// ignoredBlockKeys only move a script around the workspace.
+var ignoredBlockKeys = []string{"x", "y"}
+
+func ParseTargets(projectJson string) (targets []*Target, err error) {
+	var project struct {
+		Targets []map[string]json.RawMessage `json:"targets"`
+	}
+	if err = json.Unmarshal([]byte(projectJson), &project); err != nil {
+		return nil, ErrBadProject
+	}
+	for _, raw := range project.Targets {
+		target := &Target{Properties: make(map[string]interface{})}
+		for key, value := range raw {
+			switch key {
+			case "name":
+				err = json.Unmarshal(value, &target.Name)
+			case "isStage":
+				err = json.Unmarshal(value, &target.IsStage)
+			case "blocks":
+				err = json.Unmarshal(value, &target.Blocks)
+			default:
+				var property interface{}
+				err = json.Unmarshal(value, &property)
+				target.Properties[key] = property
+			}
+			if err != nil {
+				return nil, ErrBadProject
+			}
+		}
+		targets = append(targets, target)
+	}
+	return
+}
+
+func ParseBlocks(raw map[string]json.RawMessage) (blocks map[string]*Block, err error) {
+	blocks = make(map[string]*Block, len(raw))
+	for id, value := range raw {
+		block := &Block{}
+		if err = json.Unmarshal(value, &block.Value); err != nil {
+			return nil, ErrBadProject
+		}
+		switch v := block.Value.(type) {
+		case map[string]interface{}:
+			block.Opcode, _ = v["opcode"].(string)
+			block.Parent, _ = v["parent"].(string)
+			block.TopLevel, _ = v["topLevel"].(bool)
+			for _, key := range ignoredBlockKeys {
+				delete(v, key)
+			}
+		case []interface{}:
+			// a primitive such as a variable reporter dropped on the workspace, always top-level
+			block.Opcode = PrimitiveOpcode
+			block.TopLevel = true
+			if len(v) > 3 {
+				block.Value = v[:3]
+			}
+		default:
+			return nil, ErrBadProject
+		}
+		blocks[id] = block
+	}
+	return
+}
+
+func (b *Block) Field(name string) string {
+	value, _ := b.Value.(map[string]interface{})
+	fields, _ := value["fields"].(map[string]interface{})
+	field, _ := fields[name].([]interface{})
+	if len(field) == 0 {
+		return ""
+	}
+	text, _ := field[0].(string)
+	return text
+}
+
+func (b *Block) IsShadow() bool {
+	value, _ := b.Value.(map[string]interface{})
+	shadow, _ := value["shadow"].(bool)
+	return shadow
+}
+
+func (b *Block) HasNext() bool {
+	return b.Next() != ""
+}
+
+// Next is the id of the block below this one, none at the bottom of a stack.
+func (b *Block) Next() string {
+	value, _ := b.Value.(map[string]interface{})
+	next, _ := value["next"].(string)
+	return next
+}
+
+// InputBlocks maps the inputs of the block to the ids of the blocks put into them. Inputs holding
+// a typed in value or a variable hold no block and are left out.
+func (b *Block) InputBlocks() map[string]string {
+	value, _ := b.Value.(map[string]interface{})
+	inputs, _ := value["inputs"].(map[string]interface{})
+	blocks := make(map[string]string, len(inputs))
+	for name, input := range inputs {
+		slots, _ := input.([]interface{})
+		if len(slots) < 2 {
+			continue
+		}
+		if id, ok := slots[1].(string); ok && id != "" {
+			blocks[name] = id
+		}
+	}
+	return blocks
+}
+
+// CategoryOf is the palette a block comes from. Extension blocks keep the id of their extension.
+func CategoryOf(opcode string) string {
+	if opcode == PrimitiveOpcode {
+		return "data"
+	}
+	category := opcode
+	if i := strings.Index(opcode, "_"); i > 0 {
+		category = opcode[:i]
+	}
+	if category == "argument" {
+		return "procedures"
+	}
+	return category
+}
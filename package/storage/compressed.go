@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// PutCompressed gzips the content on its way to the blob named after the sha256 of the uncompressed
+// content, under prefix. The content is compressed through a pipe into a temporary blob that is renamed
+// once its hash is known, so neither copy is held in memory. Equal contents share one blob, a blob that
+// exists already is only touched to keep it from the collection of unused blobs, and written again if the
+// collection has deleted it meanwhile.
+func PutCompressed(s Storage, prefix string, content io.Reader) (hash string, size int64, err error) {
+	upload, err := uploadKey(prefix)
+	if err != nil {
+		return
+	}
+	reader, writer := io.Pipe()
+	hasher := sha256.New()
+	compressed := make(chan error, 1)
+	go func() {
+		compressor := gzip.NewWriter(writer)
+		var copyErr error
+		if size, copyErr = io.Copy(io.MultiWriter(compressor, hasher), content); copyErr == nil {
+			copyErr = compressor.Close()
+		}
+		writer.CloseWithError(copyErr)
+		compressed <- copyErr
+	}()
+	err = s.PutReader(upload, reader)
+	// a put that stops early leaves the compression blocked on the pipe
+	reader.CloseWithError(io.ErrClosedPipe)
+	if copyErr := <-compressed; err == nil {
+		err = copyErr
+	}
+	if err != nil {
+		s.Delete(upload)
+		return "", 0, err
+	}
+
+	hash = hex.EncodeToString(hasher.Sum(nil))
+	key := compressedKey(prefix, hash)
+	exists, err := s.Exists(key)
+	if err == nil && exists {
+		if err = s.Touch(key); errors.Is(err, ErrBlobNotFound) {
+			exists, err = false, nil
+		}
+	}
+	if err != nil || exists {
+		s.Delete(upload)
+		return
+	}
+	err = s.Rename(upload, key)
+	return
+}
+
+// GetCompressed opens the blob PutCompressed has stored, the content is decompressed as it is read.
+func GetCompressed(s Storage, prefix, hash string) (content io.ReadCloser, err error) {
+	blob, err := s.Open(compressedKey(prefix, hash))
+	if err != nil {
+		return
+	}
+	decompressor, err := gzip.NewReader(blob)
+	if err != nil {
+		blob.Close()
+		return
+	}
+	return &compressedReader{Reader: decompressor, blob: blob}, nil
+}
+
+// compressedReader closes the blob along with the decompressor.
+type compressedReader struct {
+	*gzip.Reader
+	blob io.Closer
+}
+
+func (r *compressedReader) Close() error {
+	r.Reader.Close()
+	return r.blob.Close()
+}
+
+// uploadKey is where PutCompressed writes a blob before its hash is known. It does not end in .gz,
+// so the collection of unused blobs never lists it.
+func uploadKey(prefix string) (key string, err error) {
+	random := make([]byte, 8)
+	if _, err = rand.Read(random); err != nil {
+		return
+	}
+	return prefix + "/.upload-" + hex.EncodeToString(random), nil
+}
+
+func compressedKey(prefix, hash string) string {
+	return prefix + "/" + hash + ".gz"
+}
+
+// CompressedHashes are the hashes of the blobs under prefix last written before the given time.
+func CompressedHashes(s Storage, prefix string, before time.Time) (hashes []string, err error) {
+	keys, err := s.List(prefix, before)
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, prefix+"/")
+		if hash := strings.TrimSuffix(name, ".gz"); hash != name && !strings.Contains(hash, "/") {
+			hashes = append(hashes, hash)
+		}
+	}
+	return
+}
+
+// DeleteCompressed deletes the blob unless it has been written or touched since the given time.
+func DeleteCompressed(s Storage, prefix, hash string, before time.Time) (deleted bool, err error) {
+	return s.DeleteBefore(compressedKey(prefix, hash), before)
+}
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type FileSystemStorage struct {
+	root string
+	// writes keeps DeleteBefore from removing a blob between its check and a concurrent write or touch
+	writes sync.Mutex
+}
+
+func NewFileSystemStorage(root string) (*FileSystemStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSystemStorage{root: root}, nil
+}
+
+func (s *FileSystemStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return "", ErrBadBlobKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *FileSystemStorage) Put(key string, content []byte) (err error) {
+	return s.PutReader(key, bytes.NewReader(content))
+}
+
+// PutReader writes to a temporary file first so that readers never see a half written blob.
+func (s *FileSystemStorage) PutReader(key string, content io.Reader) (err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(blobPath), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(blobPath), ".put-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	s.writes.Lock()
+	defer s.writes.Unlock()
+	return os.Rename(tmp.Name(), blobPath)
+}
+
+func (s *FileSystemStorage) Get(key string) (content []byte, err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	content, err = os.ReadFile(blobPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return
+}
+
+func (s *FileSystemStorage) Open(key string) (content io.ReadCloser, err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	file, err := os.Open(blobPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return
+	}
+	return file, nil
+}
+
+func (s *FileSystemStorage) Rename(from, to string) (err error) {
+	fromPath, err := s.path(from)
+	if err != nil {
+		return
+	}
+	toPath, err := s.path(to)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(toPath), 0o755); err != nil {
+		return
+	}
+	s.writes.Lock()
+	defer s.writes.Unlock()
+	if err = os.Rename(fromPath, toPath); errors.Is(err, fs.ErrNotExist) {
+		return ErrBlobNotFound
+	}
+	return
+}
+
+func (s *FileSystemStorage) Exists(key string) (exists bool, err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	_, err = os.Stat(blobPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *FileSystemStorage) Delete(key string) (err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	if err = os.Remove(blobPath); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return
+}
+
+func (s *FileSystemStorage) DeleteBefore(key string, before time.Time) (deleted bool, err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	s.writes.Lock()
+	defer s.writes.Unlock()
+	info, err := os.Stat(blobPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil || !info.ModTime().Before(before) {
+		return
+	}
+	if err = os.Remove(blobPath); errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *FileSystemStorage) Touch(key string) (err error) {
+	blobPath, err := s.path(key)
+	if err != nil {
+		return
+	}
+	s.writes.Lock()
+	defer s.writes.Unlock()
+	now := time.Now()
+	if err = os.Chtimes(blobPath, now, now); errors.Is(err, fs.ErrNotExist) {
+		return ErrBlobNotFound
+	}
+	return
+}
+
+// List leaves out the temporary files of the puts in progress.
+func (s *FileSystemStorage) List(prefix string, before time.Time) (keys []string, err error) {
+	dir, err := s.path(prefix)
+	if err != nil {
+		return
+	}
+	err = filepath.WalkDir(dir, func(blobPath string, entry fs.DirEntry, walkErr error) error {
+		if errors.Is(walkErr, fs.ErrNotExist) {
+			return nil
+		}
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".put-") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(before) {
+			return nil
+		}
+		relative, err := filepath.Rel(s.root, blobPath)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(relative))
+		return nil
+	})
+	return
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSystemStorage(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	exists, err := blobStorage.Exists("assets/a.svg")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	_, err = blobStorage.Get("assets/a.svg")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	assert.NoError(t, blobStorage.Put("assets/a.svg", []byte("first")))
+	assert.NoError(t, blobStorage.Put("assets/a.svg", []byte("second")))
+	content, err := blobStorage.Get("assets/a.svg")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("second"), content)
+	exists, err = blobStorage.Exists("assets/a.svg")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	assert.NoError(t, blobStorage.Delete("assets/a.svg"))
+	assert.NoError(t, blobStorage.Delete("assets/a.svg"), "deleting a missing blob is not an error")
+	exists, err = blobStorage.Exists("assets/a.svg")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestFileSystemStorageStreams(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, blobStorage.PutReader("projects/.upload-1", strings.NewReader("streamed")))
+	assert.NoError(t, blobStorage.Rename("projects/.upload-1", "projects/a.gz"))
+	assert.ErrorIs(t, blobStorage.Rename("projects/.upload-1", "projects/a.gz"), ErrBlobNotFound)
+
+	content, err := blobStorage.Open("projects/a.gz")
+	if assert.NoError(t, err) {
+		read, err := io.ReadAll(content)
+		assert.NoError(t, err)
+		assert.NoError(t, content.Close())
+		assert.Equal(t, "streamed", string(read))
+	}
+	_, err = blobStorage.Open("projects/.upload-1")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestFileSystemStorageRejectsEscapingKeys(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+	for _, key := range []string{"", "/etc/passwd", "../outside", "assets/../../outside", "assets//a"} {
+		assert.ErrorIs(t, blobStorage.Put(key, []byte("x")), ErrBadBlobKey, key)
+	}
+}
+
+func TestCompressedBlobs(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+	body := strings.Repeat(`{"targets":[]}`, 10000)
+
+	hash, size, err := PutCompressed(blobStorage, "projects", strings.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(body)), size)
+	assert.Len(t, hash, 64)
+	stored, err := blobStorage.Get("projects/" + hash + ".gz")
+	assert.NoError(t, err)
+	assert.Less(t, len(stored), len(body)/10, "the body is kept compressed")
+
+	again, _, err := PutCompressed(blobStorage, "projects", strings.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, hash, again, "equal bodies share a blob")
+
+	content, err := GetCompressed(blobStorage, "projects", hash)
+	assert.NoError(t, err)
+	read, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, body, string(read))
+
+	_, err = GetCompressed(blobStorage, "projects", "missing")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+}
+
+func TestCompressedHashesSkipTouchedBlobs(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+	old, _, err := PutCompressed(blobStorage, "projects", strings.NewReader("old"))
+	assert.NoError(t, err)
+	touched, _, err := PutCompressed(blobStorage, "projects", strings.NewReader("touched"))
+	assert.NoError(t, err)
+	assert.NoError(t, blobStorage.Put("assets/a.svg", []byte("asset")))
+
+	past := time.Now().Add(-time.Hour)
+	for _, key := range []string{"projects/" + old + ".gz", "projects/" + touched + ".gz"} {
+		path, err := blobStorage.path(key)
+		assert.NoError(t, err)
+		assert.NoError(t, os.Chtimes(path, past, past))
+	}
+	_, _, err = PutCompressed(blobStorage, "projects", strings.NewReader("touched"))
+	assert.NoError(t, err)
+
+	hashes, err := CompressedHashes(blobStorage, "projects", time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{old}, hashes, "a blob written again is young again, other prefixes are left out")
+
+	deleted, err := DeleteCompressed(blobStorage, "projects", old, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = GetCompressed(blobStorage, "projects", old)
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+	deleted, err = DeleteCompressed(blobStorage, "projects", touched, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, deleted, "a blob touched after it was listed is kept")
+	_, err = GetCompressed(blobStorage, "projects", touched)
+	assert.NoError(t, err)
+	hashes, err = CompressedHashes(blobStorage, "missing", time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestPutCompressedWritesCollectedBlobAgain(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+	hash, _, err := PutCompressed(blobStorage, "projects", strings.NewReader("body"))
+	assert.NoError(t, err)
+
+	// the collection deletes the blob between the check of a save and its touch
+	collecting := &collectedOnTouch{Storage: blobStorage}
+	again, _, err := PutCompressed(collecting, "projects", strings.NewReader("body"))
+	assert.NoError(t, err)
+	assert.Equal(t, hash, again)
+	content, err := GetCompressed(blobStorage, "projects", hash)
+	if assert.NoError(t, err) {
+		read, _ := io.ReadAll(content)
+		assert.Equal(t, "body", string(read))
+	}
+}
+
+type collectedOnTouch struct {
+	Storage
+}
+
+func (s *collectedOnTouch) Touch(key string) error {
+	if _, err := s.Storage.DeleteBefore(key, time.Now().Add(time.Hour)); err != nil {
+		return err
+	}
+	return s.Storage.Touch(key)
+}
+
+// failingReader breaks off after some content, like an upload whose client goes away.
+type failingReader struct {
+	sent bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.sent = true
+	return copy(p, `{"targets":`), nil
+}
+
+func TestPutCompressedLeavesNoUploadBehind(t *testing.T) {
+	blobStorage, err := NewFileSystemStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	_, _, err = PutCompressed(blobStorage, "projects", &failingReader{})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	hash, _, err := PutCompressed(blobStorage, "projects", strings.NewReader("body"))
+	assert.NoError(t, err)
+	_, _, err = PutCompressed(blobStorage, "projects", strings.NewReader("body"))
+	assert.NoError(t, err)
+
+	keys, err := blobStorage.List("projects", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"projects/" + hash + ".gz"}, keys, "failed and duplicate uploads are deleted")
+}
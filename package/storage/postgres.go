@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"io"
+	"time"
+)
+
+type PostgresStorage struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+func NewPostgresStorage(postgresClient db_client.PostgresClient) *PostgresStorage {
+	return &PostgresStorage{PostgresClient: &postgresClient}
+}
+
+func (s *PostgresStorage) Put(key string, content []byte) (err error) {
+	if key == "" {
+		return ErrBadBlobKey
+	}
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"content", "updated_at"}),
+		}).Create(&models.StorageBlobDB{Key: key, Content: content}).Error
+	})
+	return
+}
+
+func (s *PostgresStorage) Get(key string) (content []byte, err error) {
+	var blob models.StorageBlobDB
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("key = ?", key).First(&blob).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBlobNotFound
+		}
+		return
+	})
+	content = blob.Content
+	return
+}
+
+// PutReader reads the whole content first, a blob is one row.
+func (s *PostgresStorage) PutReader(key string, content io.Reader) (err error) {
+	read, err := io.ReadAll(content)
+	if err != nil {
+		return
+	}
+	return s.Put(key, read)
+}
+
+// Open reads the whole blob first, a blob is one row.
+func (s *PostgresStorage) Open(key string) (content io.ReadCloser, err error) {
+	read, err := s.Get(key)
+	if err != nil {
+		return
+	}
+	return io.NopCloser(bytes.NewReader(read)), nil
+}
+
+func (s *PostgresStorage) Rename(from, to string) (err error) {
+	if to == "" {
+		return ErrBadBlobKey
+	}
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("key = ?", to).Delete(&models.StorageBlobDB{}).Error; err != nil {
+			return
+		}
+		result := tx.Model(&models.StorageBlobDB{}).Where("key = ?", from).
+			Updates(map[string]interface{}{"key": to, "updated_at": time.Now()})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return ErrBlobNotFound
+		}
+		return result.Error
+	})
+	return
+}
+
+func (s *PostgresStorage) Exists(key string) (exists bool, err error) {
+	var count int64
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.StorageBlobDB{}).Where("key = ?", key).Count(&count).Error
+	})
+	exists = count > 0
+	return
+}
+
+func (s *PostgresStorage) Delete(key string) (err error) {
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("key = ?", key).Delete(&models.StorageBlobDB{}).Error
+	})
+	return
+}
+
+func (s *PostgresStorage) DeleteBefore(key string, before time.Time) (deleted bool, err error) {
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Where("key = ? AND updated_at < ?", key, before).Delete(&models.StorageBlobDB{})
+		deleted = result.RowsAffected > 0
+		return result.Error
+	})
+	return
+}
+
+func (s *PostgresStorage) Touch(key string) (err error) {
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Model(&models.StorageBlobDB{}).Where("key = ?", key).Update("updated_at", time.Now())
+		if result.Error == nil && result.RowsAffected == 0 {
+			return ErrBlobNotFound
+		}
+		return result.Error
+	})
+	return
+}
+
+func (s *PostgresStorage) List(prefix string, before time.Time) (keys []string, err error) {
+	err = s.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Model(&models.StorageBlobDB{}).
+			Where("key LIKE ? AND updated_at < ?", prefix+"/%", before).
+			Order("key").Pluck("key", &keys).Error
+	})
+	return
+}
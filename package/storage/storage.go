@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/spf13/viper"
+	"io"
+	"time"
+)
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrBadBlobKey     = errors.New("blob key must be a relative slash separated path")
+	ErrUnknownBackend = errors.New("unknown storage backend")
+)
+
+// Storage keeps opaque blobs under slash separated keys such as "assets/<md5ext>".
+// Put overwrites silently, callers that need immutability use content addressed keys.
+type Storage interface {
+	Put(key string, content []byte) (err error)
+	Get(key string) (content []byte, err error)
+	// PutReader is Put for content that is written as it is read
+	PutReader(key string, content io.Reader) (err error)
+	// Open is Get for content that is read as a stream, the caller closes it
+	Open(key string) (content io.ReadCloser, err error)
+	// Rename moves a blob to another key, a blob there is overwritten like by Put
+	Rename(from, to string) (err error)
+	Exists(key string) (exists bool, err error)
+	Delete(key string) (err error)
+	// DeleteBefore deletes the blob unless it has been written or touched since the given time,
+	// the check and the delete are one step against concurrent writers
+	DeleteBefore(key string, before time.Time) (deleted bool, err error)
+	// Touch marks a blob as written now without writing it again, ErrBlobNotFound if it is gone
+	Touch(key string) (err error)
+	// List returns the keys under prefix of the blobs last written before the given time
+	List(prefix string, before time.Time) (keys []string, err error)
+}
+
+// NewStorage picks the backend configured in storage.backend: "filesystem" keeps blobs under
+// storage.root, "postgres" keeps them in the application database.
+func NewStorage(postgresClient db_client.PostgresClient) (Storage, error) {
+	switch backend := viper.GetString("storage.backend"); backend {
+	case "filesystem":
+		return NewFileSystemStorage(viper.GetString("storage.root"))
+	case "postgres":
+		return NewPostgresStorage(postgresClient), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, backend)
+	}
+}
@@ -0,0 +1,11 @@
+package terms
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Delegate interface {
+	CreateTerm(term *models.NewTerm) (newTerm *models.TermHTTP, err error)
+	GetTermById(termId string) (term *models.TermHTTP, err error)
+	GetTermsByRobboUnitId(robboUnitId string) (terms []*models.TermHTTP, err error)
+	RolloverTerm(rollover *models.TermRollover) (nextTerm *models.TermHTTP, err error)
+	ArchiveTerm(termId string) (term *models.TermHTTP, err error)
+}
@@ -0,0 +1,116 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	"go.uber.org/fx"
+	"time"
+)
+
+type TermsDelegateImpl struct {
+	UseCase terms.UseCase
+}
+
+type TermsDelegateModule struct {
+	fx.Out
+	terms.Delegate
+}
+
+func SetupTermsDelegate(usecase terms.UseCase) TermsDelegateModule {
+	return TermsDelegateModule{
+		Delegate: &TermsDelegateImpl{
+			usecase,
+		},
+	}
+}
+
+func (p *TermsDelegateImpl) CreateTerm(term *models.NewTerm) (newTerm *models.TermHTTP, err error) {
+	startDate, endDate, err := parseDates(term.StartDate, term.EndDate)
+	if err != nil {
+		return
+	}
+	termCore, err := p.UseCase.CreateTerm(&models.TermCore{
+		RobboUnitId: term.RobboUnitID,
+		Name:        term.Name,
+		StartDate:   startDate,
+		EndDate:     endDate,
+	})
+	if err != nil {
+		return
+	}
+	newTerm = &models.TermHTTP{}
+	newTerm.FromCore(termCore)
+	return
+}
+
+func (p *TermsDelegateImpl) GetTermById(termId string) (term *models.TermHTTP, err error) {
+	termCore, err := p.UseCase.GetTermById(termId)
+	if err != nil {
+		return
+	}
+	term = &models.TermHTTP{}
+	term.FromCore(termCore)
+	return
+}
+
+func (p *TermsDelegateImpl) GetTermsByRobboUnitId(robboUnitId string) (termsHttp []*models.TermHTTP, err error) {
+	termsCore, err := p.UseCase.GetTermsByRobboUnitId(robboUnitId)
+	if err != nil {
+		return
+	}
+	termsHttp = make([]*models.TermHTTP, 0, len(termsCore))
+	for _, termCore := range termsCore {
+		var termTemp models.TermHTTP
+		termTemp.FromCore(termCore)
+		termsHttp = append(termsHttp, &termTemp)
+	}
+	return
+}
+
+func (p *TermsDelegateImpl) RolloverTerm(rollover *models.TermRollover) (nextTerm *models.TermHTTP, err error) {
+	startDate, endDate, err := parseDates(rollover.StartDate, rollover.EndDate)
+	if err != nil {
+		return
+	}
+	promotions := make(map[string]string, len(rollover.Promotions))
+	for _, promotion := range rollover.Promotions {
+		promotions[promotion.FromRobboGroupID] = promotion.ToRobboGroupID
+	}
+	termCore, err := p.UseCase.RolloverTerm(&models.TermRolloverCore{
+		FromTermId: rollover.FromTermID,
+		NextTerm: &models.TermCore{
+			Name:      rollover.Name,
+			StartDate: startDate,
+			EndDate:   endDate,
+		},
+		CarryStudents: rollover.CarryStudents,
+		Promotions:    promotions,
+	})
+	if err != nil {
+		return
+	}
+	nextTerm = &models.TermHTTP{}
+	nextTerm.FromCore(termCore)
+	return
+}
+
+func (p *TermsDelegateImpl) ArchiveTerm(termId string) (term *models.TermHTTP, err error) {
+	termCore, err := p.UseCase.ArchiveTerm(termId)
+	if err != nil {
+		return
+	}
+	term = &models.TermHTTP{}
+	term.FromCore(termCore)
+	return
+}
+
+func parseDates(start, end string) (startDate, endDate time.Time, err error) {
+	if startDate, err = time.Parse(time.RFC3339, start); err != nil {
+		err = terms.ErrBadTimestampFormat
+		return
+	}
+	if endDate, err = time.Parse(time.RFC3339, end); err != nil {
+		err = terms.ErrBadTimestampFormat
+	}
+	return
+}
@@ -0,0 +1,12 @@
+package terms
+
+import "errors"
+
+var (
+	ErrTermNotFound         = errors.New("term not found")
+	ErrTermArchived         = errors.New("term is archived")
+	ErrBadTermDates         = errors.New("term must end after it starts")
+	ErrTermsOverlap         = errors.New("next term must start after the previous one ends")
+	ErrPromotionOutsideTerm = errors.New("promotion groups must belong to the term being rolled over")
+	ErrBadTimestampFormat   = errors.New("timestamp must be in RFC3339 format")
+)
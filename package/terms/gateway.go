@@ -0,0 +1,11 @@
+package terms
+
+import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+
+type Gateway interface {
+	CreateTerm(term *models.TermCore) (termId string, err error)
+	GetTermById(termId string) (term *models.TermCore, err error)
+	GetTermsByRobboUnitId(robboUnitId string) (terms []*models.TermCore, err error)
+	RolloverTerm(rollover *models.TermRolloverCore) (nextTermId string, err error)
+	ArchiveTerm(termId string) (err error)
+}
@@ -0,0 +1,305 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"time"
+)
+
+type TermsGatewayImpl struct {
+	PostgresClient *db_client.PostgresClient
+}
+
+type TermsGatewayModule struct {
+	fx.Out
+	terms.Gateway
+}
+
+func SetupTermsGateway(postgresClient db_client.PostgresClient) TermsGatewayModule {
+	return TermsGatewayModule{
+		Gateway: &TermsGatewayImpl{PostgresClient: &postgresClient},
+	}
+}
+
+func (r *TermsGatewayImpl) CreateTerm(term *models.TermCore) (termId string, err error) {
+	termDb := models.TermDB{}
+	termDb.FromCore(term)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Create(&termDb).Error
+	})
+	termId = strconv.FormatUint(uint64(termDb.ID), 10)
+	return
+}
+
+func (r *TermsGatewayImpl) GetTermById(termId string) (term *models.TermCore, err error) {
+	var termDb models.TermDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", termId).First(&termDb).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return terms.ErrTermNotFound
+			}
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+	term = termDb.ToCore()
+	return
+}
+
+func (r *TermsGatewayImpl) GetTermsByRobboUnitId(robboUnitId string) (termsCore []*models.TermCore, err error) {
+	var termsDb []*models.TermDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("robbo_unit_id = ?", robboUnitId).Order("start_date desc").Find(&termsDb).Error
+	})
+	for _, termDb := range termsDb {
+		termsCore = append(termsCore, termDb.ToCore())
+	}
+	return
+}
+
+// RolloverTerm opens the next term with a copy of every group of the current one, together with
+// teachers, weekly schedule and course packets, moves the students over when asked to
+// and archives the current term. Capacities are copied but not enforced while moving students.
+func (r *TermsGatewayImpl) RolloverTerm(rollover *models.TermRolloverCore) (nextTermId string, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		fromTerm, err := lockActiveTerm(tx, rollover.FromTermId)
+		if err != nil {
+			return
+		}
+		if !rollover.NextTerm.StartDate.After(fromTerm.EndDate) {
+			return terms.ErrTermsOverlap
+		}
+		nextTerm := models.TermDB{}
+		nextTerm.FromCore(rollover.NextTerm)
+		nextTerm.RobboUnitId = fromTerm.RobboUnitId
+		nextTerm.Status = string(models.TermActive)
+		nextTerm.PreviousTermId = rollover.FromTermId
+		if err = tx.Create(&nextTerm).Error; err != nil {
+			return
+		}
+		nextTermId = strconv.FormatUint(uint64(nextTerm.ID), 10)
+
+		var groups []*models.RobboGroupDB
+		if err = tx.Where("term_id = ?", rollover.FromTermId).Order("id").Find(&groups).Error; err != nil {
+			return
+		}
+		groupIds := make(map[string]bool, len(groups))
+		for _, group := range groups {
+			groupIds[strconv.FormatUint(uint64(group.ID), 10)] = true
+		}
+		for from, to := range rollover.Promotions {
+			if !groupIds[from] || !groupIds[to] {
+				return terms.ErrPromotionOutsideTerm
+			}
+		}
+
+		clones := make(map[string]string, len(groups))
+		for _, group := range groups {
+			groupId := strconv.FormatUint(uint64(group.ID), 10)
+			clone := models.RobboGroupDB{
+				RobboUnitId: group.RobboUnitId,
+				Name:        group.Name,
+				Capacity:    group.Capacity,
+				TermId:      nextTermId,
+			}
+			if err = tx.Create(&clone).Error; err != nil {
+				return
+			}
+			clones[groupId] = strconv.FormatUint(uint64(clone.ID), 10)
+			if err = copyGroupSetup(tx, groupId, clones[groupId]); err != nil {
+				return
+			}
+		}
+
+		if rollover.CarryStudents {
+			if err = carryStudents(tx, groups, clones, rollover.Promotions, nextTerm.StartDate); err != nil {
+				return
+			}
+		}
+		return archive(tx, fromTerm)
+	})
+	return
+}
+
+// ArchiveTerm ends the term without a successor: its groups become read-only and lose their students.
+func (r *TermsGatewayImpl) ArchiveTerm(termId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		term, err := lockActiveTerm(tx, termId)
+		if err != nil {
+			return
+		}
+		return archive(tx, term)
+	})
+	return
+}
+
+func lockActiveTerm(tx *gorm.DB, termId string) (term *models.TermDB, err error) {
+	term = &models.TermDB{}
+	if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", termId).First(term).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, terms.ErrTermNotFound
+		}
+		return
+	}
+	if term.Status == string(models.TermArchived) {
+		return nil, terms.ErrTermArchived
+	}
+	return
+}
+
+func copyGroupSetup(tx *gorm.DB, groupId, cloneId string) (err error) {
+	var teachers []*models.TeachersRobboGroupsDB
+	if err = tx.Where("robbo_group_id = ?", groupId).Find(&teachers).Error; err != nil {
+		return
+	}
+	for _, teacher := range teachers {
+		if err = tx.Create(&models.TeachersRobboGroupsDB{TeacherId: teacher.TeacherId, RobboGroupId: cloneId}).Error; err != nil {
+			return
+		}
+	}
+
+	var slots []*models.ScheduleSlotDB
+	if err = tx.Where("robbo_group_id = ?", groupId).Find(&slots).Error; err != nil {
+		return
+	}
+	for _, slot := range slots {
+		slotClone := *slot
+		slotClone.Model = gorm.Model{}
+		slotClone.RobboGroupId = cloneId
+		if err = tx.Create(&slotClone).Error; err != nil {
+			return
+		}
+	}
+
+	var packets []*models.RobboGroupCoursePacketDB
+	if err = tx.Where("robbo_group_id = ?", groupId).Find(&packets).Error; err != nil {
+		return
+	}
+	for _, packet := range packets {
+		if err = tx.Create(&models.RobboGroupCoursePacketDB{RobboGroupId: cloneId, CoursePacketId: packet.CoursePacketId}).Error; err != nil {
+			return
+		}
+	}
+	return
+}
+
+// carryStudents enrolls the current students of the groups into the clones of their target groups
+// and makes the clone their primary group when the old group was the primary one.
+func carryStudents(tx *gorm.DB, groups []*models.RobboGroupDB, clones, promotions map[string]string, joinedAt time.Time) (err error) {
+	type placement struct{ studentId, cloneId string }
+	placed := make(map[placement]bool)
+	for _, group := range groups {
+		groupId := strconv.FormatUint(uint64(group.ID), 10)
+		target := groupId
+		if promoted, ok := promotions[groupId]; ok {
+			target = promoted
+		}
+		studentIds, membersErr := currentStudentIds(tx, group)
+		if membersErr != nil {
+			return membersErr
+		}
+		for _, studentId := range studentIds {
+			key := placement{studentId, clones[target]}
+			if placed[key] {
+				continue
+			}
+			placed[key] = true
+			if err = tx.Create(&models.GroupMembershipDB{
+				StudentId:    studentId,
+				RobboGroupId: clones[target],
+				RobboUnitId:  group.RobboUnitId,
+				JoinedAt:     joinedAt,
+			}).Error; err != nil {
+				return
+			}
+			cloneId, _ := strconv.ParseUint(clones[target], 10, 64)
+			if err = tx.Model(&models.StudentDB{}).
+				Where("id = ? AND robbo_group_id = ?", studentId, group.ID).
+				Update("robbo_group_id", cloneId).Error; err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// currentStudentIds lists active members of the group and students that only have it as their
+// primary group, the latter get a membership record so that their history is not lost.
+func currentStudentIds(tx *gorm.DB, group *models.RobboGroupDB) (studentIds []string, err error) {
+	groupId := strconv.FormatUint(uint64(group.ID), 10)
+	if err = tx.Model(&models.GroupMembershipDB{}).
+		Where("robbo_group_id = ? AND left_at IS NULL", groupId).
+		Pluck("student_id", &studentIds).Error; err != nil {
+		return
+	}
+	members := make(map[string]bool, len(studentIds))
+	for _, studentId := range studentIds {
+		members[studentId] = true
+	}
+	var legacyStudents []*models.StudentDB
+	if err = tx.Where("robbo_group_id = ?", group.ID).Find(&legacyStudents).Error; err != nil {
+		return
+	}
+	for _, student := range legacyStudents {
+		studentId := strconv.FormatUint(uint64(student.ID), 10)
+		if members[studentId] {
+			continue
+		}
+		if err = tx.Create(&models.GroupMembershipDB{
+			StudentId:    studentId,
+			RobboGroupId: groupId,
+			RobboUnitId:  group.RobboUnitId,
+			JoinedAt:     student.CreatedAt,
+		}).Error; err != nil {
+			return
+		}
+		studentIds = append(studentIds, studentId)
+	}
+	return
+}
+
+// archive closes the memberships and waitlists of the term groups and makes them read-only.
+// Students whose primary group is still one of them are left without a primary group but stay in their unit.
+func archive(tx *gorm.DB, term *models.TermDB) (err error) {
+	now := time.Now()
+	termId := strconv.FormatUint(uint64(term.ID), 10)
+	termGroups := tx.Model(&models.RobboGroupDB{}).Select("CAST(id AS text)").Where("term_id = ?", termId)
+	termGroupIds := tx.Model(&models.RobboGroupDB{}).Select("id").Where("term_id = ?", termId)
+
+	// legacy primaries get their closed membership before the groups are emptied
+	var groups []*models.RobboGroupDB
+	if err = tx.Where("term_id = ?", termId).Find(&groups).Error; err != nil {
+		return
+	}
+	for _, group := range groups {
+		if _, err = currentStudentIds(tx, group); err != nil {
+			return
+		}
+	}
+
+	if err = tx.Model(&models.GroupMembershipDB{}).
+		Where("robbo_group_id IN (?) AND left_at IS NULL", termGroups).
+		Updates(map[string]interface{}{"left_at": now, "leave_reason": string(models.MembershipTermEnded)}).Error; err != nil {
+		return
+	}
+	if err = tx.Where("robbo_group_id IN (?)", termGroups).Delete(&models.WaitlistEntryDB{}).Error; err != nil {
+		return
+	}
+	if err = tx.Model(&models.StudentDB{}).
+		Where("robbo_group_id IN (?)", termGroupIds).
+		Update("robbo_group_id", nil).Error; err != nil {
+		return
+	}
+	if err = tx.Model(&models.RobboGroupDB{}).Where("term_id = ?", termId).Update("archived_at", now).Error; err != nil {
+		return
+	}
+	return tx.Model(term).Update("status", string(models.TermArchived)).Error
+}
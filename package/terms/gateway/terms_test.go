@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestArchiveTermKeepsStudentsInTheirUnit(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(func(query string, args []interface{}) ([]string, [][]interface{}) {
+		if strings.Contains(query, `FROM "term_dbs"`) {
+			return []string{"id", "status"}, [][]interface{}{{int64(3), "active"}}
+		}
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	gateway := &TermsGatewayImpl{PostgresClient: postgresClient}
+
+	assert.NoError(t, gateway.ArchiveTerm("3"))
+	updates := recorder.Find(`UPDATE "student_dbs"`)
+	if assert.Len(t, updates, 1) {
+		assert.Contains(t, updates[0].SQL, `SET "robbo_group_id"=$1,"updated_at"=$2 WHERE`)
+		assert.Nil(t, updates[0].Args[0])
+		assert.NotContains(t, updates[0].SQL, "robbo_unit_id", "students stay in the unit of the archived groups")
+	}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	"go.uber.org/fx"
+)
+
+type TermsUseCaseImpl struct {
+	termsGateway terms.Gateway
+}
+
+type TermsUseCaseModule struct {
+	fx.Out
+	terms.UseCase
+}
+
+func SetupTermsUseCase(termsGateway terms.Gateway) TermsUseCaseModule {
+	return TermsUseCaseModule{
+		UseCase: &TermsUseCaseImpl{
+			termsGateway: termsGateway,
+		},
+	}
+}
+
+func (p *TermsUseCaseImpl) CreateTerm(term *models.TermCore) (newTerm *models.TermCore, err error) {
+	if err = validateTerm(term); err != nil {
+		return
+	}
+	term.Status = models.TermActive
+	termId, err := p.termsGateway.CreateTerm(term)
+	if err != nil {
+		return
+	}
+	return p.termsGateway.GetTermById(termId)
+}
+
+func (p *TermsUseCaseImpl) GetTermById(termId string) (term *models.TermCore, err error) {
+	return p.termsGateway.GetTermById(termId)
+}
+
+func (p *TermsUseCaseImpl) GetTermsByRobboUnitId(robboUnitId string) (termsCore []*models.TermCore, err error) {
+	return p.termsGateway.GetTermsByRobboUnitId(robboUnitId)
+}
+
+func (p *TermsUseCaseImpl) RolloverTerm(rollover *models.TermRolloverCore) (nextTerm *models.TermCore, err error) {
+	if err = validateTerm(rollover.NextTerm); err != nil {
+		return
+	}
+	nextTermId, err := p.termsGateway.RolloverTerm(rollover)
+	if err != nil {
+		return
+	}
+	return p.termsGateway.GetTermById(nextTermId)
+}
+
+func (p *TermsUseCaseImpl) ArchiveTerm(termId string) (term *models.TermCore, err error) {
+	if err = p.termsGateway.ArchiveTerm(termId); err != nil {
+		return
+	}
+	return p.termsGateway.GetTermById(termId)
+}
+
+func validateTerm(term *models.TermCore) error {
+	if !term.EndDate.After(term.StartDate) {
+		return terms.ErrBadTermDates
+	}
+	return nil
+}
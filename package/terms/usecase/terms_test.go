@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/terms"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestValidateTerm(t *testing.T) {
+	autumnStart := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	autumnEnd := time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, validateTerm(&models.TermCore{StartDate: autumnStart, EndDate: autumnEnd}))
+	assert.Equal(t, terms.ErrBadTermDates, validateTerm(&models.TermCore{StartDate: autumnEnd, EndDate: autumnStart}))
+	assert.Equal(t, terms.ErrBadTermDates, validateTerm(&models.TermCore{StartDate: autumnStart, EndDate: autumnStart}))
+}
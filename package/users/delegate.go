@@ -11,7 +11,16 @@ type Delegate interface {
 	GetStudentById(studentId string) (student *models.StudentHTTP, err error)
 	GetStudentByParentId(parentId string) (students []*models.StudentHTTP, err error)
 	UpdateStudent(student *models.StudentHTTP) (err error)
-	AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (err error)
+	AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (waitlistEntry *models.WaitlistEntryHTTP, err error)
+	RemoveStudentFromRobboGroup(studentId, robboGroupId string) (err error)
+	TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (membership *models.GroupMembershipHTTP, err error)
+	GetGroupMembershipsByStudentId(studentId string, activeOnly bool) (memberships []*models.GroupMembershipHTTP, err error)
+	GetGroupMembershipsByRobboGroupId(robboGroupId string, activeOnly bool) (memberships []*models.GroupMembershipHTTP, err error)
+	SetRobboGroupCapacity(robboGroupId string, capacity int) (err error)
+	GetWaitlistByRobboGroupId(robboGroupId string) (entries []*models.WaitlistEntryHTTP, err error)
+	RemoveFromWaitlist(studentId, robboGroupId string) (err error)
+	FindDuplicateStudents() (duplicates []*models.StudentDuplicateHTTP, err error)
+	MergeStudents(survivorId, duplicateId string) (student *models.StudentHTTP, err error)
 
 	//GetTeacher(email, password string) (teacher models.TeacherHTTP, err error)
 	GetTeacherById(teacherId string) (teacher *models.TeacherHTTP, err error)
@@ -41,6 +50,11 @@ type Delegate interface {
 	SearchUnitAdminByEmail(email string) (unitAdmins []*models.UnitAdminHTTP, err error)
 	GetUnitAdminByRobboUnitId(robboUnitId string) (unitAdmins []*models.UnitAdminHTTP, err error)
 
+	CreateRegionAdmin(regionAdminHTTP *models.RegionAdminHTTP) (id string, err error)
+	DeleteRegionAdmin(regionAdminId uint) (err error)
+	GetRegionAdminById(regionAdminId string) (regionAdmin models.RegionAdminHTTP, err error)
+	GetRegionAdminsByRegionId(regionId string) (regionAdmins []*models.RegionAdminHTTP, err error)
+
 	//GetSuperAdmin(email, password string) (superAdmin models.SuperAdminHTTP, err error)
 	GetSuperAdminById(superAdminId string) (superAdmin models.SuperAdminHTTP, err error)
 	UpdateSuperAdmin(superAdminHTTP *models.SuperAdminHTTP) (err error)
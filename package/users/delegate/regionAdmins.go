@@ -0,0 +1,40 @@
+package delegate
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+)
+
+func (p *UsersDelegateImpl) CreateRegionAdmin(regionAdminHTTP *models.RegionAdminHTTP) (id string, err error) {
+	regionAdminCore := &models.RegionAdminCore{
+		UserCore: regionAdminHTTP.UserHTTP.ToCore(),
+		RegionId: regionAdminHTTP.RegionID,
+	}
+	return p.UseCase.CreateRegionAdmin(regionAdminCore)
+}
+
+func (p *UsersDelegateImpl) DeleteRegionAdmin(regionAdminId uint) (err error) {
+	return p.UseCase.DeleteRegionAdmin(regionAdminId)
+}
+
+func (p *UsersDelegateImpl) GetRegionAdminById(regionAdminId string) (regionAdmin models.RegionAdminHTTP, err error) {
+	regionAdminCore, err := p.UseCase.GetRegionAdminById(regionAdminId)
+	if err != nil {
+		return regionAdmin, auth.ErrUserNotFound
+	}
+	regionAdmin.FromCore(regionAdminCore)
+	return
+}
+
+func (p *UsersDelegateImpl) GetRegionAdminsByRegionId(regionId string) (regionAdmins []*models.RegionAdminHTTP, err error) {
+	regionAdminsCore, err := p.UseCase.GetRegionAdminsByRegionId(regionId)
+	if err != nil {
+		return
+	}
+	for _, regionAdminCore := range regionAdminsCore {
+		regionAdmin := &models.RegionAdminHTTP{}
+		regionAdmin.FromCore(regionAdminCore)
+		regionAdmins = append(regionAdmins, regionAdmin)
+	}
+	return
+}
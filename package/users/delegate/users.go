@@ -86,8 +86,105 @@ func (p *UsersDelegateImpl) UpdateStudent(studentHTTP *models.StudentHTTP) (err
 	return p.UseCase.UpdateStudent(studentCore)
 }
 
-func (p *UsersDelegateImpl) AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (err error) {
-	return p.UseCase.AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId)
+func (p *UsersDelegateImpl) AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (waitlistEntry *models.WaitlistEntryHTTP, err error) {
+	waitlistEntryCore, err := p.UseCase.AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId)
+	if err != nil || waitlistEntryCore == nil {
+		return
+	}
+	waitlistEntry = &models.WaitlistEntryHTTP{}
+	waitlistEntry.FromCore(waitlistEntryCore)
+	return
+}
+
+func (p *UsersDelegateImpl) RemoveStudentFromRobboGroup(studentId, robboGroupId string) (err error) {
+	return p.UseCase.RemoveStudentFromRobboGroup(studentId, robboGroupId)
+}
+
+func (p *UsersDelegateImpl) TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (membership *models.GroupMembershipHTTP, err error) {
+	membershipCore, err := p.UseCase.TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId)
+	if err != nil {
+		return
+	}
+	membership = &models.GroupMembershipHTTP{}
+	membership.FromCore(membershipCore)
+	return
+}
+
+func (p *UsersDelegateImpl) GetGroupMembershipsByStudentId(studentId string, activeOnly bool) (memberships []*models.GroupMembershipHTTP, err error) {
+	membershipsCore, err := p.UseCase.GetGroupMembershipsByStudentId(studentId, activeOnly)
+	if err != nil {
+		return
+	}
+	return membershipsToHTTP(membershipsCore), nil
+}
+
+func (p *UsersDelegateImpl) GetGroupMembershipsByRobboGroupId(robboGroupId string, activeOnly bool) (memberships []*models.GroupMembershipHTTP, err error) {
+	membershipsCore, err := p.UseCase.GetGroupMembershipsByRobboGroupId(robboGroupId, activeOnly)
+	if err != nil {
+		return
+	}
+	return membershipsToHTTP(membershipsCore), nil
+}
+
+func (p *UsersDelegateImpl) SetRobboGroupCapacity(robboGroupId string, capacity int) (err error) {
+	return p.UseCase.SetRobboGroupCapacity(robboGroupId, capacity)
+}
+
+func (p *UsersDelegateImpl) GetWaitlistByRobboGroupId(robboGroupId string) (entries []*models.WaitlistEntryHTTP, err error) {
+	entriesCore, err := p.UseCase.GetWaitlistByRobboGroupId(robboGroupId)
+	if err != nil {
+		return
+	}
+	return waitlistToHTTP(entriesCore), nil
+}
+
+func (p *UsersDelegateImpl) RemoveFromWaitlist(studentId, robboGroupId string) (err error) {
+	return p.UseCase.RemoveFromWaitlist(studentId, robboGroupId)
+}
+
+func waitlistToHTTP(entriesCore []*models.WaitlistEntryCore) (entries []*models.WaitlistEntryHTTP) {
+	entries = make([]*models.WaitlistEntryHTTP, 0, len(entriesCore))
+	for _, entryCore := range entriesCore {
+		var entryTemp models.WaitlistEntryHTTP
+		entryTemp.FromCore(entryCore)
+		entries = append(entries, &entryTemp)
+	}
+	return
+}
+
+func membershipsToHTTP(membershipsCore []*models.GroupMembershipCore) (memberships []*models.GroupMembershipHTTP) {
+	memberships = make([]*models.GroupMembershipHTTP, 0, len(membershipsCore))
+	for _, membershipCore := range membershipsCore {
+		var membershipTemp models.GroupMembershipHTTP
+		membershipTemp.FromCore(membershipCore)
+		memberships = append(memberships, &membershipTemp)
+	}
+	return
+}
+
+func (p *UsersDelegateImpl) FindDuplicateStudents() (duplicates []*models.StudentDuplicateHTTP, err error) {
+	duplicatesCore, err := p.UseCase.FindDuplicateStudents()
+	if err != nil {
+		return
+	}
+	for _, duplicateCore := range duplicatesCore {
+		var duplicateTemp models.StudentDuplicateHTTP
+		duplicateTemp.FromCore(duplicateCore)
+		duplicates = append(duplicates, &duplicateTemp)
+	}
+	return
+}
+
+func (p *UsersDelegateImpl) MergeStudents(survivorId, duplicateId string) (student *models.StudentHTTP, err error) {
+	studentCore, err := p.UseCase.MergeStudents(survivorId, duplicateId)
+	if err != nil {
+		return
+	}
+	student = &models.StudentHTTP{
+		UserHTTP: &models.UserHTTP{},
+	}
+	student.FromCore(studentCore)
+	return
 }
 
 func (p *UsersDelegateImpl) GetTeacherById(teacherId string) (teacher *models.TeacherHTTP, err error) {
@@ -0,0 +1,15 @@
+package users
+
+import "errors"
+
+var (
+	ErrMergeSameStudent   = errors.New("student can not be merged with itself")
+	ErrNotGroupMember     = errors.New("student is not a member of the robbo group")
+	ErrAlreadyGroupMember = errors.New("student is already a member of the robbo group")
+	ErrTransferSameGroup  = errors.New("student can not be transferred to the same robbo group")
+	ErrRobboGroupFull     = errors.New("robbo group has no free seats")
+	ErrRobboGroupNotFound = errors.New("robbo group not found")
+	ErrNotOnWaitlist      = errors.New("student is not on the waitlist of the robbo group")
+	ErrRobboGroupArchived = errors.New("robbo group belongs to an archived term")
+	ErrBadCapacity        = errors.New("capacity can not be negative")
+)
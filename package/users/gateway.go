@@ -5,12 +5,22 @@ import "github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 type Gateway interface {
 	GetStudent(email, password string) (student *models.StudentCore, err error)
 	SearchStudentByEmail(email string) (students []*models.StudentCore, err error)
-	AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId string) (err error)
+	AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId string) (waitlistEntry *models.WaitlistEntryCore, err error)
 	CreateStudent(student *models.StudentCore) (id string, err error)
 	DeleteStudent(studentId uint) (err error)
 	GetStudentById(studentId string) (student *models.StudentCore, err error)
 	GetStudentsByRobboGroupId(robboGroupId string) (students []*models.StudentCore, err error)
 	UpdateStudent(student *models.StudentCore) (err error)
+	GetAllStudents() (students []*models.StudentCore, err error)
+	MergeStudents(survivorId, duplicateId string) (err error)
+
+	RemoveStudentFromRobboGroup(studentId, robboGroupId string) (promoted []*models.WaitlistEntryCore, err error)
+	TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (membership *models.GroupMembershipCore, promoted []*models.WaitlistEntryCore, err error)
+	GetGroupMembershipsByStudentId(studentId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error)
+	GetGroupMembershipsByRobboGroupId(robboGroupId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error)
+	SetRobboGroupCapacity(robboGroupId string, capacity int) (promoted []*models.WaitlistEntryCore, err error)
+	GetWaitlistByRobboGroupId(robboGroupId string) (entries []*models.WaitlistEntryCore, err error)
+	RemoveFromWaitlist(studentId, robboGroupId string) (err error)
 
 	GetTeacher(email, password string) (teacher models.TeacherCore, err error)
 	GetAllTeachers() (teachers []models.TeacherCore, err error)
@@ -40,6 +50,12 @@ type Gateway interface {
 	UpdateUnitAdmin(unitAdmin *models.UnitAdminCore) (err error)
 	SearchUnitAdminByEmail(email string) (unitAdmins []*models.UnitAdminCore, err error)
 
+	GetRegionAdmin(email, password string) (regionAdmin *models.RegionAdminCore, err error)
+	GetRegionAdminById(regionAdminId string) (regionAdmin *models.RegionAdminCore, err error)
+	GetRegionAdminsByRegionId(regionId string) (regionAdmins []*models.RegionAdminCore, err error)
+	CreateRegionAdmin(regionAdmin *models.RegionAdminCore) (id string, err error)
+	DeleteRegionAdmin(regionAdminId uint) (err error)
+
 	GetSuperAdmin(email, password string) (superAdmin *models.SuperAdminCore, err error)
 	GetSuperAdminById(superAdminId string) (superAdmin *models.SuperAdminCore, err error)
 	UpdateSuperAdmin(superAdmin *models.SuperAdminCore) (err error)
@@ -51,6 +67,7 @@ type Gateway interface {
 	DeleteRelation(relation *models.ChildrenOfParentCore) (err error)
 	GetRelationByParentId(parentId string) (relations []*models.ChildrenOfParentCore, err error)
 	GetRelationByChildrenId(childrenId string) (relations []*models.ChildrenOfParentCore, err error)
+	GetAllRelations() (relations []*models.ChildrenOfParentCore, err error)
 
 	SetUnitAdminForRobboUnit(relation *models.UnitAdminsRobboUnitsCore) (err error)
 	DeleteUnitAdminForRobboUnit(relation *models.UnitAdminsRobboUnitsCore) (err error)
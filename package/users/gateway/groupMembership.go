@@ -0,0 +1,399 @@
+package gateway
+
+import (
+	"errors"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"time"
+)
+
+// AddStudentToRobboGroup opens a membership in the group and makes it the primary group
+// of the student. When the group is full the student is queued on its waitlist instead and
+// the returned entry is not nil. Adding a student to a group they are already in leaves the history as is.
+func (r *UsersGatewayImpl) AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId string) (waitlistEntry *models.WaitlistEntryCore, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		student, err := ensureLegacyMembership(tx, studentId)
+		if err != nil {
+			return
+		}
+		_, err = activeMembership(tx, studentId, robboGroupId)
+		if err == nil {
+			return setPrimaryRobboGroup(tx, student, robboGroupId, robboUnitId)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return
+		}
+
+		group, err := lockRobboGroup(tx, robboGroupId)
+		if err != nil {
+			return
+		}
+		hasSeat, err := hasFreeSeat(tx, group)
+		if err != nil {
+			return
+		}
+		if !hasSeat {
+			waitlistEntry, err = enqueue(tx, studentId, robboGroupId, robboUnitId)
+			return
+		}
+
+		if err = openMembership(tx, studentId, robboGroupId, robboUnitId, time.Now()); err != nil {
+			return
+		}
+		if err = tx.Where("student_id = ? AND robbo_group_id = ?", studentId, robboGroupId).
+			Delete(&models.WaitlistEntryDB{}).Error; err != nil {
+			return
+		}
+		err = setPrimaryRobboGroup(tx, student, robboGroupId, robboUnitId)
+		return
+	})
+	return
+}
+
+// RemoveStudentFromRobboGroup closes the membership and hands the freed seat to the waitlist.
+func (r *UsersGatewayImpl) RemoveStudentFromRobboGroup(studentId, robboGroupId string) (promoted []*models.WaitlistEntryCore, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		student, err := ensureLegacyMembership(tx, studentId)
+		if err != nil {
+			return
+		}
+		membership, err := activeMembership(tx, studentId, robboGroupId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return users.ErrNotGroupMember
+			}
+			return
+		}
+		if err = closeMembership(tx, membership, models.MembershipRemoved); err != nil {
+			return
+		}
+		if err = fallbackPrimaryRobboGroup(tx, student, robboGroupId); err != nil {
+			return
+		}
+		promoted, err = promoteFromWaitlist(tx, robboGroupId)
+		return
+	})
+	return
+}
+
+// TransferStudent moves the student between groups. Unlike a plain assignment a transfer
+// into a full group fails instead of queueing, the seat left behind goes to the waitlist.
+func (r *UsersGatewayImpl) TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (membership *models.GroupMembershipCore, promoted []*models.WaitlistEntryCore, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		student, err := ensureLegacyMembership(tx, studentId)
+		if err != nil {
+			return
+		}
+		from, err := activeMembership(tx, studentId, fromRobboGroupId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return users.ErrNotGroupMember
+			}
+			return
+		}
+		_, err = activeMembership(tx, studentId, toRobboGroupId)
+		if err == nil {
+			return users.ErrAlreadyGroupMember
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return
+		}
+
+		toGroup, err := lockRobboGroup(tx, toRobboGroupId)
+		if err != nil {
+			return
+		}
+		hasSeat, err := hasFreeSeat(tx, toGroup)
+		if err != nil {
+			return
+		}
+		if !hasSeat {
+			return users.ErrRobboGroupFull
+		}
+
+		if err = closeMembership(tx, from, models.MembershipTransferred); err != nil {
+			return
+		}
+		if err = openMembership(tx, studentId, toRobboGroupId, toRobboUnitId, *from.LeftAt); err != nil {
+			return
+		}
+		if err = tx.Where("student_id = ? AND robbo_group_id = ?", studentId, toRobboGroupId).
+			Delete(&models.WaitlistEntryDB{}).Error; err != nil {
+			return
+		}
+		if student.RobboGroupId == 0 || strconv.FormatUint(uint64(student.RobboGroupId), 10) == fromRobboGroupId {
+			if err = setPrimaryRobboGroup(tx, student, toRobboGroupId, toRobboUnitId); err != nil {
+				return
+			}
+		}
+
+		var membershipDb models.GroupMembershipDB
+		if err = tx.Where("student_id = ? AND robbo_group_id = ? AND left_at IS NULL", studentId, toRobboGroupId).
+			First(&membershipDb).Error; err != nil {
+			return
+		}
+		membership = membershipDb.ToCore()
+		promoted, err = promoteFromWaitlist(tx, fromRobboGroupId)
+		return
+	})
+	return
+}
+
+// SetRobboGroupCapacity changes the number of seats of the group and fills the seats a grown
+// group has from its waitlist at once. Zero leaves the group without a limit.
+func (r *UsersGatewayImpl) SetRobboGroupCapacity(robboGroupId string, capacity int) (promoted []*models.WaitlistEntryCore, err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		group, err := lockRobboGroup(tx, robboGroupId)
+		if err != nil {
+			return
+		}
+		if err = tx.Model(group).Update("capacity", capacity).Error; err != nil {
+			return
+		}
+		promoted, err = promoteFromWaitlist(tx, robboGroupId)
+		return
+	})
+	return
+}
+
+func (r *UsersGatewayImpl) GetWaitlistByRobboGroupId(robboGroupId string) (entries []*models.WaitlistEntryCore, err error) {
+	var entriesDb []*models.WaitlistEntryDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		err = tx.Where("robbo_group_id = ?", robboGroupId).Order("id").Find(&entriesDb).Error
+		return
+	})
+
+	for i, entryDb := range entriesDb {
+		entry := entryDb.ToCore()
+		entry.Position = i + 1
+		entries = append(entries, entry)
+	}
+	return
+}
+
+func (r *UsersGatewayImpl) RemoveFromWaitlist(studentId, robboGroupId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		result := tx.Where("student_id = ? AND robbo_group_id = ?", studentId, robboGroupId).
+			Delete(&models.WaitlistEntryDB{})
+		if err = result.Error; err != nil {
+			return
+		}
+		if result.RowsAffected == 0 {
+			return users.ErrNotOnWaitlist
+		}
+		return
+	})
+	return
+}
+
+func (r *UsersGatewayImpl) GetGroupMembershipsByStudentId(studentId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error) {
+	return r.getGroupMemberships("student_id = ?", studentId, activeOnly)
+}
+
+func (r *UsersGatewayImpl) GetGroupMembershipsByRobboGroupId(robboGroupId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error) {
+	return r.getGroupMemberships("robbo_group_id = ?", robboGroupId, activeOnly)
+}
+
+func (r *UsersGatewayImpl) getGroupMemberships(condition, value string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error) {
+	var membershipsDb []*models.GroupMembershipDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		query := tx.Where(condition, value)
+		if activeOnly {
+			query = query.Where("left_at IS NULL")
+		}
+		err = query.Order("joined_at desc").Find(&membershipsDb).Error
+		return
+	})
+
+	for _, membershipDb := range membershipsDb {
+		memberships = append(memberships, membershipDb.ToCore())
+	}
+	return
+}
+
+// ensureLegacyMembership records the membership of students that were put into
+// a group before memberships existed, so that leaving the group is not lost.
+func ensureLegacyMembership(tx *gorm.DB, studentId string) (student *models.StudentDB, err error) {
+	student = &models.StudentDB{}
+	if err = tx.Where("id = ?", studentId).First(student).Error; err != nil {
+		return nil, auth.ErrUserNotFound
+	}
+	if student.RobboGroupId == 0 {
+		return
+	}
+	robboGroupId := strconv.FormatUint(uint64(student.RobboGroupId), 10)
+	var count int64
+	if err = tx.Model(&models.GroupMembershipDB{}).
+		Where("student_id = ? AND robbo_group_id = ?", studentId, robboGroupId).
+		Count(&count).Error; err != nil || count > 0 {
+		return
+	}
+	err = tx.Create(&models.GroupMembershipDB{
+		StudentId:    studentId,
+		RobboGroupId: robboGroupId,
+		RobboUnitId:  strconv.FormatUint(uint64(student.RobboUnitId), 10),
+		JoinedAt:     student.UpdatedAt,
+	}).Error
+	return
+}
+
+func activeMembership(tx *gorm.DB, studentId, robboGroupId string) (membership *models.GroupMembershipDB, err error) {
+	membership = &models.GroupMembershipDB{}
+	err = tx.Where("student_id = ? AND robbo_group_id = ? AND left_at IS NULL", studentId, robboGroupId).
+		First(membership).Error
+	return
+}
+
+func openMembership(tx *gorm.DB, studentId, robboGroupId, robboUnitId string, joinedAt time.Time) (err error) {
+	return tx.Create(&models.GroupMembershipDB{
+		StudentId:    studentId,
+		RobboGroupId: robboGroupId,
+		RobboUnitId:  robboUnitId,
+		JoinedAt:     joinedAt,
+	}).Error
+}
+
+func closeMembership(tx *gorm.DB, membership *models.GroupMembershipDB, reason models.MembershipLeaveReason) (err error) {
+	now := time.Now()
+	membership.LeftAt = &now
+	membership.LeaveReason = string(reason)
+	return tx.Model(membership).Updates(map[string]interface{}{
+		"left_at":      now,
+		"leave_reason": string(reason),
+	}).Error
+}
+
+func setPrimaryRobboGroup(tx *gorm.DB, student *models.StudentDB, robboGroupId, robboUnitId string) (err error) {
+	groupId, err := strconv.ParseUint(robboGroupId, 10, 64)
+	if err != nil {
+		return
+	}
+	unitId, err := strconv.ParseUint(robboUnitId, 10, 64)
+	if err != nil {
+		return
+	}
+	return tx.Model(student).Updates(map[string]interface{}{
+		"robbo_group_id": uint(groupId),
+		"robbo_unit_id":  uint(unitId),
+	}).Error
+}
+
+// fallbackPrimaryRobboGroup moves the primary group of the student that has just left
+// robboGroupId to the most recent of the remaining groups.
+func fallbackPrimaryRobboGroup(tx *gorm.DB, student *models.StudentDB, robboGroupId string) (err error) {
+	if strconv.FormatUint(uint64(student.RobboGroupId), 10) != robboGroupId {
+		return
+	}
+	var next models.GroupMembershipDB
+	err = tx.Where("student_id = ? AND left_at IS NULL", student.ID).Order("joined_at desc").First(&next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Model(student).Updates(map[string]interface{}{
+			"robbo_group_id": nil,
+			"robbo_unit_id":  nil,
+		}).Error
+	}
+	if err != nil {
+		return
+	}
+	return setPrimaryRobboGroup(tx, student, next.RobboGroupId, next.RobboUnitId)
+}
+
+// lockRobboGroup serializes seat accounting of the group until the transaction ends.
+// Groups of archived terms are read-only and can not be locked for changes.
+func lockRobboGroup(tx *gorm.DB, robboGroupId string) (group *models.RobboGroupDB, err error) {
+	group = &models.RobboGroupDB{}
+	if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", robboGroupId).First(group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, users.ErrRobboGroupNotFound
+		}
+		return
+	}
+	if group.ArchivedAt != nil {
+		return nil, users.ErrRobboGroupArchived
+	}
+	return
+}
+
+func hasFreeSeat(tx *gorm.DB, group *models.RobboGroupDB) (hasSeat bool, err error) {
+	if group.Capacity == 0 {
+		return true, nil
+	}
+	activeMembers := tx.Model(&models.GroupMembershipDB{}).
+		Select("CAST(student_id AS bigint)").
+		Where("robbo_group_id = ? AND left_at IS NULL", strconv.FormatUint(uint64(group.ID), 10))
+	var count int64
+	if err = tx.Model(&models.StudentDB{}).
+		Where("robbo_group_id = ? OR id IN (?)", group.ID, activeMembers).
+		Count(&count).Error; err != nil {
+		return
+	}
+	return count < int64(group.Capacity), nil
+}
+
+func enqueue(tx *gorm.DB, studentId, robboGroupId, robboUnitId string) (entry *models.WaitlistEntryCore, err error) {
+	var entryDb models.WaitlistEntryDB
+	err = tx.Where(models.WaitlistEntryDB{StudentId: studentId, RobboGroupId: robboGroupId}).
+		Attrs(models.WaitlistEntryDB{RobboUnitId: robboUnitId}).
+		FirstOrCreate(&entryDb).Error
+	if err != nil {
+		return
+	}
+	var ahead int64
+	if err = tx.Model(&models.WaitlistEntryDB{}).
+		Where("robbo_group_id = ? AND id < ?", robboGroupId, entryDb.ID).
+		Count(&ahead).Error; err != nil {
+		return
+	}
+	entry = entryDb.ToCore()
+	entry.Position = int(ahead) + 1
+	return
+}
+
+// promoteFromWaitlist enrolls queued students in order while the group has free seats.
+func promoteFromWaitlist(tx *gorm.DB, robboGroupId string) (promoted []*models.WaitlistEntryCore, err error) {
+	group, err := lockRobboGroup(tx, robboGroupId)
+	if err != nil {
+		return
+	}
+	for {
+		hasSeat, seatErr := hasFreeSeat(tx, group)
+		if seatErr != nil || !hasSeat {
+			return promoted, seatErr
+		}
+		var entryDb models.WaitlistEntryDB
+		err = tx.Where("robbo_group_id = ?", robboGroupId).Order("id").First(&entryDb).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return promoted, nil
+		}
+		if err != nil {
+			return
+		}
+		if err = tx.Delete(&entryDb).Error; err != nil {
+			return
+		}
+
+		student, legacyErr := ensureLegacyMembership(tx, entryDb.StudentId)
+		if errors.Is(legacyErr, auth.ErrUserNotFound) {
+			// the student was deleted while waiting, the seat goes to the next one
+			continue
+		}
+		if legacyErr != nil {
+			return promoted, legacyErr
+		}
+		if err = openMembership(tx, entryDb.StudentId, robboGroupId, entryDb.RobboUnitId, time.Now()); err != nil {
+			return
+		}
+		if student.RobboGroupId == 0 {
+			if err = setPrimaryRobboGroup(tx, student, robboGroupId, entryDb.RobboUnitId); err != nil {
+				return
+			}
+		}
+		entry := entryDb.ToCore()
+		entry.RobboGroupName = group.Name
+		promoted = append(promoted, entry)
+	}
+}
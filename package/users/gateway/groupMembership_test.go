@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// waitingGroup is group 3 with one seat taken and students 7 and 8 waiting for it in that order.
+// Every promotion takes a seat, the group answers with the capacity stored last.
+func waitingGroup(capacity int) dbtest.Rows {
+	members := 1
+	waiting := []string{"7", "8"}
+	return func(query string, args []interface{}) ([]string, [][]interface{}) {
+		switch {
+		case strings.Contains(query, `UPDATE "robbo_group_dbs" SET "capacity"=`):
+			capacity = args[0].(int)
+		case strings.Contains(query, `FROM "robbo_group_dbs"`):
+			return []string{"id", "name", "capacity"}, [][]interface{}{{int64(3), "Роботы", int64(capacity)}}
+		case strings.Contains(query, "count(*)"):
+			return []string{"count"}, [][]interface{}{{int64(members)}}
+		case strings.Contains(query, `FROM "waitlist_entry_dbs"`):
+			if len(waiting) == 0 {
+				return nil, nil
+			}
+			return []string{"id", "student_id", "robbo_group_id", "robbo_unit_id"},
+				[][]interface{}{{int64(10 + len(waiting)), waiting[0], "3", "1"}}
+		case strings.Contains(query, `INSERT INTO "group_membership_dbs"`):
+			members++
+			waiting = waiting[1:]
+		case strings.Contains(query, `FROM "student_dbs"`):
+			return []string{"id", "robbo_group_id", "robbo_unit_id"}, [][]interface{}{{args[0], int64(0), int64(0)}}
+		}
+		return nil, nil
+	}
+}
+
+func TestSetRobboGroupCapacityPromotesInOrder(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(waitingGroup(1))
+	assert.NoError(t, err)
+	gateway := &UsersGatewayImpl{PostgresClient: postgresClient}
+
+	promoted, err := gateway.SetRobboGroupCapacity("3", 2)
+	assert.NoError(t, err)
+	if assert.Len(t, promoted, 1, "the grown group has one free seat") {
+		assert.Equal(t, "7", promoted[0].StudentId, "the first in the queue gets it")
+		assert.Equal(t, "Роботы", promoted[0].RobboGroupName)
+	}
+
+	statements := recorder.Statements()
+	if assert.NotEmpty(t, statements) {
+		assert.Contains(t, statements[0].SQL, "FOR UPDATE", "the group is locked before its seats are counted")
+	}
+	updates := recorder.Find(`UPDATE "robbo_group_dbs" SET "capacity"=`)
+	if assert.Len(t, updates, 1) {
+		assert.Equal(t, 2, updates[0].Args[0])
+	}
+	assert.NotEmpty(t, recorder.Find(`FROM "waitlist_entry_dbs"`, `ORDER BY id`))
+}
+
+func TestSetRobboGroupCapacityWithoutLimit(t *testing.T) {
+	postgresClient, _, err := dbtest.Open(waitingGroup(1))
+	assert.NoError(t, err)
+	gateway := &UsersGatewayImpl{PostgresClient: postgresClient}
+
+	promoted, err := gateway.SetRobboGroupCapacity("3", 0)
+	assert.NoError(t, err)
+	assert.Len(t, promoted, 2, "a group without a limit takes the whole waitlist")
+	assert.Equal(t, "8", promoted[1].StudentId)
+}
+
+func TestWaitlistEntryIsUnique(t *testing.T) {
+	waitlistSchema, err := schema.Parse(&models.WaitlistEntryDB{}, &sync.Map{}, schema.NamingStrategy{})
+	assert.NoError(t, err)
+	index, ok := waitlistSchema.ParseIndexes()["idx_waitlist_student_group"]
+	if assert.True(t, ok) {
+		assert.Equal(t, "UNIQUE", index.Class)
+		assert.Equal(t, "deleted_at IS NULL", index.Where, "a student may wait for the group again after leaving its waitlist")
+		if assert.Len(t, index.Fields, 2) {
+			assert.Equal(t, "student_id", index.Fields[0].DBName)
+			assert.Equal(t, "robbo_group_id", index.Fields[1].DBName)
+		}
+	}
+}
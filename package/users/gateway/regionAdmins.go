@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"gorm.io/gorm"
+	"log"
+	"strconv"
+)
+
+func (r *UsersGatewayImpl) GetRegionAdmin(email, password string) (regionAdmin *models.RegionAdminCore, err error) {
+	var regionAdminDb models.RegionAdminDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("email = ? AND password = ?", email, password).First(&regionAdminDb).Error; err != nil {
+			err = auth.ErrUserNotFound
+			log.Println(err)
+			return
+		}
+		return
+	})
+	regionAdmin = regionAdminDb.ToCore()
+	return
+}
+
+func (r *UsersGatewayImpl) GetRegionAdminById(regionAdminId string) (regionAdmin *models.RegionAdminCore, err error) {
+	var regionAdminDb models.RegionAdminDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Where("id = ?", regionAdminId).First(&regionAdminDb).Error; err != nil {
+			err = auth.ErrUserNotFound
+			log.Println(err)
+			return
+		}
+		return
+	})
+	regionAdmin = regionAdminDb.ToCore()
+	return
+}
+
+func (r *UsersGatewayImpl) GetRegionAdminsByRegionId(regionId string) (regionAdmins []*models.RegionAdminCore, err error) {
+	var regionAdminsDb []*models.RegionAdminDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Where("region_id = ?", regionId).Order("id").Find(&regionAdminsDb).Error
+	})
+	for _, regionAdminDb := range regionAdminsDb {
+		regionAdmins = append(regionAdmins, regionAdminDb.ToCore())
+	}
+	return
+}
+
+func (r *UsersGatewayImpl) CreateRegionAdmin(regionAdmin *models.RegionAdminCore) (id string, err error) {
+	regionAdminDb := models.RegionAdminDB{}
+	regionAdminDb.FromCore(regionAdmin)
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Create(&regionAdminDb).Error
+	})
+	id = strconv.FormatUint(uint64(regionAdminDb.ID), 10)
+	return
+}
+
+func (r *UsersGatewayImpl) DeleteRegionAdmin(regionAdminId uint) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		return tx.Delete(&models.RegionAdminDB{}, regionAdminId).Error
+	})
+	return
+}
@@ -10,6 +10,7 @@ import (
 	"gorm.io/gorm"
 	"log"
 	"strconv"
+	"strings"
 )
 
 type UsersGatewayImpl struct {
@@ -27,16 +28,6 @@ func SetupUsersGateway(postgresClient db_client.PostgresClient) UsersGatewayModu
 	}
 }
 
-func (r *UsersGatewayImpl) AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId string) (err error) {
-	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		err = tx.Model(&models.StudentDB{}).Where("id = ?", studentId).
-			Update("robbo_group_id", gorm.Expr(robboGroupId)).
-			Update("robbo_unit_id", gorm.Expr(robboUnitId)).Error
-		return
-	})
-	return
-}
-
 func (r *UsersGatewayImpl) GetStudent(email, password string) (student *models.StudentCore, err error) {
 	var studentDb models.StudentDB
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
@@ -109,7 +100,10 @@ func (r *UsersGatewayImpl) GetStudentsByRobboGroupId(robboGroupId string) (stude
 	var studentsDb []*models.StudentDB
 
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		if err = tx.Where("robbo_group_id = ?", robboGroupId).Find(&studentsDb).Error; err != nil {
+		activeMembers := tx.Model(&models.GroupMembershipDB{}).
+			Select("CAST(student_id AS bigint)").
+			Where("robbo_group_id = ? AND left_at IS NULL", robboGroupId)
+		if err = tx.Where("robbo_group_id = ? OR id IN (?)", robboGroupId, activeMembers).Find(&studentsDb).Error; err != nil {
 			return
 		}
 		return
@@ -132,6 +126,161 @@ func (r *UsersGatewayImpl) UpdateStudent(student *models.StudentCore) (err error
 	return
 }
 
+func (r *UsersGatewayImpl) GetAllStudents() (students []*models.StudentCore, err error) {
+	var studentsDb []*models.StudentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Find(&studentsDb).Error; err != nil {
+			return
+		}
+		return
+	})
+
+	for _, studentDb := range studentsDb {
+		students = append(students, studentDb.ToCore())
+	}
+	return
+}
+
+// MergeStudents moves everything that references the duplicate onto the survivor
+// and archives the duplicate. Either all of it happens or nothing does.
+func (r *UsersGatewayImpl) MergeStudents(survivorId, duplicateId string) (err error) {
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		var survivor, duplicate models.StudentDB
+		if err = tx.Where("id = ?", survivorId).First(&survivor).Error; err != nil {
+			return auth.ErrUserNotFound
+		}
+		if err = tx.Where("id = ?", duplicateId).First(&duplicate).Error; err != nil {
+			return auth.ErrUserNotFound
+		}
+
+		// parents already linked to the survivor keep a single relation
+		if err = moveStudentRows(tx, &models.ChildrenOfParentDB{}, "child_id", "", survivorId, duplicateId, "parent_id"); err != nil {
+			return
+		}
+
+		// teachers and students share ids, only the projects of the student move
+		if err = moveStudentRows(tx, &models.ProjectDB{}, "author_id", "author_role", survivorId, duplicateId); err != nil {
+			return
+		}
+		survivorProjects := tx.Unscoped().Model(&models.ProjectDB{}).Select("CAST(id AS TEXT)").
+			Where("author_id = ? AND author_role = ?", survivorId, models.Student)
+		if err = tx.Model(&models.ProjectPageDB{}).
+			Where("remixed_from_author_id = ? AND remixed_from_project_id IN (?)", duplicateId, survivorProjects).
+			Update("remixed_from_author_id", survivorId).Error; err != nil {
+			return
+		}
+
+		if err = moveStudentRows(tx, &models.LoginEventDB{}, "user_id", "role", survivorId, duplicateId); err != nil {
+			return
+		}
+
+		// a group both of them are active in keeps only the survivor's membership
+		survivorGroups := tx.Model(&models.GroupMembershipDB{}).Select("robbo_group_id").
+			Where("student_id = ? AND left_at IS NULL", survivorId)
+		if err = tx.Where("student_id = ? AND left_at IS NULL AND robbo_group_id IN (?)", duplicateId, survivorGroups).
+			Delete(&models.GroupMembershipDB{}).Error; err != nil {
+			return
+		}
+		if err = tx.Model(&models.GroupMembershipDB{}).Where("student_id = ?", duplicateId).
+			Update("student_id", survivorId).Error; err != nil {
+			return
+		}
+		// nor does the survivor wait for a group they study in now
+		if err = tx.Where("student_id = ? AND robbo_group_id IN (?)", duplicateId, survivorGroups).
+			Delete(&models.WaitlistEntryDB{}).Error; err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.WaitlistEntryDB{}, "student_id", "", survivorId, duplicateId, "robbo_group_id"); err != nil {
+			return
+		}
+
+		if err = moveStudentRows(tx, &models.AttendanceDB{}, "student_id", "", survivorId, duplicateId, "lesson_id"); err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.EdxEnrollmentDB{}, "student_id", "", survivorId, duplicateId, "course_id"); err != nil {
+			return
+		}
+
+		if err = moveStudentRows(tx, &models.ProjectLikeDB{}, "user_id", "user_role", survivorId, duplicateId, "project_id"); err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.ProjectFavoriteDB{}, "user_id", "user_role", survivorId, duplicateId, "project_id"); err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.ProjectViewDB{}, "viewer_id", "viewer_role", survivorId, duplicateId); err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.ProjectCommentDB{}, "author_id", "author_role", survivorId, duplicateId); err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.CommentReportDB{}, "reporter_id", "reporter_role", survivorId, duplicateId, "comment_id"); err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.NotificationDB{}, "recipient_id", "recipient_role", survivorId, duplicateId); err != nil {
+			return
+		}
+
+		// on an assignment both of them work on the survivor's work and its reviews are kept,
+		// the project of the other work stays with the survivor as an ordinary project
+		survivorAssignments := tx.Model(&models.AssignmentWorkDB{}).Select("assignment_id").
+			Where("student_id = ?", survivorId)
+		if err = tx.Where("student_id = ? AND assignment_id IN (?)", duplicateId, survivorAssignments).
+			Delete(&models.AssignmentReviewDB{}).Error; err != nil {
+			return
+		}
+		if err = tx.Model(&models.AssignmentReviewDB{}).Where("student_id = ?", duplicateId).
+			Update("student_id", survivorId).Error; err != nil {
+			return
+		}
+		if err = moveStudentRows(tx, &models.AssignmentWorkDB{}, "student_id", "", survivorId, duplicateId, "assignment_id"); err != nil {
+			return
+		}
+
+		if survivor.RobboGroupId == 0 && duplicate.RobboGroupId != 0 {
+			if err = tx.Model(&survivor).
+				Updates(map[string]interface{}{
+					"robbo_group_id": duplicate.RobboGroupId,
+					"robbo_unit_id":  duplicate.RobboUnitId,
+				}).Error; err != nil {
+				return
+			}
+		}
+
+		if err = tx.Model(&duplicate).Update("merged_into_id", survivor.ID).Error; err != nil {
+			return
+		}
+		err = tx.Delete(&duplicate).Error
+		return
+	})
+	return
+}
+
+// moveStudentRows points the rows of the duplicate at the survivor. roleColumn narrows tables that
+// keep users of every role down to students. The rows the survivor already has a counterpart for,
+// equal in the unique columns, are deleted instead, so the unique indexes hold and the survivor's own rows win.
+func moveStudentRows(tx *gorm.DB, model interface{}, column, roleColumn, survivorId, duplicateId string, unique ...string) (err error) {
+	owner := column + " = ?"
+	if roleColumn != "" {
+		owner += " AND " + roleColumn + " = ?"
+	}
+	ownerOf := func(studentId string) []interface{} {
+		if roleColumn != "" {
+			return []interface{}{studentId, models.Student}
+		}
+		return []interface{}{studentId}
+	}
+
+	if len(unique) > 0 {
+		columns := strings.Join(unique, ", ")
+		survivorRows := tx.Model(model).Select(columns).Where(owner, ownerOf(survivorId)...)
+		if err = tx.Where(owner+" AND ("+columns+") IN (?)", append(ownerOf(duplicateId), survivorRows)...).
+			Delete(model).Error; err != nil {
+			return
+		}
+	}
+	return tx.Model(model).Where(owner, ownerOf(duplicateId)...).Update(column, survivorId).Error
+}
+
 func (r *UsersGatewayImpl) GetTeacher(email, password string) (teacher models.TeacherCore, err error) {
 	var teacherDb models.TeacherDB
 
@@ -543,7 +692,22 @@ func (r *UsersGatewayImpl) GetRelationByParentId(parentId string) (relations []*
 func (r *UsersGatewayImpl) GetRelationByChildrenId(childrenId string) (relations []*models.ChildrenOfParentCore, err error) {
 	var relationsDB []*models.ChildrenOfParentDB
 	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
-		if err = tx.Where("children_id = ?", childrenId).Find(&relationsDB).Error; err != nil {
+		if err = tx.Where("child_id = ?", childrenId).Find(&relationsDB).Error; err != nil {
+			return
+		}
+		return
+	})
+
+	for _, relationDB := range relationsDB {
+		relations = append(relations, relationDB.ToCore())
+	}
+	return
+}
+
+func (r *UsersGatewayImpl) GetAllRelations() (relations []*models.ChildrenOfParentCore, err error) {
+	var relationsDB []*models.ChildrenOfParentDB
+	err = r.PostgresClient.Db.Transaction(func(tx *gorm.DB) (err error) {
+		if err = tx.Find(&relationsDB).Error; err != nil {
 			return
 		}
 		return
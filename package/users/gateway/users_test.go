@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/db_client/dbtest"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// students answers the lookups of students 1 and 2, everything else finds nothing.
+func students(query string, args []interface{}) ([]string, [][]interface{}) {
+	if !strings.Contains(query, `FROM "student_dbs"`) {
+		return nil, nil
+	}
+	return []string{"id", "robbo_group_id", "robbo_unit_id"}, [][]interface{}{{args[0], int64(0), int64(0)}}
+}
+
+func TestMergeStudents(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(students)
+	assert.NoError(t, err)
+	gateway := &UsersGatewayImpl{PostgresClient: postgresClient}
+
+	assert.NoError(t, gateway.MergeStudents("1", "2"))
+
+	moved := map[string]string{
+		"project_dbs":            "author_id",
+		"login_event_dbs":        "user_id",
+		"project_like_dbs":       "user_id",
+		"project_favorite_dbs":   "user_id",
+		"project_view_dbs":       "viewer_id",
+		"project_comment_dbs":    "author_id",
+		"comment_report_dbs":     "reporter_id",
+		"notification_dbs":       "recipient_id",
+		"children_of_parent_dbs": "child_id",
+		"group_membership_dbs":   "student_id",
+		"waitlist_entry_dbs":     "student_id",
+		"attendance_dbs":         "student_id",
+		"edx_enrollment_dbs":     "student_id",
+		"assignment_work_dbs":    "student_id",
+		"assignment_review_dbs":  "student_id",
+		"project_page_dbs":       "remixed_from_author_id",
+	}
+	for table, column := range moved {
+		updates := recorder.Find(`UPDATE "`+table+`" SET "`+column+`"=`, column+" = ")
+		if assert.Len(t, updates, 1, table) {
+			assert.Equal(t, "1", updates[0].Args[0], table)
+			assert.Contains(t, updates[0].Args, "2", table)
+		}
+	}
+
+	// tables that keep every role only move the rows of the student
+	for _, table := range []string{"project_dbs", "project_like_dbs", "project_comment_dbs", "notification_dbs"} {
+		updates := recorder.Find(`UPDATE "` + table + `"`)
+		if assert.NotEmpty(t, updates, table) {
+			assert.Contains(t, updates[0].SQL, "_role = ", table)
+			assert.Contains(t, updates[0].Args, models.Student, table)
+		}
+	}
+
+	// the rows the survivor already has are not duplicated
+	for _, table := range []string{"attendance_dbs", "edx_enrollment_dbs", "project_like_dbs", "assignment_work_dbs"} {
+		// soft deletes run as updates of deleted_at
+		assert.NotEmpty(t, recorder.Find(`"`+table+`"`, ") IN (SELECT"), table)
+	}
+	assert.NotEmpty(t, recorder.Find(`UPDATE "student_dbs" SET "deleted_at"=`))
+
+	statements := recorder.Statements()
+	assert.Contains(t, statements[0].SQL, `FROM "student_dbs"`, "the students are looked up before anything changes")
+}
+
+func TestMergeStudentsNotFound(t *testing.T) {
+	postgresClient, recorder, err := dbtest.Open(nil)
+	assert.NoError(t, err)
+	gateway := &UsersGatewayImpl{PostgresClient: postgresClient}
+
+	assert.Error(t, gateway.MergeStudents("1", "2"))
+	assert.Empty(t, recorder.Find("UPDATE"))
+}
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
@@ -14,15 +16,18 @@ import (
 type Handler struct {
 	authDelegate  auth.Delegate
 	usersDelegate users.Delegate
+	accessScope   access.Scope
 }
 
 func NewUsersHandler(
 	authDelegate auth.Delegate,
 	usersDelegate users.Delegate,
+	accessScope access.Scope,
 ) Handler {
 	return Handler{
 		authDelegate:  authDelegate,
 		usersDelegate: usersDelegate,
+		accessScope:   accessScope,
 	}
 }
 
@@ -63,7 +68,9 @@ func (h *Handler) InitUsersRoutes(router *gin.Engine) {
 		users.GET("students/:parentId", h.GetStudentByParentId)
 		users.PUT("/student", h.UpdateStudent)
 		users.POST("/student/:studentId/robboGroup/:robboGroupId", h.SetRobboGroupIdForStudent)
-		//users.DELETE("/student/:studentId/robboGroup/:robboGroupId", h.SetRobboGroupIdForStudent)
+		users.DELETE("/student/:studentId/robboGroup/:robboGroupId", h.RemoveStudentFromRobboGroup)
+		users.POST("/student/:studentId/robboGroup/:robboGroupId/transfer", h.TransferStudent)
+		users.GET("/student/:studentId/robboGroups", h.GetGroupMembershipsByStudentId)
 
 		users.POST("/teacher", h.CreateTeacher)
 		users.GET("/teachers", h.GetAllTeachers)
@@ -107,6 +114,7 @@ func (h *Handler) GetUser(c *gin.Context) {
 
 	if err != nil {
 		c.AbortWithStatus(http.StatusUnauthorized)
+		return
 	}
 
 	switch role {
@@ -156,6 +164,17 @@ func (h *Handler) GetUser(c *gin.Context) {
 		}
 		c.JSON(http.StatusOK, superAdmin)
 		return
+	case models.RegionAdmin:
+		regionAdmin, getRegionAdminErr := h.usersDelegate.GetRegionAdminById(userId)
+		if getRegionAdminErr != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, regionAdmin)
+		return
+	default:
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
 	}
 }
 
@@ -221,7 +240,7 @@ func (h *Handler) CreateStudent(c *gin.Context) {
 	fmt.Println("Create Student")
 
 	_, role, userIdentityErr := h.authDelegate.UserIdentity(c)
-	if role < models.UnitAdmin || userIdentityErr != nil {
+	if userIdentityErr != nil || !access.IsAdmin(role) {
 		fmt.Println(role)
 		fmt.Println(userIdentityErr)
 		c.AbortWithStatus(http.StatusUnauthorized)
@@ -303,13 +322,12 @@ type SetRobboGroupIdForStudentInput struct {
 
 func (h *Handler) SetRobboGroupIdForStudent(c *gin.Context) {
 	fmt.Println("SetRobboGroupIdForStudent")
-	_, _, userIdentityErr := h.authDelegate.UserIdentity(c)
-	if userIdentityErr != nil {
-		c.AbortWithStatus(http.StatusUnauthorized)
-	}
-
 	studentId := c.Param("studentId")
 	robboGroupId := c.Param("robboGroupId")
+	if !h.canManageRobboGroup(c, robboGroupId) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
 	input := new(SetRobboGroupIdForStudentInput)
 
 	if err := c.BindJSON(&input); err != nil {
@@ -319,15 +337,121 @@ func (h *Handler) SetRobboGroupIdForStudent(c *gin.Context) {
 	}
 
 	// TODO rename method to set robboGroupId, robboUnitId
-	err := h.usersDelegate.AddStudentToRobboGroup(studentId, robboGroupId, input.RobboUnitId)
+	waitlistEntry, err := h.usersDelegate.AddStudentToRobboGroup(studentId, robboGroupId, input.RobboUnitId)
 	if err != nil {
 		log.Println(err)
+		if errors.Is(err, users.ErrRobboGroupNotFound) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
+	if waitlistEntry != nil {
+		c.JSON(http.StatusAccepted, waitlistEntry)
+		return
+	}
 	c.Status(http.StatusOK)
 }
 
+func (h *Handler) RemoveStudentFromRobboGroup(c *gin.Context) {
+	fmt.Println("RemoveStudentFromRobboGroup")
+	studentId := c.Param("studentId")
+	robboGroupId := c.Param("robboGroupId")
+	if !h.canManageRobboGroup(c, robboGroupId) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	err := h.usersDelegate.RemoveStudentFromRobboGroup(studentId, robboGroupId)
+	if err != nil {
+		log.Println(err)
+		if errors.Is(err, users.ErrNotGroupMember) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+type TransferStudentInput struct {
+	ToRobboGroupId string `json:"toRobboGroupId"`
+	ToRobboUnitId  string `json:"toRobboUnitId"`
+}
+
+func (h *Handler) TransferStudent(c *gin.Context) {
+	fmt.Println("TransferStudent")
+	studentId := c.Param("studentId")
+	robboGroupId := c.Param("robboGroupId")
+	if !h.canManageRobboGroup(c, robboGroupId) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	input := new(TransferStudentInput)
+
+	if err := c.BindJSON(&input); err != nil {
+		log.Println(err)
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	// the group the student goes to has to be the admin's as well
+	if !h.canManageRobboGroup(c, input.ToRobboGroupId) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	membership, err := h.usersDelegate.TransferStudent(studentId, robboGroupId, input.ToRobboGroupId, input.ToRobboUnitId)
+	if err != nil {
+		log.Println(err)
+		switch {
+		case errors.Is(err, users.ErrNotGroupMember):
+			c.AbortWithStatus(http.StatusNotFound)
+		case errors.Is(err, users.ErrAlreadyGroupMember), errors.Is(err, users.ErrTransferSameGroup),
+			errors.Is(err, users.ErrRobboGroupFull):
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, membership)
+}
+
+// canManageRobboGroup lets admins change the members of the groups of their own units only.
+func (h *Handler) canManageRobboGroup(c *gin.Context, robboGroupId string) bool {
+	userId, role, userIdentityErr := h.authDelegate.UserIdentity(c)
+	if userIdentityErr != nil {
+		return false
+	}
+	if err := h.accessScope.CheckRobboGroupAdmin(userId, role, robboGroupId); err != nil {
+		log.Println(err)
+		return false
+	}
+	return true
+}
+
+func (h *Handler) GetGroupMembershipsByStudentId(c *gin.Context) {
+	fmt.Println("GetGroupMembershipsByStudentId")
+	_, _, userIdentityErr := h.authDelegate.UserIdentity(c)
+	if userIdentityErr != nil {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	studentId := c.Param("studentId")
+	activeOnly := c.Query("activeOnly") == "true"
+
+	memberships, err := h.usersDelegate.GetGroupMembershipsByStudentId(studentId, activeOnly)
+	if err != nil {
+		log.Println(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, memberships)
+}
+
 func (h *Handler) CreateTeacher(c *gin.Context) {
 	fmt.Println("Create Teacher")
 	_, _, userIdentityErr := h.authDelegate.UserIdentity(c)
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/access"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/auth"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// identity is who every request of a test is sent by.
+type identity struct {
+	auth.Delegate
+	id   string
+	role models.Role
+}
+
+func (i identity) UserIdentity(*gin.Context) (string, models.Role, error) {
+	if i.role == models.Anonymous {
+		return "", models.Anonymous, auth.ErrTokenNotFound
+	}
+	return i.id, i.role, nil
+}
+
+type usersDelegate struct {
+	users.Delegate
+	removed     []string
+	transferred []string
+}
+
+func (d *usersDelegate) RemoveStudentFromRobboGroup(studentId, robboGroupId string) error {
+	d.removed = append(d.removed, studentId+" from "+robboGroupId)
+	return nil
+}
+
+func (d *usersDelegate) TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (*models.GroupMembershipHTTP, error) {
+	d.transferred = append(d.transferred, studentId+" from "+fromRobboGroupId+" to "+toRobboGroupId)
+	return &models.GroupMembershipHTTP{StudentID: studentId, RobboGroupID: toRobboGroupId, RobboUnitID: toRobboUnitId}, nil
+}
+
+func (d *usersDelegate) GetRegionAdminById(regionAdminId string) (models.RegionAdminHTTP, error) {
+	return models.RegionAdminHTTP{UserHTTP: &models.UserHTTP{ID: regionAdminId}, RegionID: "north"}, nil
+}
+
+// unitScope lets admin 1 manage groups 1 and 2 of their unit only.
+type unitScope struct {
+	access.Scope
+}
+
+func (unitScope) CheckRobboGroupAdmin(userId string, userRole models.Role, robboGroupId string) error {
+	if access.IsAdmin(userRole) && userId == "1" && (robboGroupId == "1" || robboGroupId == "2") {
+		return nil
+	}
+	return access.ErrNoAccess
+}
+
+func serve(who identity, delegate *usersDelegate, method, path, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewUsersHandler(who, delegate, unitScope{})
+	handler.InitUsersRoutes(router)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetUser(t *testing.T) {
+	response := serve(identity{id: "7", role: models.RegionAdmin}, &usersDelegate{}, "GET", "/users/", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `"regionId":"north"`)
+
+	response = serve(identity{role: models.Anonymous}, &usersDelegate{}, "GET", "/users/", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Empty(t, response.Body.String())
+}
+
+func TestRemoveStudentFromRobboGroup(t *testing.T) {
+	delegate := &usersDelegate{}
+	response := serve(identity{id: "1", role: models.UnitAdmin}, delegate, "DELETE", "/users/student/5/robboGroup/1", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"5 from 1"}, delegate.removed)
+
+	cases := []struct {
+		name string
+		who  identity
+		path string
+	}{
+		{"anonymous", identity{role: models.Anonymous}, "/users/student/5/robboGroup/1"},
+		{"teacher", identity{id: "1", role: models.Teacher}, "/users/student/5/robboGroup/1"},
+		{"student", identity{id: "5", role: models.Student}, "/users/student/5/robboGroup/1"},
+		{"admin of another unit", identity{id: "2", role: models.UnitAdmin}, "/users/student/5/robboGroup/1"},
+		{"group of another unit", identity{id: "1", role: models.UnitAdmin}, "/users/student/5/robboGroup/3"},
+	}
+	for _, c := range cases {
+		delegate = &usersDelegate{}
+		response = serve(c.who, delegate, "DELETE", c.path, "")
+		assert.Equal(t, http.StatusUnauthorized, response.Code, c.name)
+		assert.Empty(t, delegate.removed, c.name)
+	}
+}
+
+func TestTransferStudent(t *testing.T) {
+	delegate := &usersDelegate{}
+	response := serve(identity{id: "1", role: models.RegionAdmin}, delegate, "POST", "/users/student/5/robboGroup/1/transfer",
+		`{"toRobboGroupId":"2","toRobboUnitId":"1"}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"5 from 1 to 2"}, delegate.transferred)
+
+	cases := []struct {
+		name string
+		who  identity
+		path string
+		body string
+	}{
+		{"anonymous", identity{role: models.Anonymous}, "/users/student/5/robboGroup/1/transfer", `{"toRobboGroupId":"2"}`},
+		{"parent", identity{id: "1", role: models.Parent}, "/users/student/5/robboGroup/1/transfer", `{"toRobboGroupId":"2"}`},
+		{"from a group of another unit", identity{id: "1", role: models.UnitAdmin}, "/users/student/5/robboGroup/3/transfer", `{"toRobboGroupId":"2"}`},
+		{"to a group of another unit", identity{id: "1", role: models.UnitAdmin}, "/users/student/5/robboGroup/1/transfer", `{"toRobboGroupId":"3"}`},
+	}
+	for _, c := range cases {
+		delegate = &usersDelegate{}
+		response = serve(c.who, delegate, "POST", c.path, c.body)
+		assert.Equal(t, http.StatusUnauthorized, response.Code, c.name)
+		assert.Empty(t, delegate.transferred, c.name)
+	}
+}
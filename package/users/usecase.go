@@ -10,7 +10,16 @@ type UseCase interface {
 	CreateStudent(student *models.StudentCore, parentId string) (id string, err error)
 	DeleteStudent(studentId uint) (err error)
 	UpdateStudent(student *models.StudentCore) (err error)
-	AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (err error)
+	AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (waitlistEntry *models.WaitlistEntryCore, err error)
+	RemoveStudentFromRobboGroup(studentId, robboGroupId string) (err error)
+	TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (membership *models.GroupMembershipCore, err error)
+	GetGroupMembershipsByStudentId(studentId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error)
+	GetGroupMembershipsByRobboGroupId(robboGroupId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error)
+	SetRobboGroupCapacity(robboGroupId string, capacity int) (err error)
+	GetWaitlistByRobboGroupId(robboGroupId string) (entries []*models.WaitlistEntryCore, err error)
+	RemoveFromWaitlist(studentId, robboGroupId string) (err error)
+	FindDuplicateStudents() (duplicates []*models.StudentDuplicateCore, err error)
+	MergeStudents(survivorId, duplicateId string) (student *models.StudentCore, err error)
 
 	//GetTeacher(email, password string) (teacher *models.TeacherCore, err error)
 	GetTeacherById(teacherId string) (teacher models.TeacherCore, err error)
@@ -40,6 +49,11 @@ type UseCase interface {
 	DeleteUnitAdmin(unitAdminId uint) (err error)
 	SearchUnitAdminByEmail(email string) (unitAdmins []*models.UnitAdminCore, err error)
 
+	GetRegionAdminById(regionAdminId string) (regionAdmin *models.RegionAdminCore, err error)
+	GetRegionAdminsByRegionId(regionId string) (regionAdmins []*models.RegionAdminCore, err error)
+	CreateRegionAdmin(regionAdmin *models.RegionAdminCore) (id string, err error)
+	DeleteRegionAdmin(regionAdminId uint) (err error)
+
 	//GetSuperAdmin(email, password string) (superAdmin *models.SuperAdminCore, err error)
 	GetSuperAdminById(superAdminId string) (superAdmin *models.SuperAdminCore, err error)
 	UpdateSuperAdmin(superAdmin *models.SuperAdminCore) (err error)
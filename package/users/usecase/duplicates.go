@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func normalizeName(parts ...string) string {
+	name := strings.ToLower(strings.Join(parts, " "))
+	name = strings.ReplaceAll(name, "ё", "е")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// findDuplicateStudents pairs up students that share a normalized full name, an email
+// or a parent together with a first name. Every pair is reported once with all matched reasons.
+func findDuplicateStudents(students []*models.StudentCore, relations []*models.ChildrenOfParentCore) (duplicates []*models.StudentDuplicateCore) {
+	studentsById := make(map[string]*models.StudentCore)
+	byName := make(map[string][]string)
+	byEmail := make(map[string][]string)
+	for _, student := range students {
+		studentsById[student.Id] = student
+		if name := normalizeName(student.Lastname, student.Firstname, student.Middlename); name != "" {
+			byName[name] = append(byName[name], student.Id)
+		}
+		if email := normalizeEmail(student.Email); email != "" {
+			byEmail[email] = append(byEmail[email], student.Id)
+		}
+	}
+
+	byParent := make(map[string][]string)
+	for _, relation := range relations {
+		student, ok := studentsById[relation.ChildId]
+		if !ok {
+			continue
+		}
+		key := relation.ParentId + ":" + normalizeName(student.Firstname)
+		byParent[key] = append(byParent[key], student.Id)
+	}
+
+	pairs := make(map[[2]string]*models.StudentDuplicateCore)
+	collect := func(groups map[string][]string, reason models.DuplicateReason) {
+		for _, ids := range groups {
+			for i := 0; i < len(ids); i++ {
+				for j := i + 1; j < len(ids); j++ {
+					key := orderedPair(ids[i], ids[j])
+					if key[0] == key[1] {
+						continue
+					}
+					pair, ok := pairs[key]
+					if !ok {
+						pair = &models.StudentDuplicateCore{
+							Student:   studentsById[key[0]],
+							Duplicate: studentsById[key[1]],
+						}
+						pairs[key] = pair
+					}
+					if !hasReason(pair.Reasons, reason) {
+						pair.Reasons = append(pair.Reasons, reason)
+					}
+				}
+			}
+		}
+	}
+	collect(byName, models.DuplicateByName)
+	collect(byEmail, models.DuplicateByEmail)
+	collect(byParent, models.DuplicateByParent)
+
+	for _, pair := range pairs {
+		duplicates = append(duplicates, pair)
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		if len(duplicates[i].Reasons) != len(duplicates[j].Reasons) {
+			return len(duplicates[i].Reasons) > len(duplicates[j].Reasons)
+		}
+		if duplicates[i].Student.Id != duplicates[j].Student.Id {
+			return lessId(duplicates[i].Student.Id, duplicates[j].Student.Id)
+		}
+		return lessId(duplicates[i].Duplicate.Id, duplicates[j].Duplicate.Id)
+	})
+	return
+}
+
+func orderedPair(a, b string) [2]string {
+	if lessId(b, a) {
+		return [2]string{b, a}
+	}
+	return [2]string{a, b}
+}
+
+func lessId(a, b string) bool {
+	aId, _ := strconv.ParseUint(a, 10, 64)
+	bId, _ := strconv.ParseUint(b, 10, 64)
+	return aId < bId
+}
+
+func hasReason(reasons []models.DuplicateReason, reason models.DuplicateReason) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newStudent(id, email, firstname, lastname string) *models.StudentCore {
+	return &models.StudentCore{
+		UserCore: models.UserCore{
+			Id:        id,
+			Email:     email,
+			Firstname: firstname,
+			Lastname:  lastname,
+		},
+	}
+}
+
+func TestFindDuplicateStudents(t *testing.T) {
+	students := []*models.StudentCore{
+		newStudent("1", "Petya@mail.ru", "Пётр", "Иванов"),
+		newStudent("2", " petya@mail.ru ", "Петр", "Иванов"),
+		newStudent("3", "other@mail.ru", "Петр", "Сидоров"),
+		newStudent("4", "another@mail.ru", "петр ", "Сидоров-Младший"),
+		newStudent("5", "single@mail.ru", "Анна", "Смирнова"),
+	}
+	relations := []*models.ChildrenOfParentCore{
+		{ParentId: "10", ChildId: "3"},
+		{ParentId: "10", ChildId: "4"},
+		{ParentId: "11", ChildId: "5"},
+	}
+
+	duplicates := findDuplicateStudents(students, relations)
+
+	assert.Len(t, duplicates, 2)
+	assert.Equal(t, "1", duplicates[0].Student.Id)
+	assert.Equal(t, "2", duplicates[0].Duplicate.Id)
+	assert.ElementsMatch(t, []models.DuplicateReason{models.DuplicateByName, models.DuplicateByEmail}, duplicates[0].Reasons)
+	assert.Equal(t, "3", duplicates[1].Student.Id)
+	assert.Equal(t, "4", duplicates[1].Duplicate.Id)
+	assert.Equal(t, []models.DuplicateReason{models.DuplicateByParent}, duplicates[1].Reasons)
+}
+
+func TestFindDuplicateStudentsWithoutMatches(t *testing.T) {
+	students := []*models.StudentCore{
+		newStudent("1", "", "", ""),
+		newStudent("2", "", "", ""),
+	}
+
+	assert.Empty(t, findDuplicateStudents(students, nil))
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/spf13/viper"
+)
+
+func (p *UsersUseCaseImpl) GetRegionAdminById(regionAdminId string) (regionAdmin *models.RegionAdminCore, err error) {
+	return p.Gateway.GetRegionAdminById(regionAdminId)
+}
+
+func (p *UsersUseCaseImpl) GetRegionAdminsByRegionId(regionId string) (regionAdmins []*models.RegionAdminCore, err error) {
+	return p.Gateway.GetRegionAdminsByRegionId(regionId)
+}
+
+func (p *UsersUseCaseImpl) CreateRegionAdmin(regionAdmin *models.RegionAdminCore) (id string, err error) {
+	pwd := sha1.New()
+	pwd.Write([]byte(regionAdmin.Password))
+	pwd.Write([]byte(viper.GetString("auth.hash_salt")))
+	regionAdmin.Password = fmt.Sprintf("%x", pwd.Sum(nil))
+	regionAdmin.Role = models.RegionAdmin
+	return p.Gateway.CreateRegionAdmin(regionAdmin)
+}
+
+func (p *UsersUseCaseImpl) DeleteRegionAdmin(regionAdminId uint) (err error) {
+	return p.Gateway.DeleteRegionAdmin(regionAdminId)
+}
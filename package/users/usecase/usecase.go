@@ -4,6 +4,7 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
 	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
@@ -12,7 +13,8 @@ import (
 )
 
 type UsersUseCaseImpl struct {
-	Gateway users.Gateway
+	Gateway              users.Gateway
+	notificationsGateway notifications.Gateway
 }
 
 type UsersUseCaseModule struct {
@@ -20,10 +22,11 @@ type UsersUseCaseModule struct {
 	users.UseCase
 }
 
-func SetupUsersUseCase(gateway users.Gateway) UsersUseCaseModule {
+func SetupUsersUseCase(gateway users.Gateway, notificationsGateway notifications.Gateway) UsersUseCaseModule {
 	return UsersUseCaseModule{
 		UseCase: &UsersUseCaseImpl{
-			Gateway: gateway,
+			Gateway:              gateway,
+			notificationsGateway: notificationsGateway,
 		},
 	}
 }
@@ -94,10 +97,113 @@ func (p *UsersUseCaseImpl) UpdateStudent(student *models.StudentCore) (err error
 	return
 }
 
-func (p *UsersUseCaseImpl) AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (err error) {
+func (p *UsersUseCaseImpl) AddStudentToRobboGroup(studentId string, robboGroupId string, robboUnitId string) (waitlistEntry *models.WaitlistEntryCore, err error) {
 	return p.Gateway.AddStudentToRobboGroup(studentId, robboGroupId, robboUnitId)
 }
 
+func (p *UsersUseCaseImpl) RemoveStudentFromRobboGroup(studentId, robboGroupId string) (err error) {
+	promoted, err := p.Gateway.RemoveStudentFromRobboGroup(studentId, robboGroupId)
+	if err != nil {
+		return
+	}
+	p.notifyPromoted(promoted)
+	return
+}
+
+func (p *UsersUseCaseImpl) TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId string) (membership *models.GroupMembershipCore, err error) {
+	if fromRobboGroupId == toRobboGroupId {
+		return nil, users.ErrTransferSameGroup
+	}
+	membership, promoted, err := p.Gateway.TransferStudent(studentId, fromRobboGroupId, toRobboGroupId, toRobboUnitId)
+	if err != nil {
+		return
+	}
+	p.notifyPromoted(promoted)
+	return
+}
+
+func (p *UsersUseCaseImpl) GetGroupMembershipsByStudentId(studentId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error) {
+	return p.Gateway.GetGroupMembershipsByStudentId(studentId, activeOnly)
+}
+
+func (p *UsersUseCaseImpl) GetGroupMembershipsByRobboGroupId(robboGroupId string, activeOnly bool) (memberships []*models.GroupMembershipCore, err error) {
+	return p.Gateway.GetGroupMembershipsByRobboGroupId(robboGroupId, activeOnly)
+}
+
+// SetRobboGroupCapacity lets a grown group take students from its waitlist right away.
+func (p *UsersUseCaseImpl) SetRobboGroupCapacity(robboGroupId string, capacity int) (err error) {
+	if capacity < 0 {
+		return users.ErrBadCapacity
+	}
+	promoted, err := p.Gateway.SetRobboGroupCapacity(robboGroupId, capacity)
+	if err != nil {
+		return
+	}
+	p.notifyPromoted(promoted)
+	return
+}
+
+func (p *UsersUseCaseImpl) GetWaitlistByRobboGroupId(robboGroupId string) (entries []*models.WaitlistEntryCore, err error) {
+	return p.Gateway.GetWaitlistByRobboGroupId(robboGroupId)
+}
+
+func (p *UsersUseCaseImpl) RemoveFromWaitlist(studentId, robboGroupId string) (err error) {
+	return p.Gateway.RemoveFromWaitlist(studentId, robboGroupId)
+}
+
+// notifyPromoted tells parents that their child got a seat. The promotion itself is already
+// committed, so failures are only logged.
+func (p *UsersUseCaseImpl) notifyPromoted(promoted []*models.WaitlistEntryCore) {
+	var notificationsCore []*models.NotificationCore
+	for _, entry := range promoted {
+		student, err := p.Gateway.GetStudentById(entry.StudentId)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		relations, err := p.Gateway.GetRelationByChildrenId(entry.StudentId)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		for _, relation := range relations {
+			notificationsCore = append(notificationsCore, &models.NotificationCore{
+				RecipientId:   relation.ParentId,
+				RecipientRole: models.Parent,
+				Kind:          models.WaitlistPromotionNotification,
+				Text: fmt.Sprintf("%s %s got a seat in the group %s",
+					student.Firstname, student.Lastname, entry.RobboGroupName),
+			})
+		}
+	}
+	if err := p.notificationsGateway.CreateNotifications(notificationsCore); err != nil {
+		log.Println(err)
+	}
+}
+
+func (p *UsersUseCaseImpl) FindDuplicateStudents() (duplicates []*models.StudentDuplicateCore, err error) {
+	students, err := p.Gateway.GetAllStudents()
+	if err != nil {
+		return
+	}
+	relations, err := p.Gateway.GetAllRelations()
+	if err != nil {
+		return
+	}
+	return findDuplicateStudents(students, relations), nil
+}
+
+func (p *UsersUseCaseImpl) MergeStudents(survivorId, duplicateId string) (student *models.StudentCore, err error) {
+	if survivorId == duplicateId {
+		return nil, users.ErrMergeSameStudent
+	}
+	err = p.Gateway.MergeStudents(survivorId, duplicateId)
+	if err != nil {
+		return
+	}
+	return p.Gateway.GetStudentById(survivorId)
+}
+
 func (p *UsersUseCaseImpl) GetTeacherById(teacherId string) (teacher models.TeacherCore, err error) {
 	return p.Gateway.GetTeacherById(teacherId)
 }
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/models"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/notifications"
+	"github.com/skinnykaen/robbo_student_personal_account.git/package/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeUsers struct {
+	users.Gateway
+	capacities map[string]int
+	promoted   []*models.WaitlistEntryCore
+}
+
+func (f *fakeUsers) SetRobboGroupCapacity(robboGroupId string, capacity int) ([]*models.WaitlistEntryCore, error) {
+	f.capacities[robboGroupId] = capacity
+	return f.promoted, nil
+}
+
+func (f *fakeUsers) GetStudentById(studentId string) (*models.StudentCore, error) {
+	return newStudent(studentId, "", "Анна", "Смирнова"), nil
+}
+
+func (f *fakeUsers) GetRelationByChildrenId(childrenId string) ([]*models.ChildrenOfParentCore, error) {
+	return []*models.ChildrenOfParentCore{{ParentId: "p" + childrenId, ChildId: childrenId}}, nil
+}
+
+type fakeNotifications struct {
+	notifications.Gateway
+	created []*models.NotificationCore
+}
+
+func (f *fakeNotifications) CreateNotifications(notificationsCore []*models.NotificationCore) error {
+	f.created = append(f.created, notificationsCore...)
+	return nil
+}
+
+func TestSetRobboGroupCapacity(t *testing.T) {
+	gateway := &fakeUsers{
+		capacities: map[string]int{},
+		promoted: []*models.WaitlistEntryCore{
+			{StudentId: "7", RobboGroupId: "3", RobboGroupName: "Роботы"},
+			{StudentId: "8", RobboGroupId: "3", RobboGroupName: "Роботы"},
+		},
+	}
+	notificationsGateway := &fakeNotifications{}
+	p := &UsersUseCaseImpl{Gateway: gateway, notificationsGateway: notificationsGateway}
+
+	assert.NoError(t, p.SetRobboGroupCapacity("3", 12))
+	assert.Equal(t, 12, gateway.capacities["3"])
+	if assert.Len(t, notificationsGateway.created, 2) {
+		assert.Equal(t, "p7", notificationsGateway.created[0].RecipientId)
+		assert.Equal(t, models.Parent, notificationsGateway.created[0].RecipientRole)
+		assert.Contains(t, notificationsGateway.created[0].Text, "Роботы")
+		assert.Equal(t, "p8", notificationsGateway.created[1].RecipientId)
+	}
+
+	assert.ErrorIs(t, p.SetRobboGroupCapacity("3", -1), users.ErrBadCapacity)
+	assert.Equal(t, 12, gateway.capacities["3"], "a negative capacity is not stored")
+}
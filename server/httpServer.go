@@ -35,6 +35,7 @@ func NewHttpServer(lifecycle fx.Lifecycle, handlers modules.HandlerModule) {
 				handlers.RobboUnitsHandler.InitRobboUnitsRoutes(router)
 				handlers.RobboGroupHandler.InitRobboGroupRoutes(router)
 				handlers.CoursePacketHandler.InitCoursePacketRoutes(router)
+				handlers.AssetsHandler.InitAssetsRoutes(router)
 				server := &http.Server{
 					Addr: viper.GetString("server.address"),
 					Handler: cors.New(
@@ -43,12 +44,13 @@ func NewHttpServer(lifecycle fx.Lifecycle, handlers modules.HandlerModule) {
 							AllowedOrigins:   []string{"http://0.0.0.0:3030", "http://0.0.0.0:8601", "http://localhost:3030"},
 							AllowCredentials: true,
 							AllowedMethods: []string{
-								http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodOptions,
+								http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodOptions,
 							},
 							//AllowedHeaders: []string{"*"},
 							AllowedHeaders: []string{
-								"Origin", "X-Requested-With", "Content-Type", "Accept", "Set-Cookie", "Authorization",
+								"Origin", "X-Requested-With", "Content-Type", "Accept", "Set-Cookie", "Authorization", "If-Match", "If-None-Match",
 							},
+							ExposedHeaders: []string{"ETag"},
 						},
 					).Handler(router),
 					ReadTimeout:    10 * time.Second,
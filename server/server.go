@@ -32,12 +32,13 @@ func NewServer(lifecycle fx.Lifecycle, graphQLModule modules.GraphQLModule, hand
 							AllowedOrigins:   []string{"http://0.0.0.0:3030", "http://0.0.0.0:8601", "http://localhost:3030"},
 							AllowCredentials: true,
 							AllowedMethods: []string{
-								http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodOptions,
+								http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodOptions,
 							},
 							//AllowedHeaders: []string{"*"},
 							AllowedHeaders: []string{
-								"Origin", "X-Requested-With", "Content-Type", "Accept", "Set-Cookie", "Authorization",
+								"Origin", "X-Requested-With", "Content-Type", "Accept", "Set-Cookie", "Authorization", "If-Match", "If-None-Match",
 							},
+							ExposedHeaders: []string{"ETag"},
 						},
 					).Handler(router),
 					ReadTimeout:    10 * time.Second,
@@ -65,6 +66,7 @@ func SetupGinRouter(handlers modules.HandlerModule) *gin.Engine {
 		gin.Recovery(),
 		gin.Logger(),
 		GinContextToContextMiddleware(),
+		handlers.ActivityHandler.LastSeenMiddleware(),
 	)
 	handlers.AuthHandler.InitAuthRoutes(router)
 	handlers.ProjectsHandler.InitProjectRoutes(router)
@@ -75,6 +77,7 @@ func SetupGinRouter(handlers modules.HandlerModule) *gin.Engine {
 	handlers.RobboUnitsHandler.InitRobboUnitsRoutes(router)
 	handlers.RobboGroupHandler.InitRobboGroupRoutes(router)
 	handlers.CoursePacketHandler.InitCoursePacketRoutes(router)
+	handlers.AssetsHandler.InitAssetsRoutes(router)
 	return router
 }
 
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"github.com/skinnykaen/robbo_student_personal_account.git/app/modules"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"log"
+	"time"
+)
+
+// NewWorkers runs the background jobs of the application for as long as the app is up.
+func NewWorkers(lifecycle fx.Lifecycle, delegates modules.DelegateModule) {
+	done := make(chan struct{})
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(context.Context) error {
+				pollInterval := time.Duration(viper.GetInt("coursePacket.enrollment_poll_interval")) * time.Second
+				go runPeriodically(done, pollInterval, func() {
+					if err := delegates.CoursePacketDelegate.ProcessEdxEnrollments(); err != nil {
+						log.Println(err)
+					}
+				})
+				fingerprintInterval := time.Duration(viper.GetInt("assignments.fingerprint_interval")) * time.Second
+				go runPeriodically(done, fingerprintInterval, func() {
+					if err := delegates.AssignmentsDelegate.FingerprintSubmissions(); err != nil {
+						log.Println(err)
+					}
+				})
+				analysisInterval := time.Duration(viper.GetInt("projects.analysis_interval")) * time.Second
+				go runPeriodically(done, analysisInterval, func() {
+					if err := delegates.ProjectsDelegate.AnalyzeProjects(); err != nil {
+						log.Println(err)
+					}
+				})
+				placeholderInterval := time.Duration(viper.GetInt("projectPage.placeholder_interval")) * time.Second
+				go runPeriodically(done, placeholderInterval, func() {
+					if err := delegates.ProjectPageDelegate.RefreshPlaceholders(); err != nil {
+						log.Println(err)
+					}
+				})
+				bodyGcInterval := time.Duration(viper.GetInt("projects.body_gc_interval")) * time.Second
+				go runPeriodically(done, bodyGcInterval, func() {
+					if err := delegates.ProjectsDelegate.CollectOrphanedBodies(); err != nil {
+						log.Println(err)
+					}
+				})
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				close(done)
+				return nil
+			},
+		})
+}
+
+func runPeriodically(done <-chan struct{}, interval time.Duration, job func()) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			job()
+		}
+	}
+}